@@ -0,0 +1,280 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nikumar1206/loco/api/authz"
+	"github.com/nikumar1206/loco/api/contextkeys"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"google.golang.org/protobuf/proto"
+)
+
+// errSessionNotEnded is the error of a stream recorded on start until it ends
+var errSessionNotEnded = errors.New("session started, its end was not recorded")
+
+// Recorder writes audit events for mutating procedures
+type Recorder struct {
+	queries *genDb.Queries
+	targets map[string]Target
+}
+
+// NewRecorder creates a Recorder backed by the default targets table
+func NewRecorder(queries *genDb.Queries) *Recorder {
+	return &Recorder{queries: queries, targets: Targets}
+}
+
+// IsAudited reports whether calls to the procedure are recorded
+func (r *Recorder) IsAudited(procedure string) bool {
+	_, ok := r.targets[procedure]
+	return ok
+}
+
+// Before snapshots the target of a call before it runs. Returns nil if there's nothing to snapshot.
+func (r *Recorder) Before(ctx context.Context, procedure string, req any) []byte {
+	target, ok := r.targets[procedure]
+	if !ok || target.Before == nil {
+		return nil
+	}
+
+	state, err := target.Before(ctx, r.queries, req)
+	if err != nil {
+		// the target may legitimately not exist yet, e.g. adding a new workspace member
+		slog.DebugContext(ctx, "no audit snapshot for target", "procedure", procedure, "error", err)
+		return nil
+	}
+	if state == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to encode audit snapshot", "procedure", procedure, "error", err)
+		return nil
+	}
+	// typed nil pointers make it past the nil check above
+	if string(b) == "null" {
+		return nil
+	}
+	return b
+}
+
+// Record writes the audit event for a completed call. res is nil and callErr is set when the call failed.
+// The call has already committed, so an event that can't be written is logged rather than failing it.
+func (r *Recorder) Record(ctx context.Context, procedure string, req, res any, callErr error, before []byte) {
+	target, ok := r.targets[procedure]
+	if !ok {
+		return
+	}
+
+	params := r.params(ctx, target, procedure, req, res, callErr)
+	params.Request = marshalRedacted(req)
+	params.Before = before
+	params.After = marshalRedacted(res)
+	r.write(ctx, params)
+}
+
+// RecordsOnStart reports whether the procedure's streams are recorded as soon as they start, see StartStream
+func (r *Recorder) RecordsOnStart(procedure string) bool {
+	return r.targets[procedure].RecordOnStart
+}
+
+// StartStream writes the audit event of a streaming call that just received its first message. Until
+// RecordStream completes it, the event is a failure that says the session's end wasn't recorded, which is
+// what it stays when the server goes down mid-session.
+func (r *Recorder) StartStream(ctx context.Context, procedure string, stream *Stream) {
+	target, ok := r.targets[procedure]
+	if !ok {
+		return
+	}
+
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+
+	params := r.params(ctx, target, procedure, stream.firstReceived, nil, errSessionNotEnded)
+	params.Request = marshalSummary(stream.receivedCount, marshalRedacted(stream.firstReceived), "first")
+	params.Before = stream.Before
+	r.resolveOrg(ctx, &params)
+
+	// the client going away shouldn't lose the record
+	id, err := r.queries.StartAuditEvent(context.WithoutCancel(ctx), genDb.StartAuditEventParams(params))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to write audit event", "procedure", procedure, "actor", params.Actor,
+			"request_id", params.RequestID, "resource_id", params.ResourceID.Int64, "error", err)
+		return
+	}
+	stream.eventID = id
+}
+
+// RecordStream writes the audit event for a streaming call once it ended, with a summary of what it received
+// and sent, or completes the one StartStream wrote. Like Record, an event that can't be written is logged.
+func (r *Recorder) RecordStream(ctx context.Context, procedure string, stream *Stream, callErr error) {
+	target, ok := r.targets[procedure]
+	if !ok {
+		return
+	}
+
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+
+	params := r.params(ctx, target, procedure, stream.firstReceived, stream.firstSent, callErr)
+	params.Request = marshalSummary(stream.receivedCount, marshalRedacted(stream.firstReceived), "first")
+	params.Before = stream.Before
+	params.After = marshalSummary(stream.sentCount, marshalRedacted(stream.lastSent), "last")
+	if stream.eventID == 0 {
+		r.write(ctx, params)
+		return
+	}
+
+	err := r.queries.FinishAuditEvent(context.WithoutCancel(ctx), genDb.FinishAuditEventParams{
+		ID:      stream.eventID,
+		Request: params.Request,
+		After:   params.After,
+		Success: params.Success,
+		Error:   params.Error,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to complete audit event", "procedure", procedure, "event_id", stream.eventID,
+			"success", params.Success, "error", err)
+	}
+}
+
+// params fills in who made the call and what it acted on. req and res are what targets read IDs from.
+func (r *Recorder) params(ctx context.Context, target Target, procedure string, req, res any, callErr error) genDb.CreateAuditEventParams {
+	params := genDb.CreateAuditEventParams{
+		Procedure:    procedure,
+		ResourceType: target.ResourceType,
+		Success:      callErr == nil,
+	}
+
+	if userID, ok := contextkeys.UserID(ctx); ok {
+		params.ActorID = pgtype.Int8{Int64: userID, Valid: true}
+	}
+	params.Actor, _ = contextkeys.User(ctx)
+	params.RequestID, _ = contextkeys.RequestID(ctx)
+	params.SourceIp, _ = ctx.Value(contextkeys.SourceIPKey).(string)
+
+	if callErr != nil {
+		params.Error = pgtype.Text{String: callErr.Error(), Valid: true}
+	}
+
+	if target.ResourceID != nil {
+		params.ResourceID = optionalID(target.ResourceID(req, res))
+	}
+	if target.AppID != nil {
+		params.AppID = optionalID(target.AppID(req, res))
+	}
+
+	if decision, ok := authz.FromContext(ctx); ok {
+		switch decision.Scope {
+		case authz.ScopeWorkspace:
+			params.WorkspaceID = optionalID(decision.ResourceID)
+		case authz.ScopeOrg:
+			params.OrgID = optionalID(decision.ResourceID)
+		}
+	}
+	if target.WorkspaceID != nil && !params.WorkspaceID.Valid {
+		params.WorkspaceID = optionalID(target.WorkspaceID(req, res))
+	}
+	return params
+}
+
+func (r *Recorder) write(ctx context.Context, params genDb.CreateAuditEventParams) {
+	// the client going away shouldn't lose the record
+	ctx = context.WithoutCancel(ctx)
+	r.resolveOrg(ctx, &params)
+
+	if err := r.queries.CreateAuditEvent(ctx, params); err != nil {
+		// everything needed to reconstruct the event by hand, the request being redacted already
+		slog.ErrorContext(ctx, "failed to write audit event", "procedure", params.Procedure, "actor", params.Actor,
+			"request_id", params.RequestID, "resource_id", params.ResourceID.Int64, "success", params.Success,
+			"request", string(params.Request), "error", err)
+	}
+}
+
+// resolveOrg fills in the org of events that only know their workspace
+func (r *Recorder) resolveOrg(ctx context.Context, params *genDb.CreateAuditEventParams) {
+	if params.WorkspaceID.Valid && !params.OrgID.Valid {
+		if orgID, err := r.queries.GetWorkspaceOrgID(context.WithoutCancel(ctx), params.WorkspaceID.Int64); err == nil {
+			params.OrgID = optionalID(orgID)
+		}
+	}
+}
+
+// Stream summarises the messages of a streaming call as they pass, for RecordStream. Only counts, the first
+// message received and the last sent are kept, so a session that runs for hours records no more than a short one.
+// Receiving and sending may happen on different goroutines.
+type Stream struct {
+	// Before is the snapshot taken when the first message arrived
+	Before []byte
+
+	mu            sync.Mutex
+	firstReceived any
+	firstSent     any
+	// lastSent holds how the stream ended, like an exec's exit code
+	lastSent                 any
+	receivedCount, sentCount int
+	// eventID is the event StartStream wrote, 0 when it didn't
+	eventID int64
+}
+
+// Received counts a message the client sent
+func (s *Stream) Received(msg any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.firstReceived == nil {
+		s.firstReceived = cloneMessage(msg)
+	}
+	s.receivedCount++
+}
+
+// Sent counts a message sent to the client
+func (s *Stream) Sent(msg any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSent = cloneMessage(msg)
+	if s.firstSent == nil {
+		s.firstSent = s.lastSent
+	}
+	s.sentCount++
+}
+
+// HasReceived reports whether the client sent anything yet
+func (s *Stream) HasReceived() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.firstReceived != nil
+}
+
+// cloneMessage copies proto messages, handlers may reuse the one they pass for the next message
+func cloneMessage(msg any) any {
+	if m, ok := msg.(proto.Message); ok && m != nil {
+		return proto.Clone(m)
+	}
+	return msg
+}
+
+// marshalSummary encodes how many messages went one way, along with one already redacted message under key.
+// Returns nil when there were none.
+func marshalSummary(count int, msg []byte, key string) []byte {
+	if count == 0 {
+		return nil
+	}
+	summary := map[string]any{"messages": count}
+	if msg != nil {
+		summary[key] = json.RawMessage(msg)
+	}
+	b, err := json.Marshal(summary)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func optionalID(id int64) pgtype.Int8 {
+	return pgtype.Int8{Int64: id, Valid: id != 0}
+}
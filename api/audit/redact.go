@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const redacted = "[redacted]"
+
+// fields whose values must never reach the audit table. env maps keep their keys.
+var sensitiveFields = map[protoreflect.Name]bool{
+	"env":        true,
+	"token":      true,
+	"password":   true,
+	"secret":     true,
+	"kubeconfig": true,
+	// what's typed into an exec session
+	"stdin": true,
+	// what an exec session printed and the bytes a port-forward carried, streams record that they were sent
+	"stdout": true,
+	"stderr": true,
+	"data":   true,
+	// a LogEntry's line and the keys parsed from it, what a one-off command or an app printed
+	"log":    true,
+	"fields": true,
+}
+
+// marshalRedacted encodes msg as JSON with sensitive fields masked. Returns nil for non-proto or nil messages.
+func marshalRedacted(msg any) []byte {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil || !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	clone := proto.Clone(m)
+	redact(clone.ProtoReflect())
+
+	b, err := protojson.Marshal(clone)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func redact(m protoreflect.Message) {
+	var masked []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case sensitiveFields[fd.Name()]:
+			masked = append(masked, fd)
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					redact(mv.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					redact(v.List().Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			redact(v.Message())
+		}
+		return true
+	})
+
+	for _, fd := range masked {
+		switch {
+		case fd.IsMap() && fd.MapValue().Kind() == protoreflect.StringKind:
+			mp := m.Mutable(fd).Map()
+			mp.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				mp.Set(k, protoreflect.ValueOfString(redacted))
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Kind() == protoreflect.StringKind:
+			m.Set(fd, protoreflect.ValueOfString(redacted))
+		default:
+			m.Clear(fd)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/nikumar1206/loco/api/domains"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
+	"github.com/nikumar1206/loco/shared/proto/app/v1/appv1connect"
+	clusterv1 "github.com/nikumar1206/loco/shared/proto/cluster/v1"
+	"github.com/nikumar1206/loco/shared/proto/cluster/v1/clusterv1connect"
+	deploymentv1 "github.com/nikumar1206/loco/shared/proto/deployment/v1"
+	"github.com/nikumar1206/loco/shared/proto/deployment/v1/deploymentv1connect"
+	domainv1 "github.com/nikumar1206/loco/shared/proto/domain/v1"
+	"github.com/nikumar1206/loco/shared/proto/domain/v1/domainv1connect"
+	jobv1 "github.com/nikumar1206/loco/shared/proto/job/v1"
+	"github.com/nikumar1206/loco/shared/proto/job/v1/jobv1connect"
+	orgv1 "github.com/nikumar1206/loco/shared/proto/org/v1"
+	"github.com/nikumar1206/loco/shared/proto/org/v1/orgv1connect"
+	quotav1 "github.com/nikumar1206/loco/shared/proto/quota/v1"
+	"github.com/nikumar1206/loco/shared/proto/quota/v1/quotav1connect"
+	"github.com/nikumar1206/loco/shared/proto/registry/v1/registryv1connect"
+	userv1 "github.com/nikumar1206/loco/shared/proto/user/v1"
+	"github.com/nikumar1206/loco/shared/proto/user/v1/userv1connect"
+	workspacev1 "github.com/nikumar1206/loco/shared/proto/workspace/v1"
+	"github.com/nikumar1206/loco/shared/proto/workspace/v1/workspacev1connect"
+)
+
+// IDFunc extracts an ID from a request, or from the response for creates. res is nil when the call failed.
+type IDFunc func(req, res any) int64
+
+// SnapshotFunc loads the state of the target before the call runs
+type SnapshotFunc func(ctx context.Context, q *genDb.Queries, req any) (any, error)
+
+// Target describes what a mutating procedure acts on
+type Target struct {
+	ResourceType string
+	ResourceID   IDFunc
+	// AppID is set for procedures that act on an app or something owned by one
+	AppID IDFunc
+	// WorkspaceID is set for procedures that belong to a workspace but aren't authorized against it
+	WorkspaceID IDFunc
+	Before      SnapshotFunc
+	// RecordOnStart writes a stream's event once its first message arrived and completes it when the stream
+	// ends, for sessions like shells whose end may never be seen
+	RecordOnStart bool
+}
+
+// Targets lists every mutating procedure. Procedures missing from this table are not audited.
+var Targets = map[string]Target{
+	// user service
+	userv1connect.UserServiceCreateUserProcedure: {
+		ResourceType: "user",
+		ResourceID:   fromResponse(func(m *userv1.CreateUserResponse) int64 { return m.GetUser().GetId() }),
+	},
+	userv1connect.UserServiceUpdateUserProcedure: {
+		ResourceType: "user",
+		ResourceID:   fromRequest(func(m *userv1.UpdateUserRequest) int64 { return m.Id }),
+		Before:       userSnapshot(func(m *userv1.UpdateUserRequest) int64 { return m.Id }),
+	},
+	userv1connect.UserServiceDeleteUserProcedure: {
+		ResourceType: "user",
+		ResourceID:   fromRequest(func(m *userv1.DeleteUserRequest) int64 { return m.Id }),
+		Before:       userSnapshot(func(m *userv1.DeleteUserRequest) int64 { return m.Id }),
+	},
+
+	// org service
+	orgv1connect.OrgServiceCreateOrgProcedure: {
+		ResourceType: "org",
+		ResourceID:   fromResponse(func(m *orgv1.CreateOrgResponse) int64 { return m.GetOrg().GetId() }),
+	},
+	orgv1connect.OrgServiceUpdateOrgProcedure: {
+		ResourceType: "org",
+		ResourceID:   fromRequest(func(m *orgv1.UpdateOrgRequest) int64 { return m.Id }),
+		Before:       orgSnapshot(func(m *orgv1.UpdateOrgRequest) int64 { return m.Id }),
+	},
+	orgv1connect.OrgServiceDeleteOrgProcedure: {
+		ResourceType: "org",
+		ResourceID:   fromRequest(func(m *orgv1.DeleteOrgRequest) int64 { return m.Id }),
+		Before:       orgSnapshot(func(m *orgv1.DeleteOrgRequest) int64 { return m.Id }),
+	},
+
+	// workspace service
+	workspacev1connect.WorkspaceServiceCreateWorkspaceProcedure: {
+		ResourceType: "workspace",
+		ResourceID:   fromResponse(func(m *workspacev1.CreateWorkspaceResponse) int64 { return m.GetWorkspace().GetId() }),
+		WorkspaceID:  fromResponse(func(m *workspacev1.CreateWorkspaceResponse) int64 { return m.GetWorkspace().GetId() }),
+	},
+	workspacev1connect.WorkspaceServiceUpdateWorkspaceProcedure: {
+		ResourceType: "workspace",
+		ResourceID:   fromRequest(func(m *workspacev1.UpdateWorkspaceRequest) int64 { return m.Id }),
+		Before:       workspaceSnapshot(func(m *workspacev1.UpdateWorkspaceRequest) int64 { return m.Id }),
+	},
+	workspacev1connect.WorkspaceServiceDeleteWorkspaceProcedure: {
+		ResourceType: "workspace",
+		ResourceID:   fromRequest(func(m *workspacev1.DeleteWorkspaceRequest) int64 { return m.Id }),
+		Before:       workspaceSnapshot(func(m *workspacev1.DeleteWorkspaceRequest) int64 { return m.Id }),
+	},
+	workspacev1connect.WorkspaceServiceAddMemberProcedure: {
+		ResourceType: "workspace_member",
+		ResourceID:   fromRequest(func(m *workspacev1.AddMemberRequest) int64 { return m.UserId }),
+		Before: memberSnapshot(func(m *workspacev1.AddMemberRequest) (int64, int64) {
+			return m.WorkspaceId, m.UserId
+		}),
+	},
+	workspacev1connect.WorkspaceServiceRemoveMemberProcedure: {
+		ResourceType: "workspace_member",
+		ResourceID:   fromRequest(func(m *workspacev1.RemoveMemberRequest) int64 { return m.UserId }),
+		Before: memberSnapshot(func(m *workspacev1.RemoveMemberRequest) (int64, int64) {
+			return m.WorkspaceId, m.UserId
+		}),
+	},
+
+	// app service
+	appv1connect.AppServiceCreateAppProcedure: {
+		ResourceType: "app",
+		ResourceID:   fromResponse(func(m *appv1.CreateAppResponse) int64 { return m.GetApp().GetId() }),
+		AppID:        fromResponse(func(m *appv1.CreateAppResponse) int64 { return m.GetApp().GetId() }),
+	},
+	appv1connect.AppServiceUpdateAppProcedure: {
+		ResourceType: "app",
+		ResourceID:   fromRequest(func(m *appv1.UpdateAppRequest) int64 { return m.Id }),
+		AppID:        fromRequest(func(m *appv1.UpdateAppRequest) int64 { return m.Id }),
+		Before:       appSnapshot(func(m *appv1.UpdateAppRequest) int64 { return m.Id }),
+	},
+	appv1connect.AppServiceDeleteAppProcedure: {
+		ResourceType: "app",
+		ResourceID:   fromRequest(func(m *appv1.DeleteAppRequest) int64 { return m.Id }),
+		AppID:        fromRequest(func(m *appv1.DeleteAppRequest) int64 { return m.Id }),
+		Before:       appSnapshot(func(m *appv1.DeleteAppRequest) int64 { return m.Id }),
+	},
+	appv1connect.AppServiceScaleAppProcedure: {
+		ResourceType: "app",
+		ResourceID:   fromRequest(func(m *appv1.ScaleAppRequest) int64 { return m.AppId }),
+		AppID:        fromRequest(func(m *appv1.ScaleAppRequest) int64 { return m.AppId }),
+		Before:       currentDeploymentSnapshot(func(m *appv1.ScaleAppRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceUpdateAppEnvProcedure: {
+		ResourceType: "app",
+		ResourceID:   fromRequest(func(m *appv1.UpdateAppEnvRequest) int64 { return m.AppId }),
+		AppID:        fromRequest(func(m *appv1.UpdateAppEnvRequest) int64 { return m.AppId }),
+		Before:       currentDeploymentSnapshot(func(m *appv1.UpdateAppEnvRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceRestartAppProcedure: {
+		ResourceType: "app",
+		ResourceID:   fromRequest(func(m *appv1.RestartAppRequest) int64 { return m.AppId }),
+		AppID:        fromRequest(func(m *appv1.RestartAppRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceRunCommandProcedure: {
+		ResourceType: "app",
+		ResourceID:   fromRequest(func(m *appv1.RunCommandRequest) int64 { return m.AppId }),
+		AppID:        fromRequest(func(m *appv1.RunCommandRequest) int64 { return m.AppId }),
+	},
+	// only the first message of the stream is recorded, keystrokes never are
+	appv1connect.AppServiceExecProcedure: {
+		ResourceType:  "app",
+		ResourceID:    fromRequest(func(m *appv1.ExecRequest) int64 { return m.AppId }),
+		AppID:         fromRequest(func(m *appv1.ExecRequest) int64 { return m.AppId }),
+		RecordOnStart: true,
+	},
+
+	// deployment service
+	deploymentv1connect.DeploymentServiceCreateDeploymentProcedure: {
+		ResourceType: "deployment",
+		ResourceID: fromResponse(func(m *deploymentv1.CreateDeploymentResponse) int64 {
+			return m.GetDeployment().GetId()
+		}),
+		AppID:  fromRequest(func(m *deploymentv1.CreateDeploymentRequest) int64 { return m.AppId }),
+		Before: currentDeploymentSnapshot(func(m *deploymentv1.CreateDeploymentRequest) int64 { return m.AppId }),
+	},
+
+	// quota service
+	quotav1connect.QuotaServiceSetWorkspaceQuotaProcedure: {
+		ResourceType: "workspace_quota",
+		ResourceID:   fromRequest(func(m *quotav1.SetWorkspaceQuotaRequest) int64 { return m.WorkspaceId }),
+		WorkspaceID:  fromRequest(func(m *quotav1.SetWorkspaceQuotaRequest) int64 { return m.WorkspaceId }),
+		Before: snapshot(func(ctx context.Context, q *genDb.Queries, m *quotav1.SetWorkspaceQuotaRequest) (genDb.WorkspaceQuota, error) {
+			return q.GetWorkspaceQuota(ctx, m.WorkspaceId)
+		}),
+	},
+
+	// registry service
+	registryv1connect.RegistryServiceGitlabTokenProcedure: {
+		ResourceType: "registry_token",
+	},
+
+	// cluster service
+	clusterv1connect.ClusterServiceRegisterClusterProcedure: {
+		ResourceType: "cluster",
+		ResourceID:   fromResponse(func(m *clusterv1.RegisterClusterResponse) int64 { return m.GetCluster().GetId() }),
+	},
+	clusterv1connect.ClusterServiceDeactivateClusterProcedure: {
+		ResourceType: "cluster",
+		ResourceID:   fromRequest(func(m *clusterv1.DeactivateClusterRequest) int64 { return m.Id }),
+		Before: snapshot(func(ctx context.Context, q *genDb.Queries, m *clusterv1.DeactivateClusterRequest) (genDb.Cluster, error) {
+			cluster, err := q.GetClusterByID(ctx, m.Id)
+			// the sealed kubeconfig never leaves the clusters table
+			cluster.Credentials = nil
+			return cluster, err
+		}),
+	},
+	// domain service
+	domainv1connect.DomainServiceAddDomainProcedure: {
+		ResourceType: "domain",
+		ResourceID:   fromResponse(func(m *domainv1.AddDomainResponse) int64 { return m.GetDomain().GetId() }),
+		AppID:        fromRequest(func(m *domainv1.AddDomainRequest) int64 { return m.AppId }),
+	},
+	domainv1connect.DomainServiceVerifyDomainProcedure: {
+		ResourceType: "domain",
+		ResourceID:   fromResponse(func(m *domainv1.VerifyDomainResponse) int64 { return m.GetDomain().GetId() }),
+		AppID:        fromRequest(func(m *domainv1.VerifyDomainRequest) int64 { return m.AppId }),
+		Before:       domainSnapshot(func(m *domainv1.VerifyDomainRequest) (int64, string) { return m.AppId, m.Hostname }),
+	},
+	domainv1connect.DomainServiceRemoveDomainProcedure: {
+		ResourceType: "domain",
+		AppID:        fromRequest(func(m *domainv1.RemoveDomainRequest) int64 { return m.AppId }),
+		Before:       domainSnapshot(func(m *domainv1.RemoveDomainRequest) (int64, string) { return m.AppId, m.Hostname }),
+	},
+	// job service
+	jobv1connect.JobServiceTriggerJobProcedure: {
+		ResourceType: "job",
+		AppID:        fromRequest(func(m *jobv1.TriggerJobRequest) int64 { return m.AppId }),
+	},
+
+	// audited because it can repair, scans without repair are recorded too
+	clusterv1connect.ClusterServiceDetectDriftProcedure: {
+		ResourceType: "cluster",
+		ResourceID:   fromRequest(func(m *clusterv1.DetectDriftRequest) int64 { return m.GetClusterId() }),
+	},
+}
+
+func fromRequest[T any](get func(*T) int64) IDFunc {
+	return func(req, _ any) int64 {
+		if m, ok := req.(*T); ok {
+			return get(m)
+		}
+		return 0
+	}
+}
+
+func fromResponse[T any](get func(*T) int64) IDFunc {
+	return func(_, res any) int64 {
+		if m, ok := res.(*T); ok {
+			return get(m)
+		}
+		return 0
+	}
+}
+
+// snapshot adapts a typed lookup into a SnapshotFunc
+func snapshot[T any, R any](lookup func(ctx context.Context, q *genDb.Queries, m *T) (R, error)) SnapshotFunc {
+	return func(ctx context.Context, q *genDb.Queries, req any) (any, error) {
+		m, ok := req.(*T)
+		if !ok {
+			return nil, nil
+		}
+		return lookup(ctx, q, m)
+	}
+}
+
+func userSnapshot[T any](id func(*T) int64) SnapshotFunc {
+	return snapshot(func(ctx context.Context, q *genDb.Queries, m *T) (genDb.User, error) {
+		return q.GetUserByID(ctx, id(m))
+	})
+}
+
+func orgSnapshot[T any](id func(*T) int64) SnapshotFunc {
+	return snapshot(func(ctx context.Context, q *genDb.Queries, m *T) (genDb.Organization, error) {
+		return q.GetOrgByID(ctx, id(m))
+	})
+}
+
+func workspaceSnapshot[T any](id func(*T) int64) SnapshotFunc {
+	return snapshot(func(ctx context.Context, q *genDb.Queries, m *T) (genDb.Workspace, error) {
+		return q.GetWorkspaceByIDQuery(ctx, id(m))
+	})
+}
+
+func memberSnapshot[T any](ids func(*T) (workspaceID int64, userID int64)) SnapshotFunc {
+	return snapshot(func(ctx context.Context, q *genDb.Queries, m *T) (genDb.GetWorkspaceMemberRow, error) {
+		workspaceID, userID := ids(m)
+		return q.GetWorkspaceMember(ctx, genDb.GetWorkspaceMemberParams{
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+		})
+	})
+}
+
+func appSnapshot[T any](id func(*T) int64) SnapshotFunc {
+	return snapshot(func(ctx context.Context, q *genDb.Queries, m *T) (genDb.App, error) {
+		return q.GetAppByID(ctx, id(m))
+	})
+}
+
+func domainSnapshot[T any](appDomain func(*T) (int64, string)) SnapshotFunc {
+	return snapshot(func(ctx context.Context, q *genDb.Queries, m *T) (*genDb.AppDomain, error) {
+		appID, hostname := appDomain(m)
+		normalized, err := domains.NormalizeHostname(hostname)
+		if err != nil {
+			return nil, nil
+		}
+		domain, err := q.GetAppDomainForApp(ctx, genDb.GetAppDomainForAppParams{AppID: appID, Hostname: normalized})
+		if err != nil {
+			return nil, err
+		}
+		return &domain, nil
+	})
+}
+
+// deploymentSummary leaves out the deployment config since it carries env values
+type deploymentSummary struct {
+	ID       int64                  `json:"id"`
+	Image    string                 `json:"image"`
+	Replicas int32                  `json:"replicas"`
+	Status   genDb.DeploymentStatus `json:"status"`
+}
+
+func currentDeploymentSnapshot[T any](appID func(*T) int64) SnapshotFunc {
+	return snapshot(func(ctx context.Context, q *genDb.Queries, m *T) (*deploymentSummary, error) {
+		deployments, err := q.ListDeploymentsForApp(ctx, genDb.ListDeploymentsForAppParams{
+			AppID:  appID(m),
+			Limit:  1,
+			Offset: 0,
+		})
+		if err != nil || len(deployments) == 0 {
+			return nil, err
+		}
+		d := deployments[0]
+		return &deploymentSummary{ID: d.ID, Image: d.Image, Replicas: d.Replicas, Status: d.Status}, nil
+	})
+}
@@ -0,0 +1,215 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/nikumar1206/loco/api/contextkeys"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+)
+
+var (
+	ErrNoPolicy            = errors.New("no authorization policy for procedure")
+	ErrUnauthenticated     = errors.New("unauthorized")
+	ErrUnexpectedMessage   = errors.New("unexpected request message")
+	ErrResourceNotFound    = errors.New("resource not found")
+	ErrNotSelf             = errors.New("user cannot act on behalf of another user")
+	ErrNotOrgMember        = errors.New("user is not a member of this organization")
+	ErrOrgRoleTooLow       = errors.New("user does not have the required role in this organization")
+	ErrNotWorkspaceMember  = errors.New("user is not a member of this workspace")
+	ErrWorkspaceRoleTooLow = errors.New("user does not have the required role in this workspace")
+	ErrNotAdmin            = errors.New("user is not a platform admin")
+)
+
+// higher rank grants everything a lower rank does
+var orgRoleRank = map[genDb.OrganizationRole]int{
+	genDb.OrganizationRoleMember: 1,
+	genDb.OrganizationRoleAdmin:  2,
+}
+
+var workspaceRoleRank = map[genDb.WorkspaceRole]int{
+	genDb.WorkspaceRoleRead:   1,
+	genDb.WorkspaceRoleDeploy: 2,
+	genDb.WorkspaceRoleAdmin:  3,
+}
+
+// Decision is the outcome of a single authorization check
+type Decision struct {
+	Procedure  string
+	Scope      Scope
+	UserID     int64
+	ResourceID int64
+	Required   string
+	Role       string
+	Allowed    bool
+	Reason     string
+}
+
+// Authorizer evaluates the policy table against the caller's memberships
+type Authorizer struct {
+	queries  *genDb.Queries
+	policies map[string]Policy
+	// admins are the external usernames (e.g. github:foo) allowed to call admin procedures
+	admins map[string]bool
+}
+
+// NewAuthorizer creates an Authorizer backed by the default policy table
+func NewAuthorizer(queries *genDb.Queries, admins []string) *Authorizer {
+	adminSet := make(map[string]bool, len(admins))
+	for _, a := range admins {
+		adminSet[a] = true
+	}
+	return &Authorizer{queries: queries, policies: Policies, admins: adminSet}
+}
+
+// NeedsMessage reports whether authorizing the procedure requires the request message.
+// Streaming handlers use this to defer the check until the first message is received.
+func (a *Authorizer) NeedsMessage(procedure string) bool {
+	policy, ok := a.policies[procedure]
+	return ok && policy.Resolve != nil
+}
+
+// Authorize is the single decision path for every procedure. It returns a connect error when the call is denied.
+// Every decision is logged, allowed or not.
+func (a *Authorizer) Authorize(ctx context.Context, procedure string, msg any) (Decision, error) {
+	decision, err := a.decide(ctx, procedure, msg)
+	decision.Procedure = procedure
+	decision.Allowed = err == nil
+	if err != nil {
+		decision.Reason = err.Error()
+	}
+	record(ctx, decision)
+	return decision, err
+}
+
+func (a *Authorizer) decide(ctx context.Context, procedure string, msg any) (Decision, error) {
+	policy, ok := a.policies[procedure]
+	if !ok {
+		return Decision{}, connect.NewError(connect.CodePermissionDenied, ErrNoPolicy)
+	}
+	decision := Decision{Scope: policy.Scope}
+
+	if policy.Scope == ScopePublic {
+		return decision, nil
+	}
+
+	userID, ok := contextkeys.UserID(ctx)
+	if !ok {
+		return decision, connect.NewError(connect.CodeUnauthenticated, ErrUnauthenticated)
+	}
+	decision.UserID = userID
+
+	if policy.Scope == ScopeAuthenticated {
+		return decision, nil
+	}
+
+	if policy.Scope == ScopeAdmin {
+		decision.Required = "admin"
+		username, _ := contextkeys.ExternalUsername(ctx)
+		if !a.admins[username] {
+			return decision, connect.NewError(connect.CodePermissionDenied, ErrNotAdmin)
+		}
+		decision.Role = "admin"
+		return decision, nil
+	}
+
+	if policy.Resolve == nil {
+		return decision, connect.NewError(connect.CodeInternal, fmt.Errorf("%w: missing resolver", ErrNoPolicy))
+	}
+
+	resourceID, err := policy.Resolve(ctx, a.queries, msg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return decision, connect.NewError(connect.CodeNotFound, ErrResourceNotFound)
+		}
+		if errors.Is(err, ErrUnexpectedMessage) {
+			return decision, connect.NewError(connect.CodeInternal, err)
+		}
+		return decision, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	decision.ResourceID = resourceID
+
+	switch policy.Scope {
+	case ScopeSelf:
+		decision.Required = "self"
+		if resourceID != userID {
+			return decision, connect.NewError(connect.CodePermissionDenied, ErrNotSelf)
+		}
+		return decision, nil
+
+	case ScopeOrg:
+		decision.Required = string(policy.OrgRole)
+		role, err := a.queries.GetOrgMemberRole(ctx, genDb.GetOrgMemberRoleParams{
+			OrganizationID: resourceID,
+			UserID:         userID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return decision, connect.NewError(connect.CodePermissionDenied, ErrNotOrgMember)
+			}
+			return decision, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		}
+		decision.Role = string(role)
+		if orgRoleRank[role] < orgRoleRank[policy.OrgRole] {
+			return decision, connect.NewError(connect.CodePermissionDenied, ErrOrgRoleTooLow)
+		}
+		return decision, nil
+
+	case ScopeWorkspace:
+		required := policy.WorkspaceRole
+		if policy.AllowSelf != nil && policy.AllowSelf(userID, msg) {
+			required = genDb.WorkspaceRoleRead
+		}
+		decision.Required = string(required)
+		role, err := a.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
+			WorkspaceID: resourceID,
+			UserID:      userID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return decision, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
+			}
+			return decision, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		}
+		decision.Role = string(role)
+		if workspaceRoleRank[role] < workspaceRoleRank[required] {
+			return decision, connect.NewError(connect.CodePermissionDenied, ErrWorkspaceRoleTooLow)
+		}
+		return decision, nil
+	}
+
+	return decision, connect.NewError(connect.CodeInternal, fmt.Errorf("%w: unknown scope %d", ErrNoPolicy, policy.Scope))
+}
+
+// NewContext returns a copy of ctx carrying the decision, for interceptors and handlers that run after authorization
+func NewContext(ctx context.Context, d Decision) context.Context {
+	return context.WithValue(ctx, contextkeys.AuthzDecisionKey, d)
+}
+
+// FromContext returns the decision made for the current request, if any
+func FromContext(ctx context.Context) (Decision, bool) {
+	d, ok := ctx.Value(contextkeys.AuthzDecisionKey).(Decision)
+	return d, ok
+}
+
+// record is the audit trail for authorization decisions
+func record(ctx context.Context, d Decision) {
+	attrs := []any{
+		"procedure", d.Procedure,
+		"scope", d.Scope.String(),
+		"userId", d.UserID,
+		"resourceId", d.ResourceID,
+		"required", d.Required,
+		"role", d.Role,
+		"allowed", d.Allowed,
+	}
+	if !d.Allowed {
+		slog.WarnContext(ctx, "authorization denied", append(attrs, "reason", d.Reason)...)
+		return
+	}
+	slog.DebugContext(ctx, "authorization granted", attrs...)
+}
@@ -0,0 +1,418 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
+	"github.com/nikumar1206/loco/shared/proto/app/v1/appv1connect"
+	auditv1 "github.com/nikumar1206/loco/shared/proto/audit/v1"
+	"github.com/nikumar1206/loco/shared/proto/audit/v1/auditv1connect"
+	"github.com/nikumar1206/loco/shared/proto/cluster/v1/clusterv1connect"
+	deploymentv1 "github.com/nikumar1206/loco/shared/proto/deployment/v1"
+	"github.com/nikumar1206/loco/shared/proto/deployment/v1/deploymentv1connect"
+	domainv1 "github.com/nikumar1206/loco/shared/proto/domain/v1"
+	"github.com/nikumar1206/loco/shared/proto/domain/v1/domainv1connect"
+	jobv1 "github.com/nikumar1206/loco/shared/proto/job/v1"
+	"github.com/nikumar1206/loco/shared/proto/job/v1/jobv1connect"
+	"github.com/nikumar1206/loco/shared/proto/oauth/v1/oauthv1connect"
+	orgv1 "github.com/nikumar1206/loco/shared/proto/org/v1"
+	"github.com/nikumar1206/loco/shared/proto/org/v1/orgv1connect"
+	quotav1 "github.com/nikumar1206/loco/shared/proto/quota/v1"
+	"github.com/nikumar1206/loco/shared/proto/quota/v1/quotav1connect"
+	"github.com/nikumar1206/loco/shared/proto/registry/v1/registryv1connect"
+	userv1 "github.com/nikumar1206/loco/shared/proto/user/v1"
+	"github.com/nikumar1206/loco/shared/proto/user/v1/userv1connect"
+	workspacev1 "github.com/nikumar1206/loco/shared/proto/workspace/v1"
+	"github.com/nikumar1206/loco/shared/proto/workspace/v1/workspacev1connect"
+)
+
+// Scope describes what kind of resource a procedure acts on, and therefore which membership is checked
+type Scope int
+
+const (
+	// ScopePublic procedures don't require a token at all
+	ScopePublic Scope = iota + 1
+	// ScopeAuthenticated procedures only require a valid token
+	ScopeAuthenticated
+	// ScopeSelf procedures may only be called by the user they act on
+	ScopeSelf
+	// ScopeOrg procedures require a role in the resolved organization
+	ScopeOrg
+	// ScopeWorkspace procedures require a role in the resolved workspace
+	ScopeWorkspace
+	// ScopeAdmin procedures are reserved for platform operators
+	ScopeAdmin
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopePublic:
+		return "public"
+	case ScopeAuthenticated:
+		return "authenticated"
+	case ScopeSelf:
+		return "self"
+	case ScopeOrg:
+		return "org"
+	case ScopeWorkspace:
+		return "workspace"
+	case ScopeAdmin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+// Resolver returns the ID of the org, workspace or user a request acts on, depending on the policy's scope
+type Resolver func(ctx context.Context, q *genDb.Queries, msg any) (int64, error)
+
+// Policy is the authorization rule for a single procedure
+type Policy struct {
+	Scope Scope
+	// OrgRole is the minimum org role required when Scope is ScopeOrg
+	OrgRole genDb.OrganizationRole
+	// WorkspaceRole is the minimum workspace role required when Scope is ScopeWorkspace
+	WorkspaceRole genDb.WorkspaceRole
+	// Resolve extracts the resource from the request message. Required for self, org and workspace scopes.
+	Resolve Resolver
+	// AllowSelf lowers the required role to plain membership when the caller acts on themselves
+	AllowSelf func(userID int64, msg any) bool
+}
+
+// Policies maps every procedure served by loco-api to its authorization rule.
+// Procedures missing from this table are denied.
+var Policies = map[string]Policy{
+	// oauth service
+	oauthv1connect.OAuthServiceGithubOAuthDetailsProcedure:  {Scope: ScopePublic},
+	oauthv1connect.OAuthServiceExchangeGithubTokenProcedure: {Scope: ScopePublic},
+
+	// user service
+	userv1connect.UserServiceCreateUserProcedure:     {Scope: ScopeAuthenticated},
+	userv1connect.UserServiceGetUserProcedure:        {Scope: ScopeAuthenticated},
+	userv1connect.UserServiceGetCurrentUserProcedure: {Scope: ScopeAuthenticated},
+	userv1connect.UserServiceListUsersProcedure:      {Scope: ScopeAuthenticated},
+	userv1connect.UserServiceUpdateUserProcedure: {
+		Scope:   ScopeSelf,
+		Resolve: fromMessage(func(m *userv1.UpdateUserRequest) int64 { return m.Id }),
+	},
+	userv1connect.UserServiceDeleteUserProcedure: {
+		Scope:   ScopeSelf,
+		Resolve: fromMessage(func(m *userv1.DeleteUserRequest) int64 { return m.Id }),
+	},
+
+	// org service
+	orgv1connect.OrgServiceCreateOrgProcedure:          {Scope: ScopeAuthenticated},
+	orgv1connect.OrgServiceGetCurrentUserOrgsProcedure: {Scope: ScopeAuthenticated},
+	orgv1connect.OrgServiceIsUniqueOrgNameProcedure:    {Scope: ScopeAuthenticated},
+	// lists the orgs a user is a member of, so only that user may
+	orgv1connect.OrgServiceListOrgsProcedure: {
+		Scope:   ScopeSelf,
+		Resolve: fromMessage(func(m *orgv1.ListOrgsRequest) int64 { return m.UserId }),
+	},
+	orgv1connect.OrgServiceGetOrgProcedure: {
+		Scope:   ScopeOrg,
+		OrgRole: genDb.OrganizationRoleMember,
+		Resolve: fromMessage(func(m *orgv1.GetOrgRequest) int64 { return m.Id }),
+	},
+	orgv1connect.OrgServiceListWorkspacesProcedure: {
+		Scope:   ScopeOrg,
+		OrgRole: genDb.OrganizationRoleMember,
+		Resolve: fromMessage(func(m *orgv1.ListWorkspacesRequest) int64 { return m.OrgId }),
+	},
+	orgv1connect.OrgServiceUpdateOrgProcedure: {
+		Scope:   ScopeOrg,
+		OrgRole: genDb.OrganizationRoleAdmin,
+		Resolve: fromMessage(func(m *orgv1.UpdateOrgRequest) int64 { return m.Id }),
+	},
+	orgv1connect.OrgServiceDeleteOrgProcedure: {
+		Scope:   ScopeOrg,
+		OrgRole: genDb.OrganizationRoleAdmin,
+		Resolve: fromMessage(func(m *orgv1.DeleteOrgRequest) int64 { return m.Id }),
+	},
+
+	// workspace service
+	workspacev1connect.WorkspaceServiceGetUserWorkspacesProcedure: {Scope: ScopeAuthenticated},
+	workspacev1connect.WorkspaceServiceCreateWorkspaceProcedure: {
+		Scope:   ScopeOrg,
+		OrgRole: genDb.OrganizationRoleAdmin,
+		Resolve: fromMessage(func(m *workspacev1.CreateWorkspaceRequest) int64 { return m.OrgId }),
+	},
+	workspacev1connect.WorkspaceServiceListWorkspacesProcedure: {
+		Scope:   ScopeOrg,
+		OrgRole: genDb.OrganizationRoleMember,
+		Resolve: fromMessage(func(m *workspacev1.ListWorkspacesRequest) int64 { return m.OrgId }),
+	},
+	workspacev1connect.WorkspaceServiceGetWorkspaceProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       fromMessage(func(m *workspacev1.GetWorkspaceRequest) int64 { return m.Id }),
+	},
+	workspacev1connect.WorkspaceServiceListMembersProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       fromMessage(func(m *workspacev1.ListMembersRequest) int64 { return m.WorkspaceId }),
+	},
+	workspacev1connect.WorkspaceServiceUpdateWorkspaceProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleAdmin,
+		Resolve:       fromMessage(func(m *workspacev1.UpdateWorkspaceRequest) int64 { return m.Id }),
+	},
+	workspacev1connect.WorkspaceServiceDeleteWorkspaceProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleAdmin,
+		Resolve:       fromMessage(func(m *workspacev1.DeleteWorkspaceRequest) int64 { return m.Id }),
+	},
+	workspacev1connect.WorkspaceServiceAddMemberProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleAdmin,
+		Resolve:       fromMessage(func(m *workspacev1.AddMemberRequest) int64 { return m.WorkspaceId }),
+	},
+	workspacev1connect.WorkspaceServiceRemoveMemberProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleAdmin,
+		Resolve:       fromMessage(func(m *workspacev1.RemoveMemberRequest) int64 { return m.WorkspaceId }),
+		// members can always leave a workspace
+		AllowSelf: func(userID int64, msg any) bool {
+			m, ok := msg.(*workspacev1.RemoveMemberRequest)
+			return ok && m.UserId == userID
+		},
+	},
+
+	// app service
+	// the answer depends on the workspace's own apps, so only its members may ask
+	appv1connect.AppServiceCheckSubdomainAvailabilityProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       fromMessage(func(m *appv1.CheckSubdomainAvailabilityRequest) int64 { return m.GetWorkspaceId() }),
+	},
+	appv1connect.AppServiceCreateAppProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       fromMessage(func(m *appv1.CreateAppRequest) int64 { return m.WorkspaceId }),
+	},
+	appv1connect.AppServiceGetAppByNameProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       fromMessage(func(m *appv1.GetAppByNameRequest) int64 { return m.WorkspaceId }),
+	},
+	appv1connect.AppServiceListAppsProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       fromMessage(func(m *appv1.ListAppsRequest) int64 { return m.WorkspaceId }),
+	},
+	appv1connect.AppServiceGetAppProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       appWorkspace(func(m *appv1.GetAppRequest) int64 { return m.Id }),
+	},
+	appv1connect.AppServiceGetAppStatusProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       appWorkspace(func(m *appv1.GetAppStatusRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceStreamLogsProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       appWorkspace(func(m *appv1.StreamLogsRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceGetAppMetricsProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       appWorkspace(func(m *appv1.GetAppMetricsRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceGetEventsProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       appWorkspace(func(m *appv1.GetEventsRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceUpdateAppProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *appv1.UpdateAppRequest) int64 { return m.Id }),
+	},
+	appv1connect.AppServiceScaleAppProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *appv1.ScaleAppRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceUpdateAppEnvProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *appv1.UpdateAppEnvRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceRestartAppProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *appv1.RestartAppRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceRunCommandProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *appv1.RunCommandRequest) int64 { return m.AppId }),
+	},
+	// a shell reaches everything the app's container can, secrets included
+	appv1connect.AppServiceExecProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleAdmin,
+		Resolve:       appWorkspace(func(m *appv1.ExecRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServicePortForwardProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *appv1.PortForwardRequest) int64 { return m.AppId }),
+	},
+	appv1connect.AppServiceDeleteAppProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleAdmin,
+		Resolve:       appWorkspace(func(m *appv1.DeleteAppRequest) int64 { return m.Id }),
+	},
+
+	// deployment service
+	deploymentv1connect.DeploymentServiceCreateDeploymentProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *deploymentv1.CreateDeploymentRequest) int64 { return m.AppId }),
+	},
+	deploymentv1connect.DeploymentServiceListDeploymentsProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       appWorkspace(func(m *deploymentv1.ListDeploymentsRequest) int64 { return m.AppId }),
+	},
+	deploymentv1connect.DeploymentServiceGetDeploymentProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       deploymentWorkspace(func(m *deploymentv1.GetDeploymentRequest) int64 { return m.DeploymentId }),
+	},
+	deploymentv1connect.DeploymentServiceStreamDeploymentProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       deploymentWorkspace(func(m *deploymentv1.StreamDeploymentRequest) int64 { return m.DeploymentId }),
+	},
+
+	// registry service
+	registryv1connect.RegistryServiceGitlabTokenProcedure: {Scope: ScopeAuthenticated},
+
+	// audit service
+	auditv1connect.AuditServiceListEventsProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleAdmin,
+		Resolve:       fromMessage(func(m *auditv1.ListEventsRequest) int64 { return m.WorkspaceId }),
+	},
+
+	// quota service
+	quotav1connect.QuotaServiceGetQuotaProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       fromMessage(func(m *quotav1.GetQuotaRequest) int64 { return m.WorkspaceId }),
+	},
+	// workspace admins can't raise their own limits, only the org can
+	quotav1connect.QuotaServiceSetWorkspaceQuotaProcedure: {
+		Scope:   ScopeOrg,
+		OrgRole: genDb.OrganizationRoleAdmin,
+		Resolve: workspaceOrg(func(m *quotav1.SetWorkspaceQuotaRequest) int64 { return m.WorkspaceId }),
+	},
+
+	// cluster service
+	clusterv1connect.ClusterServiceRegisterClusterProcedure:   {Scope: ScopeAdmin},
+	clusterv1connect.ClusterServiceListClustersProcedure:      {Scope: ScopeAdmin},
+	clusterv1connect.ClusterServiceDeactivateClusterProcedure: {Scope: ScopeAdmin},
+	clusterv1connect.ClusterServiceGetCapacityProcedure:       {Scope: ScopeAdmin},
+	clusterv1connect.ClusterServiceGetClusterHealthProcedure:  {Scope: ScopeAdmin},
+	clusterv1connect.ClusterServiceDetectDriftProcedure:       {Scope: ScopeAdmin},
+	clusterv1connect.ClusterServiceRebuildClusterProcedure:    {Scope: ScopeAdmin},
+
+	// domain service
+	domainv1connect.DomainServiceAddDomainProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *domainv1.AddDomainRequest) int64 { return m.AppId }),
+	},
+	domainv1connect.DomainServiceVerifyDomainProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *domainv1.VerifyDomainRequest) int64 { return m.AppId }),
+	},
+	domainv1connect.DomainServiceListDomainsProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       appWorkspace(func(m *domainv1.ListDomainsRequest) int64 { return m.AppId }),
+	},
+	domainv1connect.DomainServiceRemoveDomainProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *domainv1.RemoveDomainRequest) int64 { return m.AppId }),
+	},
+
+	// job service
+	jobv1connect.JobServiceListJobsProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       appWorkspace(func(m *jobv1.ListJobsRequest) int64 { return m.AppId }),
+	},
+	jobv1connect.JobServiceListJobRunsProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleRead,
+		Resolve:       appWorkspace(func(m *jobv1.ListJobRunsRequest) int64 { return m.AppId }),
+	},
+	jobv1connect.JobServiceTriggerJobProcedure: {
+		Scope:         ScopeWorkspace,
+		WorkspaceRole: genDb.WorkspaceRoleDeploy,
+		Resolve:       appWorkspace(func(m *jobv1.TriggerJobRequest) int64 { return m.AppId }),
+	},
+}
+
+// IsPublic reports whether a procedure can be called without a token
+func IsPublic(procedure string) bool {
+	policy, ok := Policies[procedure]
+	return ok && policy.Scope == ScopePublic
+}
+
+// fromMessage resolves to an ID carried directly on the request message
+func fromMessage[T any](get func(*T) int64) Resolver {
+	return func(_ context.Context, _ *genDb.Queries, msg any) (int64, error) {
+		m, ok := msg.(*T)
+		if !ok {
+			return 0, fmt.Errorf("%w: got %T", ErrUnexpectedMessage, msg)
+		}
+		return get(m), nil
+	}
+}
+
+// workspaceOrg resolves to the org that owns the workspace referenced by the request
+func workspaceOrg[T any](get func(*T) int64) Resolver {
+	return func(ctx context.Context, q *genDb.Queries, msg any) (int64, error) {
+		m, ok := msg.(*T)
+		if !ok {
+			return 0, fmt.Errorf("%w: got %T", ErrUnexpectedMessage, msg)
+		}
+		return q.GetWorkspaceOrgID(ctx, get(m))
+	}
+}
+
+// appWorkspace resolves to the workspace that owns the app referenced by the request
+func appWorkspace[T any](get func(*T) int64) Resolver {
+	return func(ctx context.Context, q *genDb.Queries, msg any) (int64, error) {
+		m, ok := msg.(*T)
+		if !ok {
+			return 0, fmt.Errorf("%w: got %T", ErrUnexpectedMessage, msg)
+		}
+		return q.GetAppWorkspaceID(ctx, get(m))
+	}
+}
+
+// deploymentWorkspace resolves to the workspace that owns the deployment referenced by the request
+func deploymentWorkspace[T any](get func(*T) int64) Resolver {
+	return func(ctx context.Context, q *genDb.Queries, msg any) (int64, error) {
+		m, ok := msg.(*T)
+		if !ok {
+			return 0, fmt.Errorf("%w: got %T", ErrUnexpectedMessage, msg)
+		}
+		appID, err := q.GetDeploymentAppID(ctx, get(m))
+		if err != nil {
+			return 0, err
+		}
+		return q.GetAppWorkspaceID(ctx, appID)
+	}
+}
@@ -0,0 +1,300 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/nikumar1206/loco/api/contextkeys"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
+	"github.com/nikumar1206/loco/shared/proto/app/v1/appv1connect"
+	auditv1 "github.com/nikumar1206/loco/shared/proto/audit/v1"
+	clusterv1 "github.com/nikumar1206/loco/shared/proto/cluster/v1"
+	"github.com/nikumar1206/loco/shared/proto/cluster/v1/clusterv1connect"
+	deploymentv1 "github.com/nikumar1206/loco/shared/proto/deployment/v1"
+	"github.com/nikumar1206/loco/shared/proto/deployment/v1/deploymentv1connect"
+	domainv1 "github.com/nikumar1206/loco/shared/proto/domain/v1"
+	jobv1 "github.com/nikumar1206/loco/shared/proto/job/v1"
+	oauthv1 "github.com/nikumar1206/loco/shared/proto/oauth/v1"
+	"github.com/nikumar1206/loco/shared/proto/oauth/v1/oauthv1connect"
+	orgv1 "github.com/nikumar1206/loco/shared/proto/org/v1"
+	"github.com/nikumar1206/loco/shared/proto/org/v1/orgv1connect"
+	quotav1 "github.com/nikumar1206/loco/shared/proto/quota/v1"
+	"github.com/nikumar1206/loco/shared/proto/quota/v1/quotav1connect"
+	registryv1 "github.com/nikumar1206/loco/shared/proto/registry/v1"
+	userv1 "github.com/nikumar1206/loco/shared/proto/user/v1"
+	"github.com/nikumar1206/loco/shared/proto/user/v1/userv1connect"
+	workspacev1 "github.com/nikumar1206/loco/shared/proto/workspace/v1"
+	"github.com/nikumar1206/loco/shared/proto/workspace/v1/workspacev1connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// serviceFiles are the proto files of every service loco-api serves
+var serviceFiles = []protoreflect.FileDescriptor{
+	oauthv1.File_shared_proto_oauth_v1_oauth_proto,
+	userv1.File_shared_proto_user_v1_user_proto,
+	orgv1.File_shared_proto_org_v1_org_proto,
+	workspacev1.File_shared_proto_workspace_v1_workspace_proto,
+	appv1.File_shared_proto_app_v1_app_proto,
+	deploymentv1.File_shared_proto_deployment_v1_deployment_proto,
+	registryv1.File_shared_proto_registry_v1_registry_proto,
+	auditv1.File_shared_proto_audit_v1_audit_proto,
+	quotav1.File_shared_proto_quota_v1_quota_proto,
+	clusterv1.File_shared_proto_cluster_v1_cluster_proto,
+	domainv1.File_shared_proto_domain_v1_domain_proto,
+	jobv1.File_shared_proto_job_v1_job_proto,
+}
+
+// procedures returns every procedure of serviceFiles, the values of the generated *Procedure constants,
+// with the request message each one takes
+func procedures(t *testing.T) map[string]proto.Message {
+	t.Helper()
+
+	result := map[string]proto.Message{}
+	for _, file := range serviceFiles {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				method := methods.Get(j)
+				msgType, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+				if err != nil {
+					t.Fatalf("request message of %s: %v", method.FullName(), err)
+				}
+				procedure := fmt.Sprintf("/%s/%s", services.Get(i).FullName(), method.Name())
+				result[procedure] = msgType.New().Interface()
+			}
+		}
+	}
+	return result
+}
+
+func TestEveryProcedureHasPolicy(t *testing.T) {
+	all := procedures(t)
+
+	for procedure, msg := range all {
+		policy, ok := Policies[procedure]
+		if !ok {
+			t.Errorf("%s has no policy, it would always be denied", procedure)
+			continue
+		}
+
+		switch policy.Scope {
+		case ScopePublic, ScopeAuthenticated, ScopeAdmin:
+			if policy.Resolve != nil {
+				t.Errorf("%s is %s but has a resolver", procedure, policy.Scope)
+			}
+		case ScopeSelf, ScopeOrg, ScopeWorkspace:
+			if policy.Resolve == nil {
+				t.Errorf("%s is %s but has no resolver", procedure, policy.Scope)
+				continue
+			}
+			// a resolver for another request's message only fails once the procedure is called
+			_, err := policy.Resolve(context.Background(), genDb.New(fakeDB{}), msg)
+			if errors.Is(err, ErrUnexpectedMessage) {
+				t.Errorf("resolver of %s doesn't take its request: %v", procedure, err)
+			}
+		default:
+			t.Errorf("%s has unknown scope %d", procedure, policy.Scope)
+		}
+
+		if policy.Scope == ScopeOrg && orgRoleRank[policy.OrgRole] == 0 {
+			t.Errorf("%s has no org role", procedure)
+		}
+		if policy.Scope == ScopeWorkspace && workspaceRoleRank[policy.WorkspaceRole] == 0 {
+			t.Errorf("%s has no workspace role", procedure)
+		}
+	}
+
+	for procedure := range Policies {
+		if _, ok := all[procedure]; !ok {
+			t.Errorf("policy for %s matches no procedure", procedure)
+		}
+	}
+}
+
+// Fixtures of fakeDB. Workspace 10 belongs to org 20 and holds app 100, whose deployment is 1000.
+const (
+	testOrgID        int64 = 20
+	testWorkspaceID  int64 = 10
+	testAppID        int64 = 100
+	testDeploymentID int64 = 1000
+
+	userWorkspaceAdmin  int64 = 1
+	userWorkspaceDeploy int64 = 2
+	userWorkspaceRead   int64 = 3
+	userOutsider        int64 = 4
+	userOrgAdmin        int64 = 5
+)
+
+// fakeDB answers the lookups authorization makes from the fixtures above
+type fakeDB struct{}
+
+func (fakeDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.New("unexpected exec")
+}
+
+func (fakeDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+func (fakeDB) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	workspaceRoles := map[int64]genDb.WorkspaceRole{
+		userWorkspaceAdmin:  genDb.WorkspaceRoleAdmin,
+		userWorkspaceDeploy: genDb.WorkspaceRoleDeploy,
+		userWorkspaceRead:   genDb.WorkspaceRoleRead,
+	}
+	orgRoles := map[int64]genDb.OrganizationRole{
+		userOrgAdmin:        genDb.OrganizationRoleAdmin,
+		userWorkspaceAdmin:  genDb.OrganizationRoleMember,
+		userWorkspaceDeploy: genDb.OrganizationRoleMember,
+		userWorkspaceRead:   genDb.OrganizationRoleMember,
+	}
+
+	switch {
+	case strings.Contains(sql, "name: GetAppWorkspaceID "):
+		if args[0] == testAppID {
+			return fakeRow{value: testWorkspaceID}
+		}
+	case strings.Contains(sql, "name: GetDeploymentAppID "):
+		if args[0] == testDeploymentID {
+			return fakeRow{value: testAppID}
+		}
+	case strings.Contains(sql, "name: GetWorkspaceOrgID "):
+		if args[0] == testWorkspaceID {
+			return fakeRow{value: testOrgID}
+		}
+	case strings.Contains(sql, "name: GetWorkspaceMemberRole "):
+		if role, ok := workspaceRoles[args[1].(int64)]; ok && args[0] == testWorkspaceID {
+			return fakeRow{value: role}
+		}
+	case strings.Contains(sql, "name: GetOrgMemberRole "):
+		if role, ok := orgRoles[args[1].(int64)]; ok && args[0] == testOrgID {
+			return fakeRow{value: role}
+		}
+	}
+	return fakeRow{err: pgx.ErrNoRows}
+}
+
+type fakeRow struct {
+	value any
+	err   error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	switch d := dest[0].(type) {
+	case *int64:
+		*d = r.value.(int64)
+	case *genDb.WorkspaceRole:
+		*d = r.value.(genDb.WorkspaceRole)
+	case *genDb.OrganizationRole:
+		*d = r.value.(genDb.OrganizationRole)
+	default:
+		return fmt.Errorf("unexpected scan into %T", dest[0])
+	}
+	return nil
+}
+
+func TestAuthorize(t *testing.T) {
+	const admin = "github:operator"
+
+	tests := []struct {
+		name      string
+		procedure string
+		msg       any
+		// userID 0 calls without a token
+		userID   int64
+		username string
+		// want is the code the call is denied with, 0 when it's allowed
+		want connect.Code
+	}{
+		// public
+		{name: "public without token", procedure: oauthv1connect.OAuthServiceGithubOAuthDetailsProcedure, want: 0},
+
+		// authenticated
+		{name: "authenticated without token", procedure: userv1connect.UserServiceGetCurrentUserProcedure, want: connect.CodeUnauthenticated},
+		{name: "authenticated with token", procedure: userv1connect.UserServiceGetCurrentUserProcedure, userID: userOutsider, want: 0},
+
+		// self
+		{name: "self on themselves", procedure: userv1connect.UserServiceUpdateUserProcedure, msg: &userv1.UpdateUserRequest{Id: userOutsider}, userID: userOutsider, want: 0},
+		{name: "self on another user", procedure: userv1connect.UserServiceUpdateUserProcedure, msg: &userv1.UpdateUserRequest{Id: userWorkspaceAdmin}, userID: userOutsider, want: connect.CodePermissionDenied},
+		{name: "list own orgs", procedure: orgv1connect.OrgServiceListOrgsProcedure, msg: &orgv1.ListOrgsRequest{UserId: userOutsider}, userID: userOutsider, want: 0},
+		{name: "list another user's orgs", procedure: orgv1connect.OrgServiceListOrgsProcedure, msg: &orgv1.ListOrgsRequest{UserId: userWorkspaceAdmin}, userID: userOutsider, want: connect.CodePermissionDenied},
+
+		// org
+		{name: "org member reads org", procedure: orgv1connect.OrgServiceGetOrgProcedure, msg: &orgv1.GetOrgRequest{Id: testOrgID}, userID: userWorkspaceRead, want: 0},
+		{name: "outsider reads org", procedure: orgv1connect.OrgServiceGetOrgProcedure, msg: &orgv1.GetOrgRequest{Id: testOrgID}, userID: userOutsider, want: connect.CodePermissionDenied},
+		{name: "org member deletes org", procedure: orgv1connect.OrgServiceDeleteOrgProcedure, msg: &orgv1.DeleteOrgRequest{Id: testOrgID}, userID: userWorkspaceAdmin, want: connect.CodePermissionDenied},
+		{name: "org admin deletes org", procedure: orgv1connect.OrgServiceDeleteOrgProcedure, msg: &orgv1.DeleteOrgRequest{Id: testOrgID}, userID: userOrgAdmin, want: 0},
+		{name: "workspace admin raises own quota", procedure: quotav1connect.QuotaServiceSetWorkspaceQuotaProcedure, msg: &quotav1.SetWorkspaceQuotaRequest{WorkspaceId: testWorkspaceID}, userID: userWorkspaceAdmin, want: connect.CodePermissionDenied},
+		{name: "org admin sets workspace quota", procedure: quotav1connect.QuotaServiceSetWorkspaceQuotaProcedure, msg: &quotav1.SetWorkspaceQuotaRequest{WorkspaceId: testWorkspaceID}, userID: userOrgAdmin, want: 0},
+
+		// workspace, read
+		{name: "reader gets app", procedure: appv1connect.AppServiceGetAppProcedure, msg: &appv1.GetAppRequest{Id: testAppID}, userID: userWorkspaceRead, want: 0},
+		{name: "outsider gets app", procedure: appv1connect.AppServiceGetAppProcedure, msg: &appv1.GetAppRequest{Id: testAppID}, userID: userOutsider, want: connect.CodePermissionDenied},
+		{name: "org admin outside workspace gets app", procedure: appv1connect.AppServiceGetAppProcedure, msg: &appv1.GetAppRequest{Id: testAppID}, userID: userOrgAdmin, want: connect.CodePermissionDenied},
+		{name: "unknown app", procedure: appv1connect.AppServiceGetAppProcedure, msg: &appv1.GetAppRequest{Id: 999}, userID: userWorkspaceAdmin, want: connect.CodeNotFound},
+		{name: "reader gets deployment", procedure: deploymentv1connect.DeploymentServiceGetDeploymentProcedure, msg: &deploymentv1.GetDeploymentRequest{DeploymentId: testDeploymentID}, userID: userWorkspaceRead, want: 0},
+		{name: "member checks subdomain", procedure: appv1connect.AppServiceCheckSubdomainAvailabilityProcedure, msg: &appv1.CheckSubdomainAvailabilityRequest{WorkspaceId: proto.Int64(testWorkspaceID)}, userID: userWorkspaceRead, want: 0},
+		{name: "outsider checks subdomain", procedure: appv1connect.AppServiceCheckSubdomainAvailabilityProcedure, msg: &appv1.CheckSubdomainAvailabilityRequest{WorkspaceId: proto.Int64(testWorkspaceID)}, userID: userOutsider, want: connect.CodePermissionDenied},
+		{name: "subdomain check without workspace", procedure: appv1connect.AppServiceCheckSubdomainAvailabilityProcedure, msg: &appv1.CheckSubdomainAvailabilityRequest{}, userID: userWorkspaceRead, want: connect.CodePermissionDenied},
+
+		// workspace, deploy
+		{name: "reader deploys", procedure: deploymentv1connect.DeploymentServiceCreateDeploymentProcedure, msg: &deploymentv1.CreateDeploymentRequest{AppId: testAppID}, userID: userWorkspaceRead, want: connect.CodePermissionDenied},
+		{name: "deployer deploys", procedure: deploymentv1connect.DeploymentServiceCreateDeploymentProcedure, msg: &deploymentv1.CreateDeploymentRequest{AppId: testAppID}, userID: userWorkspaceDeploy, want: 0},
+		{name: "admin deploys", procedure: deploymentv1connect.DeploymentServiceCreateDeploymentProcedure, msg: &deploymentv1.CreateDeploymentRequest{AppId: testAppID}, userID: userWorkspaceAdmin, want: 0},
+
+		// workspace, admin
+		{name: "deployer execs", procedure: appv1connect.AppServiceExecProcedure, msg: &appv1.ExecRequest{AppId: testAppID}, userID: userWorkspaceDeploy, want: connect.CodePermissionDenied},
+		{name: "admin execs", procedure: appv1connect.AppServiceExecProcedure, msg: &appv1.ExecRequest{AppId: testAppID}, userID: userWorkspaceAdmin, want: 0},
+		{name: "deployer deletes app", procedure: appv1connect.AppServiceDeleteAppProcedure, msg: &appv1.DeleteAppRequest{Id: testAppID}, userID: userWorkspaceDeploy, want: connect.CodePermissionDenied},
+		{name: "reader removes another member", procedure: workspacev1connect.WorkspaceServiceRemoveMemberProcedure, msg: &workspacev1.RemoveMemberRequest{WorkspaceId: testWorkspaceID, UserId: userWorkspaceDeploy}, userID: userWorkspaceRead, want: connect.CodePermissionDenied},
+		{name: "reader leaves workspace", procedure: workspacev1connect.WorkspaceServiceRemoveMemberProcedure, msg: &workspacev1.RemoveMemberRequest{WorkspaceId: testWorkspaceID, UserId: userWorkspaceRead}, userID: userWorkspaceRead, want: 0},
+
+		// admin
+		{name: "non-admin lists clusters", procedure: clusterv1connect.ClusterServiceListClustersProcedure, userID: userOrgAdmin, username: "github:someone", want: connect.CodePermissionDenied},
+		{name: "admin lists clusters", procedure: clusterv1connect.ClusterServiceListClustersProcedure, userID: userOrgAdmin, username: admin, want: 0},
+
+		// no policy
+		{name: "unknown procedure", procedure: "/loco.unknown.v1.UnknownService/Do", userID: userWorkspaceAdmin, want: connect.CodePermissionDenied},
+	}
+
+	authorizer := NewAuthorizer(genDb.New(fakeDB{}), []string{admin})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.userID != 0 {
+				ctx = context.WithValue(ctx, contextkeys.UserIDKey, tt.userID)
+				ctx = context.WithValue(ctx, contextkeys.ExternalUsernameKey, tt.username)
+			}
+
+			decision, err := authorizer.Authorize(ctx, tt.procedure, tt.msg)
+			if tt.want == 0 {
+				if err != nil {
+					t.Fatalf("want allowed, got %v", err)
+				}
+				if !decision.Allowed {
+					t.Fatalf("decision not allowed: %+v", decision)
+				}
+				return
+			}
+			if got := connect.CodeOf(err); got != tt.want {
+				t.Fatalf("want %v, got %v (%v)", tt.want, got, err)
+			}
+			if decision.Allowed {
+				t.Fatalf("decision allowed: %+v", decision)
+			}
+		})
+	}
+}
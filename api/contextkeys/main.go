@@ -0,0 +1,41 @@
+package contextkeys
+
+import "context"
+
+// key is unexported so values set by this package can't collide with keys from other packages
+type key string
+
+const (
+	RequestIDKey        key = "requestId"
+	MethodKey           key = "method"
+	PathKey             key = "path"
+	SourceIPKey         key = "sourceIp"
+	UserKey             key = "user"
+	UserIDKey           key = "userId"
+	ExternalUsernameKey key = "externalUsername"
+	AuthzDecisionKey    key = "authzDecision"
+)
+
+// UserID returns the authenticated user's ID set by the auth interceptor
+func UserID(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(UserIDKey).(int64)
+	return userID, ok
+}
+
+// User returns the authenticated user's username set by the auth interceptor
+func User(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(UserKey).(string)
+	return user, ok
+}
+
+// ExternalUsername returns the authenticated user's external (e.g. github:foo) username
+func ExternalUsername(ctx context.Context) (string, bool) {
+	externalUsername, ok := ctx.Value(ExternalUsernameKey).(string)
+	return externalUsername, ok
+}
+
+// RequestID returns the request ID set by the context middleware
+func RequestID(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(RequestIDKey).(string)
+	return requestID, ok
+}
@@ -0,0 +1,124 @@
+// Package domains verifies that users own the custom hostnames they add to apps, using a TXT record challenge.
+package domains
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Verification statuses, matching the app_domains.verification_status check constraint
+const (
+	StatusPending  = "pending"
+	StatusVerified = "verified"
+	StatusFailed   = "failed"
+)
+
+// Certificate statuses, matching the app_domains.certificate_status check constraint
+const (
+	CertificateNone    = "none"
+	CertificateIssuing = "issuing"
+	CertificateReady   = "ready"
+	CertificateFailed  = "failed"
+)
+
+const (
+	// ChallengeLabel is prepended to a hostname to get the name of its TXT record
+	ChallengeLabel = "_loco-challenge"
+	// challengePrefix starts the TXT record's value, the rest is the domain's token
+	challengePrefix = "loco-verification="
+)
+
+var (
+	ErrInvalidHostname   = errors.New("invalid hostname")
+	ErrChallengeNotFound = errors.New("verification record not found")
+)
+
+// a lowercase DNS label, the hostname must have at least two
+var labelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Resolver looks up TXT records. *net.Resolver implements it, tests can use a stub.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Verifier checks TXT record challenges
+type Verifier struct {
+	resolver Resolver
+}
+
+// NewVerifier creates a Verifier. A nil resolver uses the system resolver.
+func NewVerifier(resolver Resolver) *Verifier {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &Verifier{resolver: resolver}
+}
+
+// Verify reports whether hostname's challenge record holds token.
+// ErrChallengeNotFound means the record is missing or wrong, which is worth retrying once DNS propagates.
+func (v *Verifier) Verify(ctx context.Context, hostname, token string) error {
+	name := ChallengeName(hostname)
+
+	records, err := v.resolver.LookupTXT(ctx, name)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return fmt.Errorf("%w: no TXT record at %s", ErrChallengeNotFound, name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up %s: %w", name, err)
+	}
+
+	want := ChallengeValue(token)
+	if slices.Contains(records, want) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s has %d TXT record(s), none of them %q", ErrChallengeNotFound, name, len(records), want)
+}
+
+// NormalizeHostname lowercases a hostname and strips a trailing dot. Wildcards, IPs and single labels are rejected.
+func NormalizeHostname(hostname string) (string, error) {
+	h := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(hostname)), ".")
+	if len(h) == 0 || len(h) > 253 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidHostname, hostname)
+	}
+	if net.ParseIP(h) != nil {
+		return "", fmt.Errorf("%w: %q is an IP address", ErrInvalidHostname, hostname)
+	}
+
+	labels := strings.Split(h, ".")
+	if len(labels) < 2 {
+		return "", fmt.Errorf("%w: %q is not a fully qualified name", ErrInvalidHostname, hostname)
+	}
+	for _, label := range labels {
+		if !labelPattern.MatchString(label) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidHostname, hostname)
+		}
+	}
+	return h, nil
+}
+
+// NewToken generates a domain's verification token
+func NewToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ChallengeName is the TXT record that proves ownership of hostname
+func ChallengeName(hostname string) string {
+	return ChallengeLabel + "." + hostname
+}
+
+// ChallengeValue is the content the challenge record must have
+func ChallengeValue(token string) string {
+	return challengePrefix + token
+}
@@ -0,0 +1,150 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+// stubResolver answers TXT lookups from a map, names missing from it don't exist
+type stubResolver struct {
+	records map[string][]string
+	err     error
+	looked  []string
+}
+
+func (r *stubResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	r.looked = append(r.looked, name)
+	if r.err != nil {
+		return nil, r.err
+	}
+	records, ok := r.records[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestVerify(t *testing.T) {
+	const token = "0123456789abcdef"
+	tests := []struct {
+		name     string
+		resolver *stubResolver
+		wantErr  error
+	}{
+		{
+			name: "record holds token",
+			resolver: &stubResolver{records: map[string][]string{
+				"_loco-challenge.app.example.com": {"v=spf1 -all", "loco-verification=" + token},
+			}},
+		},
+		{
+			name:     "no record",
+			resolver: &stubResolver{records: map[string][]string{}},
+			wantErr:  ErrChallengeNotFound,
+		},
+		{
+			name: "wrong token",
+			resolver: &stubResolver{records: map[string][]string{
+				"_loco-challenge.app.example.com": {"loco-verification=someone-elses"},
+			}},
+			wantErr: ErrChallengeNotFound,
+		},
+		{
+			name: "record on the hostname itself",
+			resolver: &stubResolver{records: map[string][]string{
+				"app.example.com": {"loco-verification=" + token},
+			}},
+			wantErr: ErrChallengeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewVerifier(tt.resolver).Verify(context.Background(), "app.example.com", token)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Verify() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(tt.resolver.looked) != 1 || tt.resolver.looked[0] != "_loco-challenge.app.example.com" {
+				t.Errorf("looked up %v, want only the challenge record", tt.resolver.looked)
+			}
+		})
+	}
+}
+
+func TestVerifyLookupFailure(t *testing.T) {
+	resolver := &stubResolver{err: &net.DNSError{Err: "server misbehaving", Name: "_loco-challenge.app.example.com", IsTemporary: true}}
+
+	err := NewVerifier(resolver).Verify(context.Background(), "app.example.com", "token")
+	if err == nil {
+		t.Fatal("Verify() error = nil, want the lookup failure")
+	}
+	// a failing DNS server isn't a missing record, the caller reports it rather than marking the domain failed
+	if errors.Is(err, ErrChallengeNotFound) {
+		t.Errorf("Verify() error = %v, must not be ErrChallengeNotFound", err)
+	}
+}
+
+func TestNewVerifierDefaultsToSystemResolver(t *testing.T) {
+	if v := NewVerifier(nil); v.resolver != net.DefaultResolver {
+		t.Errorf("NewVerifier(nil) resolver = %v, want net.DefaultResolver", v.resolver)
+	}
+}
+
+func TestNormalizeHostname(t *testing.T) {
+	tests := []struct {
+		hostname string
+		want     string
+		wantErr  bool
+	}{
+		{hostname: "App.Example.com.", want: "app.example.com"},
+		{hostname: "  api.example.co.uk ", want: "api.example.co.uk"},
+		{hostname: "xn--bcher-kva.example", want: "xn--bcher-kva.example"},
+		{hostname: "localhost", wantErr: true},
+		{hostname: "*.example.com", wantErr: true},
+		{hostname: "10.0.0.1", wantErr: true},
+		{hostname: "-bad.example.com", wantErr: true},
+		{hostname: "under_score.example.com", wantErr: true},
+		{hostname: strings.Repeat("a", 64) + ".example.com", wantErr: true},
+		{hostname: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			got, err := NormalizeHostname(tt.hostname)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidHostname) {
+					t.Errorf("NormalizeHostname(%q) error = %v, want ErrInvalidHostname", tt.hostname, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeHostname(%q) error = %v", tt.hostname, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeHostname(%q) = %q, want %q", tt.hostname, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChallenge(t *testing.T) {
+	token, err := NewToken()
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+	if len(token) != 32 {
+		t.Errorf("NewToken() = %q, want 32 hex characters", token)
+	}
+	if got := ChallengeName("app.example.com"); got != "_loco-challenge.app.example.com" {
+		t.Errorf("ChallengeName() = %q", got)
+	}
+	if got := ChallengeValue(token); got != "loco-verification="+token {
+		t.Errorf("ChallengeValue() = %q", got)
+	}
+}
@@ -13,17 +13,19 @@ import (
 
 const checkSubdomainAvailability = `-- name: CheckSubdomainAvailability :one
 SELECT COUNT(*) = 0 AS available
-FROM apps
-WHERE subdomain = $1 AND domain = $2
+FROM hostnames
+WHERE subdomain = $1 AND domain = $2 AND workspace_id <> $3
 `
 
 type CheckSubdomainAvailabilityParams struct {
-	Subdomain string `json:"subdomain"`
-	Domain    string `json:"domain"`
+	Subdomain   string `json:"subdomain"`
+	Domain      string `json:"domain"`
+	WorkspaceID int64  `json:"workspaceId"`
 }
 
+// a subdomain is available to the workspace that already owns it
 func (q *Queries) CheckSubdomainAvailability(ctx context.Context, arg CheckSubdomainAvailabilityParams) (bool, error) {
-	row := q.db.QueryRow(ctx, checkSubdomainAvailability, arg.Subdomain, arg.Domain)
+	row := q.db.QueryRow(ctx, checkSubdomainAvailability, arg.Subdomain, arg.Domain, arg.WorkspaceID)
 	var available bool
 	err := row.Scan(&available)
 	return available, err
@@ -166,6 +168,44 @@ func (q *Queries) GetClusterDetails(ctx context.Context, id int64) (GetClusterDe
 	return i, err
 }
 
+const listAppsForCluster = `-- name: ListAppsForCluster :many
+SELECT id, workspace_id, cluster_id, name, namespace, type, subdomain, domain, created_by, created_at, updated_at FROM apps
+WHERE cluster_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListAppsForCluster(ctx context.Context, clusterID int64) ([]App, error) {
+	rows, err := q.db.Query(ctx, listAppsForCluster, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []App
+	for rows.Next() {
+		var i App
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ClusterID,
+			&i.Name,
+			&i.Namespace,
+			&i.Type,
+			&i.Subdomain,
+			&i.Domain,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAppsForWorkspace = `-- name: ListAppsForWorkspace :many
 SELECT id, workspace_id, cluster_id, name, namespace, type, subdomain, domain, created_by, created_at, updated_at FROM apps
 WHERE workspace_id = $1
@@ -204,6 +244,25 @@ func (q *Queries) ListAppsForWorkspace(ctx context.Context, workspaceID int64) (
 	return items, nil
 }
 
+const moveAppToCluster = `-- name: MoveAppToCluster :exec
+WITH moved AS (
+    UPDATE apps SET cluster_id = $2, updated_at = NOW() WHERE apps.id = $1
+)
+UPDATE deployments
+SET cluster_id = $2, updated_at = NOW()
+WHERE app_id = $1 AND is_current = true
+`
+
+type MoveAppToClusterParams struct {
+	ID        int64 `json:"id"`
+	ClusterID int64 `json:"clusterId"`
+}
+
+func (q *Queries) MoveAppToCluster(ctx context.Context, arg MoveAppToClusterParams) error {
+	_, err := q.db.Exec(ctx, moveAppToCluster, arg.ID, arg.ClusterID)
+	return err
+}
+
 const updateApp = `-- name: UpdateApp :one
 UPDATE apps
 SET name = COALESCE($2, name),
@@ -78,6 +78,34 @@ func (q *Queries) CreateDeployment(ctx context.Context, arg CreateDeploymentPara
 	return i, err
 }
 
+const getCurrentDeploymentForApp = `-- name: GetCurrentDeploymentForApp :one
+SELECT id, app_id, cluster_id, image, replicas, status, is_current, error_message, message, config, schema_version, created_by, created_at, started_at, completed_at, updated_at FROM deployments WHERE app_id = $1 AND is_current = true
+`
+
+func (q *Queries) GetCurrentDeploymentForApp(ctx context.Context, appID int64) (Deployment, error) {
+	row := q.db.QueryRow(ctx, getCurrentDeploymentForApp, appID)
+	var i Deployment
+	err := row.Scan(
+		&i.ID,
+		&i.AppID,
+		&i.ClusterID,
+		&i.Image,
+		&i.Replicas,
+		&i.Status,
+		&i.IsCurrent,
+		&i.ErrorMessage,
+		&i.Message,
+		&i.Config,
+		&i.SchemaVersion,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getDeploymentAppID = `-- name: GetDeploymentAppID :one
 SELECT app_id FROM deployments WHERE id = $1
 `
@@ -167,6 +195,17 @@ func (q *Queries) ListDeploymentsForApp(ctx context.Context, arg ListDeployments
 	return items, nil
 }
 
+const markDeploymentCurrent = `-- name: MarkDeploymentCurrent :exec
+UPDATE deployments
+SET is_current = true, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkDeploymentCurrent(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, markDeploymentCurrent, id)
+	return err
+}
+
 const markPreviousDeploymentsNotCurrent = `-- name: MarkPreviousDeploymentsNotCurrent :exec
 UPDATE deployments
 SET is_current = false, updated_at = NOW()
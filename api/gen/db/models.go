@@ -140,6 +140,27 @@ func (ns NullWorkspaceRole) Value() (driver.Value, error) {
 	return string(ns.WorkspaceRole), nil
 }
 
+type AppDomain struct {
+	ID                  int64              `json:"id"`
+	AppID               int64              `json:"appId"`
+	Hostname            string             `json:"hostname"`
+	VerificationToken   string             `json:"verificationToken"`
+	VerificationStatus  string             `json:"verificationStatus"`
+	VerificationMessage pgtype.Text        `json:"verificationMessage"`
+	VerifiedAt          pgtype.Timestamptz `json:"verifiedAt"`
+	CertificateStatus   string             `json:"certificateStatus"`
+	CertificateMessage  pgtype.Text        `json:"certificateMessage"`
+	CreatedBy           int64              `json:"createdBy"`
+	CreatedAt           pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt           pgtype.Timestamptz `json:"updatedAt"`
+}
+
+type AppSleep struct {
+	AppID   int64              `json:"appId"`
+	SleptAt pgtype.Timestamptz `json:"sleptAt"`
+	WokeAt  pgtype.Timestamptz `json:"wokeAt"`
+}
+
 type App struct {
 	ID          int64              `json:"id"`
 	WorkspaceID int64              `json:"workspaceId"`
@@ -154,6 +175,34 @@ type App struct {
 	UpdatedAt   pgtype.Timestamptz `json:"updatedAt"`
 }
 
+type AuditEvent struct {
+	ID           int64              `json:"id"`
+	ActorID      pgtype.Int8        `json:"actorId"`
+	Actor        string             `json:"actor"`
+	Procedure    string             `json:"procedure"`
+	OrgID        pgtype.Int8        `json:"orgId"`
+	WorkspaceID  pgtype.Int8        `json:"workspaceId"`
+	AppID        pgtype.Int8        `json:"appId"`
+	ResourceType string             `json:"resourceType"`
+	ResourceID   pgtype.Int8        `json:"resourceId"`
+	Request      []byte             `json:"request"`
+	Before       []byte             `json:"before"`
+	After        []byte             `json:"after"`
+	Success      bool               `json:"success"`
+	Error        pgtype.Text        `json:"error"`
+	RequestID    string             `json:"requestId"`
+	SourceIp     string             `json:"sourceIp"`
+	CreatedAt    pgtype.Timestamptz `json:"createdAt"`
+}
+
+type ClusterHealthCheck struct {
+	ID        int64              `json:"id"`
+	ClusterID int64              `json:"clusterId"`
+	Status    string             `json:"status"`
+	Checks    []byte             `json:"checks"`
+	CheckedAt pgtype.Timestamptz `json:"checkedAt"`
+}
+
 type Cluster struct {
 	ID              int64              `json:"id"`
 	Name            string             `json:"name"`
@@ -166,6 +215,17 @@ type Cluster struct {
 	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt       pgtype.Timestamptz `json:"updatedAt"`
 	CreatedBy       int64              `json:"createdBy"`
+	Credentials     []byte             `json:"credentials"`
+}
+
+type DeploymentEvent struct {
+	ID           int64              `json:"id"`
+	DeploymentID int64              `json:"deploymentId"`
+	Reason       string             `json:"reason"`
+	Message      string             `json:"message"`
+	PodName      pgtype.Text        `json:"podName"`
+	CreatedBy    int64              `json:"createdBy"`
+	CreatedAt    pgtype.Timestamptz `json:"createdAt"`
 }
 
 type Deployment struct {
@@ -187,6 +247,34 @@ type Deployment struct {
 	UpdatedAt     pgtype.Timestamptz `json:"updatedAt"`
 }
 
+type GatewayPort struct {
+	ID        int64              `json:"id"`
+	AppID     int64              `json:"appId"`
+	Name      string             `json:"name"`
+	Protocol  string             `json:"protocol"`
+	Port      int32              `json:"port"`
+	CreatedAt pgtype.Timestamptz `json:"createdAt"`
+}
+
+type Hostname struct {
+	ID          int64              `json:"id"`
+	WorkspaceID int64              `json:"workspaceId"`
+	Subdomain   string             `json:"subdomain"`
+	Domain      string             `json:"domain"`
+	CreatedBy   int64              `json:"createdBy"`
+	CreatedAt   pgtype.Timestamptz `json:"createdAt"`
+}
+
+type OrgQuota struct {
+	OrgID            int64              `json:"orgId"`
+	MaxApps          int32              `json:"maxApps"`
+	MaxCpuMillicores int64              `json:"maxCpuMillicores"`
+	MaxMemoryMb      int64              `json:"maxMemoryMb"`
+	MaxReplicas      int32              `json:"maxReplicas"`
+	CreatedAt        pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt        pgtype.Timestamptz `json:"updatedAt"`
+}
+
 type Organization struct {
 	ID        int64              `json:"id"`
 	Name      string             `json:"name"`
@@ -202,6 +290,22 @@ type OrganizationMember struct {
 	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
 }
 
+type ReleaseLog struct {
+	ID           int64              `json:"id"`
+	DeploymentID int64              `json:"deploymentId"`
+	Line         string             `json:"line"`
+	LoggedAt     pgtype.Timestamptz `json:"loggedAt"`
+}
+
+type RouteClaim struct {
+	ID         int64              `json:"id"`
+	HostnameID int64              `json:"hostnameId"`
+	AppID      int64              `json:"appId"`
+	PathPrefix string             `json:"pathPrefix"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt  pgtype.Timestamptz `json:"updatedAt"`
+}
+
 type User struct {
 	ID         int64              `json:"id"`
 	ExternalID string             `json:"externalId"`
@@ -228,3 +332,14 @@ type WorkspaceMember struct {
 	Role        WorkspaceRole      `json:"role"`
 	CreatedAt   pgtype.Timestamptz `json:"createdAt"`
 }
+
+type WorkspaceQuota struct {
+	WorkspaceID      int64              `json:"workspaceId"`
+	MaxApps          int32              `json:"maxApps"`
+	MaxCpuMillicores int64              `json:"maxCpuMillicores"`
+	MaxMemoryMb      int64              `json:"maxMemoryMb"`
+	MaxReplicas      int32              `json:"maxReplicas"`
+	UpdatedBy        pgtype.Int8        `json:"updatedBy"`
+	CreatedAt        pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt        pgtype.Timestamptz `json:"updatedAt"`
+}
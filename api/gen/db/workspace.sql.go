@@ -11,6 +11,17 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countAppsInWorkspace = `-- name: CountAppsInWorkspace :one
+SELECT COUNT(*) FROM apps WHERE workspace_id = $1
+`
+
+func (q *Queries) CountAppsInWorkspace(ctx context.Context, workspaceID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countAppsInWorkspace, workspaceID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteWorkspaceMember = `-- name: DeleteWorkspaceMember :exec
 DELETE FROM workspace_members
 WHERE workspace_id = $1 AND user_id = $2
@@ -94,13 +105,9 @@ func (q *Queries) GetWorkspaceMembers(ctx context.Context, workspaceID int64) ([
 }
 
 const getWorkspaceOrgID = `-- name: GetWorkspaceOrgID :one
-
 SELECT org_id FROM workspaces WHERE id = $1
 `
 
-// TODO: Uncomment when apps table exists
-// -- name: CountAppsInWorkspace :one
-// SELECT COUNT(*) FROM apps WHERE workspace_id = $1;
 func (q *Queries) GetWorkspaceOrgID(ctx context.Context, id int64) (int64, error) {
 	row := q.db.QueryRow(ctx, getWorkspaceOrgID, id)
 	var org_id int64
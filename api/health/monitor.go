@@ -0,0 +1,182 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+)
+
+const (
+	// DefaultInterval is how often every cluster is probed
+	DefaultInterval = time.Minute
+	// retention is how long probe history is kept
+	retention = 7 * 24 * time.Hour
+	// probeTimeout bounds a single cluster's probes so one hung cluster can't stall the rest
+	probeTimeout = 30 * time.Second
+
+	CheckCredentials = "credentials"
+	CheckIngress     = "ingress"
+)
+
+// Monitor periodically probes every registered cluster, recording the result on the cluster and in its history
+type Monitor struct {
+	queries    *genDb.Queries
+	clusters   *kube.Pool
+	interval   time.Duration
+	resolver   *net.Resolver
+	httpClient *http.Client
+}
+
+// NewMonitor creates a Monitor. An interval of zero or less falls back to DefaultInterval.
+func NewMonitor(queries *genDb.Queries, clusters *kube.Pool, interval time.Duration) *Monitor {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Monitor{
+		queries:  queries,
+		clusters: clusters,
+		interval: interval,
+		resolver: net.DefaultResolver,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+			// any response means the ingress path works, so don't follow redirects to https
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+}
+
+// Run probes every cluster immediately and then on each interval until ctx is done
+func (m *Monitor) Run(ctx context.Context) {
+	slog.InfoContext(ctx, "Starting cluster health monitor", "interval", m.interval)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.CheckAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll probes every registered cluster. Deactivated clusters are still probed since their apps keep running.
+func (m *Monitor) CheckAll(ctx context.Context) {
+	clusters, err := m.queries.ListClusters(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list clusters for health check", "error", err)
+		return
+	}
+
+	for _, cluster := range clusters {
+		m.Check(ctx, cluster)
+	}
+
+	cutoff := pgtype.Timestamptz{Time: time.Now().Add(-retention), Valid: true}
+	if err := m.queries.DeleteClusterHealthChecksBefore(ctx, cutoff); err != nil {
+		slog.WarnContext(ctx, "Failed to prune cluster health history", "error", err)
+	}
+}
+
+// Check probes a single cluster and records the result
+func (m *Monitor) Check(ctx context.Context, cluster genDb.Cluster) (string, []kube.HealthCheck) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	checks := m.probe(ctx, cluster)
+	status := kube.HealthStatus(checks)
+	checkedAt := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	if status != cluster.HealthStatus.String {
+		slog.WarnContext(ctx, "Cluster health changed", "cluster_id", cluster.ID, "from", cluster.HealthStatus.String, "to", status)
+	}
+
+	// record with a fresh context so a timed out probe is still written
+	recordCtx := context.WithoutCancel(ctx)
+	if err := m.queries.UpdateClusterHealth(recordCtx, genDb.UpdateClusterHealthParams{
+		ID:              cluster.ID,
+		HealthStatus:    pgtype.Text{String: status, Valid: true},
+		LastHealthCheck: checkedAt,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to update cluster health", "cluster_id", cluster.ID, "error", err)
+	}
+
+	checksJSON, err := json.Marshal(checks)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to marshal health checks", "cluster_id", cluster.ID, "error", err)
+		return status, checks
+	}
+	if err := m.queries.CreateClusterHealthCheck(recordCtx, genDb.CreateClusterHealthCheckParams{
+		ClusterID: cluster.ID,
+		Status:    status,
+		Checks:    checksJSON,
+		CheckedAt: checkedAt,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to record cluster health check", "cluster_id", cluster.ID, "error", err)
+	}
+
+	return status, checks
+}
+
+func (m *Monitor) probe(ctx context.Context, cluster genDb.Cluster) []kube.HealthCheck {
+	kc, err := m.clusters.Get(ctx, cluster.ID)
+	if err != nil {
+		return []kube.HealthCheck{{Name: CheckCredentials, Critical: true, Message: err.Error()}}
+	}
+
+	checks := kc.ProbeHealth(ctx)
+	if cluster.Endpoint.Valid && cluster.Endpoint.String != "" {
+		checks = append(checks, m.probeIngress(ctx, cluster.Endpoint.String))
+	}
+	return checks
+}
+
+// probeIngress resolves the cluster's public endpoint and makes a request through it.
+// Any HTTP response counts, an error status from the gateway still means DNS and the load balancer work.
+func (m *Monitor) probeIngress(ctx context.Context, endpoint string) kube.HealthCheck {
+	check := kube.HealthCheck{Name: CheckIngress}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Hostname() == "" {
+		// endpoints may be registered as a bare hostname
+		u, err = url.Parse("https://" + endpoint)
+		if err != nil {
+			check.Message = fmt.Sprintf("invalid endpoint %q: %v", endpoint, err)
+			return check
+		}
+	}
+
+	addrs, err := m.resolver.LookupHost(ctx, u.Hostname())
+	if err != nil {
+		check.Message = fmt.Sprintf("failed to resolve %s: %v", u.Hostname(), err)
+		return check
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		check.Message = fmt.Sprintf("invalid endpoint %q: %v", endpoint, err)
+		return check
+	}
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		check.Message = fmt.Sprintf("request to %s failed: %v", u.Host, err)
+		return check
+	}
+	resp.Body.Close()
+
+	check.OK = true
+	check.Message = fmt.Sprintf("%s resolved to %v, responded %d", u.Hostname(), addrs, resp.StatusCode)
+	return check
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"log/slog"
+
+	"github.com/nikumar1206/loco/api/contextkeys"
 )
 
 type CustomHandler struct {
@@ -10,17 +12,17 @@ type CustomHandler struct {
 }
 
 func (l CustomHandler) Handle(ctx context.Context, r slog.Record) error {
-	if ctx.Value("requestId") == nil {
+	requestId, ok := contextkeys.RequestID(ctx)
+	if !ok {
 		return l.Handler.Handle(ctx, r)
 	}
 
-	requestId := ctx.Value("requestId").(string)
-	sourceIp := ctx.Value("sourceIp").(string)
-	path := ctx.Value("path").(string)
-	method := ctx.Value("method").(string)
+	sourceIp, _ := ctx.Value(contextkeys.SourceIPKey).(string)
+	path, _ := ctx.Value(contextkeys.PathKey).(string)
+	method, _ := ctx.Value(contextkeys.MethodKey).(string)
 
 	// can be null on routes where oAuth Middleware doesn't run
-	user := ctx.Value("user")
+	user := ctx.Value(contextkeys.UserKey)
 
 	requestGroup := slog.Group(
 		"request",
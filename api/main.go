@@ -5,23 +5,45 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"connectrpc.com/grpcreflect"
 	charmLog "github.com/charmbracelet/log"
+	"github.com/nikumar1206/loco/api/audit"
+	"github.com/nikumar1206/loco/api/authz"
+	"github.com/nikumar1206/loco/api/client"
 	"github.com/nikumar1206/loco/api/db"
+	"github.com/nikumar1206/loco/api/domains"
 	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/health"
 	"github.com/nikumar1206/loco/api/middleware"
+	"github.com/nikumar1206/loco/api/pkg/clickhouse"
 	"github.com/nikumar1206/loco/api/pkg/kube"
+	"github.com/nikumar1206/loco/api/pkg/logstore"
+	"github.com/nikumar1206/loco/api/pkg/metricstore"
+	"github.com/nikumar1206/loco/api/pkg/secretbox"
+	"github.com/nikumar1206/loco/api/placement"
+	"github.com/nikumar1206/loco/api/quota"
+	"github.com/nikumar1206/loco/api/reconcile"
+	"github.com/nikumar1206/loco/api/resurrect"
 	"github.com/nikumar1206/loco/api/service"
+	"github.com/nikumar1206/loco/api/sleep"
 	"github.com/nikumar1206/loco/shared"
 	"github.com/nikumar1206/loco/shared/proto/app/v1/appv1connect"
+	"github.com/nikumar1206/loco/shared/proto/audit/v1/auditv1connect"
+	"github.com/nikumar1206/loco/shared/proto/cluster/v1/clusterv1connect"
 	"github.com/nikumar1206/loco/shared/proto/deployment/v1/deploymentv1connect"
+	"github.com/nikumar1206/loco/shared/proto/domain/v1/domainv1connect"
+	"github.com/nikumar1206/loco/shared/proto/job/v1/jobv1connect"
 	"github.com/nikumar1206/loco/shared/proto/oauth/v1/oauthv1connect"
 	"github.com/nikumar1206/loco/shared/proto/org/v1/orgv1connect"
+	"github.com/nikumar1206/loco/shared/proto/quota/v1/quotav1connect"
 	"github.com/nikumar1206/loco/shared/proto/registry/v1/registryv1connect"
 	"github.com/nikumar1206/loco/shared/proto/user/v1/userv1connect"
 	"github.com/nikumar1206/loco/shared/proto/workspace/v1/workspacev1connect"
@@ -30,17 +52,25 @@ import (
 )
 
 type AppConfig struct {
-	Env             string     // Environment (e.g., dev, prod)
-	ProjectID       string     // GitLab project ID
-	GitlabURL       string     // Container registry URL
-	RegistryURL     string     // Container registry URL
-	DeployTokenName string     // Deploy token name
-	GitlabPAT       string     // GitLab Personal Access Token
-	DatabaseURL     string     // PostgreSQL connection string
+	Env             string // Environment (e.g., dev, prod)
+	ProjectID       string // GitLab project ID
+	GitlabURL       string // Container registry URL
+	RegistryURL     string // Container registry URL
+	DeployTokenName string // Deploy token name
+	GitlabPAT       string // GitLab Personal Access Token
+	DatabaseURL     string // PostgreSQL connection string
 	LogLevel        slog.Level
 	Port            string
 	JwtSecret       string
 	RegistryTag     string
+	Admins          []string      // external usernames (e.g. github:foo) allowed to call admin procedures
+	Headroom        float64       // multiple of a deployment's requests that must be free before it's accepted
+	CredentialsKey  string        // base64 AES-256 key that seals registered cluster kubeconfigs
+	HealthInterval  time.Duration // how often every registered cluster is probed
+	DriftInterval   time.Duration // how often apps are compared with their clusters
+	RepairDrift     bool          // re-apply drifted objects on each scan instead of only reporting them
+	ClickHouseURL   string        // ClickHouse HTTP endpoint holding the otel logs and metrics, live values are used when unset
+	SleepInterval   time.Duration // how often apps with routing.sleepAfter are checked for idleness
 }
 
 func newAppConfig() *AppConfig {
@@ -52,6 +82,42 @@ func newAppConfig() *AppConfig {
 		}
 	}
 
+	var admins []string
+	for _, a := range strings.Split(os.Getenv("LOCO_ADMINS"), ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			admins = append(admins, a)
+		}
+	}
+
+	headroom := kube.DefaultHeadroomFactor
+	if headroomStr := os.Getenv("CAPACITY_HEADROOM"); headroomStr != "" {
+		if parsed, err := strconv.ParseFloat(headroomStr, 64); err == nil {
+			headroom = parsed
+		}
+	}
+
+	healthInterval := health.DefaultInterval
+	if intervalStr := os.Getenv("HEALTH_CHECK_INTERVAL"); intervalStr != "" {
+		if parsed, err := time.ParseDuration(intervalStr); err == nil {
+			healthInterval = parsed
+		}
+	}
+
+	driftInterval := reconcile.DefaultInterval
+	if intervalStr := os.Getenv("DRIFT_CHECK_INTERVAL"); intervalStr != "" {
+		if parsed, err := time.ParseDuration(intervalStr); err == nil {
+			driftInterval = parsed
+		}
+	}
+	repairDrift, _ := strconv.ParseBool(os.Getenv("DRIFT_REPAIR"))
+
+	sleepInterval := sleep.DefaultInterval
+	if intervalStr := os.Getenv("SLEEP_CHECK_INTERVAL"); intervalStr != "" {
+		if parsed, err := time.ParseDuration(intervalStr); err == nil {
+			sleepInterval = parsed
+		}
+	}
+
 	return &AppConfig{
 		Env:             os.Getenv("APP_ENV"),
 		ProjectID:       os.Getenv("GITLAB_PROJECT_ID"),
@@ -64,6 +130,14 @@ func newAppConfig() *AppConfig {
 		LogLevel:        logLevel,
 		JwtSecret:       os.Getenv("JWT_SECRET"),
 		RegistryTag:     os.Getenv("REGISTRY_TAG"),
+		Admins:          admins,
+		Headroom:        headroom,
+		CredentialsKey:  os.Getenv("CLUSTER_CREDENTIALS_KEY"),
+		HealthInterval:  healthInterval,
+		DriftInterval:   driftInterval,
+		RepairDrift:     repairDrift,
+		ClickHouseURL:   os.Getenv("CLICKHOUSE_URL"),
+		SleepInterval:   sleepInterval,
 	}
 }
 
@@ -74,8 +148,6 @@ func main() {
 	slog.SetDefault(logger)
 
 	mux := http.NewServeMux()
-	interceptors := connect.WithInterceptors(middleware.NewGithubAuthInterceptor())
-
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintln(w, "Loco Service is Running")
@@ -97,14 +169,93 @@ func main() {
 	pool := dbConn.Pool()
 	queries := genDb.New(pool)
 
+	// auth must run before authz so the caller is known when policies are evaluated,
+	// and audit runs last so only authorized calls are recorded
+	interceptors := connect.WithInterceptors(
+		middleware.NewGithubAuthInterceptor(),
+		middleware.NewAuthzInterceptor(authz.NewAuthorizer(queries, ac.Admins)),
+		middleware.NewAuditInterceptor(audit.NewRecorder(queries)),
+	)
+
 	httpClient := shared.NewHTTPClient()
+	quotas := quota.NewEnforcer(queries)
+	planner := kube.NewCapacityPlanner(ac.Headroom)
+
+	var sealer *secretbox.Sealer
+	if ac.CredentialsKey != "" {
+		sealer, err = secretbox.NewSealerFromBase64(ac.CredentialsKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// clusters registered without a kubeconfig are reached with loco-api's own client
+	clusters := kube.NewPool(kubeClient, func(ctx context.Context, clusterID int64) ([]byte, error) {
+		sealed, err := queries.GetClusterCredentials(ctx, clusterID)
+		if err != nil || sealed == nil {
+			return nil, err
+		}
+		if sealer == nil {
+			return nil, service.ErrCredentialsDisabled
+		}
+		return sealer.Open(sealed)
+	})
+	scheduler := placement.NewScheduler(queries, clusters)
+
+	var logs *logstore.Client
+	var metrics *metricstore.Client
+	if ac.ClickHouseURL != "" {
+		ch, err := clickhouse.NewClient(ac.ClickHouseURL, httpClient)
+		if err != nil {
+			log.Fatal(err)
+		}
+		logs = logstore.NewClient(ch)
+		metrics = metricstore.NewClient(ch)
+	}
+
+	monitor := health.NewMonitor(queries, clusters, ac.HealthInterval)
+	go monitor.Run(context.Background())
+
+	reconciler := reconcile.NewReconciler(queries, clusters, ac.DriftInterval, ac.RepairDrift)
+	go reconciler.Run(context.Background())
+
+	sleeper := sleep.NewSleeper(queries, clusters, metrics, ac.SleepInterval)
+	go sleeper.Run(context.Background())
+
+	// sleeping apps' routes rewrite their requests to /wake/<app id>/<rest of the path>, so these are
+	// reached by anyone requesting a sleeping app and take no credentials. Only requests for one of the
+	// app's hostnames wake it.
+	mux.HandleFunc("/wake/{appID}", sleeper.Wake)
+	mux.HandleFunc("/wake/{appID}/{rest...}", sleeper.Wake)
+
+	var pullCredentials resurrect.PullCredentials
+	if ac.GitlabPAT != "" {
+		// rebuilt apps keep pulling long after the rebuild, so they get their own read-only deploy token
+		pullCredentials = func(ctx context.Context) (*kube.DockerRegistryConfig, error) {
+			token, err := client.NewGitlabClient(ac.GitlabURL, httpClient).CreateDeployToken(ctx, ac.GitlabPAT, ac.ProjectID, map[string]any{
+				"name":       ac.DeployTokenName + "-pull",
+				"scopes":     []string{"read_registry"},
+				"expires_at": time.Now().AddDate(1, 0, 0).UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &kube.DockerRegistryConfig{Server: ac.RegistryURL, Username: token.Username, Password: token.Token}, nil
+		}
+	}
+	rebuilder := resurrect.NewResurrector(queries, clusters, quotas, pullCredentials)
 
 	oAuthServiceHandler := service.NewOAuthServer(pool, queries, httpClient)
 	userServiceHandler := service.NewUserServer(pool, queries)
 	orgServiceHandler := service.NewOrgServer(pool, queries)
 	workspaceServiceHandler := service.NewWorkspaceServer(pool, queries)
-	appServiceHandler := service.NewAppServer(pool, queries, kubeClient)
-	deploymentServiceHandler := service.NewDeploymentServer(pool, queries, kubeClient)
+	deploymentServiceHandler := service.NewDeploymentServer(pool, queries, clusters, quotas, planner)
+	appServiceHandler := service.NewAppServer(pool, queries, clusters, scheduler, quotas, logs, metrics, deploymentServiceHandler)
+	auditServiceHandler := service.NewAuditServer(pool, queries)
+	quotaServiceHandler := service.NewQuotaServer(pool, queries, clusters, quotas)
+	domainServiceHandler := service.NewDomainServer(pool, queries, clusters, domains.NewVerifier(net.DefaultResolver))
+	jobServiceHandler := service.NewJobServer(pool, queries, clusters)
+	clusterServiceHandler := service.NewClusterServer(pool, queries, clusters, planner, sealer, reconciler, rebuilder)
 	registryServiceHandler := service.NewRegistryServer(
 		pool,
 		queries,
@@ -123,6 +274,11 @@ func main() {
 	appPath, appHandler := appv1connect.NewAppServiceHandler(appServiceHandler, interceptors)
 	deploymentPath, deploymentHandler := deploymentv1connect.NewDeploymentServiceHandler(deploymentServiceHandler, interceptors)
 	registryPath, registryHandler := registryv1connect.NewRegistryServiceHandler(registryServiceHandler, interceptors)
+	auditPath, auditHandler := auditv1connect.NewAuditServiceHandler(auditServiceHandler, interceptors)
+	quotaPath, quotaHandler := quotav1connect.NewQuotaServiceHandler(quotaServiceHandler, interceptors)
+	clusterPath, clusterHandler := clusterv1connect.NewClusterServiceHandler(clusterServiceHandler, interceptors)
+	domainPath, domainHandler := domainv1connect.NewDomainServiceHandler(domainServiceHandler, interceptors)
+	jobPath, jobHandler := jobv1connect.NewJobServiceHandler(jobServiceHandler, interceptors)
 
 	reflector := grpcreflect.NewStaticReflector(
 		// user service
@@ -162,6 +318,11 @@ func main() {
 		appv1connect.AppServiceUpdateAppProcedure,
 		appv1connect.AppServiceDeleteAppProcedure,
 		appv1connect.AppServiceCheckSubdomainAvailabilityProcedure,
+		appv1connect.AppServiceGetAppMetricsProcedure,
+		appv1connect.AppServiceRestartAppProcedure,
+		appv1connect.AppServiceRunCommandProcedure,
+		appv1connect.AppServiceExecProcedure,
+		appv1connect.AppServicePortForwardProcedure,
 
 		// deployment service
 		deploymentv1connect.DeploymentServiceCreateDeploymentProcedure,
@@ -171,6 +332,33 @@ func main() {
 
 		// registry service
 		registryv1connect.RegistryServiceGitlabTokenProcedure,
+
+		// audit service
+		auditv1connect.AuditServiceListEventsProcedure,
+
+		// quota service
+		quotav1connect.QuotaServiceGetQuotaProcedure,
+		quotav1connect.QuotaServiceSetWorkspaceQuotaProcedure,
+
+		// cluster service
+		clusterv1connect.ClusterServiceRegisterClusterProcedure,
+		clusterv1connect.ClusterServiceListClustersProcedure,
+		clusterv1connect.ClusterServiceDeactivateClusterProcedure,
+		clusterv1connect.ClusterServiceGetCapacityProcedure,
+		clusterv1connect.ClusterServiceGetClusterHealthProcedure,
+		clusterv1connect.ClusterServiceDetectDriftProcedure,
+		clusterv1connect.ClusterServiceRebuildClusterProcedure,
+
+		// domain service
+		domainv1connect.DomainServiceAddDomainProcedure,
+		domainv1connect.DomainServiceVerifyDomainProcedure,
+		domainv1connect.DomainServiceListDomainsProcedure,
+		domainv1connect.DomainServiceRemoveDomainProcedure,
+
+		// job service
+		jobv1connect.JobServiceListJobsProcedure,
+		jobv1connect.JobServiceListJobRunsProcedure,
+		jobv1connect.JobServiceTriggerJobProcedure,
 	)
 
 	// mount both old and new reflectors for backwards compatibility
@@ -184,6 +372,11 @@ func main() {
 	mux.Handle(appPath, appHandler)
 	mux.Handle(deploymentPath, deploymentHandler)
 	mux.Handle(registryPath, registryHandler)
+	mux.Handle(auditPath, auditHandler)
+	mux.Handle(quotaPath, quotaHandler)
+	mux.Handle(clusterPath, clusterHandler)
+	mux.Handle(domainPath, domainHandler)
+	mux.Handle(jobPath, jobHandler)
 
 	muxWTiming := middleware.Timing(mux)
 	muxWContext := middleware.SetContext(muxWTiming)
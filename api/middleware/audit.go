@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/nikumar1206/loco/api/audit"
+	"github.com/nikumar1206/loco/api/authz"
+)
+
+type auditInterceptor struct {
+	recorder *audit.Recorder
+}
+
+// NewAuditInterceptor records mutating calls, streams included. It must run after the authz interceptor so denied calls never reach it.
+func NewAuditInterceptor(recorder *audit.Recorder) *auditInterceptor {
+	return &auditInterceptor{recorder: recorder}
+}
+
+func (i *auditInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		if !i.recorder.IsAudited(procedure) {
+			return next(ctx, req)
+		}
+
+		before := i.recorder.Before(ctx, procedure, req.Any())
+		res, err := next(ctx, req)
+
+		var resMsg any
+		if err == nil && res != nil {
+			resMsg = res.Any()
+		}
+		// the handler has committed, failing the call now would only have the client retry what succeeded
+		i.recorder.Record(ctx, procedure, req.Any(), resMsg, err, before)
+
+		return res, err
+	})
+}
+
+func (i *auditInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *auditInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(
+		ctx context.Context,
+		conn connect.StreamingHandlerConn,
+	) error {
+		procedure := conn.Spec().Procedure
+		if !i.recorder.IsAudited(procedure) {
+			return next(ctx, conn)
+		}
+
+		// streams are recorded once they end, with a summary of the messages each way
+		recording := &recordingConn{
+			StreamingHandlerConn: conn,
+			before: func(msg any) []byte {
+				return i.recorder.Before(ctx, procedure, msg)
+			},
+		}
+		if i.recorder.RecordsOnStart(procedure) {
+			recording.start = func(stream *audit.Stream) {
+				i.recorder.StartStream(decisionContext(ctx, conn), procedure, stream)
+			}
+		}
+		err := next(ctx, recording)
+		if !recording.stream.HasReceived() {
+			// denied on its first message, or the client never sent one
+			return err
+		}
+
+		i.recorder.RecordStream(decisionContext(ctx, conn), procedure, &recording.stream, err)
+
+		return err
+	})
+}
+
+// recordingConn counts the messages a stream received and sent, and takes the audit snapshot on the first
+type recordingConn struct {
+	connect.StreamingHandlerConn
+	before func(msg any) []byte
+	// start is set for streams recorded as soon as their first message arrived
+	start func(stream *audit.Stream)
+
+	stream audit.Stream
+}
+
+func (c *recordingConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	first := !c.stream.HasReceived()
+	if first {
+		c.stream.Before = c.before(msg)
+	}
+	c.stream.Received(msg)
+	if first && c.start != nil {
+		c.start(&c.stream)
+	}
+	return nil
+}
+
+func (c *recordingConn) Send(msg any) error {
+	if err := c.StreamingHandlerConn.Send(msg); err != nil {
+		return err
+	}
+	c.stream.Sent(msg)
+	return nil
+}
+
+// decisionContext adds the authz decision of a stream to ctx, streams authorized on their first message only
+// know it once that arrived
+func decisionContext(ctx context.Context, conn connect.StreamingHandlerConn) context.Context {
+	if authorizing, ok := conn.(*authorizingConn); ok && authorizing.authorized {
+		return authz.NewContext(ctx, authorizing.decision)
+	}
+	return ctx
+}
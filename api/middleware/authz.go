@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/nikumar1206/loco/api/authz"
+)
+
+var errStreamNotAuthorized = errors.New("stream has not been authorized")
+
+type authzInterceptor struct {
+	authorizer *authz.Authorizer
+}
+
+// NewAuthzInterceptor enforces the authz policy table. It must run after the auth interceptor so the caller is known.
+func NewAuthzInterceptor(authorizer *authz.Authorizer) *authzInterceptor {
+	return &authzInterceptor{authorizer: authorizer}
+}
+
+func (i *authzInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		decision, err := i.authorizer.Authorize(ctx, req.Spec().Procedure, req.Any())
+		if err != nil {
+			return nil, err
+		}
+		return next(authz.NewContext(ctx, decision), req)
+	})
+}
+
+func (i *authzInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *authzInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(
+		ctx context.Context,
+		conn connect.StreamingHandlerConn,
+	) error {
+		procedure := conn.Spec().Procedure
+		if !i.authorizer.NeedsMessage(procedure) {
+			decision, err := i.authorizer.Authorize(ctx, procedure, nil)
+			if err != nil {
+				return err
+			}
+			return next(authz.NewContext(ctx, decision), conn)
+		}
+
+		// the resource is only known once the first message arrives, so authorize lazily
+		return next(ctx, &authorizingConn{
+			StreamingHandlerConn: conn,
+			authorize: func(msg any) (authz.Decision, error) {
+				return i.authorizer.Authorize(ctx, procedure, msg)
+			},
+		})
+	})
+}
+
+// authorizingConn authorizes a stream on its first received message and refuses to send anything before that
+type authorizingConn struct {
+	connect.StreamingHandlerConn
+	authorize func(msg any) (authz.Decision, error)
+
+	authorized bool
+	decision   authz.Decision
+	err        error
+}
+
+func (c *authorizingConn) Receive(msg any) error {
+	if c.err != nil {
+		return c.err
+	}
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	if !c.authorized {
+		if c.decision, c.err = c.authorize(msg); c.err != nil {
+			return c.err
+		}
+		c.authorized = true
+	}
+	return nil
+}
+
+func (c *authorizingConn) Send(msg any) error {
+	if !c.authorized {
+		return connect.NewError(connect.CodePermissionDenied, errStreamNotAuthorized)
+	}
+	return c.StreamingHandlerConn.Send(msg)
+}
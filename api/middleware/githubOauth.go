@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/http"
 	"strings"
 
 	"connectrpc.com/connect"
+	"github.com/nikumar1206/loco/api/authz"
+	"github.com/nikumar1206/loco/api/contextkeys"
 	"github.com/nikumar1206/loco/api/jwtutil"
 )
 
@@ -21,36 +24,10 @@ func (i *githubAuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryF
 		ctx context.Context,
 		req connect.AnyRequest,
 	) (connect.AnyResponse, error) {
-		// todo: need to fix the service name
-		if req.Spec().Procedure == "/shared.proto.oauth.v1.OAuthService/GithubOAuthDetails" ||
-			req.Spec().Procedure == "/shared.proto.oauth.v1.OAuthService/ExchangeGithubToken" {
-			return next(ctx, req)
-		}
-
-		authHeader := req.Header().Get("Authorization")
-		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			return nil, connect.NewError(
-				connect.CodeUnauthenticated,
-				errors.New("no token provided"),
-			)
-		}
-		token := strings.TrimPrefix(authHeader, "Bearer ")
-
-		claims, err := jwtutil.ValidateLocoJWT(token)
+		c, err := authenticate(ctx, req.Spec().Procedure, req.Header())
 		if err != nil {
-			slog.Error(err.Error())
-			return nil, connect.NewError(
-				connect.CodeUnauthenticated,
-				err,
-			)
+			return nil, err
 		}
-
-		slog.Info("claims validated; populating ctx", slog.Int64("userId", claims.UserId))
-
-		c := context.WithValue(ctx, "user", claims.Username)
-		c = context.WithValue(c, "userId", claims.UserId)
-		c = context.WithValue(c, "externalUsername", claims.ExternalUsername)
-
 		return next(c, req)
 	})
 }
@@ -65,40 +42,48 @@ func (i *githubAuthInterceptor) WrapStreamingClient(next connect.StreamingClient
 	})
 }
 
-// todo: logic is very similar to unary; should refactor this
 func (i *githubAuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
 	return connect.StreamingHandlerFunc(func(
 		ctx context.Context,
 		conn connect.StreamingHandlerConn,
 	) error {
-		if conn.Spec().Procedure == "/shared.proto.oauth.v1.OAuthService/GithubOAuthDetails" ||
-			conn.Spec().Procedure == "/shared.proto.oauth.v1.OAuthService/ExchangeGithubToken" {
-			return next(ctx, conn)
-		}
-		authHeader := conn.RequestHeader().Get("Authorization")
-		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			return connect.NewError(
-				connect.CodeUnauthenticated,
-				errors.New("no token provided"),
-			)
-		}
-		token := strings.TrimPrefix(authHeader, "Bearer ")
-
-		claims, err := jwtutil.ValidateLocoJWT(token)
+		c, err := authenticate(ctx, conn.Spec().Procedure, conn.RequestHeader())
 		if err != nil {
-			slog.Error(err.Error())
-			return connect.NewError(
-				connect.CodeUnauthenticated,
-				err,
-			)
+			return err
 		}
-
-		slog.Info("claims validated; populating ctx", slog.Int64("userId", claims.UserId))
-
-		c := context.WithValue(ctx, "user", claims.Username)
-		c = context.WithValue(c, "userId", claims.UserId)
-		c = context.WithValue(c, "externalUsername", claims.ExternalUsername)
-
 		return next(c, conn)
 	})
 }
+
+// authenticate validates the bearer token and populates ctx with the caller's identity
+func authenticate(ctx context.Context, procedure string, header http.Header) (context.Context, error) {
+	if authz.IsPublic(procedure) {
+		return ctx, nil
+	}
+
+	authHeader := header.Get("Authorization")
+	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+		return nil, connect.NewError(
+			connect.CodeUnauthenticated,
+			errors.New("no token provided"),
+		)
+	}
+	token := strings.TrimPrefix(authHeader, "Bearer ")
+
+	claims, err := jwtutil.ValidateLocoJWT(token)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, connect.NewError(
+			connect.CodeUnauthenticated,
+			err,
+		)
+	}
+
+	slog.Info("claims validated; populating ctx", slog.Int64("userId", claims.UserId))
+
+	c := context.WithValue(ctx, contextkeys.UserKey, claims.Username)
+	c = context.WithValue(c, contextkeys.UserIDKey, claims.UserId)
+	c = context.WithValue(c, contextkeys.ExternalUsernameKey, claims.ExternalUsername)
+
+	return c, nil
+}
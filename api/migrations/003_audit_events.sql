@@ -0,0 +1,25 @@
+-- Audit events table
+-- actor_id is nulled if the user is deleted; actor keeps the username for the record
+CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    actor TEXT NOT NULL DEFAULT '',
+    procedure TEXT NOT NULL,
+    org_id BIGINT,
+    workspace_id BIGINT,
+    app_id BIGINT,
+    resource_type TEXT NOT NULL,
+    resource_id BIGINT,
+    request JSONB,
+    before JSONB,
+    after JSONB,
+    success BOOLEAN NOT NULL,
+    error TEXT,
+    request_id TEXT NOT NULL DEFAULT '',
+    source_ip TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_events_workspace_id_created_at ON audit_events (workspace_id, created_at DESC);
+CREATE INDEX idx_audit_events_app_id ON audit_events (app_id);
+CREATE INDEX idx_audit_events_actor_id ON audit_events (actor_id);
@@ -0,0 +1,25 @@
+-- Org quotas table
+-- rows are managed by platform operators; orgs without a row get the API's default limits
+-- cpu is in millicores and memory in MiB. max_replicas caps a single app.
+CREATE TABLE org_quotas (
+    org_id BIGINT PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    max_apps INT NOT NULL,
+    max_cpu_millicores BIGINT NOT NULL,
+    max_memory_mb BIGINT NOT NULL,
+    max_replicas INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Workspace quotas table
+-- set by org admins, and never allowed above the org's own limits
+CREATE TABLE workspace_quotas (
+    workspace_id BIGINT PRIMARY KEY REFERENCES workspaces(id) ON DELETE CASCADE,
+    max_apps INT NOT NULL,
+    max_cpu_millicores BIGINT NOT NULL,
+    max_memory_mb BIGINT NOT NULL,
+    max_replicas INT NOT NULL,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
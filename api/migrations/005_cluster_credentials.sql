@@ -0,0 +1,4 @@
+-- Cluster credentials
+-- credentials holds the cluster's kubeconfig sealed with CLUSTER_CREDENTIALS_KEY and is never returned by the API.
+-- clusters without credentials are reached with loco-api's own in-cluster (or local kubeconfig) credentials.
+ALTER TABLE clusters ADD COLUMN credentials BYTEA;
@@ -0,0 +1,12 @@
+-- Cluster health checks table
+-- one row per probe run, clusters.health_status and last_health_check hold the latest result
+-- checks is the list of individual probe results, see kube.HealthCheck
+CREATE TABLE cluster_health_checks (
+    id BIGSERIAL PRIMARY KEY,
+    cluster_id BIGINT NOT NULL REFERENCES clusters(id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('healthy', 'unhealthy', 'degraded')),
+    checks JSONB NOT NULL,
+    checked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_cluster_health_checks_cluster_id_checked_at ON cluster_health_checks (cluster_id, checked_at DESC);
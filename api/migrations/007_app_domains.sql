@@ -0,0 +1,23 @@
+-- App domains table
+-- custom hostnames users bring for their apps, served alongside <subdomain>.<domain>.
+-- ownership is proven with a TXT record holding verification_token, after which a certificate is issued
+-- and the hostname is attached to the gateway and the app's HTTPRoute.
+-- any app can claim a hostname, it belongs to the first one to verify it and the other claims are dropped then.
+CREATE TABLE app_domains (
+    id BIGSERIAL PRIMARY KEY,
+    app_id BIGINT NOT NULL REFERENCES apps(id) ON DELETE CASCADE,
+    hostname TEXT NOT NULL,
+    verification_token TEXT NOT NULL,
+    verification_status TEXT NOT NULL DEFAULT 'pending' CHECK (verification_status IN ('pending', 'verified', 'failed')),
+    verification_message TEXT,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    certificate_status TEXT NOT NULL DEFAULT 'none' CHECK (certificate_status IN ('none', 'issuing', 'ready', 'failed')),
+    certificate_message TEXT,
+    created_by BIGINT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (app_id, hostname)
+);
+
+-- unverified claims don't hold the hostname, so nobody can squat on one they can't prove
+CREATE UNIQUE INDEX idx_app_domains_verified_hostname ON app_domains (hostname) WHERE verification_status = 'verified';
@@ -0,0 +1,40 @@
+-- Hostnames table
+-- <subdomain>.<domain> hostnames are owned by a workspace, so several of its apps can share one behind different path prefixes.
+-- a hostname is reserved when the first app using it is created and released when the last one is deleted or moved.
+CREATE TABLE hostnames (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    subdomain TEXT NOT NULL,
+    domain TEXT NOT NULL,
+    created_by BIGINT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (subdomain, domain)
+);
+
+CREATE INDEX idx_hostnames_workspace_id ON hostnames (workspace_id);
+
+-- Route claims table
+-- the path prefix each app serves on its hostname, claimed on deploy. an app has one claim,
+-- and a prefix belongs to one app per hostname. nested prefixes are allowed, the longest match wins.
+-- path_prefix is normalized by routes.NormalizePrefix, so /api and /api/ are the same claim.
+CREATE TABLE route_claims (
+    id BIGSERIAL PRIMARY KEY,
+    hostname_id BIGINT NOT NULL REFERENCES hostnames(id) ON DELETE CASCADE,
+    app_id BIGINT UNIQUE NOT NULL REFERENCES apps(id) ON DELETE CASCADE,
+    path_prefix TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (hostname_id, path_prefix)
+);
+
+-- until now every app had its own hostname and was served from /
+INSERT INTO hostnames (workspace_id, subdomain, domain, created_by)
+SELECT workspace_id, subdomain, domain, created_by FROM apps;
+
+INSERT INTO route_claims (hostname_id, app_id, path_prefix)
+SELECT h.id, a.id, '/'
+FROM apps a
+JOIN hostnames h ON h.subdomain = a.subdomain AND h.domain = a.domain;
+
+-- apps in a workspace can now share a subdomain, hostnames enforces it across workspaces
+ALTER TABLE apps DROP CONSTRAINT apps_subdomain_domain_key;
@@ -0,0 +1,12 @@
+-- Gateway ports table
+-- the gateway listener port each public routing.ports entry is reachable on. listener ports are shared by
+-- every app behind the gateway, so they're allocated here on deploy and kept across redeploys.
+CREATE TABLE gateway_ports (
+    id BIGSERIAL PRIMARY KEY,
+    app_id BIGINT NOT NULL REFERENCES apps(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    protocol TEXT NOT NULL CHECK (protocol IN ('TCP', 'UDP')),
+    port INTEGER UNIQUE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (app_id, name)
+);
@@ -0,0 +1,8 @@
+-- App sleep table
+-- apps with routing.sleepAfter are scaled to zero once idle and woken by their next request.
+-- slept_at is set while the app sleeps, woke_at is when it last woke and restarts its idle clock.
+CREATE TABLE app_sleep (
+    app_id BIGINT PRIMARY KEY REFERENCES apps(id) ON DELETE CASCADE,
+    slept_at TIMESTAMP WITH TIME ZONE,
+    woke_at TIMESTAMP WITH TIME ZONE
+);
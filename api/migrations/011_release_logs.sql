@@ -0,0 +1,10 @@
+-- Release logs table
+-- the output of a deployment's release command, sent with its deployment events. lines are ordered by id.
+CREATE TABLE release_logs (
+    id BIGSERIAL PRIMARY KEY,
+    deployment_id BIGINT NOT NULL REFERENCES deployments(id) ON DELETE CASCADE,
+    line TEXT NOT NULL,
+    logged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_release_logs_deployment ON release_logs(deployment_id, id);
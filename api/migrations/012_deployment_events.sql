@@ -0,0 +1,15 @@
+-- Deployment events table
+-- what happened to a deployment after it rolled out, like its pods being restarted. listed with the
+-- cluster's events of the app, newest first.
+CREATE TABLE deployment_events (
+    id BIGSERIAL PRIMARY KEY,
+    deployment_id BIGINT NOT NULL REFERENCES deployments(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    message TEXT NOT NULL,
+    -- set when the event concerns a single pod
+    pod_name TEXT,
+    created_by BIGINT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_deployment_events_deployment ON deployment_events(deployment_id, created_at);
@@ -27,6 +27,9 @@ type LogEntry struct {
 	Container string
 	Message   string
 	IsError   bool
+	// Level and Fields are set by the ParseLevel and ParseStructured transforms
+	Level  string
+	Fields map[string]string
 }
 
 // FilterFunc is a function that filters log entries
@@ -49,6 +52,7 @@ type LogStream struct {
 	since      *metav1.Time
 	timestamps bool
 	previous   bool
+	completed  bool
 
 	// Concurrency primitives (already thread-safe)
 	ctx     context.Context
@@ -57,6 +61,12 @@ type LogStream struct {
 	entries chan LogEntry
 	errors  chan error
 
+	// Set by Start, used by Drain to stop watching pods while their streams finish
+	watchCtx    context.Context
+	watchCancel context.CancelFunc
+	synced      cache.InformerSynced
+	streams     sync.WaitGroup
+
 	// Mutable state (protected by respective mechanisms)
 	running atomic.Bool // Atomic - no lock needed
 	pods    sync.Map    // Thread-safe map - no lock needed
@@ -83,6 +93,7 @@ type Builder struct {
 	since      *metav1.Time
 	timestamps bool
 	previous   bool
+	completed  bool
 	bufferSize int
 }
 
@@ -181,6 +192,12 @@ func (b *Builder) Previous(previous bool) *Builder {
 	return b
 }
 
+// Completed also streams pods that already finished, like the pods of Jobs
+func (b *Builder) Completed(completed bool) *Builder {
+	b.completed = completed
+	return b
+}
+
 // Timestamps includes timestamps in log output
 func (b *Builder) Timestamps(timestamps bool) *Builder {
 	b.timestamps = timestamps
@@ -245,6 +262,7 @@ func (b *Builder) Build() *LogStream {
 		since:      b.since,
 		timestamps: b.timestamps,
 		previous:   b.previous,
+		completed:  b.completed,
 		entries:    make(chan LogEntry, b.bufferSize),
 		errors:     make(chan error, 100),
 	}
@@ -259,6 +277,7 @@ func (s *LogStream) Start(ctx context.Context) error {
 	}
 
 	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.watchCtx, s.watchCancel = context.WithCancel(s.ctx)
 
 	factory := informers.NewSharedInformerFactoryWithOptions(
 		s.client,
@@ -287,8 +306,9 @@ func (s *LogStream) Start(ctx context.Context) error {
 		},
 	})
 
+	s.synced = informer.HasSynced
 	s.wg.Go(func() {
-		informer.Run(s.ctx.Done())
+		informer.Run(s.watchCtx.Done())
 	})
 
 	return nil
@@ -313,6 +333,26 @@ func (s *LogStream) Stop() {
 	close(s.errors)
 }
 
+// Drain stops watching for new pods, waits for the pod streams already started to reach the end of their
+// logs, then closes the channels. Only streams of finished containers end on their own, so Drain is for
+// pods that are done, with Completed set. Like Stop, only the first of the two calls does anything.
+func (s *LogStream) Drain() {
+	if !s.running.CompareAndSwap(true, false) {
+		return
+	}
+
+	// pods that finished before Start are only streamed once the informer has listed them
+	cache.WaitForCacheSync(s.ctx.Done(), s.synced)
+	s.watchCancel()
+	s.streams.Wait()
+
+	s.cancel()
+	s.wg.Wait()
+
+	close(s.entries)
+	close(s.errors)
+}
+
 // Entries returns the channel for receiving log entries
 func (s *LogStream) Entries() <-chan LogEntry {
 	return s.entries
@@ -326,8 +366,14 @@ func (s *LogStream) Errors() <-chan error {
 // handlePod starts log streams for a pod's containers
 // Called by informer callbacks (potentially concurrent)
 func (s *LogStream) handlePod(pod *corev1.Pod) {
-	// Only stream running pods (or previous containers if requested)
-	if pod.Status.Phase != corev1.PodRunning && !s.previous {
+	// Drain has stopped watching, no new streams may start
+	if s.watchCtx.Err() != nil {
+		return
+	}
+
+	// Only stream running pods (or previous containers, or finished pods, if requested)
+	finished := pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	if pod.Status.Phase != corev1.PodRunning && !s.previous && !(s.completed && finished) {
 		return
 	}
 
@@ -395,8 +441,10 @@ func (s *LogStream) startPodStream(pod *corev1.Pod, container, key string) {
 
 	// Start the streaming goroutine
 	s.wg.Add(1)
+	s.streams.Add(1)
 	go func() {
 		defer s.wg.Done()
+		defer s.streams.Done()
 		s.streamPodLogs(ctx, stream)
 	}()
 }
@@ -0,0 +1,227 @@
+package klogmux
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/go-logfmt/logfmt"
+)
+
+// Normalized log levels, from least to most severe
+const (
+	LevelDebug = "DEBUG"
+	LevelInfo  = "INFO"
+	LevelWarn  = "WARN"
+	LevelError = "ERROR"
+	LevelFatal = "FATAL"
+)
+
+// Levels lists the normalized levels in order of severity
+var Levels = []string{LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal}
+
+var (
+	levelKeys   = []string{"level", "lvl", "severity"}
+	messageKeys = []string{"msg", "message"}
+
+	// matches lines such as "ERROR: ...", "[warn] ..." and "INFO ..."
+	levelPrefix = regexp.MustCompile(`^\s*\[?(?i:(trace|debug|info|warn|warning|error|fatal|panic))\]?[:\s]`)
+	// matches a level=... pair anywhere in an otherwise unstructured line
+	levelKeyval = regexp.MustCompile(`(?i)\b(?:level|lvl|severity)="?(\w+)`)
+)
+
+// NormalizeLevel maps the common spellings of a level to one of the Level constants.
+// Returns "" when the level isn't recognized.
+func NormalizeLevel(level string) string {
+	switch strings.ToUpper(strings.TrimSpace(level)) {
+	case "TRACE", "DEBUG", "DBG":
+		return LevelDebug
+	case "INFO", "INF", "NOTICE", "INFORMATION":
+		return LevelInfo
+	case "WARN", "WARNING", "WRN":
+		return LevelWarn
+	case "ERROR", "ERR":
+		return LevelError
+	case "FATAL", "PANIC", "DPANIC", "CRITICAL", "CRIT", "ALERT", "EMERG", "EMERGENCY":
+		return LevelFatal
+	}
+	return ""
+}
+
+// LevelRank returns a level's position in Levels, or -1 when it isn't a normalized level
+func LevelRank(level string) int {
+	for i, l := range Levels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// AtLeast reports whether level is as severe as min. Unrecognized levels count as INFO.
+func AtLeast(level, min string) bool {
+	rank := LevelRank(NormalizeLevel(level))
+	if rank < 0 {
+		rank = LevelRank(LevelInfo)
+	}
+	return rank >= LevelRank(NormalizeLevel(min))
+}
+
+// ParseLevel is a TransformFunc that sets the entry's level from JSON, logfmt or a level prefix
+// and leaves the message untouched. Lines with no level fall back to ERROR when IsError is set, INFO otherwise.
+func ParseLevel(entry LogEntry) LogEntry {
+	level, _, _ := parseLine(entry.Message)
+	return withLevel(entry, level)
+}
+
+// ParseStructured is a TransformFunc for apps that log JSON or logfmt. On top of ParseLevel it replaces
+// the message with the line's message field and moves the remaining keys into Fields.
+func ParseStructured(entry LogEntry) LogEntry {
+	level, message, fields := parseLine(entry.Message)
+	if message != "" {
+		entry.Message = message
+	}
+	if len(fields) > 0 {
+		entry.Fields = fields
+	}
+	return withLevel(entry, level)
+}
+
+func withLevel(entry LogEntry, level string) LogEntry {
+	switch {
+	case level != "":
+		entry.Level = level
+		entry.IsError = LevelRank(level) >= LevelRank(LevelError)
+	case entry.IsError:
+		entry.Level = LevelError
+	default:
+		entry.Level = LevelInfo
+	}
+	return entry
+}
+
+// parseLine detects the line's format. Unstructured lines only yield a level, if they have a prefix.
+func parseLine(line string) (level, message string, fields map[string]string) {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		if level, message, fields, ok := parseJSON(trimmed); ok {
+			return level, message, fields
+		}
+	}
+	if level, message, fields, ok := parseLogfmt(trimmed); ok {
+		return level, message, fields
+	}
+	if m := levelPrefix.FindStringSubmatch(line); m != nil {
+		return NormalizeLevel(m[1]), "", nil
+	}
+	if m := levelKeyval.FindStringSubmatch(line); m != nil {
+		return NormalizeLevel(m[1]), "", nil
+	}
+	return "", "", nil
+}
+
+func parseJSON(line string) (level, message string, fields map[string]string, ok bool) {
+	var obj map[string]any
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return "", "", nil, false
+	}
+
+	fields = make(map[string]string, len(obj))
+	for k, v := range obj {
+		switch val := v.(type) {
+		case string:
+			fields[k] = val
+		case json.Number:
+			fields[k] = val.String()
+		default:
+			b, err := json.Marshal(val)
+			if err != nil {
+				continue
+			}
+			fields[k] = string(b)
+		}
+	}
+
+	for _, k := range levelKeys {
+		if v, found := obj[k]; found {
+			// pino and bunyan log numeric levels
+			if n, isNumber := v.(json.Number); isNumber {
+				level = numericLevel(n)
+			} else {
+				level = NormalizeLevel(fields[k])
+			}
+			delete(fields, k)
+			break
+		}
+	}
+	message = takeMessage(fields)
+	return level, message, fields, true
+}
+
+// parseLogfmt only accepts lines with a level or message key, plain text otherwise decodes as bare keys
+func parseLogfmt(line string) (level, message string, fields map[string]string, ok bool) {
+	if !strings.Contains(line, "=") {
+		return "", "", nil, false
+	}
+
+	dec := logfmt.NewDecoder(bytes.NewBufferString(line))
+	fields = map[string]string{}
+	for dec.ScanRecord() {
+		for dec.ScanKeyval() {
+			if dec.Value() == nil {
+				return "", "", nil, false
+			}
+			fields[string(dec.Key())] = string(dec.Value())
+		}
+	}
+	if dec.Err() != nil {
+		return "", "", nil, false
+	}
+
+	for _, k := range levelKeys {
+		if v, found := fields[k]; found {
+			level = NormalizeLevel(v)
+			delete(fields, k)
+			ok = true
+			break
+		}
+	}
+	if message = takeMessage(fields); message != "" {
+		ok = true
+	}
+	return level, message, fields, ok
+}
+
+func takeMessage(fields map[string]string) string {
+	for _, k := range messageKeys {
+		if v, found := fields[k]; found {
+			delete(fields, k)
+			return v
+		}
+	}
+	return ""
+}
+
+// numericLevel maps pino/bunyan levels: 10 trace, 20 debug, 30 info, 40 warn, 50 error, 60 fatal
+func numericLevel(n json.Number) string {
+	v, err := n.Int64()
+	if err != nil {
+		return ""
+	}
+	switch {
+	case v >= 60:
+		return LevelFatal
+	case v >= 50:
+		return LevelError
+	case v >= 40:
+		return LevelWarn
+	case v >= 30:
+		return LevelInfo
+	case v > 0:
+		return LevelDebug
+	}
+	return ""
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/nikumar1206/loco/shared/config"
 	v1 "k8s.io/api/core/v1"
 	rbacV1 "k8s.io/api/rbac/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// AllocateResources orchestrates the creation of all Kubernetes resources for a deployment.
-// If any step fails, it attempts to clean up by deleting the namespace.
+// AllocateResources creates or updates every Kubernetes resource of a deployment, so it serves an app's first
+// deploy and every redeploy. If any step fails, the resources this call created are deleted again, what it
+// updated is left as is. Volume claims are never deleted, so their data outlives a failed deploy.
 func (kc *Client) AllocateResources(
 	ctx context.Context,
 	ldc *LocoDeploymentContext,
@@ -21,75 +24,174 @@ func (kc *Client) AllocateResources(
 	namespace := ldc.Namespace()
 	slog.InfoContext(ctx, "Starting resource allocation", "namespace", namespace, "app", ldc.App.Name)
 
-	_, err := kc.CreateNS(ctx, ldc)
+	createdNS, err := kc.ApplyNS(ctx, ldc)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create namespace", "error", err)
+		slog.ErrorContext(ctx, "Failed to apply namespace", "error", err)
 		return fmt.Errorf("failed to allocate resources: %w", err)
 	}
 
+	var created []createdResource
+	track := func(wasCreated bool, kind, name string, remove deleteFunc) {
+		if wasCreated {
+			created = append(created, createdResource{kind: kind, name: name, remove: remove})
+		}
+	}
+	claimsApplied := false
+
 	defer func() {
-		if err != nil {
+		if err == nil {
+			return
+		}
+		// a namespace holding volume claims is kept along with them, otherwise only this call's objects go
+		if createdNS && !claimsApplied {
 			slog.WarnContext(ctx, "Cleaning up namespace due to allocation failure", "namespace", namespace)
 			if deleteErr := kc.DeleteNS(ctx, namespace); deleteErr != nil {
 				slog.ErrorContext(ctx, "Failed to delete namespace during cleanup", "error", deleteErr)
 			}
+			return
 		}
+		rollback(ctx, namespace, created)
 	}()
 
-	_, err = kc.CreateSecret(ctx, ldc, envVars)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create secret", "error", err)
-		return fmt.Errorf("failed to create secret: %w", err)
+	if ldc.Quota != nil {
+		_, err = kc.ApplyResourceQuota(ctx, ldc, *ldc.Quota)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to apply resource quota", "error", err)
+			return fmt.Errorf("failed to apply resource quota: %w", err)
+		}
+
+		_, err = kc.ApplyLimitRange(ctx, ldc, *ldc.Quota)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to apply limit range", "error", err)
+			return fmt.Errorf("failed to apply limit range: %w", err)
+		}
 	}
 
 	if registryConfig != nil {
-		err = kc.CreateDockerPullSecret(ctx, ldc, *registryConfig)
+		err = kc.ApplyDockerPullSecret(ctx, ldc, *registryConfig)
 		if err != nil {
-			slog.ErrorContext(ctx, "Failed to create docker pull secret", "error", err)
-			return fmt.Errorf("failed to create docker pull secret: %w", err)
+			slog.ErrorContext(ctx, "Failed to apply docker pull secret", "error", err)
+			return fmt.Errorf("failed to apply docker pull secret: %w", err)
 		}
 	}
 
-	_, err = kc.CreateServiceAccount(ctx, ldc)
+	var wasCreated bool
+	wasCreated, err = kc.ApplyServiceAccount(ctx, ldc)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create service account", "error", err)
-		return fmt.Errorf("failed to create service account: %w", err)
+		slog.ErrorContext(ctx, "Failed to apply service account", "error", err)
+		return fmt.Errorf("failed to apply service account: %w", err)
+	}
+	track(wasCreated, "ServiceAccount", ldc.ServiceAccountName(), kc.ClientSet.CoreV1().ServiceAccounts(namespace).Delete)
+
+	wasCreated, err = kc.ApplyRole(ctx, ldc)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to apply role", "error", err)
+		return fmt.Errorf("failed to apply role: %w", err)
+	}
+	track(wasCreated, "Role", ldc.RoleName(), kc.ClientSet.RbacV1().Roles(namespace).Delete)
+
+	wasCreated, err = kc.ApplyRoleBinding(ctx, ldc)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to apply role binding", "error", err)
+		return fmt.Errorf("failed to apply role binding: %w", err)
+	}
+	track(wasCreated, "RoleBinding", ldc.RoleBindingName(), kc.ClientSet.RbacV1().RoleBindings(namespace).Delete)
+
+	// the release runs before the objects the old pods use change, with the new env in a Secret of its own.
+	// Until it succeeded the old pods keep serving with their env, ports and volumes.
+	if ldc.ReleaseCommand() != nil {
+		err = kc.RunRelease(ctx, ldc, envVars)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to run release command", "error", err)
+			return fmt.Errorf("failed to run release command: %w", err)
+		}
 	}
 
-	_, err = kc.createRoleWithSecretName(ctx, ldc, ldc.EnvSecretName())
+	wasCreated, err = kc.ApplySecret(ctx, ldc, envVars)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create role", "error", err)
-		return fmt.Errorf("failed to create role: %w", err)
+		slog.ErrorContext(ctx, "Failed to apply secret", "error", err)
+		return fmt.Errorf("failed to apply secret: %w", err)
 	}
+	track(wasCreated, "Secret", ldc.EnvSecretName(), kc.ClientSet.CoreV1().Secrets(namespace).Delete)
 
-	_, err = kc.CreateRoleBinding(ctx, ldc)
+	wasCreated, err = kc.ApplyService(ctx, ldc)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create role binding", "error", err)
-		return fmt.Errorf("failed to create role binding: %w", err)
+		slog.ErrorContext(ctx, "Failed to apply service", "error", err)
+		return fmt.Errorf("failed to apply service: %w", err)
 	}
+	track(wasCreated, KindService, ldc.ServiceName(), kc.ClientSet.CoreV1().Services(namespace).Delete)
 
-	_, err = kc.CreateService(ctx, ldc)
+	// set first, a claim created before a later one failed counts too
+	claimsApplied = len(ldc.PersistentVolumes()) > 0
+	err = kc.ApplyVolumeClaims(ctx, ldc)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create service", "error", err)
-		return fmt.Errorf("failed to create service: %w", err)
+		slog.ErrorContext(ctx, "Failed to apply volume claims", "error", err)
+		return fmt.Errorf("failed to apply volume claims: %w", err)
 	}
 
-	_, err = kc.CreateDeployment(ctx, ldc)
+	wasCreated, err = kc.ApplyDeployment(ctx, ldc)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create deployment", "error", err)
-		return fmt.Errorf("failed to create deployment: %w", err)
+		slog.ErrorContext(ctx, "Failed to apply deployment", "error", err)
+		return fmt.Errorf("failed to apply deployment: %w", err)
+	}
+	track(wasCreated, KindDeployment, ldc.DeploymentName(), kc.ClientSet.AppsV1().Deployments(namespace).Delete)
+
+	if ldc.Protocol() == config.ProtocolGRPC {
+		wasCreated, err = kc.ApplyGRPCRoute(ctx, ldc)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to apply GRPCRoute", "error", err)
+			return fmt.Errorf("failed to apply GRPCRoute: %w", err)
+		}
+		track(wasCreated, KindGRPCRoute, ldc.GRPCRouteName(), kc.GatewaySet.GatewayV1().GRPCRoutes(namespace).Delete)
+	} else {
+		wasCreated, err = kc.ApplyHTTPRoute(ctx, ldc)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to apply HTTPRoute", "error", err)
+			return fmt.Errorf("failed to apply HTTPRoute: %w", err)
+		}
+		track(wasCreated, KindHTTPRoute, ldc.HTTPRouteName(), kc.GatewaySet.GatewayV1().HTTPRoutes(namespace).Delete)
 	}
 
-	_, err = kc.CreateHTTPRoute(ctx, ldc)
+	// also runs without jobs, to delete the CronJobs of jobs the app no longer declares
+	err = kc.ApplyCronJobs(ctx, ldc)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create HTTPRoute", "error", err)
-		return fmt.Errorf("failed to create HTTPRoute: %w", err)
+		slog.ErrorContext(ctx, "Failed to apply cron jobs", "error", err)
+		return fmt.Errorf("failed to apply cron jobs: %w", err)
+	}
+
+	if len(ldc.PublicPorts()) > 0 {
+		err = kc.ExposePorts(ctx, ldc)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to expose ports", "error", err)
+			return fmt.Errorf("failed to expose ports: %w", err)
+		}
 	}
 
 	slog.InfoContext(ctx, "Resource allocation completed successfully", "namespace", namespace, "app", ldc.App.Name)
 	return nil
 }
 
+// deleteFunc deletes an object by name, the Delete of its typed client
+type deleteFunc func(ctx context.Context, name string, opts metaV1.DeleteOptions) error
+
+// createdResource is an object AllocateResources created, deleted again when a later step fails
+type createdResource struct {
+	kind   string
+	name   string
+	remove deleteFunc
+}
+
+// rollback deletes what a failed allocation created, newest first
+func rollback(ctx context.Context, namespace string, created []createdResource) {
+	for i := len(created) - 1; i >= 0; i-- {
+		r := created[i]
+		slog.WarnContext(ctx, "Deleting resource due to allocation failure", "namespace", namespace, "kind", r.kind, "name", r.name)
+		if err := r.remove(ctx, r.name, metaV1.DeleteOptions{}); err != nil && !apiErrors.IsNotFound(err) {
+			slog.ErrorContext(ctx, "Failed to delete resource during cleanup", "kind", r.kind, "name", r.name, "error", err)
+		}
+	}
+}
+
 // createRoleWithSecretName is a helper that creates a role referencing a secret name
 func (kc *Client) createRoleWithSecretName(ctx context.Context, ldc *LocoDeploymentContext, secretName string) (*rbacV1.Role, error) {
 	slog.InfoContext(ctx, "Creating role", "namespace", ldc.Namespace(), "name", ldc.RoleName())
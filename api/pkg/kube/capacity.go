@@ -0,0 +1,240 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultHeadroomFactor is how many times a deployment's requests must be free before it's accepted
+const DefaultHeadroomFactor = 2.0
+
+const mebibyte = 1024 * 1024
+
+// NodeCapacity is the allocatable and already requested resources of a single schedulable node.
+// CPU is in millicores and memory in bytes.
+type NodeCapacity struct {
+	Name              string
+	AllocatableCPU    int64
+	AllocatableMemory int64
+	RequestedCPU      int64
+	RequestedMemory   int64
+}
+
+// FreeCPU is the node's unrequested cpu in millicores
+func (n NodeCapacity) FreeCPU() int64 {
+	return max(n.AllocatableCPU-n.RequestedCPU, 0)
+}
+
+// FreeMemory is the node's unrequested memory in bytes
+func (n NodeCapacity) FreeMemory() int64 {
+	return max(n.AllocatableMemory-n.RequestedMemory, 0)
+}
+
+// Capacity is a snapshot of the cluster's schedulable nodes.
+// Pending pods that haven't been placed yet count against the cluster but not against any node.
+type Capacity struct {
+	Nodes         []NodeCapacity
+	PendingCPU    int64
+	PendingMemory int64
+}
+
+// AllocatableCPU is the cpu of every schedulable node in millicores
+func (c Capacity) AllocatableCPU() int64 {
+	var total int64
+	for _, n := range c.Nodes {
+		total += n.AllocatableCPU
+	}
+	return total
+}
+
+// AllocatableMemory is the memory of every schedulable node in bytes
+func (c Capacity) AllocatableMemory() int64 {
+	var total int64
+	for _, n := range c.Nodes {
+		total += n.AllocatableMemory
+	}
+	return total
+}
+
+// FreeCPU is the cluster's unrequested cpu in millicores
+func (c Capacity) FreeCPU() int64 {
+	var total int64
+	for _, n := range c.Nodes {
+		total += n.FreeCPU()
+	}
+	return max(total-c.PendingCPU, 0)
+}
+
+// FreeMemory is the cluster's unrequested memory in bytes
+func (c Capacity) FreeMemory() int64 {
+	var total int64
+	for _, n := range c.Nodes {
+		total += n.FreeMemory()
+	}
+	return max(total-c.PendingMemory, 0)
+}
+
+// ClusterCapacity sums node allocatable resources and the requests of every pod that is still running or waiting to run
+func (kc *Client) ClusterCapacity(ctx context.Context) (Capacity, error) {
+	nodes, err := kc.ClientSet.CoreV1().Nodes().List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list nodes", "error", err)
+		return Capacity{}, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	byName := map[string]int{}
+	var capacity Capacity
+	for _, node := range nodes.Items {
+		if !isSchedulable(&node) {
+			continue
+		}
+		byName[node.Name] = len(capacity.Nodes)
+		capacity.Nodes = append(capacity.Nodes, NodeCapacity{
+			Name:              node.Name,
+			AllocatableCPU:    node.Status.Allocatable.Cpu().MilliValue(),
+			AllocatableMemory: node.Status.Allocatable.Memory().Value(),
+		})
+	}
+
+	pods, err := kc.ClientSet.CoreV1().Pods("").List(ctx, metaV1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list pods", "error", err)
+		return Capacity{}, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		cpu, memory := podRequests(&pod)
+		if pod.Spec.NodeName == "" {
+			capacity.PendingCPU += cpu
+			capacity.PendingMemory += memory
+			continue
+		}
+		i, ok := byName[pod.Spec.NodeName]
+		if !ok {
+			// pods on cordoned or not ready nodes don't take from schedulable capacity
+			continue
+		}
+		capacity.Nodes[i].RequestedCPU += cpu
+		capacity.Nodes[i].RequestedMemory += memory
+	}
+
+	return capacity, nil
+}
+
+func isSchedulable(node *v1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func podRequests(pod *v1.Pod) (cpu int64, memory int64) {
+	for _, c := range pod.Spec.Containers {
+		cpu += c.Resources.Requests.Cpu().MilliValue()
+		memory += c.Resources.Requests.Memory().Value()
+	}
+	return cpu, memory
+}
+
+// Fit is the planner's verdict on a deployment
+type Fit struct {
+	// Fits is true when the deployment can be scheduled now with headroom to spare
+	Fits bool
+	// Possible is false when the deployment could never fit, even on an empty cluster
+	Possible bool
+	// Reason explains why the deployment doesn't fit
+	Reason   string
+	Capacity Capacity
+}
+
+// CapacityPlanner decides whether a cluster can sustain a deployment
+type CapacityPlanner struct {
+	headroom float64
+}
+
+// NewCapacityPlanner creates a CapacityPlanner. A headroom below 1 falls back to DefaultHeadroomFactor.
+func NewCapacityPlanner(headroom float64) *CapacityPlanner {
+	if headroom < 1 {
+		headroom = DefaultHeadroomFactor
+	}
+	return &CapacityPlanner{headroom: headroom}
+}
+
+// Headroom returns the factor applied to requested resources
+func (p *CapacityPlanner) Headroom() float64 {
+	return p.headroom
+}
+
+// Check reports whether replicas of the given per-replica cpu and memory fit in the cluster kc points at.
+// The whole deployment must fit in free capacity with headroom, and at least one node must have room for a single replica.
+func (p *CapacityPlanner) Check(ctx context.Context, kc *Client, replicas int32, cpu, memory string) (Fit, error) {
+	if cpu == "" {
+		cpu = DefaultCPU
+	}
+	if memory == "" {
+		memory = DefaultMemory
+	}
+	cpuQuantity, err := resource.ParseQuantity(cpu)
+	if err != nil {
+		return Fit{}, fmt.Errorf("invalid cpu value: %w", err)
+	}
+	memoryQuantity, err := resource.ParseQuantity(memory)
+	if err != nil {
+		return Fit{}, fmt.Errorf("invalid memory value: %w", err)
+	}
+
+	capacity, err := kc.ClusterCapacity(ctx)
+	if err != nil {
+		return Fit{}, err
+	}
+	fit := Fit{Capacity: capacity, Possible: true}
+
+	replicaCPU := cpuQuantity.MilliValue()
+	replicaMemory := memoryQuantity.Value()
+	needCPU := int64(float64(int64(replicas)*replicaCPU) * p.headroom)
+	needMemory := int64(float64(int64(replicas)*replicaMemory) * p.headroom)
+
+	var largestCPU, largestMemory int64
+	roomOnSomeNode := false
+	for _, n := range capacity.Nodes {
+		largestCPU = max(largestCPU, n.AllocatableCPU)
+		largestMemory = max(largestMemory, n.AllocatableMemory)
+		if n.FreeCPU() >= replicaCPU && n.FreeMemory() >= replicaMemory {
+			roomOnSomeNode = true
+		}
+	}
+
+	switch {
+	case len(capacity.Nodes) == 0:
+		fit.Reason = "the cluster has no schedulable nodes"
+	case replicaCPU > largestCPU || replicaMemory > largestMemory:
+		fit.Possible = false
+		fit.Reason = fmt.Sprintf("a single replica needs %dm cpu and %dMi memory, more than any node has (%dm cpu, %dMi memory)",
+			replicaCPU, replicaMemory/mebibyte, largestCPU, largestMemory/mebibyte)
+	case needCPU > capacity.AllocatableCPU() || needMemory > capacity.AllocatableMemory():
+		fit.Possible = false
+		fit.Reason = fmt.Sprintf("with %.1fx headroom the deployment needs %dm cpu and %dMi memory, but the whole cluster only has %dm cpu and %dMi memory",
+			p.headroom, needCPU, needMemory/mebibyte, capacity.AllocatableCPU(), capacity.AllocatableMemory()/mebibyte)
+	case needCPU > capacity.FreeCPU() || needMemory > capacity.FreeMemory():
+		fit.Reason = fmt.Sprintf("with %.1fx headroom the deployment needs %dm cpu and %dMi memory, but only %dm cpu and %dMi memory are free",
+			p.headroom, needCPU, needMemory/mebibyte, capacity.FreeCPU(), capacity.FreeMemory()/mebibyte)
+	case !roomOnSomeNode:
+		fit.Reason = fmt.Sprintf("no node has %dm cpu and %dMi memory free for a single replica", replicaCPU, replicaMemory/mebibyte)
+	default:
+		fit.Fits = true
+	}
+
+	return fit, nil
+}
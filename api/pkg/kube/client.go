@@ -1,8 +1,11 @@
 package kube
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -13,6 +16,10 @@ import (
 type Client struct {
 	ClientSet  kubernetes.Interface
 	GatewaySet gatewayCs.Interface
+	// DynamicSet reaches CRDs without a typed client, like cert-manager's
+	DynamicSet dynamic.Interface
+	// Config is kept for the streaming subresources the clientsets can't speak, like pod exec
+	Config *rest.Config
 }
 
 // NewClient initializes a new Kubernetes client based on the application environment.
@@ -24,10 +31,13 @@ func NewClient(appEnv string) *Client {
 
 	clientSet := buildKubeClientSet(config)
 	gatewaySet := buildGatewayClient(config)
+	dynamicSet := buildDynamicClient(config)
 
 	return &Client{
 		ClientSet:  clientSet,
 		GatewaySet: gatewaySet,
+		DynamicSet: dynamicSet,
+		Config:     config,
 	}
 }
 
@@ -75,3 +85,55 @@ func buildGatewayClient(config *rest.Config) gatewayCs.Interface {
 	slog.Info("Gateway client initialized")
 	return gwcs
 }
+
+func buildDynamicClient(config *rest.Config) dynamic.Interface {
+	dcs, err := dynamic.NewForConfig(config)
+	if err != nil {
+		slog.Error("Failed to create dynamic client", "error", err)
+		panic(err)
+	}
+
+	slog.Info("Dynamic client initialized")
+	return dcs
+}
+
+// NewClientFromKubeconfig builds a client for a registered cluster from its kubeconfig.
+// Unlike NewClient it returns an error rather than panicking, since the kubeconfig comes from an API request.
+func NewClientFromKubeconfig(kubeconfig []byte) (*Client, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	gatewaySet, err := gatewayCs.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gateway client: %w", err)
+	}
+
+	dynamicSet, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	return &Client{
+		ClientSet:  clientSet,
+		GatewaySet: gatewaySet,
+		DynamicSet: dynamicSet,
+		Config:     config,
+	}, nil
+}
+
+// ServerVersion checks the cluster is reachable and returns its kubernetes version
+func (kc *Client) ServerVersion(ctx context.Context) (string, error) {
+	info, err := kc.ClientSet.Discovery().ServerVersion()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to reach cluster", "error", err)
+		return "", fmt.Errorf("failed to reach cluster: %w", err)
+	}
+	return info.GitVersion, nil
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"time"
 
+	"github.com/nikumar1206/loco/shared/config"
 	appsV1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // CheckDeploymentExists checks if a Deployment exists in the specified namespace
@@ -39,6 +44,52 @@ func (kc *Client) CreateDeployment(ctx context.Context, ldc *LocoDeploymentConte
 		return nil, fmt.Errorf("deployment already exists: %s", ldc.DeploymentName())
 	}
 
+	deployment, err := buildDeployment(ctx, ldc)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := kc.ClientSet.AppsV1().Deployments(ldc.Namespace()).Create(ctx, deployment, metaV1.CreateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create deployment", "deployment", ldc.DeploymentName(), "error", err)
+		return nil, fmt.Errorf("failed to create deployment: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Deployment created", "deployment", result.Name)
+	return result, nil
+}
+
+// ApplyDeployment creates ldc's Deployment, or rolls its spec out over the existing one.
+// It reports whether it created it.
+func (kc *Client) ApplyDeployment(ctx context.Context, ldc *LocoDeploymentContext) (bool, error) {
+	deploymentsClient := kc.ClientSet.AppsV1().Deployments(ldc.Namespace())
+	existing, err := deploymentsClient.Get(ctx, ldc.DeploymentName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		if _, err := kc.CreateDeployment(ctx, ldc); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get deployment", "deployment", ldc.DeploymentName(), "error", err)
+		return false, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	want, err := buildDeployment(ctx, ldc)
+	if err != nil {
+		return false, err
+	}
+	existing.Spec = want.Spec
+	if _, err := deploymentsClient.Update(ctx, existing, metaV1.UpdateOptions{}); err != nil {
+		slog.ErrorContext(ctx, "Failed to update deployment", "deployment", ldc.DeploymentName(), "error", err)
+		return false, fmt.Errorf("failed to update deployment: %w", err)
+	}
+	slog.InfoContext(ctx, "Deployment updated", "deployment", ldc.DeploymentName())
+	return false, nil
+}
+
+// buildDeployment renders the Deployment loco wants for ldc
+func buildDeployment(ctx context.Context, ldc *LocoDeploymentContext) (*appsV1.Deployment, error) {
 	replicas := int32(ldc.Deployment.Replicas)
 	if replicas == 0 {
 		replicas = DefaultReplicas
@@ -56,6 +107,23 @@ func (kc *Client) CreateDeployment(ctx context.Context, ldc *LocoDeploymentConte
 		return nil, fmt.Errorf("invalid memory value: %w", err)
 	}
 
+	volumes, volumeMounts, err := buildPodVolumes(ldc)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := appsV1.DeploymentStrategy{
+		Type: appsV1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsV1.RollingUpdateDeployment{
+			MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: MaxSurgePercent},
+			MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: MaxUnavailablePercent},
+		},
+	}
+	if len(ldc.PersistentVolumes()) > 0 {
+		// a ReadWriteOnce claim can't be mounted by the new pod until the old one lets go of it
+		strategy = appsV1.DeploymentStrategy{Type: appsV1.RecreateDeploymentStrategyType}
+	}
+
 	deployment := &appsV1.Deployment{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name:      ldc.DeploymentName(),
@@ -69,13 +137,7 @@ func (kc *Client) CreateDeployment(ctx context.Context, ldc *LocoDeploymentConte
 					LabelAppName: ldc.App.Name,
 				},
 			},
-			Strategy: appsV1.DeploymentStrategy{
-				Type: appsV1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsV1.RollingUpdateDeployment{
-					MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: MaxSurgePercent},
-					MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: MaxUnavailablePercent},
-				},
-			},
+			Strategy:             strategy,
 			RevisionHistoryLimit: ptrToInt32(MaxReplicaHistory),
 			Template: v1.PodTemplateSpec{
 				ObjectMeta: metaV1.ObjectMeta{
@@ -89,6 +151,7 @@ func (kc *Client) CreateDeployment(ctx context.Context, ldc *LocoDeploymentConte
 						},
 					},
 					ServiceAccountName: ldc.ServiceAccountName(),
+					Volumes:            volumes,
 					Containers: []v1.Container{
 						{
 							Name:  ldc.ContainerName(),
@@ -102,11 +165,8 @@ func (kc *Client) CreateDeployment(ctx context.Context, ldc *LocoDeploymentConte
 									Drop: []v1.Capability{"ALL"},
 								},
 							},
-							Ports: []v1.ContainerPort{
-								{
-									ContainerPort: ldc.Config.Routing.Port,
-								},
-							},
+							Ports:        buildContainerPorts(ldc),
+							VolumeMounts: volumeMounts,
 							EnvFrom: []v1.EnvFromSource{
 								{
 									SecretRef: &v1.SecretEnvSource{
@@ -126,20 +186,9 @@ func (kc *Client) CreateDeployment(ctx context.Context, ldc *LocoDeploymentConte
 									v1.ResourceMemory: memoryQuantity,
 								},
 							},
-							LivenessProbe: &v1.Probe{
-								InitialDelaySeconds:           ldc.Config.Health.StartupGracePeriod,
-								TimeoutSeconds:                ldc.Config.Health.Timeout,
-								PeriodSeconds:                 ldc.Config.Health.Interval,
-								TerminationGracePeriodSeconds: ptrToInt64(TerminationGracePeriod),
-								SuccessThreshold:              1,
-								FailureThreshold:              ldc.Config.Health.FailThreshold,
-								ProbeHandler: v1.ProbeHandler{
-									HTTPGet: &v1.HTTPGetAction{
-										Path: ldc.Config.Health.Path,
-										Port: intstr.FromInt32(ldc.Config.Routing.Port),
-									},
-								},
-							},
+							LivenessProbe:  buildLivenessProbe(ldc),
+							ReadinessProbe: buildProbe(ldc, ldc.Config.Health.Readiness),
+							StartupProbe:   buildProbe(ldc, ldc.Config.Health.Startup),
 						},
 					},
 				},
@@ -147,14 +196,84 @@ func (kc *Client) CreateDeployment(ctx context.Context, ldc *LocoDeploymentConte
 		},
 	}
 
-	result, err := kc.ClientSet.AppsV1().Deployments(ldc.Namespace()).Create(ctx, deployment, metaV1.CreateOptions{})
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create deployment", "deployment", ldc.DeploymentName(), "error", err)
-		return nil, fmt.Errorf("failed to create deployment: %w", err)
+	return deployment, nil
+}
+
+// buildLivenessProbe renders the configured liveness probe. Deployments made before probes were
+// configurable only have the Health defaults, which render the liveness check they always had.
+func buildLivenessProbe(ldc *LocoDeploymentContext) *v1.Probe {
+	if ldc.Config.Health.Liveness != nil {
+		probe := buildProbe(ldc, ldc.Config.Health.Liveness)
+		probe.TerminationGracePeriodSeconds = ptrToInt64(TerminationGracePeriod)
+		return probe
 	}
 
-	slog.InfoContext(ctx, "Deployment created", "deployment", result.Name)
-	return result, nil
+	handler := v1.ProbeHandler{
+		HTTPGet: &v1.HTTPGetAction{
+			Path: ldc.Config.Health.Path,
+			Port: intstr.FromInt32(ldc.Config.Routing.Port),
+		},
+	}
+	// grpc apps are checked with the gRPC health protocol
+	if ldc.Protocol() == config.ProtocolGRPC {
+		handler = v1.ProbeHandler{
+			GRPC: &v1.GRPCAction{
+				Port: ldc.Config.Routing.Port,
+			},
+		}
+	}
+	return &v1.Probe{
+		InitialDelaySeconds:           ldc.Config.Health.StartupGracePeriod,
+		TimeoutSeconds:                ldc.Config.Health.Timeout,
+		PeriodSeconds:                 ldc.Config.Health.Interval,
+		TerminationGracePeriodSeconds: ptrToInt64(TerminationGracePeriod),
+		SuccessThreshold:              1,
+		FailureThreshold:              ldc.Config.Health.FailThreshold,
+		ProbeHandler:                  handler,
+	}
+}
+
+// buildProbe renders a configured probe, nil when it isn't set. Unset ports check routing.port.
+func buildProbe(ldc *LocoDeploymentContext, p *config.Probe) *v1.Probe {
+	if p == nil {
+		return nil
+	}
+
+	port := p.Port
+	if port == 0 {
+		port = ldc.Config.Routing.Port
+	}
+
+	var handler v1.ProbeHandler
+	switch p.Type {
+	case config.ProbeTCP:
+		handler.TCPSocket = &v1.TCPSocketAction{Port: intstr.FromInt32(port)}
+	case config.ProbeExec:
+		handler.Exec = &v1.ExecAction{Command: p.Command}
+	case config.ProbeGRPC:
+		handler.GRPC = &v1.GRPCAction{Port: port}
+		if p.Service != "" {
+			handler.GRPC.Service = ptrToString(p.Service)
+		}
+	default:
+		handler.HTTPGet = &v1.HTTPGetAction{
+			Path: p.Path,
+			Port: intstr.FromInt32(port),
+		}
+		// sorted so the rendered probe is stable for drift detection
+		for _, name := range slices.Sorted(maps.Keys(p.Headers)) {
+			handler.HTTPGet.HTTPHeaders = append(handler.HTTPGet.HTTPHeaders, v1.HTTPHeader{Name: name, Value: p.Headers[name]})
+		}
+	}
+
+	return &v1.Probe{
+		ProbeHandler:        handler,
+		InitialDelaySeconds: p.InitialDelay,
+		TimeoutSeconds:      p.Timeout,
+		PeriodSeconds:       p.Interval,
+		SuccessThreshold:    p.SuccessThreshold,
+		FailureThreshold:    p.FailThreshold,
+	}
 }
 
 // UpdateContainer updates the container image in an existing Deployment
@@ -260,3 +379,125 @@ func (kc *Client) RestartDeployment(ctx context.Context, namespace, deploymentNa
 	slog.InfoContext(ctx, "Deployment restarted successfully")
 	return nil
 }
+
+// RolloutProgress is how far a deployment's rollout got
+type RolloutProgress struct {
+	Replicas  int32
+	Updated   int32
+	Available int32
+}
+
+// WaitForDeploymentReady polls until every replica of the deployment is updated and available, or ctx is done
+func (kc *Client) WaitForDeploymentReady(ctx context.Context, namespace, deploymentName string, interval time.Duration) error {
+	return kc.WatchRollout(ctx, namespace, deploymentName, interval, nil)
+}
+
+// WatchRollout is WaitForDeploymentReady, calling onProgress whenever the rollout moved. An error from onProgress
+// stops watching.
+func (kc *Client) WatchRollout(
+	ctx context.Context,
+	namespace, deploymentName string,
+	interval time.Duration,
+	onProgress func(RolloutProgress) error,
+) error {
+	slog.InfoContext(ctx, "Waiting for deployment to become ready", "namespace", namespace, "deployment", deploymentName)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last RolloutProgress
+	for {
+		deployment, err := kc.ClientSet.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metaV1.GetOptions{})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get deployment", "error", err)
+			return fmt.Errorf("failed to get deployment: %w", err)
+		}
+		if deploymentReady(deployment) {
+			slog.InfoContext(ctx, "Deployment is ready", "namespace", namespace, "deployment", deploymentName)
+			return nil
+		}
+
+		progress := RolloutProgress{
+			Replicas:  ptrValue(deployment.Spec.Replicas),
+			Updated:   deployment.Status.UpdatedReplicas,
+			Available: deployment.Status.AvailableReplicas,
+		}
+		if onProgress != nil && progress != last {
+			if err := onProgress(progress); err != nil {
+				return err
+			}
+			last = progress
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("deployment %s not ready: %w", deploymentName, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// RestartPod deletes one of ldc's app's pods so its ReplicaSet replaces it
+func (kc *Client) RestartPod(ctx context.Context, ldc *LocoDeploymentContext, podName string) error {
+	podsClient := kc.ClientSet.CoreV1().Pods(ldc.Namespace())
+
+	pod, err := podsClient.Get(ctx, podName, metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		return fmt.Errorf("%w: %s", ErrPodNotInApp, podName)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get pod", "namespace", ldc.Namespace(), "pod", podName, "error", err)
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+	// only the app's own pods come back, the ones of jobs and one-off commands wouldn't
+	if pod.Labels[LabelAppName] != ldc.App.Name {
+		return fmt.Errorf("%w: %s", ErrPodNotInApp, podName)
+	}
+
+	slog.InfoContext(ctx, "Restarting pod", "namespace", ldc.Namespace(), "pod", podName)
+	if err := podsClient.Delete(ctx, podName, metaV1.DeleteOptions{}); err != nil && !apiErrors.IsNotFound(err) {
+		slog.ErrorContext(ctx, "Failed to delete pod", "namespace", ldc.Namespace(), "pod", podName, "error", err)
+		return fmt.Errorf("failed to restart pod: %w", err)
+	}
+	return nil
+}
+
+// WaitForPodDeleted polls until a pod is gone, once it's done shutting down, or ctx is done
+func (kc *Client) WaitForPodDeleted(ctx context.Context, namespace, podName string, interval time.Duration) error {
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		_, err := kc.ClientSet.CoreV1().Pods(namespace).Get(ctx, podName, metaV1.GetOptions{})
+		if apiErrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("pod %s not deleted: %w", podName, err)
+	}
+	return nil
+}
+
+// deploymentReady reports whether the controller has rolled out the latest spec to every replica
+func deploymentReady(d *appsV1.Deployment) bool {
+	replicas := ptrValue(d.Spec.Replicas)
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.AvailableReplicas == replicas
+}
+
+// buildContainerPorts lists the routing port followed by the app's routing.ports
+func buildContainerPorts(ldc *LocoDeploymentContext) []v1.ContainerPort {
+	ports := []v1.ContainerPort{
+		{
+			ContainerPort: ldc.Config.Routing.Port,
+		},
+	}
+	for _, port := range ldc.Config.Routing.Ports {
+		ports = append(ports, v1.ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.Port,
+			Protocol:      v1.Protocol(port.Protocol),
+		})
+	}
+	return ports
+}
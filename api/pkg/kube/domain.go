@@ -0,0 +1,269 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+	v1Gateway "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// DomainIssuerName is the ClusterIssuer custom domain certificates are requested from.
+// It must solve HTTP-01 challenges through the gateway, since loco has no access to users' DNS.
+const DomainIssuerName = "letsencrypt-http"
+
+// Certificate states read from a custom domain's cert-manager Certificate
+const (
+	CertificateMissing = "missing"
+	CertificateIssuing = "issuing"
+	CertificateReady   = "ready"
+	CertificateFailed  = "failed"
+)
+
+// DomainCertificateName names the Certificate, and the gateway listener, of a custom domain.
+// Names use the domain's ID since hostnames can be longer than a listener name allows.
+func DomainCertificateName(domainID int64) string {
+	return fmt.Sprintf("domain-%d", domainID)
+}
+
+// DomainSecretName names the secret a custom domain's certificate is stored in
+func DomainSecretName(domainID int64) string {
+	return fmt.Sprintf("domain-%d-tls", domainID)
+}
+
+// AttachDomain issues a certificate for hostname and adds an HTTPS listener for it to the loco gateway.
+// Only routes in namespace can attach to the listener, so another app can't claim the hostname.
+func (kc *Client) AttachDomain(ctx context.Context, domainID int64, hostname, namespace string) error {
+	slog.InfoContext(ctx, "Attaching domain", "hostname", hostname, "namespace", namespace)
+
+	if err := kc.applyDomainCertificate(ctx, domainID, hostname); err != nil {
+		return err
+	}
+
+	listener := buildDomainListener(domainID, hostname, namespace)
+	err := kc.updateGateway(ctx, func(gw *v1Gateway.Gateway) {
+		i := slices.IndexFunc(gw.Spec.Listeners, func(l v1Gateway.Listener) bool { return l.Name == listener.Name })
+		if i < 0 {
+			gw.Spec.Listeners = append(gw.Spec.Listeners, listener)
+			return
+		}
+		gw.Spec.Listeners[i] = listener
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to add gateway listener", "hostname", hostname, "error", err)
+		return fmt.Errorf("failed to add gateway listener: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Domain attached", "hostname", hostname, "listener", listener.Name)
+	return nil
+}
+
+// DetachDomain removes a custom domain's gateway listener, certificate and certificate secret
+func (kc *Client) DetachDomain(ctx context.Context, domainID int64) error {
+	name := DomainCertificateName(domainID)
+	slog.InfoContext(ctx, "Detaching domain", "name", name)
+
+	err := kc.updateGateway(ctx, func(gw *v1Gateway.Gateway) {
+		gw.Spec.Listeners = slices.DeleteFunc(gw.Spec.Listeners, func(l v1Gateway.Listener) bool {
+			return string(l.Name) == name
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to remove gateway listener", "name", name, "error", err)
+		return fmt.Errorf("failed to remove gateway listener: %w", err)
+	}
+
+	err = kc.DynamicSet.Resource(certificateGVR).Namespace(LocoNS).Delete(ctx, name, metaV1.DeleteOptions{})
+	if err != nil && !apiErrors.IsNotFound(err) {
+		slog.ErrorContext(ctx, "Failed to delete certificate", "name", name, "error", err)
+		return fmt.Errorf("failed to delete certificate: %w", err)
+	}
+
+	// cert-manager leaves the secret behind when its certificate is deleted
+	err = kc.ClientSet.CoreV1().Secrets(LocoNS).Delete(ctx, DomainSecretName(domainID), metaV1.DeleteOptions{})
+	if err != nil && !apiErrors.IsNotFound(err) {
+		slog.ErrorContext(ctx, "Failed to delete certificate secret", "name", DomainSecretName(domainID), "error", err)
+		return fmt.Errorf("failed to delete certificate secret: %w", err)
+	}
+	return nil
+}
+
+// DomainCertificateStatus reads a custom domain's certificate, returning one of the Certificate states and cert-manager's message
+func (kc *Client) DomainCertificateStatus(ctx context.Context, domainID int64) (string, string, error) {
+	cert, err := kc.DynamicSet.Resource(certificateGVR).Namespace(LocoNS).Get(ctx, DomainCertificateName(domainID), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		return CertificateMissing, "", nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get certificate", "name", DomainCertificateName(domainID), "error", err)
+		return "", "", fmt.Errorf("failed to get certificate: %w", err)
+	}
+
+	status := CertificateIssuing
+	var message string
+	conditions, _, _ := unstructured.NestedSlice(cert.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch {
+		case cond["type"] == "Ready" && cond["status"] == string(v1.ConditionTrue):
+			status, message = CertificateReady, stringField(cond, "message")
+			return status, message, nil
+		case cond["type"] == "Ready":
+			message = stringField(cond, "message")
+		// cert-manager backs off and retries failed issuances, until then the certificate stays failed
+		case cond["type"] == "Issuing" && cond["status"] == string(v1.ConditionFalse) && cond["reason"] == "Failed":
+			status, message = CertificateFailed, stringField(cond, "message")
+		}
+	}
+	return status, message, nil
+}
+
+// SetRouteHostnames replaces the hostnames of an app's HTTPRoute, or its GRPCRoute for grpc apps.
+// A route that doesn't exist yet is left alone, it gets the hostnames when it's created.
+func (kc *Client) SetRouteHostnames(ctx context.Context, namespace, name string, hostnames []string) error {
+	gatewayHostnames := make([]v1Gateway.Hostname, len(hostnames))
+	for i, h := range hostnames {
+		gatewayHostnames[i] = v1Gateway.Hostname(h)
+	}
+
+	httpRoutes := kc.GatewaySet.GatewayV1().HTTPRoutes(namespace)
+	kind := KindHTTPRoute
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		route, err := httpRoutes.Get(ctx, name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		route.Spec.Hostnames = gatewayHostnames
+		_, err = httpRoutes.Update(ctx, route, metaV1.UpdateOptions{})
+		return err
+	})
+	if apiErrors.IsNotFound(err) {
+		grpcRoutes := kc.GatewaySet.GatewayV1().GRPCRoutes(namespace)
+		kind = KindGRPCRoute
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			route, err := grpcRoutes.Get(ctx, name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			route.Spec.Hostnames = gatewayHostnames
+			_, err = grpcRoutes.Update(ctx, route, metaV1.UpdateOptions{})
+			return err
+		})
+	}
+	if apiErrors.IsNotFound(err) {
+		slog.InfoContext(ctx, "Route not found, hostnames apply on next deploy", "namespace", namespace, "name", name)
+		return nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update route hostnames", "namespace", namespace, "name", name, "kind", kind, "error", err)
+		return fmt.Errorf("failed to update %s hostnames: %w", kind, err)
+	}
+	return nil
+}
+
+func (kc *Client) applyDomainCertificate(ctx context.Context, domainID int64, hostname string) error {
+	certs := kc.DynamicSet.Resource(certificateGVR).Namespace(LocoNS)
+	want := buildDomainCertificate(domainID, hostname)
+
+	_, err := certs.Create(ctx, want, metaV1.CreateOptions{})
+	if apiErrors.IsAlreadyExists(err) {
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			have, err := certs.Get(ctx, want.GetName(), metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			have.Object["spec"] = want.Object["spec"]
+			_, err = certs.Update(ctx, have, metaV1.UpdateOptions{})
+			return err
+		})
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to apply certificate", "hostname", hostname, "error", err)
+		return fmt.Errorf("failed to apply certificate: %w", err)
+	}
+	return nil
+}
+
+// updateGateway applies mutate to the loco gateway, retrying when another writer got there first
+func (kc *Client) updateGateway(ctx context.Context, mutate func(*v1Gateway.Gateway)) error {
+	gateways := kc.GatewaySet.GatewayV1().Gateways(LocoNS)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		gw, err := gateways.Get(ctx, LocoGatewayName, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(gw)
+		_, err = gateways.Update(ctx, gw, metaV1.UpdateOptions{})
+		return err
+	})
+}
+
+func buildDomainCertificate(domainID int64, hostname string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]any{
+			"name":      DomainCertificateName(domainID),
+			"namespace": LocoNS,
+			"labels":    map[string]any{LabelAppManagedBy: "loco"},
+		},
+		"spec": map[string]any{
+			"secretName": DomainSecretName(domainID),
+			"dnsNames":   []any{hostname},
+			"issuerRef": map[string]any{
+				"name": DomainIssuerName,
+				"kind": "ClusterIssuer",
+			},
+		},
+	}}
+}
+
+func buildDomainListener(domainID int64, hostname, namespace string) v1Gateway.Listener {
+	hostnameRef := v1Gateway.Hostname(hostname)
+	tlsMode := v1Gateway.TLSModeTerminate
+	from := v1Gateway.NamespacesFromSelector
+
+	return v1Gateway.Listener{
+		Name:     v1Gateway.SectionName(DomainCertificateName(domainID)),
+		Hostname: &hostnameRef,
+		Port:     443,
+		Protocol: v1Gateway.HTTPSProtocolType,
+		TLS: &v1Gateway.ListenerTLSConfig{
+			Mode: &tlsMode,
+			CertificateRefs: []v1Gateway.SecretObjectReference{
+				{
+					Name:  v1Gateway.ObjectName(DomainSecretName(domainID)),
+					Kind:  ptrToKind("Secret"),
+					Group: ptrToGroup(""),
+				},
+			},
+		},
+		AllowedRoutes: &v1Gateway.AllowedRoutes{
+			Namespaces: &v1Gateway.RouteNamespaces{
+				From: &from,
+				Selector: &metaV1.LabelSelector{
+					MatchLabels: map[string]string{v1.LabelMetadataName: namespace},
+				},
+			},
+		},
+	}
+}
+
+func ptrToGroup(g string) *v1Gateway.Group {
+	t := v1Gateway.Group(g)
+	return &t
+}
+
+func stringField(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
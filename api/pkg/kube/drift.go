@@ -0,0 +1,516 @@
+package kube
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/nikumar1206/loco/shared/config"
+	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1Gateway "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// Drift reasons
+const (
+	DriftMissing  = "missing"
+	DriftModified = "modified"
+	// DriftUnexpected is an object loco no longer renders, like the CronJob of a job removed from loco.toml
+	DriftUnexpected = "unexpected"
+)
+
+// Kinds of objects checked for drift
+const (
+	KindNamespace   = "Namespace"
+	KindDeployment  = "Deployment"
+	KindService     = "Service"
+	KindSecret      = "Secret"
+	KindHTTPRoute   = "HTTPRoute"
+	KindGRPCRoute   = "GRPCRoute"
+	KindTCPRoute    = "TCPRoute"
+	KindUDPRoute    = "UDPRoute"
+	KindVolumeClaim = "PersistentVolumeClaim"
+	KindCronJob     = "CronJob"
+)
+
+// Drift is a difference between an object loco renders from Postgres and the live object.
+// Fields name what changed but never include secret values.
+type Drift struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	Reason string   `json:"reason"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+// DetectDrift compares the objects loco would create for ldc with what's in the cluster.
+// Only the fields loco sets are compared, so defaults filled in by the API server aren't drift.
+func (kc *Client) DetectDrift(ctx context.Context, ldc *LocoDeploymentContext, envVars map[string]string) ([]Drift, error) {
+	namespace := ldc.Namespace()
+
+	_, err := kc.ClientSet.CoreV1().Namespaces().Get(ctx, namespace, metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		// nothing else can exist without the namespace
+		return []Drift{{Kind: KindNamespace, Name: namespace, Reason: DriftMissing}}, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get namespace", "namespace", namespace, "error", err)
+		return nil, fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	var drift []Drift
+
+	wantDeployment, err := buildDeployment(ctx, ldc)
+	if err != nil {
+		return nil, err
+	}
+	haveDeployment, err := kc.ClientSet.AppsV1().Deployments(namespace).Get(ctx, ldc.DeploymentName(), metaV1.GetOptions{})
+	if d, err := compare(KindDeployment, ldc.DeploymentName(), err, func() []string { return deploymentDiff(wantDeployment, haveDeployment) }); err != nil {
+		return nil, err
+	} else if d != nil {
+		drift = append(drift, *d)
+	}
+
+	for _, volume := range ldc.PersistentVolumes() {
+		wantClaim, err := buildVolumeClaim(ldc, volume)
+		if err != nil {
+			return nil, err
+		}
+		haveClaim, err := kc.ClientSet.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, wantClaim.Name, metaV1.GetOptions{})
+		if d, err := compare(KindVolumeClaim, wantClaim.Name, err, func() []string { return volumeClaimDiff(wantClaim, haveClaim) }); err != nil {
+			return nil, err
+		} else if d != nil {
+			drift = append(drift, *d)
+		}
+	}
+
+	wantService := buildService(ldc)
+	haveService, err := kc.ClientSet.CoreV1().Services(namespace).Get(ctx, ldc.ServiceName(), metaV1.GetOptions{})
+	if d, err := compare(KindService, ldc.ServiceName(), err, func() []string { return serviceDiff(wantService, haveService) }); err != nil {
+		return nil, err
+	} else if d != nil {
+		drift = append(drift, *d)
+	}
+
+	wantSecret := buildSecret(ldc, envVars)
+	haveSecret, err := kc.ClientSet.CoreV1().Secrets(namespace).Get(ctx, ldc.EnvSecretName(), metaV1.GetOptions{})
+	if d, err := compare(KindSecret, ldc.EnvSecretName(), err, func() []string { return secretDiff(wantSecret, haveSecret) }); err != nil {
+		return nil, err
+	} else if d != nil {
+		drift = append(drift, *d)
+	}
+
+	if ldc.Protocol() == config.ProtocolGRPC {
+		wantRoute := buildGRPCRoute(ldc)
+		haveRoute, err := kc.GatewaySet.GatewayV1().GRPCRoutes(namespace).Get(ctx, ldc.GRPCRouteName(), metaV1.GetOptions{})
+		if d, err := compare(KindGRPCRoute, ldc.GRPCRouteName(), err, func() []string { return grpcRouteDiff(wantRoute, haveRoute) }); err != nil {
+			return nil, err
+		} else if d != nil {
+			drift = append(drift, *d)
+		}
+	} else {
+		wantRoute := buildHTTPRoute(ldc)
+		haveRoute, err := kc.GatewaySet.GatewayV1().HTTPRoutes(namespace).Get(ctx, ldc.HTTPRouteName(), metaV1.GetOptions{})
+		if d, err := compare(KindHTTPRoute, ldc.HTTPRouteName(), err, func() []string { return httpRouteDiff(wantRoute, haveRoute) }); err != nil {
+			return nil, err
+		} else if d != nil {
+			drift = append(drift, *d)
+		}
+	}
+
+	wantCronJobs := map[string]bool{}
+	for _, job := range ldc.Config.Jobs {
+		wantCronJob, err := buildCronJob(ldc, job)
+		if err != nil {
+			return nil, err
+		}
+		wantCronJobs[wantCronJob.Name] = true
+		haveCronJob, err := kc.ClientSet.BatchV1().CronJobs(namespace).Get(ctx, wantCronJob.Name, metaV1.GetOptions{})
+		if d, err := compare(KindCronJob, wantCronJob.Name, err, func() []string { return cronJobDiff(wantCronJob, haveCronJob) }); err != nil {
+			return nil, err
+		} else if d != nil {
+			drift = append(drift, *d)
+		}
+	}
+	haveCronJobs, err := kc.ClientSet.BatchV1().CronJobs(namespace).List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", LabelAppName, ldc.App.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cron jobs: %w", err)
+	}
+	for _, cronJob := range haveCronJobs.Items {
+		if !wantCronJobs[cronJob.Name] {
+			drift = append(drift, Drift{Kind: KindCronJob, Name: cronJob.Name, Reason: DriftUnexpected})
+		}
+	}
+
+	for _, port := range ldc.PublicPorts() {
+		name := ldc.PortRouteName(port.Name)
+		var d *Drift
+		if port.Protocol == string(v1.ProtocolUDP) {
+			wantRoute := buildUDPRoute(ldc, port)
+			haveRoute, err := kc.GatewaySet.GatewayV1alpha2().UDPRoutes(namespace).Get(ctx, name, metaV1.GetOptions{})
+			d, err = compare(KindUDPRoute, name, err, func() []string {
+				var haveBackends []v1alpha2.BackendRef
+				for _, rule := range haveRoute.Spec.Rules {
+					haveBackends = append(haveBackends, rule.BackendRefs...)
+				}
+				return portRouteDiff(wantRoute.Spec.CommonRouteSpec, haveRoute.Spec.CommonRouteSpec, wantRoute.Spec.Rules[0].BackendRefs, haveBackends)
+			})
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			wantRoute := buildTCPRoute(ldc, port)
+			haveRoute, err := kc.GatewaySet.GatewayV1alpha2().TCPRoutes(namespace).Get(ctx, name, metaV1.GetOptions{})
+			d, err = compare(KindTCPRoute, name, err, func() []string {
+				var haveBackends []v1alpha2.BackendRef
+				for _, rule := range haveRoute.Spec.Rules {
+					haveBackends = append(haveBackends, rule.BackendRefs...)
+				}
+				return portRouteDiff(wantRoute.Spec.CommonRouteSpec, haveRoute.Spec.CommonRouteSpec, wantRoute.Spec.Rules[0].BackendRefs, haveBackends)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if d != nil {
+			drift = append(drift, *d)
+		}
+	}
+
+	return drift, nil
+}
+
+// compare turns the result of fetching a live object into drift. diff only runs when the object was found.
+func compare(kind, name string, getErr error, diff func() []string) (*Drift, error) {
+	if apiErrors.IsNotFound(getErr) {
+		return &Drift{Kind: kind, Name: name, Reason: DriftMissing}, nil
+	}
+	if getErr != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", strings.ToLower(kind), name, getErr)
+	}
+	if fields := diff(); len(fields) > 0 {
+		return &Drift{Kind: kind, Name: name, Reason: DriftModified, Fields: fields}, nil
+	}
+	return nil, nil
+}
+
+func deploymentDiff(want, have *appsV1.Deployment) []string {
+	var fields []string
+	if ptrValue(want.Spec.Replicas) != ptrValue(have.Spec.Replicas) {
+		fields = append(fields, fmt.Sprintf("spec.replicas: want %d, have %d", ptrValue(want.Spec.Replicas), ptrValue(have.Spec.Replicas)))
+	}
+	if !equality.Semantic.DeepEqual(want.Spec.Selector, have.Spec.Selector) {
+		fields = append(fields, "spec.selector")
+	}
+
+	if want.Spec.Strategy.Type != have.Spec.Strategy.Type {
+		fields = append(fields, fmt.Sprintf("spec.strategy.type: want %s, have %s", want.Spec.Strategy.Type, have.Spec.Strategy.Type))
+	}
+
+	wantPod, havePod := want.Spec.Template.Spec, have.Spec.Template.Spec
+	if wantPod.ServiceAccountName != havePod.ServiceAccountName {
+		fields = append(fields, fmt.Sprintf("serviceAccountName: want %q, have %q", wantPod.ServiceAccountName, havePod.ServiceAccountName))
+	}
+	if !slices.EqualFunc(wantPod.Volumes, havePod.Volumes, volumeEqual) {
+		fields = append(fields, "volumes")
+	}
+
+	for _, wc := range wantPod.Containers {
+		i := slices.IndexFunc(havePod.Containers, func(c v1.Container) bool { return c.Name == wc.Name })
+		if i < 0 {
+			fields = append(fields, fmt.Sprintf("containers[%s]: missing", wc.Name))
+			continue
+		}
+		hc := havePod.Containers[i]
+		if wc.Image != hc.Image {
+			fields = append(fields, fmt.Sprintf("containers[%s].image: want %q, have %q", wc.Name, wc.Image, hc.Image))
+		}
+		if !equality.Semantic.DeepEqual(wc.Resources, hc.Resources) {
+			fields = append(fields, fmt.Sprintf("containers[%s].resources", wc.Name))
+		}
+		if !equality.Semantic.DeepEqual(wc.EnvFrom, hc.EnvFrom) {
+			fields = append(fields, fmt.Sprintf("containers[%s].envFrom", wc.Name))
+		}
+		if !slices.EqualFunc(wc.Ports, hc.Ports, func(a, b v1.ContainerPort) bool { return a.ContainerPort == b.ContainerPort }) {
+			fields = append(fields, fmt.Sprintf("containers[%s].ports", wc.Name))
+		}
+		if !equality.Semantic.DeepEqual(wc.VolumeMounts, hc.VolumeMounts) {
+			fields = append(fields, fmt.Sprintf("containers[%s].volumeMounts", wc.Name))
+		}
+		if !probeEqual(wc.LivenessProbe, hc.LivenessProbe) {
+			fields = append(fields, fmt.Sprintf("containers[%s].livenessProbe", wc.Name))
+		}
+		if !probeEqual(wc.ReadinessProbe, hc.ReadinessProbe) {
+			fields = append(fields, fmt.Sprintf("containers[%s].readinessProbe", wc.Name))
+		}
+		if !probeEqual(wc.StartupProbe, hc.StartupProbe) {
+			fields = append(fields, fmt.Sprintf("containers[%s].startupProbe", wc.Name))
+		}
+	}
+	if len(havePod.Containers) > len(wantPod.Containers) {
+		fields = append(fields, "containers: unexpected extra containers")
+	}
+
+	return fields
+}
+
+// probeEqual compares the check and timing loco sets on a probe, skipping fields defaulted by the API server like the http scheme
+func probeEqual(want, have *v1.Probe) bool {
+	if want == nil || have == nil {
+		return want == have
+	}
+	if want.InitialDelaySeconds != have.InitialDelaySeconds || want.TimeoutSeconds != have.TimeoutSeconds ||
+		want.PeriodSeconds != have.PeriodSeconds || want.SuccessThreshold != have.SuccessThreshold ||
+		want.FailureThreshold != have.FailureThreshold {
+		return false
+	}
+
+	switch {
+	case want.HTTPGet != nil:
+		return have.HTTPGet != nil && want.HTTPGet.Path == have.HTTPGet.Path && want.HTTPGet.Port == have.HTTPGet.Port &&
+			equality.Semantic.DeepEqual(want.HTTPGet.HTTPHeaders, have.HTTPGet.HTTPHeaders)
+	case want.TCPSocket != nil:
+		return have.TCPSocket != nil && want.TCPSocket.Port == have.TCPSocket.Port
+	case want.Exec != nil:
+		return have.Exec != nil && slices.Equal(want.Exec.Command, have.Exec.Command)
+	case want.GRPC != nil:
+		return have.GRPC != nil && want.GRPC.Port == have.GRPC.Port && ptrValue(want.GRPC.Service) == ptrValue(have.GRPC.Service)
+	}
+	return true
+}
+
+// volumeEqual compares the claim or emptyDir size limit loco sets on a pod volume
+func volumeEqual(want, have v1.Volume) bool {
+	if want.Name != have.Name {
+		return false
+	}
+	switch {
+	case want.PersistentVolumeClaim != nil:
+		return have.PersistentVolumeClaim != nil && want.PersistentVolumeClaim.ClaimName == have.PersistentVolumeClaim.ClaimName
+	case want.EmptyDir != nil:
+		return have.EmptyDir != nil && equality.Semantic.DeepEqual(want.EmptyDir.SizeLimit, have.EmptyDir.SizeLimit)
+	}
+	return true
+}
+
+// volumeClaimDiff only compares the requested size, a claim can't be changed otherwise once it's bound
+func volumeClaimDiff(want, have *v1.PersistentVolumeClaim) []string {
+	wantSize := want.Spec.Resources.Requests[v1.ResourceStorage]
+	haveSize := have.Spec.Resources.Requests[v1.ResourceStorage]
+	if wantSize.Cmp(haveSize) > 0 {
+		return []string{fmt.Sprintf("spec.resources.requests.storage: want %s, have %s", wantSize.String(), haveSize.String())}
+	}
+	return nil
+}
+
+func cronJobDiff(want, have *batchV1.CronJob) []string {
+	var fields []string
+	if want.Spec.Schedule != have.Spec.Schedule {
+		fields = append(fields, fmt.Sprintf("spec.schedule: want %q, have %q", want.Spec.Schedule, have.Spec.Schedule))
+	}
+	if ptrValue(have.Spec.Suspend) {
+		fields = append(fields, "spec.suspend: want false, have true")
+	}
+
+	wantPod, havePod := want.Spec.JobTemplate.Spec.Template.Spec, have.Spec.JobTemplate.Spec.Template.Spec
+	if wantPod.ServiceAccountName != havePod.ServiceAccountName {
+		fields = append(fields, fmt.Sprintf("serviceAccountName: want %q, have %q", wantPod.ServiceAccountName, havePod.ServiceAccountName))
+	}
+	if len(havePod.Containers) != 1 {
+		return append(fields, fmt.Sprintf("containers: want 1, have %d", len(havePod.Containers)))
+	}
+	wc, hc := wantPod.Containers[0], havePod.Containers[0]
+	if wc.Image != hc.Image {
+		fields = append(fields, fmt.Sprintf("containers[%s].image: want %q, have %q", wc.Name, wc.Image, hc.Image))
+	}
+	if !slices.Equal(wc.Command, hc.Command) {
+		fields = append(fields, fmt.Sprintf("containers[%s].command", wc.Name))
+	}
+	if !equality.Semantic.DeepEqual(wc.Resources, hc.Resources) {
+		fields = append(fields, fmt.Sprintf("containers[%s].resources", wc.Name))
+	}
+	if !equality.Semantic.DeepEqual(wc.EnvFrom, hc.EnvFrom) {
+		fields = append(fields, fmt.Sprintf("containers[%s].envFrom", wc.Name))
+	}
+	return fields
+}
+
+func serviceDiff(want, have *v1.Service) []string {
+	var fields []string
+	if want.Spec.Type != have.Spec.Type {
+		fields = append(fields, fmt.Sprintf("spec.type: want %s, have %s", want.Spec.Type, have.Spec.Type))
+	}
+	if !maps.Equal(want.Spec.Selector, have.Spec.Selector) {
+		fields = append(fields, "spec.selector")
+	}
+	if !slices.EqualFunc(want.Spec.Ports, have.Spec.Ports, func(a, b v1.ServicePort) bool {
+		return a.Port == b.Port && a.TargetPort == b.TargetPort && a.Protocol == b.Protocol && ptrValue(a.AppProtocol) == ptrValue(b.AppProtocol)
+	}) {
+		fields = append(fields, "spec.ports")
+	}
+	return fields
+}
+
+// secretDiff names the keys that differ, never their values
+func secretDiff(want, have *v1.Secret) []string {
+	var fields []string
+	for _, key := range slices.Sorted(maps.Keys(want.Data)) {
+		value, ok := have.Data[key]
+		switch {
+		case !ok:
+			fields = append(fields, fmt.Sprintf("data.%s: missing", key))
+		case !bytes.Equal(value, want.Data[key]):
+			fields = append(fields, fmt.Sprintf("data.%s: changed", key))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(have.Data)) {
+		if _, ok := want.Data[key]; !ok {
+			fields = append(fields, fmt.Sprintf("data.%s: unexpected", key))
+		}
+	}
+	return fields
+}
+
+func httpRouteDiff(want, have *v1Gateway.HTTPRoute) []string {
+	var fields []string
+	if !slices.Equal(want.Spec.Hostnames, have.Spec.Hostnames) {
+		fields = append(fields, fmt.Sprintf("spec.hostnames: want %v, have %v", want.Spec.Hostnames, have.Spec.Hostnames))
+	}
+	// group and kind are defaulted by the API server, so parents are matched on name and namespace alone
+	if !slices.EqualFunc(want.Spec.ParentRefs, have.Spec.ParentRefs, func(a, b v1Gateway.ParentReference) bool {
+		return a.Name == b.Name && ptrValue(a.Namespace) == ptrValue(b.Namespace)
+	}) {
+		fields = append(fields, "spec.parentRefs")
+	}
+	if len(want.Spec.Rules) != len(have.Spec.Rules) {
+		fields = append(fields, "spec.rules")
+		return fields
+	}
+	for i := range want.Spec.Rules {
+		wr, hr := want.Spec.Rules[i], have.Spec.Rules[i]
+		if !equality.Semantic.DeepEqual(wr.Matches, hr.Matches) {
+			fields = append(fields, fmt.Sprintf("spec.rules[%d].matches", i))
+		}
+		if !slices.EqualFunc(wr.BackendRefs, hr.BackendRefs, func(a, b v1Gateway.HTTPBackendRef) bool {
+			return a.Name == b.Name && ptrValue(a.Port) == ptrValue(b.Port)
+		}) {
+			fields = append(fields, fmt.Sprintf("spec.rules[%d].backendRefs", i))
+		}
+	}
+	return fields
+}
+
+func grpcRouteDiff(want, have *v1Gateway.GRPCRoute) []string {
+	var fields []string
+	if !slices.Equal(want.Spec.Hostnames, have.Spec.Hostnames) {
+		fields = append(fields, fmt.Sprintf("spec.hostnames: want %v, have %v", want.Spec.Hostnames, have.Spec.Hostnames))
+	}
+	if !slices.EqualFunc(want.Spec.ParentRefs, have.Spec.ParentRefs, func(a, b v1Gateway.ParentReference) bool {
+		return a.Name == b.Name && ptrValue(a.Namespace) == ptrValue(b.Namespace)
+	}) {
+		fields = append(fields, "spec.parentRefs")
+	}
+	if len(want.Spec.Rules) != len(have.Spec.Rules) {
+		fields = append(fields, "spec.rules")
+		return fields
+	}
+	for i := range want.Spec.Rules {
+		wr, hr := want.Spec.Rules[i], have.Spec.Rules[i]
+		if !equality.Semantic.DeepEqual(wr.Matches, hr.Matches) {
+			fields = append(fields, fmt.Sprintf("spec.rules[%d].matches", i))
+		}
+		if !slices.EqualFunc(wr.BackendRefs, hr.BackendRefs, func(a, b v1Gateway.GRPCBackendRef) bool {
+			return a.Name == b.Name && ptrValue(a.Port) == ptrValue(b.Port)
+		}) {
+			fields = append(fields, fmt.Sprintf("spec.rules[%d].backendRefs", i))
+		}
+	}
+	return fields
+}
+
+// portRouteDiff compares the parents and backends of a TCPRoute or UDPRoute, haveBackends are from all of its rules
+func portRouteDiff(want, have v1alpha2.CommonRouteSpec, wantBackends, haveBackends []v1alpha2.BackendRef) []string {
+	var fields []string
+	if !slices.EqualFunc(want.ParentRefs, have.ParentRefs, func(a, b v1Gateway.ParentReference) bool {
+		return a.Name == b.Name && ptrValue(a.Namespace) == ptrValue(b.Namespace) && ptrValue(a.SectionName) == ptrValue(b.SectionName)
+	}) {
+		fields = append(fields, "spec.parentRefs")
+	}
+	if !slices.EqualFunc(wantBackends, haveBackends, func(a, b v1alpha2.BackendRef) bool {
+		return a.Name == b.Name && ptrValue(a.Port) == ptrValue(b.Port)
+	}) {
+		fields = append(fields, "spec.rules[0].backendRefs")
+	}
+	return fields
+}
+
+// RepairDrift re-applies the objects loco renders for ldc over the drifted ones.
+// A missing namespace is re-created with everything in it.
+func (kc *Client) RepairDrift(ctx context.Context, ldc *LocoDeploymentContext, envVars map[string]string, drift []Drift) error {
+	namespace := ldc.Namespace()
+
+	for _, d := range drift {
+		slog.InfoContext(ctx, "Repairing drift", "namespace", namespace, "kind", d.Kind, "name", d.Name, "reason", d.Reason)
+
+		var err error
+		switch d.Kind {
+		case KindNamespace:
+			return kc.AllocateResources(ctx, ldc, envVars, nil)
+		case KindDeployment:
+			_, err = kc.ApplyDeployment(ctx, ldc)
+		case KindService:
+			_, err = kc.ApplyService(ctx, ldc)
+		case KindSecret:
+			_, err = kc.ApplySecret(ctx, ldc, envVars)
+		case KindHTTPRoute:
+			_, err = kc.ApplyHTTPRoute(ctx, ldc)
+		case KindGRPCRoute:
+			_, err = kc.ApplyGRPCRoute(ctx, ldc)
+		case KindVolumeClaim:
+			err = kc.ApplyVolumeClaims(ctx, ldc)
+		case KindCronJob:
+			err = kc.ApplyCronJobs(ctx, ldc)
+		case KindTCPRoute, KindUDPRoute:
+			// re-applies the listeners too, a route whose listener is gone never attaches
+			err = kc.ExposePorts(ctx, ldc)
+		default:
+			err = fmt.Errorf("unknown kind %s", d.Kind)
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to repair drift", "namespace", namespace, "kind", d.Kind, "name", d.Name, "error", err)
+			return fmt.Errorf("failed to repair %s %s: %w", strings.ToLower(d.Kind), d.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ListManagedNamespaces lists every namespace loco created, in any workspace
+func (kc *Client) ListManagedNamespaces(ctx context.Context) ([]v1.Namespace, error) {
+	namespaces, err := kc.ClientSet.CoreV1().Namespaces().List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=loco", LabelAppManagedBy),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list managed namespaces", "error", err)
+		return nil, fmt.Errorf("failed to list managed namespaces: %w", err)
+	}
+	return namespaces.Items, nil
+}
+
+func ptrValue[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,144 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilExec "k8s.io/client-go/util/exec"
+)
+
+var (
+	// ErrNoRunningPod is returned when an app has no running pod to reach, like when it's asleep
+	ErrNoRunningPod = errors.New("app has no running pod")
+	// ErrPodNotInApp is returned for a pod that doesn't exist or belongs to something other than the app
+	ErrPodNotInApp = errors.New("pod is not one of the app's")
+)
+
+// TerminalSize is the width and height of a terminal, in characters
+type TerminalSize = remotecommand.TerminalSize
+
+// ExecOptions describes the streams of a command run in a container
+type ExecOptions struct {
+	Command []string
+	// TTY runs the command in a terminal, its stderr then comes through Stdout
+	TTY    bool
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	// Sizes delivers the terminal's size and its resizes, only read with TTY. Closing it stops resizing.
+	Sizes <-chan TerminalSize
+}
+
+// RunningPod picks the pod of ldc's app to exec into or forward to: podName when it's one of the app's
+// running pods, otherwise the app's first running pod
+func (kc *Client) RunningPod(ctx context.Context, ldc *LocoDeploymentContext, podName string) (string, error) {
+	podsClient := kc.ClientSet.CoreV1().Pods(ldc.Namespace())
+
+	if podName != "" {
+		pod, err := podsClient.Get(ctx, podName, metaV1.GetOptions{})
+		if apiErrors.IsNotFound(err) {
+			return "", fmt.Errorf("%w: %s", ErrPodNotInApp, podName)
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get pod", "namespace", ldc.Namespace(), "pod", podName, "error", err)
+			return "", fmt.Errorf("failed to get pod: %w", err)
+		}
+		// the namespace also holds the pods of jobs and one-off commands, only the app's own are reachable
+		if pod.Labels[LabelAppName] != ldc.App.Name {
+			return "", fmt.Errorf("%w: %s", ErrPodNotInApp, podName)
+		}
+		if pod.Status.Phase != v1.PodRunning {
+			return "", fmt.Errorf("%w: %s is %s", ErrNoRunningPod, podName, pod.Status.Phase)
+		}
+		return pod.Name, nil
+	}
+
+	pods, err := podsClient.List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", LabelAppName, ldc.App.Name),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list pods", "namespace", ldc.Namespace(), "error", err)
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == v1.PodRunning && pod.DeletionTimestamp == nil {
+			return pod.Name, nil
+		}
+	}
+	return "", ErrNoRunningPod
+}
+
+// Exec runs a command in the app container of a pod and returns its exit code once it ends. It speaks the
+// WebSocket exec protocol and falls back to SPDY for API servers older than 1.30.
+func (kc *Client) Exec(ctx context.Context, ldc *LocoDeploymentContext, podName string, opts ExecOptions) (int32, error) {
+	req := kc.ClientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(ldc.Namespace()).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: ldc.ContainerName(),
+			Command:   opts.Command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    true,
+			Stderr:    !opts.TTY,
+			TTY:       opts.TTY,
+		}, scheme.ParameterCodec)
+
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(kc.Config, "POST", req.URL())
+	if err != nil {
+		return 0, fmt.Errorf("failed to create exec stream: %w", err)
+	}
+	wsExecutor, err := remotecommand.NewWebSocketExecutor(kc.Config, "GET", req.URL().String())
+	if err != nil {
+		return 0, fmt.Errorf("failed to create exec stream: %w", err)
+	}
+	executor, err := remotecommand.NewFallbackExecutor(wsExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create exec stream: %w", err)
+	}
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: opts.Stdout,
+		Tty:    opts.TTY,
+	}
+	if opts.TTY {
+		streamOpts.TerminalSizeQueue = sizeQueue(opts.Sizes)
+	} else {
+		streamOpts.Stderr = opts.Stderr
+	}
+
+	err = executor.StreamWithContext(ctx, streamOpts)
+	var exitErr utilExec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		return int32(exitErr.ExitStatus()), nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to exec in pod", "namespace", ldc.Namespace(), "pod", podName, "error", err)
+		return 0, fmt.Errorf("failed to exec in pod: %w", err)
+	}
+	return 0, nil
+}
+
+// sizeQueue hands the terminal sizes of a channel to the exec stream
+type sizeQueue <-chan TerminalSize
+
+func (q sizeQueue) Next() *TerminalSize {
+	size, ok := <-q
+	if !ok {
+		return nil
+	}
+	return &size
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1Gateway "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -13,11 +15,53 @@ import (
 func (kc *Client) CreateHTTPRoute(ctx context.Context, ldc *LocoDeploymentContext) (*v1Gateway.HTTPRoute, error) {
 	slog.InfoContext(ctx, "Creating HTTPRoute", "namespace", ldc.Namespace(), "name", ldc.HTTPRouteName())
 
-	hostname := fmt.Sprintf("%s.%s", ldc.App.Subdomain, ldc.App.Domain)
+	route := buildHTTPRoute(ldc)
+
+	createdRoute, err := kc.GatewaySet.GatewayV1().HTTPRoutes(ldc.Namespace()).Create(ctx, route, metaV1.CreateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create HTTPRoute", "name", ldc.HTTPRouteName(), "error", err)
+		return nil, fmt.Errorf("failed to create HTTPRoute: %w", err)
+	}
+
+	slog.InfoContext(ctx, "HTTPRoute created", "name", ldc.HTTPRouteName(), "hostname", ldc.Hostname())
+	return createdRoute, nil
+}
+
+// ApplyHTTPRoute creates ldc's HTTPRoute, or resets its spec when it already exists. It reports whether it created it.
+func (kc *Client) ApplyHTTPRoute(ctx context.Context, ldc *LocoDeploymentContext) (bool, error) {
+	routesClient := kc.GatewaySet.GatewayV1().HTTPRoutes(ldc.Namespace())
+	existing, err := routesClient.Get(ctx, ldc.HTTPRouteName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		if _, err := kc.CreateHTTPRoute(ctx, ldc); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get HTTPRoute", "name", ldc.HTTPRouteName(), "error", err)
+		return false, fmt.Errorf("failed to get HTTPRoute: %w", err)
+	}
+
+	existing.Spec = buildHTTPRoute(ldc).Spec
+	if _, err := routesClient.Update(ctx, existing, metaV1.UpdateOptions{}); err != nil {
+		slog.ErrorContext(ctx, "Failed to update HTTPRoute", "name", ldc.HTTPRouteName(), "error", err)
+		return false, fmt.Errorf("failed to update HTTPRoute: %w", err)
+	}
+	return false, nil
+}
+
+// buildHTTPRoute renders the HTTPRoute loco wants for ldc.
+// Apps sharing a hostname each have their own route for their path prefix, the gateway merges
+// every route for a hostname and matches the longest prefix first, so no rule ordering is needed here.
+func buildHTTPRoute(ldc *LocoDeploymentContext) *v1Gateway.HTTPRoute {
 	pathType := v1Gateway.PathMatchPathPrefix
 	timeout := DefaultRequestTimeout
+	hostnames := make([]v1Gateway.Hostname, 0, len(ldc.Domains)+1)
+	for _, h := range ldc.Hostnames() {
+		hostnames = append(hostnames, v1Gateway.Hostname(h))
+	}
 
-	route := &v1Gateway.HTTPRoute{
+	return &v1Gateway.HTTPRoute{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name:      ldc.HTTPRouteName(),
 			Namespace: ldc.Namespace(),
@@ -32,14 +76,14 @@ func (kc *Client) CreateHTTPRoute(ctx context.Context, ldc *LocoDeploymentContex
 					},
 				},
 			},
-			Hostnames: []v1Gateway.Hostname{v1Gateway.Hostname(hostname)},
+			Hostnames: hostnames,
 			Rules: []v1Gateway.HTTPRouteRule{
 				{
 					Matches: []v1Gateway.HTTPRouteMatch{
 						{
 							Path: &v1Gateway.HTTPPathMatch{
 								Type:  &pathType,
-								Value: ptrToString(ldc.Config.Routing.PathPrefix),
+								Value: ptrToString(ldc.PathPrefix()),
 							},
 						},
 					},
@@ -61,17 +105,102 @@ func (kc *Client) CreateHTTPRoute(ctx context.Context, ldc *LocoDeploymentContex
 			},
 		},
 	}
+}
 
-	createdRoute, err := kc.GatewaySet.GatewayV1().HTTPRoutes(ldc.Namespace()).Create(ctx, route, metaV1.CreateOptions{})
+// CreateGRPCRoute creates a GRPCRoute for a grpc app's deployment via the Loco gateway
+func (kc *Client) CreateGRPCRoute(ctx context.Context, ldc *LocoDeploymentContext) (*v1Gateway.GRPCRoute, error) {
+	slog.InfoContext(ctx, "Creating GRPCRoute", "namespace", ldc.Namespace(), "name", ldc.GRPCRouteName())
+
+	route := buildGRPCRoute(ldc)
+
+	createdRoute, err := kc.GatewaySet.GatewayV1().GRPCRoutes(ldc.Namespace()).Create(ctx, route, metaV1.CreateOptions{})
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create HTTPRoute", "name", ldc.HTTPRouteName(), "error", err)
-		return nil, fmt.Errorf("failed to create HTTPRoute: %w", err)
+		slog.ErrorContext(ctx, "Failed to create GRPCRoute", "name", ldc.GRPCRouteName(), "error", err)
+		return nil, fmt.Errorf("failed to create GRPCRoute: %w", err)
 	}
 
-	slog.InfoContext(ctx, "HTTPRoute created", "name", ldc.HTTPRouteName(), "hostname", hostname)
+	slog.InfoContext(ctx, "GRPCRoute created", "name", ldc.GRPCRouteName(), "hostname", ldc.Hostname())
 	return createdRoute, nil
 }
 
+// ApplyGRPCRoute creates ldc's GRPCRoute, or resets its spec when it already exists. It reports whether it created it.
+func (kc *Client) ApplyGRPCRoute(ctx context.Context, ldc *LocoDeploymentContext) (bool, error) {
+	routesClient := kc.GatewaySet.GatewayV1().GRPCRoutes(ldc.Namespace())
+	existing, err := routesClient.Get(ctx, ldc.GRPCRouteName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		if _, err := kc.CreateGRPCRoute(ctx, ldc); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get GRPCRoute", "name", ldc.GRPCRouteName(), "error", err)
+		return false, fmt.Errorf("failed to get GRPCRoute: %w", err)
+	}
+
+	existing.Spec = buildGRPCRoute(ldc).Spec
+	if _, err := routesClient.Update(ctx, existing, metaV1.UpdateOptions{}); err != nil {
+		slog.ErrorContext(ctx, "Failed to update GRPCRoute", "name", ldc.GRPCRouteName(), "error", err)
+		return false, fmt.Errorf("failed to update GRPCRoute: %w", err)
+	}
+	return false, nil
+}
+
+// buildGRPCRoute renders the GRPCRoute loco wants for a grpc app.
+// Each of Routing.GRPCServices becomes an exact service or method match, no matches route every call.
+func buildGRPCRoute(ldc *LocoDeploymentContext) *v1Gateway.GRPCRoute {
+	hostnames := make([]v1Gateway.Hostname, 0, len(ldc.Domains)+1)
+	for _, h := range ldc.Hostnames() {
+		hostnames = append(hostnames, v1Gateway.Hostname(h))
+	}
+
+	matchType := v1Gateway.GRPCMethodMatchExact
+	var matches []v1Gateway.GRPCRouteMatch
+	for _, entry := range ldc.Config.Routing.GRPCServices {
+		service, method, hasMethod := strings.Cut(entry, "/")
+		match := v1Gateway.GRPCMethodMatch{Type: &matchType, Service: ptrToString(service)}
+		if hasMethod {
+			match.Method = ptrToString(method)
+		}
+		matches = append(matches, v1Gateway.GRPCRouteMatch{Method: &match})
+	}
+
+	return &v1Gateway.GRPCRoute{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      ldc.GRPCRouteName(),
+			Namespace: ldc.Namespace(),
+			Labels:    ldc.Labels(),
+		},
+		Spec: v1Gateway.GRPCRouteSpec{
+			CommonRouteSpec: v1Gateway.CommonRouteSpec{
+				ParentRefs: []v1Gateway.ParentReference{
+					{
+						Name:      v1Gateway.ObjectName(LocoGatewayName),
+						Namespace: ptrToNamespace(LocoNS),
+					},
+				},
+			},
+			Hostnames: hostnames,
+			Rules: []v1Gateway.GRPCRouteRule{
+				{
+					Matches: matches,
+					BackendRefs: []v1Gateway.GRPCBackendRef{
+						{
+							BackendRef: v1Gateway.BackendRef{
+								BackendObjectReference: v1Gateway.BackendObjectReference{
+									Name: v1Gateway.ObjectName(ldc.ServiceName()),
+									Port: ptrToPortNumber(int(DefaultServicePort)),
+									Kind: ptrToKind("Service"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // Helper functions for gateway API pointer conversions
 func ptrToPortNumber(p int) *v1Gateway.PortNumber {
 	n := v1Gateway.PortNumber(p)
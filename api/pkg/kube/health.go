@@ -0,0 +1,168 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	v1Gateway "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Cluster health statuses, matching the clusters.health_status check constraint
+const (
+	HealthHealthy   = "healthy"
+	HealthDegraded  = "degraded"
+	HealthUnhealthy = "unhealthy"
+)
+
+const (
+	CertManagerNS   = "cert-manager"
+	LocoCertName    = "loco-cert"
+	HealthCheckAPI  = "api_server"
+	HealthCheckNode = "nodes"
+	HealthCheckGW   = "gateway"
+	HealthCheckCert = "cert_manager"
+)
+
+var certificateGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+// HealthCheck is the outcome of a single probe
+type HealthCheck struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+	// Critical checks make the cluster unhealthy when they fail, the rest only degrade it
+	Critical bool `json:"critical"`
+}
+
+// HealthStatus rolls checks up into a cluster health status
+func HealthStatus(checks []HealthCheck) string {
+	status := HealthHealthy
+	for _, c := range checks {
+		if c.OK {
+			continue
+		}
+		if c.Critical {
+			return HealthUnhealthy
+		}
+		status = HealthDegraded
+	}
+	return status
+}
+
+// ProbeHealth checks the API server, node readiness, the loco gateway and cert-manager.
+// When the API server can't be reached the other probes are skipped.
+func (kc *Client) ProbeHealth(ctx context.Context) []HealthCheck {
+	version, err := kc.ServerVersion(ctx)
+	if err != nil {
+		return []HealthCheck{{Name: HealthCheckAPI, Critical: true, Message: err.Error()}}
+	}
+
+	return []HealthCheck{
+		{Name: HealthCheckAPI, Critical: true, OK: true, Message: version},
+		kc.probeNodes(ctx),
+		kc.probeGateway(ctx),
+		kc.probeCertManager(ctx),
+	}
+}
+
+// probeNodes fails critically when no node is ready, and degrades when only some are
+func (kc *Client) probeNodes(ctx context.Context) HealthCheck {
+	check := HealthCheck{Name: HealthCheckNode}
+
+	nodes, err := kc.ClientSet.CoreV1().Nodes().List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list nodes", "error", err)
+		check.Critical = true
+		check.Message = fmt.Sprintf("failed to list nodes: %v", err)
+		return check
+	}
+
+	ready := 0
+	for _, node := range nodes.Items {
+		if isSchedulable(&node) {
+			ready++
+		}
+	}
+
+	check.Message = fmt.Sprintf("%d/%d nodes ready", ready, len(nodes.Items))
+	check.Critical = ready == 0
+	check.OK = ready > 0 && ready == len(nodes.Items)
+	return check
+}
+
+// probeGateway checks the loco gateway has been programmed by its controller
+func (kc *Client) probeGateway(ctx context.Context) HealthCheck {
+	check := HealthCheck{Name: HealthCheckGW}
+
+	gw, err := kc.GatewaySet.GatewayV1().Gateways(LocoNS).Get(ctx, LocoGatewayName, metaV1.GetOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get gateway", "name", LocoGatewayName, "error", err)
+		check.Message = fmt.Sprintf("failed to get gateway: %v", err)
+		return check
+	}
+
+	for _, cond := range gw.Status.Conditions {
+		if cond.Type != string(v1Gateway.GatewayConditionProgrammed) {
+			continue
+		}
+		check.OK = cond.Status == metaV1.ConditionTrue
+		check.Message = cond.Message
+		return check
+	}
+
+	check.Message = "gateway has not been programmed"
+	return check
+}
+
+// probeCertManager checks every cert-manager deployment is available and the loco certificate is ready
+func (kc *Client) probeCertManager(ctx context.Context) HealthCheck {
+	check := HealthCheck{Name: HealthCheckCert}
+
+	deployments, err := kc.ClientSet.AppsV1().Deployments(CertManagerNS).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list cert-manager deployments", "error", err)
+		check.Message = fmt.Sprintf("failed to list cert-manager deployments: %v", err)
+		return check
+	}
+	if len(deployments.Items) == 0 {
+		check.Message = "cert-manager is not installed"
+		return check
+	}
+	for _, d := range deployments.Items {
+		if d.Status.AvailableReplicas < 1 {
+			check.Message = fmt.Sprintf("deployment %s has no available replicas", d.Name)
+			return check
+		}
+	}
+
+	cert, err := kc.DynamicSet.Resource(certificateGVR).Namespace(LocoNS).Get(ctx, LocoCertName, metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		check.Message = fmt.Sprintf("certificate %s not found", LocoCertName)
+		return check
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get certificate", "name", LocoCertName, "error", err)
+		check.Message = fmt.Sprintf("failed to get certificate: %v", err)
+		return check
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(cert.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok || cond["type"] != "Ready" {
+			continue
+		}
+		check.OK = cond["status"] == string(v1.ConditionTrue)
+		check.Message, _ = cond["message"].(string)
+		return check
+	}
+
+	check.Message = fmt.Sprintf("certificate %s is not ready", LocoCertName)
+	return check
+}
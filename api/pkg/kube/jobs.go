@@ -0,0 +1,369 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/nikumar1206/loco/shared/config"
+	batchV1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LabelJobName marks the Jobs and pods of an app's scheduled job with the job's name
+const LabelJobName = "app.loco.io/job"
+
+// Scheduled job settings
+const (
+	// JobHistoryLimit is how many succeeded and how many failed runs of each job the cluster keeps
+	JobHistoryLimit = 10
+	JobBackoffLimit = 2
+	// annotationInstantiate is set by kubectl on Jobs created from a CronJob by hand, loco sets it the same way
+	annotationInstantiate = "cronjob.kubernetes.io/instantiate"
+)
+
+// Job run statuses
+const (
+	JobRunPending   = "pending"
+	JobRunRunning   = "running"
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+)
+
+// Job run triggers
+const (
+	TriggerScheduled = "scheduled"
+	TriggerManual    = "manual"
+)
+
+// CronJobName returns the K8s CronJob name of a scheduled job
+func (ldc *LocoDeploymentContext) CronJobName(jobName string) string {
+	return fmt.Sprintf("%s-%s", ldc.App.Name, jobName)
+}
+
+// JobLabels are the labels of a job's pods. They leave out the app's name label so the app's
+// Service never sends them traffic.
+func (ldc *LocoDeploymentContext) JobLabels(jobName string) map[string]string {
+	labels := maps.Clone(ldc.Labels())
+	delete(labels, LabelAppName)
+	labels[LabelAppComponent] = "job"
+	labels[LabelJobName] = jobName
+	return labels
+}
+
+// ApplyCronJobs makes the namespace's CronJobs match ldc's jobs, deleting the ones it no longer declares
+func (kc *Client) ApplyCronJobs(ctx context.Context, ldc *LocoDeploymentContext) error {
+	cronJobsClient := kc.ClientSet.BatchV1().CronJobs(ldc.Namespace())
+
+	want := map[string]bool{}
+	for _, job := range ldc.Config.Jobs {
+		cronJob, err := buildCronJob(ldc, job)
+		if err != nil {
+			return err
+		}
+		want[cronJob.Name] = true
+
+		have, err := cronJobsClient.Get(ctx, cronJob.Name, metaV1.GetOptions{})
+		if apiErrors.IsNotFound(err) {
+			slog.InfoContext(ctx, "Creating cron job", "namespace", ldc.Namespace(), "name", cronJob.Name, "schedule", job.Schedule)
+			_, err = cronJobsClient.Create(ctx, cronJob, metaV1.CreateOptions{})
+		} else if err == nil {
+			have.Spec = cronJob.Spec
+			_, err = cronJobsClient.Update(ctx, have, metaV1.UpdateOptions{})
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to apply cron job", "name", cronJob.Name, "error", err)
+			return fmt.Errorf("failed to apply cron job %s: %w", job.Name, err)
+		}
+	}
+
+	existing, err := cronJobsClient.List(ctx, metaV1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", LabelAppName, ldc.App.Name)})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list cron jobs", "namespace", ldc.Namespace(), "error", err)
+		return fmt.Errorf("failed to list cron jobs: %w", err)
+	}
+	background := metaV1.DeletePropagationBackground
+	for _, cronJob := range existing.Items {
+		if want[cronJob.Name] {
+			continue
+		}
+		slog.InfoContext(ctx, "Deleting cron job", "namespace", ldc.Namespace(), "name", cronJob.Name)
+		err := cronJobsClient.Delete(ctx, cronJob.Name, metaV1.DeleteOptions{PropagationPolicy: &background})
+		if err != nil && !apiErrors.IsNotFound(err) {
+			slog.ErrorContext(ctx, "Failed to delete cron job", "name", cronJob.Name, "error", err)
+			return fmt.Errorf("failed to delete cron job: %w", err)
+		}
+	}
+	return nil
+}
+
+// CronJobStatus is when a job last ran
+type CronJobStatus struct {
+	LastScheduledAt *time.Time
+	LastSucceededAt *time.Time
+}
+
+// CronJobStatuses returns the status of ldc's jobs by job name. Jobs whose CronJob doesn't exist yet are left out.
+func (kc *Client) CronJobStatuses(ctx context.Context, ldc *LocoDeploymentContext) (map[string]CronJobStatus, error) {
+	cronJobs, err := kc.ClientSet.BatchV1().CronJobs(ldc.Namespace()).List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", LabelAppName, ldc.App.Name),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list cron jobs", "namespace", ldc.Namespace(), "error", err)
+		return nil, fmt.Errorf("failed to list cron jobs: %w", err)
+	}
+
+	statuses := map[string]CronJobStatus{}
+	for _, cronJob := range cronJobs.Items {
+		name := cronJob.Spec.JobTemplate.Labels[LabelJobName]
+		if name == "" {
+			continue
+		}
+		var status CronJobStatus
+		if t := cronJob.Status.LastScheduleTime; t != nil {
+			status.LastScheduledAt = &t.Time
+		}
+		if t := cronJob.Status.LastSuccessfulTime; t != nil {
+			status.LastSucceededAt = &t.Time
+		}
+		statuses[name] = status
+	}
+	return statuses, nil
+}
+
+// JobRun is one run of a scheduled job
+type JobRun struct {
+	Name    string
+	Job     string
+	Trigger string
+	Status  string
+	Message string
+
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// ListJobRuns returns the runs the cluster still keeps of ldc's jobs, or only of jobName when it's set, newest first
+func (kc *Client) ListJobRuns(ctx context.Context, ldc *LocoDeploymentContext, jobName string) ([]JobRun, error) {
+	selector := LabelJobName
+	if jobName != "" {
+		selector = fmt.Sprintf("%s=%s", LabelJobName, jobName)
+	}
+	jobs, err := kc.ClientSet.BatchV1().Jobs(ldc.Namespace()).List(ctx, metaV1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list jobs", "namespace", ldc.Namespace(), "error", err)
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	runs := make([]JobRun, 0, len(jobs.Items))
+	for _, job := range jobs.Items {
+		runs = append(runs, jobRunFrom(&job))
+	}
+	slices.SortFunc(runs, func(a, b JobRun) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return runs, nil
+}
+
+// TriggerJob starts a run of a scheduled job now, from its CronJob's template like `kubectl create job --from`.
+// The run is owned by the CronJob, so it's kept and cleaned up with the scheduled runs.
+func (kc *Client) TriggerJob(ctx context.Context, ldc *LocoDeploymentContext, jobName string) (JobRun, error) {
+	cronJob, err := kc.ClientSet.BatchV1().CronJobs(ldc.Namespace()).Get(ctx, ldc.CronJobName(jobName), metaV1.GetOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get cron job", "name", ldc.CronJobName(jobName), "error", err)
+		return JobRun{}, fmt.Errorf("failed to get cron job: %w", err)
+	}
+
+	annotations := maps.Clone(cronJob.Spec.JobTemplate.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[annotationInstantiate] = TriggerManual
+
+	job := &batchV1.Job{
+		ObjectMeta: metaV1.ObjectMeta{
+			// scheduled runs are suffixed with the minutes since the epoch, manual ones with base36 seconds
+			Name:        fmt.Sprintf("%s-%s", cronJob.Name, strconv.FormatInt(time.Now().Unix(), 36)),
+			Namespace:   cronJob.Namespace,
+			Labels:      maps.Clone(cronJob.Spec.JobTemplate.Labels),
+			Annotations: annotations,
+			OwnerReferences: []metaV1.OwnerReference{
+				*metaV1.NewControllerRef(cronJob, batchV1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+
+	slog.InfoContext(ctx, "Triggering job", "namespace", ldc.Namespace(), "job", jobName, "name", job.Name)
+	created, err := kc.ClientSet.BatchV1().Jobs(ldc.Namespace()).Create(ctx, job, metaV1.CreateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create job", "name", job.Name, "error", err)
+		return JobRun{}, fmt.Errorf("failed to create job: %w", err)
+	}
+	return jobRunFrom(created), nil
+}
+
+func jobRunFrom(job *batchV1.Job) JobRun {
+	run := JobRun{
+		Name:      job.Name,
+		Job:       job.Labels[LabelJobName],
+		Trigger:   TriggerScheduled,
+		Status:    JobRunPending,
+		CreatedAt: job.CreationTimestamp.Time,
+	}
+	if job.Annotations[annotationInstantiate] == TriggerManual {
+		run.Trigger = TriggerManual
+	}
+	if job.Status.StartTime != nil {
+		run.StartedAt = &job.Status.StartTime.Time
+	}
+	if job.Status.Active > 0 {
+		run.Status = JobRunRunning
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != v1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchV1.JobComplete:
+			run.Status = JobRunSucceeded
+		case batchV1.JobFailed:
+			run.Status = JobRunFailed
+			run.Message = cond.Message
+		default:
+			continue
+		}
+		finishedAt := cond.LastTransitionTime.Time
+		if job.Status.CompletionTime != nil {
+			finishedAt = job.Status.CompletionTime.Time
+		}
+		run.FinishedAt = &finishedAt
+	}
+	return run
+}
+
+// buildCronJob renders the CronJob of a scheduled job. Runs never overlap, a run still going when the
+// next is due makes that one skip.
+func buildCronJob(ldc *LocoDeploymentContext, job config.Job) (*batchV1.CronJob, error) {
+	podSpec, err := buildJobPodSpec(ldc, job.Name, job.Command, job.CPU, job.Memory, ldc.EnvSecretName())
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchV1.CronJob{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      ldc.CronJobName(job.Name),
+			Namespace: ldc.Namespace(),
+			Labels:    ldc.Labels(),
+		},
+		Spec: batchV1.CronJobSpec{
+			Schedule:                   job.Schedule,
+			TimeZone:                   ptrToString("Etc/UTC"),
+			ConcurrencyPolicy:          batchV1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: ptrToInt32(JobHistoryLimit),
+			FailedJobsHistoryLimit:     ptrToInt32(JobHistoryLimit),
+			JobTemplate: batchV1.JobTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{
+					Labels: ldc.JobLabels(job.Name),
+				},
+				Spec: batchV1.JobSpec{
+					BackoffLimit: ptrToInt32(JobBackoffLimit),
+					Template: v1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{
+							Labels: ldc.JobLabels(job.Name),
+						},
+						Spec: podSpec,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// buildJobPodSpec renders a pod that runs command once in the app's image, with the env of envSecret, the app's
+// service account and its ephemeral volumes. cpu and memory override the app's resources when set.
+func buildJobPodSpec(ldc *LocoDeploymentContext, containerName string, command []string, cpu, memory, envSecret string) (v1.PodSpec, error) {
+	if cpu == "" {
+		cpu = ldc.Config.Resources.CPU
+	}
+	if memory == "" {
+		memory = ldc.Config.Resources.Memory
+	}
+	cpuQuantity, err := resource.ParseQuantity(cpu)
+	if err != nil {
+		return v1.PodSpec{}, fmt.Errorf("invalid cpu value: %w", err)
+	}
+	memoryQuantity, err := resource.ParseQuantity(memory)
+	if err != nil {
+		return v1.PodSpec{}, fmt.Errorf("invalid memory value: %w", err)
+	}
+
+	// persistent volumes are ReadWriteOnce and already mounted by the app's pod
+	var volumes []v1.Volume
+	var mounts []v1.VolumeMount
+	for _, volume := range ldc.Config.Volumes {
+		if volume.Type != config.VolumeEphemeral {
+			continue
+		}
+		emptyDir, err := buildEmptyDir(volume)
+		if err != nil {
+			return v1.PodSpec{}, err
+		}
+		volumes = append(volumes, v1.Volume{Name: volume.Name, VolumeSource: v1.VolumeSource{EmptyDir: emptyDir}})
+		mounts = append(mounts, v1.VolumeMount{Name: volume.Name, MountPath: volume.MountPath})
+	}
+
+	return v1.PodSpec{
+		RestartPolicy: v1.RestartPolicyNever,
+		ImagePullSecrets: []v1.LocalObjectReference{
+			{
+				Name: ldc.RegistrySecretName(),
+			},
+		},
+		ServiceAccountName: ldc.ServiceAccountName(),
+		Volumes:            volumes,
+		Containers: []v1.Container{
+			{
+				Name:    containerName,
+				Image:   ldc.Deployment.Image,
+				Command: command,
+				SecurityContext: &v1.SecurityContext{
+					AllowPrivilegeEscalation: ptrToBool(false),
+					Privileged:               ptrToBool(false),
+					ReadOnlyRootFilesystem:   ptrToBool(true),
+					RunAsNonRoot:             ptrToBool(true),
+					Capabilities: &v1.Capabilities{
+						Drop: []v1.Capability{"ALL"},
+					},
+				},
+				EnvFrom: []v1.EnvFromSource{
+					{
+						SecretRef: &v1.SecretEnvSource{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: envSecret,
+							},
+						},
+					},
+				},
+				VolumeMounts: mounts,
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    cpuQuantity,
+						v1.ResourceMemory: memoryQuantity,
+					},
+					Limits: v1.ResourceList{
+						v1.ResourceCPU:    cpuQuantity,
+						v1.ResourceMemory: memoryQuantity,
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,116 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// podMetricsGVR is served by metrics-server, read through the dynamic client since the typed metrics client isn't vendored
+var podMetricsGVR = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+
+// PodUsage is a pod's state and its live resource usage
+type PodUsage struct {
+	Name     string
+	Phase    string
+	Ready    bool
+	Restarts int32
+	// HasUsage is false when metrics-server has no sample for the pod yet, or isn't installed
+	HasUsage      bool
+	CPUMillicores int64
+	MemoryBytes   int64
+	CollectedAt   time.Time
+}
+
+// PodMetrics returns the usage of the pods matching selector. Pods are listed even when
+// metrics-server is missing, only without usage.
+func (kc *Client) PodMetrics(ctx context.Context, namespace, selector string) ([]PodUsage, error) {
+	pods, err := kc.ClientSet.CoreV1().Pods(namespace).List(ctx, metaV1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list pods", "namespace", namespace, "error", err)
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	usage := map[string]PodUsage{}
+	list, err := kc.DynamicSet.Resource(podMetricsGVR).Namespace(namespace).List(ctx, metaV1.ListOptions{LabelSelector: selector})
+	switch {
+	case apiErrors.IsNotFound(err):
+		slog.WarnContext(ctx, "Pod metrics are unavailable, is metrics-server installed?", "namespace", namespace)
+	case err != nil:
+		slog.ErrorContext(ctx, "Failed to list pod metrics", "namespace", namespace, "error", err)
+		return nil, fmt.Errorf("failed to list pod metrics: %w", err)
+	default:
+		for _, item := range list.Items {
+			u, err := parsePodMetrics(item)
+			if err != nil {
+				slog.WarnContext(ctx, "Skipping unreadable pod metrics", "namespace", namespace, "pod", item.GetName(), "error", err)
+				continue
+			}
+			usage[item.GetName()] = u
+		}
+	}
+
+	result := make([]PodUsage, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		u := usage[pod.Name]
+		u.Name = pod.Name
+		u.Phase = string(pod.Status.Phase)
+		for _, cs := range pod.Status.ContainerStatuses {
+			u.Restarts += cs.RestartCount
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == v1.PodReady {
+				u.Ready = cond.Status == v1.ConditionTrue
+			}
+		}
+		result = append(result, u)
+	}
+	return result, nil
+}
+
+// parsePodMetrics sums a PodMetrics object's container usage
+func parsePodMetrics(item unstructured.Unstructured) (PodUsage, error) {
+	u := PodUsage{HasUsage: true}
+
+	if ts, found, _ := unstructured.NestedString(item.Object, "timestamp"); found {
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			u.CollectedAt = t
+		}
+	}
+
+	containers, _, err := unstructured.NestedSlice(item.Object, "containers")
+	if err != nil {
+		return u, err
+	}
+	for _, c := range containers {
+		container, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		cpu, _, _ := unstructured.NestedString(container, "usage", "cpu")
+		memory, _, _ := unstructured.NestedString(container, "usage", "memory")
+		if cpu != "" {
+			q, err := resource.ParseQuantity(cpu)
+			if err != nil {
+				return u, fmt.Errorf("invalid cpu usage %q: %w", cpu, err)
+			}
+			u.CPUMillicores += q.MilliValue()
+		}
+		if memory != "" {
+			q, err := resource.ParseQuantity(memory)
+			if err != nil {
+				return u, fmt.Errorf("invalid memory usage %q: %w", memory, err)
+			}
+			u.MemoryBytes += q.Value()
+		}
+	}
+	return u, nil
+}
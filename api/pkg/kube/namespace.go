@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -66,6 +67,24 @@ func (kc *Client) CreateNS(ctx context.Context, ldc *LocoDeploymentContext) (*v1
 	return ns, nil
 }
 
+// ApplyNS creates ldc's namespace unless it already exists, and reports whether it created it
+func (kc *Client) ApplyNS(ctx context.Context, ldc *LocoDeploymentContext) (bool, error) {
+	_, err := kc.ClientSet.CoreV1().Namespaces().Get(ctx, ldc.Namespace(), metaV1.GetOptions{})
+	if err == nil {
+		slog.InfoContext(ctx, "Namespace already exists", "namespace", ldc.Namespace())
+		return false, nil
+	}
+	if !apiErrors.IsNotFound(err) {
+		slog.ErrorContext(ctx, "Failed to get namespace", "namespace", ldc.Namespace(), "error", err)
+		return false, fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	if _, err := kc.CreateNS(ctx, ldc); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // DeleteNS deletes a namespace in the Kubernetes cluster.
 func (kc *Client) DeleteNS(ctx context.Context, namespace string) error {
 	slog.InfoContext(ctx, "Deleting namespace", "namespace", namespace)
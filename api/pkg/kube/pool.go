@@ -0,0 +1,65 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// CredentialsLoader returns the kubeconfig of a registered cluster.
+// A nil kubeconfig means the cluster is reached with the pool's fallback client.
+type CredentialsLoader func(ctx context.Context, clusterID int64) ([]byte, error)
+
+// Pool hands out one Client per registered cluster, building each lazily on first use
+type Pool struct {
+	mu       sync.Mutex
+	clients  map[int64]*Client
+	fallback *Client
+	load     CredentialsLoader
+}
+
+// NewPool creates a Pool. fallback is loco-api's own client, used for clusters registered without credentials.
+func NewPool(fallback *Client, load CredentialsLoader) *Pool {
+	return &Pool{
+		clients:  map[int64]*Client{},
+		fallback: fallback,
+		load:     load,
+	}
+}
+
+// Get returns the client for a cluster
+func (p *Pool) Get(ctx context.Context, clusterID int64) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if kc, ok := p.clients[clusterID]; ok {
+		return kc, nil
+	}
+
+	kubeconfig, err := p.load(ctx, clusterID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load cluster credentials", "cluster_id", clusterID, "error", err)
+		return nil, fmt.Errorf("failed to load credentials for cluster %d: %w", clusterID, err)
+	}
+
+	kc := p.fallback
+	if kubeconfig != nil {
+		kc, err = NewClientFromKubeconfig(kubeconfig)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to build cluster client", "cluster_id", clusterID, "error", err)
+			return nil, err
+		}
+	}
+
+	slog.InfoContext(ctx, "Cluster client initialized", "cluster_id", clusterID)
+	p.clients[clusterID] = kc
+	return kc, nil
+}
+
+// Evict drops a cached client so the next Get rebuilds it
+func (p *Pool) Evict(clusterID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, clusterID)
+}
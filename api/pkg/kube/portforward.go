@@ -0,0 +1,138 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward is a tunnel to a port of a pod that connections to it are opened over
+type PortForward struct {
+	conn httpstream.Connection
+	port int32
+
+	requestID atomic.Int64
+}
+
+// ForwardedConn is a connection to the port of a PortForward
+type ForwardedConn struct {
+	pf          *PortForward
+	data        httpstream.Stream
+	errorStream httpstream.Stream
+	// receives what the kubelet reported about the connection once it ended, closed after
+	errs chan error
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// PortForward opens a tunnel to port of a pod of ldc's app. It speaks SPDY over WebSocket and falls back to
+// SPDY for API servers older than 1.31.
+func (kc *Client) PortForward(ctx context.Context, ldc *LocoDeploymentContext, podName string, port int32) (*PortForward, error) {
+	req := kc.ClientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(ldc.Namespace()).
+		Name(podName).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(kc.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port-forward stream: %w", err)
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+	tunnelingDialer, err := portforward.NewSPDYOverWebsocketDialer(req.URL(), kc.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port-forward stream: %w", err)
+	}
+	dialer := portforward.NewFallbackDialer(tunnelingDialer, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+
+	conn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to port-forward to pod", "namespace", ldc.Namespace(), "pod", podName, "port", port, "error", err)
+		return nil, fmt.Errorf("failed to port-forward to pod: %w", err)
+	}
+	return &PortForward{conn: conn, port: port}, nil
+}
+
+// Dial opens a connection to the forwarded port
+func (pf *PortForward) Dial() (*ForwardedConn, error) {
+	requestID := strconv.FormatInt(pf.requestID.Add(1), 10)
+
+	headers := http.Header{}
+	headers.Set(v1.StreamType, v1.StreamTypeError)
+	headers.Set(v1.PortHeader, strconv.Itoa(int(pf.port)))
+	headers.Set(v1.PortForwardRequestIDHeader, requestID)
+	errorStream, err := pf.conn.CreateStream(headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open connection to port %d: %w", pf.port, err)
+	}
+	// nothing is ever written to the error stream
+	errorStream.Close()
+
+	headers.Set(v1.StreamType, v1.StreamTypeData)
+	data, err := pf.conn.CreateStream(headers)
+	if err != nil {
+		pf.conn.RemoveStreams(errorStream)
+		return nil, fmt.Errorf("failed to open connection to port %d: %w", pf.port, err)
+	}
+
+	fc := &ForwardedConn{pf: pf, data: data, errorStream: errorStream, errs: make(chan error, 1)}
+	go func() {
+		defer close(fc.errs)
+		message, err := io.ReadAll(errorStream)
+		switch {
+		case err != nil:
+			fc.errs <- fmt.Errorf("failed to read errors of connection to port %d: %w", pf.port, err)
+		case len(message) > 0:
+			fc.errs <- fmt.Errorf("connection to port %d failed: %s", pf.port, message)
+		}
+	}()
+	return fc, nil
+}
+
+// Done is closed once the tunnel to the pod is closed
+func (pf *PortForward) Done() <-chan bool {
+	return pf.conn.CloseChan()
+}
+
+// Close closes the tunnel and every connection over it
+func (pf *PortForward) Close() error {
+	return pf.conn.Close()
+}
+
+func (fc *ForwardedConn) Read(p []byte) (int, error) {
+	return fc.data.Read(p)
+}
+
+func (fc *ForwardedConn) Write(p []byte) (int, error) {
+	return fc.data.Write(p)
+}
+
+// CloseWrite tells the pod no more data is coming, what it sends back can still be read
+func (fc *ForwardedConn) CloseWrite() error {
+	return fc.data.Close()
+}
+
+// Close discards unsent data and returns what the kubelet reported about the connection, like the pod not
+// listening on the port
+func (fc *ForwardedConn) Close() error {
+	fc.closeOnce.Do(func() {
+		// the error stream only ends once the data stream did
+		_ = fc.data.Reset()
+		fc.closeErr = <-fc.errs
+		fc.pf.conn.RemoveStreams(fc.data, fc.errorStream)
+	})
+	return fc.closeErr
+}
@@ -0,0 +1,277 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/nikumar1206/loco/shared/config"
+	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1Gateway "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// Gateway listener ports handed out to public routing.ports, allocated in Postgres so no two apps share one
+const (
+	MinListenerPort = 20000
+	MaxListenerPort = 29999
+)
+
+// ListenerName names the gateway listener of an app's public port. Listeners are named by app ID
+// so every app's listeners can be found on the shared gateway without its namespace.
+func ListenerName(appID int64, portName string) string {
+	return fmt.Sprintf("%s%s", listenerPrefix(appID), portName)
+}
+
+func listenerPrefix(appID int64) string {
+	return fmt.Sprintf("app-%d-", appID)
+}
+
+// PortRouteName returns the K8s TCPRoute or UDPRoute name of a public port
+func (ldc *LocoDeploymentContext) PortRouteName(portName string) string {
+	return fmt.Sprintf("%s-%s", ldc.App.Name, portName)
+}
+
+// PublicPorts returns the routing.ports exposed through the gateway
+func (ldc *LocoDeploymentContext) PublicPorts() []config.Port {
+	var ports []config.Port
+	for _, port := range ldc.Config.Routing.Ports {
+		if port.Exposure == config.ExposurePublic && port.ListenerPort != 0 {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// ExposePorts makes the gateway match ldc's public ports: a listener and a TCPRoute or UDPRoute per port,
+// and none for ports the app no longer declares. It's safe to call on every deploy.
+func (kc *Client) ExposePorts(ctx context.Context, ldc *LocoDeploymentContext) error {
+	slog.InfoContext(ctx, "Exposing ports", "namespace", ldc.Namespace(), "app", ldc.App.Name)
+
+	ports := ldc.PublicPorts()
+	prefix := listenerPrefix(ldc.App.ID)
+	err := kc.updateGateway(ctx, func(gw *v1Gateway.Gateway) {
+		gw.Spec.Listeners = slices.DeleteFunc(gw.Spec.Listeners, func(l v1Gateway.Listener) bool {
+			return strings.HasPrefix(string(l.Name), prefix)
+		})
+		for _, port := range ports {
+			gw.Spec.Listeners = append(gw.Spec.Listeners, buildPortListener(ldc, port))
+		}
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update gateway listeners", "app", ldc.App.Name, "error", err)
+		return fmt.Errorf("failed to update gateway listeners: %w", err)
+	}
+
+	wantTCP := map[string]bool{}
+	wantUDP := map[string]bool{}
+	for _, port := range ports {
+		if port.Protocol == string(v1.ProtocolUDP) {
+			wantUDP[ldc.PortRouteName(port.Name)] = true
+			err = kc.applyUDPRoute(ctx, buildUDPRoute(ldc, port))
+		} else {
+			wantTCP[ldc.PortRouteName(port.Name)] = true
+			err = kc.applyTCPRoute(ctx, buildTCPRoute(ldc, port))
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to apply port route", "name", ldc.PortRouteName(port.Name), "error", err)
+			return fmt.Errorf("failed to apply %s route for port %s: %w", port.Protocol, port.Name, err)
+		}
+	}
+
+	return kc.deleteStalePortRoutes(ctx, ldc, wantTCP, wantUDP)
+}
+
+// UnexposePorts removes every gateway listener of an app. Its routes go with its namespace.
+func (kc *Client) UnexposePorts(ctx context.Context, appID int64) error {
+	prefix := listenerPrefix(appID)
+	err := kc.updateGateway(ctx, func(gw *v1Gateway.Gateway) {
+		gw.Spec.Listeners = slices.DeleteFunc(gw.Spec.Listeners, func(l v1Gateway.Listener) bool {
+			return strings.HasPrefix(string(l.Name), prefix)
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to remove gateway listeners", "app_id", appID, "error", err)
+		return fmt.Errorf("failed to remove gateway listeners: %w", err)
+	}
+	return nil
+}
+
+func (kc *Client) applyTCPRoute(ctx context.Context, route *v1alpha2.TCPRoute) error {
+	routesClient := kc.GatewaySet.GatewayV1alpha2().TCPRoutes(route.Namespace)
+	have, err := routesClient.Get(ctx, route.Name, metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		_, err = routesClient.Create(ctx, route, metaV1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	have.Spec = route.Spec
+	_, err = routesClient.Update(ctx, have, metaV1.UpdateOptions{})
+	return err
+}
+
+func (kc *Client) applyUDPRoute(ctx context.Context, route *v1alpha2.UDPRoute) error {
+	routesClient := kc.GatewaySet.GatewayV1alpha2().UDPRoutes(route.Namespace)
+	have, err := routesClient.Get(ctx, route.Name, metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		_, err = routesClient.Create(ctx, route, metaV1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	have.Spec = route.Spec
+	_, err = routesClient.Update(ctx, have, metaV1.UpdateOptions{})
+	return err
+}
+
+// deleteStalePortRoutes deletes the app's TCPRoutes and UDPRoutes for ports it no longer exposes
+func (kc *Client) deleteStalePortRoutes(ctx context.Context, ldc *LocoDeploymentContext, wantTCP, wantUDP map[string]bool) error {
+	selector := metaV1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", LabelAppName, ldc.App.Name)}
+
+	tcpClient := kc.GatewaySet.GatewayV1alpha2().TCPRoutes(ldc.Namespace())
+	tcpRoutes, err := tcpClient.List(ctx, selector)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list TCPRoutes", "namespace", ldc.Namespace(), "error", err)
+		return fmt.Errorf("failed to list TCPRoutes: %w", err)
+	}
+	for _, route := range tcpRoutes.Items {
+		if wantTCP[route.Name] {
+			continue
+		}
+		if err := tcpClient.Delete(ctx, route.Name, metaV1.DeleteOptions{}); err != nil && !apiErrors.IsNotFound(err) {
+			slog.ErrorContext(ctx, "Failed to delete TCPRoute", "name", route.Name, "error", err)
+			return fmt.Errorf("failed to delete TCPRoute: %w", err)
+		}
+	}
+
+	udpClient := kc.GatewaySet.GatewayV1alpha2().UDPRoutes(ldc.Namespace())
+	udpRoutes, err := udpClient.List(ctx, selector)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list UDPRoutes", "namespace", ldc.Namespace(), "error", err)
+		return fmt.Errorf("failed to list UDPRoutes: %w", err)
+	}
+	for _, route := range udpRoutes.Items {
+		if wantUDP[route.Name] {
+			continue
+		}
+		if err := udpClient.Delete(ctx, route.Name, metaV1.DeleteOptions{}); err != nil && !apiErrors.IsNotFound(err) {
+			slog.ErrorContext(ctx, "Failed to delete UDPRoute", "name", route.Name, "error", err)
+			return fmt.Errorf("failed to delete UDPRoute: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildPortListener renders the gateway listener of a public port.
+// Only the app's namespace can attach routes to it, so another app can't take over the port.
+func buildPortListener(ldc *LocoDeploymentContext, port config.Port) v1Gateway.Listener {
+	from := v1Gateway.NamespacesFromSelector
+	protocol, kind := v1Gateway.TCPProtocolType, "TCPRoute"
+	if port.Protocol == string(v1.ProtocolUDP) {
+		protocol, kind = v1Gateway.UDPProtocolType, "UDPRoute"
+	}
+
+	return v1Gateway.Listener{
+		Name:     v1Gateway.SectionName(ListenerName(ldc.App.ID, port.Name)),
+		Port:     v1Gateway.PortNumber(port.ListenerPort),
+		Protocol: protocol,
+		AllowedRoutes: &v1Gateway.AllowedRoutes{
+			Namespaces: &v1Gateway.RouteNamespaces{
+				From: &from,
+				Selector: &metaV1.LabelSelector{
+					MatchLabels: map[string]string{v1.LabelMetadataName: ldc.Namespace()},
+				},
+			},
+			Kinds: []v1Gateway.RouteGroupKind{{Kind: v1Gateway.Kind(kind)}},
+		},
+	}
+}
+
+// portRouteSpec attaches a route to its port's listener and sends it to the port on the app's Service
+func portRouteSpec(ldc *LocoDeploymentContext, port config.Port) (v1alpha2.CommonRouteSpec, []v1alpha2.BackendRef) {
+	sectionName := v1Gateway.SectionName(ListenerName(ldc.App.ID, port.Name))
+	common := v1alpha2.CommonRouteSpec{
+		ParentRefs: []v1alpha2.ParentReference{
+			{
+				Name:        v1Gateway.ObjectName(LocoGatewayName),
+				Namespace:   ptrToNamespace(LocoNS),
+				SectionName: &sectionName,
+			},
+		},
+	}
+	backendRefs := []v1alpha2.BackendRef{
+		{
+			BackendObjectReference: v1Gateway.BackendObjectReference{
+				Name: v1Gateway.ObjectName(ldc.ServiceName()),
+				Port: ptrToPortNumber(int(port.Port)),
+				Kind: ptrToKind("Service"),
+			},
+		},
+	}
+	return common, backendRefs
+}
+
+func buildTCPRoute(ldc *LocoDeploymentContext, port config.Port) *v1alpha2.TCPRoute {
+	common, backendRefs := portRouteSpec(ldc, port)
+	return &v1alpha2.TCPRoute{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      ldc.PortRouteName(port.Name),
+			Namespace: ldc.Namespace(),
+			Labels:    ldc.Labels(),
+		},
+		Spec: v1alpha2.TCPRouteSpec{
+			CommonRouteSpec: common,
+			Rules:           []v1alpha2.TCPRouteRule{{BackendRefs: backendRefs}},
+		},
+	}
+}
+
+func buildUDPRoute(ldc *LocoDeploymentContext, port config.Port) *v1alpha2.UDPRoute {
+	common, backendRefs := portRouteSpec(ldc, port)
+	return &v1alpha2.UDPRoute{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      ldc.PortRouteName(port.Name),
+			Namespace: ldc.Namespace(),
+			Labels:    ldc.Labels(),
+		},
+		Spec: v1alpha2.UDPRouteSpec{
+			CommonRouteSpec: common,
+			Rules:           []v1alpha2.UDPRouteRule{{BackendRefs: backendRefs}},
+		},
+	}
+}
+
+// Endpoints returns where each of ldc's routing.ports can be reached, from outside the cluster for public ports
+func (ldc *LocoDeploymentContext) Endpoints() []Endpoint {
+	endpoints := make([]Endpoint, 0, len(ldc.Config.Routing.Ports))
+	for _, port := range ldc.Config.Routing.Ports {
+		endpoint := Endpoint{
+			Name:     port.Name,
+			Protocol: port.Protocol,
+			Port:     port.Port,
+			Address:  fmt.Sprintf("%s.%s.svc.cluster.local:%d", ldc.ServiceName(), ldc.Namespace(), port.Port),
+		}
+		if port.Exposure == config.ExposurePublic && port.ListenerPort != 0 {
+			endpoint.Public = true
+			endpoint.Address = fmt.Sprintf("%s:%d", ldc.Hostname(), port.ListenerPort)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
+
+// Endpoint is a TCP or UDP port of an app and the address it's reachable on
+type Endpoint struct {
+	Name     string
+	Protocol string
+	Port     int32
+	Public   bool
+	Address  string
+}
@@ -0,0 +1,149 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespaceQuota is the ceiling applied to an app namespace. It mirrors the workspace quota,
+// so a single namespace can never request more than the whole workspace is allowed.
+type NamespaceQuota struct {
+	CPUMillicores int64
+	MemoryMb      int64
+	MaxReplicas   int32
+}
+
+// runPods is how many one-off command pods an app namespace has room for at once
+const runPods = 2
+
+// pods leaves room for the extra pods a rolling update surges to, the release pod quota.CheckDeployment counts
+// next to the app's replicas, a pod per CronJob since they don't run concurrently, and runPods one-off commands
+func (q NamespaceQuota) pods(ldc *LocoDeploymentContext) int64 {
+	surge := (int64(q.MaxReplicas) + 3) / 4
+	pods := int64(q.MaxReplicas) + surge + int64(len(ldc.Config.Jobs)) + runPods
+	if ldc.Config.Deploy.Release != "" {
+		pods++
+	}
+	return pods
+}
+
+func (q NamespaceQuota) cpu() resource.Quantity {
+	return *resource.NewMilliQuantity(q.CPUMillicores, resource.DecimalSI)
+}
+
+func (q NamespaceQuota) memory() resource.Quantity {
+	return *resource.NewQuantity(q.MemoryMb*mebibyte, resource.BinarySI)
+}
+
+// ApplyResourceQuota creates or updates the namespace's ResourceQuota
+func (kc *Client) ApplyResourceQuota(ctx context.Context, ldc *LocoDeploymentContext, quota NamespaceQuota) (*v1.ResourceQuota, error) {
+	slog.InfoContext(ctx, "Applying resource quota", "namespace", ldc.Namespace(), "name", ldc.ResourceQuotaName())
+
+	spec := v1.ResourceQuotaSpec{
+		Hard: v1.ResourceList{
+			v1.ResourceRequestsCPU:    quota.cpu(),
+			v1.ResourceLimitsCPU:      quota.cpu(),
+			v1.ResourceRequestsMemory: quota.memory(),
+			v1.ResourceLimitsMemory:   quota.memory(),
+			v1.ResourcePods:           *resource.NewQuantity(quota.pods(ldc), resource.DecimalSI),
+		},
+	}
+
+	quotasClient := kc.ClientSet.CoreV1().ResourceQuotas(ldc.Namespace())
+	existing, err := quotasClient.Get(ctx, ldc.ResourceQuotaName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		rq := &v1.ResourceQuota{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      ldc.ResourceQuotaName(),
+				Namespace: ldc.Namespace(),
+				Labels:    ldc.Labels(),
+			},
+			Spec: spec,
+		}
+		result, err := quotasClient.Create(ctx, rq, metaV1.CreateOptions{})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create resource quota", "name", ldc.ResourceQuotaName(), "error", err)
+			return nil, fmt.Errorf("failed to create resource quota: %w", err)
+		}
+		slog.InfoContext(ctx, "Resource quota created", "name", result.Name)
+		return result, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get resource quota", "name", ldc.ResourceQuotaName(), "error", err)
+		return nil, fmt.Errorf("failed to get resource quota: %w", err)
+	}
+
+	existing.Spec = spec
+	result, err := quotasClient.Update(ctx, existing, metaV1.UpdateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update resource quota", "name", ldc.ResourceQuotaName(), "error", err)
+		return nil, fmt.Errorf("failed to update resource quota: %w", err)
+	}
+	slog.InfoContext(ctx, "Resource quota updated", "name", result.Name)
+	return result, nil
+}
+
+// ApplyLimitRange creates or updates the namespace's LimitRange.
+// Containers without resources get the loco defaults, and no container may ask for more than the quota.
+func (kc *Client) ApplyLimitRange(ctx context.Context, ldc *LocoDeploymentContext, quota NamespaceQuota) (*v1.LimitRange, error) {
+	slog.InfoContext(ctx, "Applying limit range", "namespace", ldc.Namespace(), "name", ldc.LimitRangeName())
+
+	spec := v1.LimitRangeSpec{
+		Limits: []v1.LimitRangeItem{
+			{
+				Type: v1.LimitTypeContainer,
+				Default: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse(DefaultCPU),
+					v1.ResourceMemory: resource.MustParse(DefaultMemory),
+				},
+				DefaultRequest: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse(DefaultCPU),
+					v1.ResourceMemory: resource.MustParse(DefaultMemory),
+				},
+				Max: v1.ResourceList{
+					v1.ResourceCPU:    quota.cpu(),
+					v1.ResourceMemory: quota.memory(),
+				},
+			},
+		},
+	}
+
+	limitRangesClient := kc.ClientSet.CoreV1().LimitRanges(ldc.Namespace())
+	existing, err := limitRangesClient.Get(ctx, ldc.LimitRangeName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		lr := &v1.LimitRange{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      ldc.LimitRangeName(),
+				Namespace: ldc.Namespace(),
+				Labels:    ldc.Labels(),
+			},
+			Spec: spec,
+		}
+		result, err := limitRangesClient.Create(ctx, lr, metaV1.CreateOptions{})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create limit range", "name", ldc.LimitRangeName(), "error", err)
+			return nil, fmt.Errorf("failed to create limit range: %w", err)
+		}
+		slog.InfoContext(ctx, "Limit range created", "name", result.Name)
+		return result, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get limit range", "name", ldc.LimitRangeName(), "error", err)
+		return nil, fmt.Errorf("failed to get limit range: %w", err)
+	}
+
+	existing.Spec = spec
+	result, err := limitRangesClient.Update(ctx, existing, metaV1.UpdateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update limit range", "name", ldc.LimitRangeName(), "error", err)
+		return nil, fmt.Errorf("failed to update limit range: %w", err)
+	}
+	slog.InfoContext(ctx, "Limit range updated", "name", result.Name)
+	return result, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	rbacV1 "k8s.io/api/rbac/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,7 +37,37 @@ func (kc *Client) CreateServiceAccount(ctx context.Context, ldc *LocoDeploymentC
 func (kc *Client) CreateRole(ctx context.Context, ldc *LocoDeploymentContext, secret *v1.Secret) (*rbacV1.Role, error) {
 	slog.InfoContext(ctx, "Creating role", "namespace", ldc.Namespace(), "name", ldc.RoleName())
 
-	role := &rbacV1.Role{
+	role := buildRole(ldc, secret.Name)
+
+	result, err := kc.ClientSet.RbacV1().Roles(ldc.Namespace()).Create(ctx, role, metaV1.CreateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create role", "name", ldc.RoleName(), "error", err)
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Role created", "name", result.Name)
+	return result, nil
+}
+
+// CreateRoleBinding creates a Kubernetes RoleBinding
+func (kc *Client) CreateRoleBinding(ctx context.Context, ldc *LocoDeploymentContext) (*rbacV1.RoleBinding, error) {
+	slog.InfoContext(ctx, "Creating role binding", "namespace", ldc.Namespace(), "name", ldc.RoleBindingName())
+
+	rb := buildRoleBinding(ldc)
+
+	result, err := kc.ClientSet.RbacV1().RoleBindings(ldc.Namespace()).Create(ctx, rb, metaV1.CreateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create role binding", "name", ldc.RoleBindingName(), "error", err)
+		return nil, fmt.Errorf("failed to create role binding: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Role binding created", "name", result.Name)
+	return result, nil
+}
+
+// buildRole renders the Role that lets ldc's pods read the secret named secretName
+func buildRole(ldc *LocoDeploymentContext, secretName string) *rbacV1.Role {
+	return &rbacV1.Role{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name:      ldc.RoleName(),
 			Namespace: ldc.Namespace(),
@@ -48,26 +79,15 @@ func (kc *Client) CreateRole(ctx context.Context, ldc *LocoDeploymentContext, se
 				APIGroups:     []string{""},
 				Resources:     []string{"secrets"},
 				Verbs:         []string{"get", "list", "watch"},
-				ResourceNames: []string{secret.Name},
+				ResourceNames: []string{secretName},
 			},
 		},
 	}
-
-	result, err := kc.ClientSet.RbacV1().Roles(ldc.Namespace()).Create(ctx, role, metaV1.CreateOptions{})
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create role", "name", ldc.RoleName(), "error", err)
-		return nil, fmt.Errorf("failed to create role: %w", err)
-	}
-
-	slog.InfoContext(ctx, "Role created", "name", result.Name)
-	return result, nil
 }
 
-// CreateRoleBinding creates a Kubernetes RoleBinding
-func (kc *Client) CreateRoleBinding(ctx context.Context, ldc *LocoDeploymentContext) (*rbacV1.RoleBinding, error) {
-	slog.InfoContext(ctx, "Creating role binding", "namespace", ldc.Namespace(), "name", ldc.RoleBindingName())
-
-	rb := &rbacV1.RoleBinding{
+// buildRoleBinding renders the RoleBinding that grants ldc's service account its Role
+func buildRoleBinding(ldc *LocoDeploymentContext) *rbacV1.RoleBinding {
+	return &rbacV1.RoleBinding{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name:      ldc.RoleBindingName(),
 			Namespace: ldc.Namespace(),
@@ -86,13 +106,68 @@ func (kc *Client) CreateRoleBinding(ctx context.Context, ldc *LocoDeploymentCont
 			APIGroup: "rbac.authorization.k8s.io",
 		},
 	}
+}
 
-	result, err := kc.ClientSet.RbacV1().RoleBindings(ldc.Namespace()).Create(ctx, rb, metaV1.CreateOptions{})
+// ApplyServiceAccount creates ldc's ServiceAccount unless it already exists, and reports whether it created it
+func (kc *Client) ApplyServiceAccount(ctx context.Context, ldc *LocoDeploymentContext) (bool, error) {
+	_, err := kc.ClientSet.CoreV1().ServiceAccounts(ldc.Namespace()).Get(ctx, ldc.ServiceAccountName(), metaV1.GetOptions{})
+	if err == nil {
+		return false, nil
+	}
+	if !apiErrors.IsNotFound(err) {
+		slog.ErrorContext(ctx, "Failed to get service account", "name", ldc.ServiceAccountName(), "error", err)
+		return false, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	if _, err := kc.CreateServiceAccount(ctx, ldc); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ApplyRole creates ldc's Role, or resets its rules when it already exists. It reports whether it created it.
+func (kc *Client) ApplyRole(ctx context.Context, ldc *LocoDeploymentContext) (bool, error) {
+	rolesClient := kc.ClientSet.RbacV1().Roles(ldc.Namespace())
+	existing, err := rolesClient.Get(ctx, ldc.RoleName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		if _, err := kc.createRoleWithSecretName(ctx, ldc, ldc.EnvSecretName()); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create role binding", "name", ldc.RoleBindingName(), "error", err)
-		return nil, fmt.Errorf("failed to create role binding: %w", err)
+		slog.ErrorContext(ctx, "Failed to get role", "name", ldc.RoleName(), "error", err)
+		return false, fmt.Errorf("failed to get role: %w", err)
 	}
 
-	slog.InfoContext(ctx, "Role binding created", "name", result.Name)
-	return result, nil
+	existing.Rules = buildRole(ldc, ldc.EnvSecretName()).Rules
+	if _, err := rolesClient.Update(ctx, existing, metaV1.UpdateOptions{}); err != nil {
+		slog.ErrorContext(ctx, "Failed to update role", "name", ldc.RoleName(), "error", err)
+		return false, fmt.Errorf("failed to update role: %w", err)
+	}
+	return false, nil
+}
+
+// ApplyRoleBinding creates ldc's RoleBinding, or resets its subjects when it already exists.
+// Its role is never changed, the API server refuses that. It reports whether it created it.
+func (kc *Client) ApplyRoleBinding(ctx context.Context, ldc *LocoDeploymentContext) (bool, error) {
+	bindingsClient := kc.ClientSet.RbacV1().RoleBindings(ldc.Namespace())
+	existing, err := bindingsClient.Get(ctx, ldc.RoleBindingName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		if _, err := kc.CreateRoleBinding(ctx, ldc); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get role binding", "name", ldc.RoleBindingName(), "error", err)
+		return false, fmt.Errorf("failed to get role binding: %w", err)
+	}
+
+	existing.Subjects = buildRoleBinding(ldc).Subjects
+	if _, err := bindingsClient.Update(ctx, existing, metaV1.UpdateOptions{}); err != nil {
+		slog.ErrorContext(ctx, "Failed to update role binding", "name", ldc.RoleBindingName(), "error", err)
+		return false, fmt.Errorf("failed to update role binding: %w", err)
+	}
+	return false, nil
 }
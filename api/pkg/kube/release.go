@@ -0,0 +1,108 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nikumar1206/loco/api/pkg/klogmux"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReleaseTimeout is how long a release command may run before its deploy fails
+const ReleaseTimeout = 30 * time.Minute
+
+// ErrReleaseFailed is returned when a release command doesn't exit 0
+var ErrReleaseFailed = errors.New("release command failed")
+
+// ReleaseCommand returns the command a deploy runs before the app's pods are updated, nil when it has none.
+// It goes through /bin/sh like a Procfile's release line.
+func (ldc *LocoDeploymentContext) ReleaseCommand() []string {
+	if ldc.Config.Deploy.Release == "" {
+		return nil
+	}
+	return []string{"/bin/sh", "-c", ldc.Config.Deploy.Release}
+}
+
+// ReleaseSecretName returns the name of the Secret holding the env a deployment's release command runs with
+func (ldc *LocoDeploymentContext) ReleaseSecretName() string {
+	return fmt.Sprintf("%s-release-%d", ldc.App.Name, ldc.Deployment.ID)
+}
+
+// RunRelease runs ldc's release command once in a Job with the new image and waits for it to exit, sending
+// its output to ldc.ReleaseLogs. The command gets envVars from a Secret of its own, so the app's pods keep
+// their env until the release succeeded. The Job and its Secret are deleted afterwards.
+func (kc *Client) RunRelease(ctx context.Context, ldc *LocoDeploymentContext, envVars map[string]string) error {
+	if err := kc.applyReleaseSecret(ctx, ldc, envVars); err != nil {
+		return err
+	}
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		err := kc.ClientSet.CoreV1().Secrets(ldc.Namespace()).Delete(cleanupCtx, ldc.ReleaseSecretName(), metaV1.DeleteOptions{})
+		if err != nil && !apiErrors.IsNotFound(err) {
+			slog.WarnContext(ctx, "Failed to delete release secret", "name", ldc.ReleaseSecretName(), "error", err)
+		}
+	}()
+
+	job, err := kc.createOneOffJob(ctx, ldc, "release", ldc.ReleaseCommand(), "", "", ldc.ReleaseSecretName(), ReleaseTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// also when ctx is done, the Job must not outlive the deploy
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := kc.DeleteRunJob(cleanupCtx, ldc.Namespace(), job.Name); err != nil {
+			slog.WarnContext(ctx, "Failed to delete release job", "name", job.Name, "error", err)
+		}
+	}()
+
+	slog.InfoContext(ctx, "Running release command", "namespace", ldc.Namespace(), "job", job.Name)
+	pod, err := kc.WaitForRunPod(ctx, ldc.Namespace(), job.Name)
+	if err != nil {
+		return err
+	}
+
+	result, err := kc.FollowRun(ctx, ldc.Namespace(), job.Name, pod, func(entry klogmux.LogEntry) error {
+		if ldc.ReleaseLogs != nil {
+			ldc.ReleaseLogs(entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.ExitCode != 0 {
+		slog.WarnContext(ctx, "Release command failed", "job", job.Name, "exit_code", result.ExitCode, "reason", result.Reason)
+		if result.Reason != "" {
+			return fmt.Errorf("%w: exited with code %d (%s)", ErrReleaseFailed, result.ExitCode, result.Reason)
+		}
+		return fmt.Errorf("%w: exited with code %d", ErrReleaseFailed, result.ExitCode)
+	}
+
+	slog.InfoContext(ctx, "Release command succeeded", "job", job.Name)
+	return nil
+}
+
+// applyReleaseSecret creates the Secret of ldc's release command, or replaces its variables when an earlier
+// attempt left it behind
+func (kc *Client) applyReleaseSecret(ctx context.Context, ldc *LocoDeploymentContext, envVars map[string]string) error {
+	secretsClient := kc.ClientSet.CoreV1().Secrets(ldc.Namespace())
+	secret := buildSecret(ldc, envVars)
+	secret.Name = ldc.ReleaseSecretName()
+
+	_, err := secretsClient.Create(ctx, secret, metaV1.CreateOptions{})
+	if apiErrors.IsAlreadyExists(err) {
+		_, err = secretsClient.Update(ctx, secret, metaV1.UpdateOptions{})
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to apply release secret", "name", secret.Name, "error", err)
+		return fmt.Errorf("failed to apply release secret: %w", err)
+	}
+	return nil
+}
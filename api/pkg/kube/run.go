@@ -0,0 +1,266 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/nikumar1206/loco/api/pkg/klogmux"
+	batchV1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// One-off command settings
+const (
+	// RunTimeout is how long a one-off command may run before the cluster stops it
+	RunTimeout = time.Hour
+	// RunStartTimeout is how long the pod of a one-off command may take to start
+	RunStartTimeout = 5 * time.Minute
+	// oneOffTTL is how long after finishing the cluster deletes the Jobs of one-off commands that loco-api
+	// couldn't delete itself
+	oneOffTTL = time.Hour
+)
+
+// ErrRunNotStarted is returned when the pod of a one-off command can't start
+var ErrRunNotStarted = errors.New("command did not start")
+
+// RunResult is how a one-off command ended
+type RunResult struct {
+	ExitCode int32
+	// Reason is set when the command didn't end on its own, like OOMKilled or DeadlineExceeded
+	Reason string
+}
+
+// OneOffLabels are the labels of a one-off command's pod, component being "run" or "release". Like JobLabels,
+// they leave out the app's name label so the app's Service never sends it traffic.
+func (ldc *LocoDeploymentContext) OneOffLabels(component string) map[string]string {
+	labels := maps.Clone(ldc.Labels())
+	delete(labels, LabelAppName)
+	labels[LabelAppComponent] = component
+	return labels
+}
+
+// CreateRunJob starts a Job that runs command once in ldc's image, with its env and service account.
+// It's never retried and the cluster stops it after RunTimeout.
+func (kc *Client) CreateRunJob(ctx context.Context, ldc *LocoDeploymentContext, command []string, cpu, memory string) (*batchV1.Job, error) {
+	return kc.createOneOffJob(ctx, ldc, "run", command, cpu, memory, ldc.EnvSecretName(), RunTimeout)
+}
+
+// createOneOffJob starts a Job named after component that runs command once with the env of envSecret and is
+// stopped after timeout
+func (kc *Client) createOneOffJob(
+	ctx context.Context,
+	ldc *LocoDeploymentContext,
+	component string,
+	command []string,
+	cpu, memory, envSecret string,
+	timeout time.Duration,
+) (*batchV1.Job, error) {
+	podSpec, err := buildJobPodSpec(ldc, component, command, cpu, memory, envSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &batchV1.Job{
+		ObjectMeta: metaV1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", ldc.App.Name, component),
+			Namespace:    ldc.Namespace(),
+			Labels:       ldc.OneOffLabels(component),
+		},
+		Spec: batchV1.JobSpec{
+			BackoffLimit:            ptrToInt32(0),
+			ActiveDeadlineSeconds:   ptrToInt64(int64(timeout.Seconds())),
+			TTLSecondsAfterFinished: ptrToInt32(int32(oneOffTTL.Seconds())),
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{
+					Labels: ldc.OneOffLabels(component),
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	created, err := kc.ClientSet.BatchV1().Jobs(ldc.Namespace()).Create(ctx, job, metaV1.CreateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create one-off job", "namespace", ldc.Namespace(), "component", component, "error", err)
+		return nil, fmt.Errorf("failed to create %s job: %w", component, err)
+	}
+	slog.InfoContext(ctx, "Created one-off job", "namespace", ldc.Namespace(), "name", created.Name)
+	return created, nil
+}
+
+// WaitForRunPod waits for the pod of a one-off command's Job to start and returns its name.
+// Pods that can't pull their image or read their env fail right away instead of waiting out RunStartTimeout.
+func (kc *Client) WaitForRunPod(ctx context.Context, namespace, jobName string) (string, error) {
+	podsClient := kc.ClientSet.CoreV1().Pods(namespace)
+
+	var podName, waiting string
+	err := wait.PollUntilContextTimeout(ctx, time.Second, RunStartTimeout, true, func(ctx context.Context) (bool, error) {
+		pods, err := podsClient.List(ctx, metaV1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", batchV1.JobNameLabel, jobName),
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase != v1.PodPending {
+				podName = pod.Name
+				return true, nil
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.State.Waiting == nil {
+					continue
+				}
+				waiting = status.State.Waiting.Reason
+				switch waiting {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError":
+					return false, fmt.Errorf("%w: %s: %s", ErrRunNotStarted, waiting, status.State.Waiting.Message)
+				}
+			}
+		}
+		return false, nil
+	})
+	if errors.Is(err, ErrRunNotStarted) {
+		return "", err
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Run pod did not start", "namespace", namespace, "job", jobName, "waiting", waiting, "error", err)
+		if waiting != "" {
+			return "", fmt.Errorf("%w: still %s: %w", ErrRunNotStarted, waiting, err)
+		}
+		return "", fmt.Errorf("%w: %w", ErrRunNotStarted, err)
+	}
+	return podName, nil
+}
+
+// FollowRun sends the logs of a one-off command's pod to onLog until the command exits, and returns how it ended.
+// An error from onLog stops following, the command itself keeps running until its Job is deleted.
+func (kc *Client) FollowRun(
+	ctx context.Context,
+	namespace, jobName, podName string,
+	onLog func(klogmux.LogEntry) error,
+) (RunResult, error) {
+	// cancelled on return, stopping the log stream and the wait for the command
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logStream := klogmux.NewBuilder(kc.ClientSet).
+		Namespace(namespace).
+		LabelSelector(fmt.Sprintf("%s=%s", batchV1.JobNameLabel, jobName)).
+		Follow(true).
+		Completed(true).
+		Transform(klogmux.ParseLevel).
+		Build()
+	if err := logStream.Start(ctx); err != nil {
+		return RunResult{}, err
+	}
+
+	// entries is closed once the command exited and its logs were read to the end
+	type exit struct {
+		result RunResult
+		err    error
+	}
+	exited := make(chan exit, 1)
+	go func() {
+		result, err := kc.WaitForRunExit(ctx, namespace, jobName, podName)
+		if err != nil {
+			logStream.Stop()
+		} else {
+			logStream.Drain()
+		}
+		exited <- exit{result: result, err: err}
+	}()
+
+	for entry := range logStream.Entries() {
+		if err := onLog(entry); err != nil {
+			return RunResult{}, err
+		}
+	}
+	for err := range logStream.Errors() {
+		// the exit code is still worth returning when some of the output was lost
+		slog.WarnContext(ctx, "Lost part of a one-off command's logs", "job", jobName, "error", err)
+	}
+
+	// the entries close once the wait stopped or drained them, its outcome is sent right after
+	e := <-exited
+	if e.err != nil {
+		return RunResult{}, e.err
+	}
+	return e.result, nil
+}
+
+// WaitForRunExit waits for the pod of a one-off command to finish and returns how it ended.
+// A pod the Job removed, once it ran past RunTimeout, ends with the Job's failure reason.
+func (kc *Client) WaitForRunExit(ctx context.Context, namespace, jobName, podName string) (RunResult, error) {
+	var result RunResult
+	err := wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		pod, err := kc.ClientSet.CoreV1().Pods(namespace).Get(ctx, podName, metaV1.GetOptions{})
+		if apiErrors.IsNotFound(err) {
+			result, err = kc.runJobResult(ctx, namespace, jobName)
+			return true, err
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+			return false, nil
+		}
+		result = runPodResult(pod)
+		return true, nil
+	})
+	if err != nil {
+		return RunResult{}, fmt.Errorf("failed to wait for command: %w", err)
+	}
+	return result, nil
+}
+
+// DeleteRunJob deletes a one-off command's Job and its pod
+func (kc *Client) DeleteRunJob(ctx context.Context, namespace, jobName string) error {
+	background := metaV1.DeletePropagationBackground
+	err := kc.ClientSet.BatchV1().Jobs(namespace).Delete(ctx, jobName, metaV1.DeleteOptions{PropagationPolicy: &background})
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete run job: %w", err)
+	}
+	return nil
+}
+
+// runJobResult reads how a one-off command ended from its Job, once its pod is gone
+func (kc *Client) runJobResult(ctx context.Context, namespace, jobName string) (RunResult, error) {
+	job, err := kc.ClientSet.BatchV1().Jobs(namespace).Get(ctx, jobName, metaV1.GetOptions{})
+	if err != nil {
+		return RunResult{}, err
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchV1.JobFailed && cond.Status == v1.ConditionTrue {
+			return RunResult{ExitCode: 1, Reason: cond.Reason}, nil
+		}
+	}
+	return RunResult{ExitCode: 1, Reason: "PodDeleted"}, nil
+}
+
+// runPodResult reads how a one-off command ended from its finished pod
+func runPodResult(pod *v1.Pod) RunResult {
+	// one-off pods have a single container
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated == nil {
+			continue
+		}
+		terminated := status.State.Terminated
+		result := RunResult{ExitCode: terminated.ExitCode}
+		// the kubelet's reasons for commands that exited on their own say nothing the exit code doesn't
+		if terminated.Reason != "Completed" && terminated.Reason != "Error" {
+			result.Reason = terminated.Reason
+		}
+		return result
+	}
+
+	// evicted or otherwise stopped before the container reported an exit code
+	return RunResult{ExitCode: 1, Reason: pod.Status.Reason}
+}
@@ -8,6 +8,7 @@ import (
 
 	json "github.com/goccy/go-json"
 	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,12 +16,26 @@ import (
 func (kc *Client) CreateSecret(ctx context.Context, ldc *LocoDeploymentContext, envVars map[string]string) (*v1.Secret, error) {
 	slog.InfoContext(ctx, "Creating secret", "namespace", ldc.Namespace(), "name", ldc.EnvSecretName())
 
+	secret := buildSecret(ldc, envVars)
+
+	result, err := kc.ClientSet.CoreV1().Secrets(ldc.Namespace()).Create(ctx, secret, metaV1.CreateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create secret", "name", ldc.EnvSecretName(), "error", err)
+		return nil, fmt.Errorf("failed to create secret: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Secret created", "name", result.Name)
+	return result, nil
+}
+
+// buildSecret renders the env var Secret loco wants for ldc
+func buildSecret(ldc *LocoDeploymentContext, envVars map[string]string) *v1.Secret {
 	secretData := make(map[string][]byte)
 	for key, value := range envVars {
 		secretData[key] = []byte(value)
 	}
 
-	secret := &v1.Secret{
+	return &v1.Secret{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name:      ldc.EnvSecretName(),
 			Namespace: ldc.Namespace(),
@@ -29,15 +44,31 @@ func (kc *Client) CreateSecret(ctx context.Context, ldc *LocoDeploymentContext,
 		Data: secretData,
 		Type: v1.SecretTypeOpaque,
 	}
+}
 
-	result, err := kc.ClientSet.CoreV1().Secrets(ldc.Namespace()).Create(ctx, secret, metaV1.CreateOptions{})
+// ApplySecret creates the env var Secret, or replaces its variables with envVars when it already exists.
+// It reports whether it created the Secret.
+func (kc *Client) ApplySecret(ctx context.Context, ldc *LocoDeploymentContext, envVars map[string]string) (bool, error) {
+	secretsClient := kc.ClientSet.CoreV1().Secrets(ldc.Namespace())
+	existing, err := secretsClient.Get(ctx, ldc.EnvSecretName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		if _, err := kc.CreateSecret(ctx, ldc, envVars); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create secret", "name", ldc.EnvSecretName(), "error", err)
-		return nil, fmt.Errorf("failed to create secret: %w", err)
+		slog.ErrorContext(ctx, "Failed to get secret", "name", ldc.EnvSecretName(), "error", err)
+		return false, fmt.Errorf("failed to get secret: %w", err)
 	}
 
-	slog.InfoContext(ctx, "Secret created", "name", result.Name)
-	return result, nil
+	existing.Data = buildSecret(ldc, envVars).Data
+	if _, err := secretsClient.Update(ctx, existing, metaV1.UpdateOptions{}); err != nil {
+		slog.ErrorContext(ctx, "Failed to update secret", "name", ldc.EnvSecretName(), "error", err)
+		return false, fmt.Errorf("failed to update secret: %w", err)
+	}
+	slog.InfoContext(ctx, "Secret updated", "name", ldc.EnvSecretName())
+	return false, nil
 }
 
 // UpdateSecret updates a Kubernetes Secret for environment variables
@@ -158,3 +189,16 @@ func (kc *Client) UpdateDockerPullSecret(ctx context.Context, ldc *LocoDeploymen
 	slog.InfoContext(ctx, "Docker pull secret updated", "name", ldc.RegistrySecretName())
 	return nil
 }
+
+// ApplyDockerPullSecret creates the Docker pull secret, or updates it when it already exists
+func (kc *Client) ApplyDockerPullSecret(ctx context.Context, ldc *LocoDeploymentContext, registry DockerRegistryConfig) error {
+	_, err := kc.ClientSet.CoreV1().Secrets(ldc.Namespace()).Get(ctx, ldc.RegistrySecretName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		return kc.CreateDockerPullSecret(ctx, ldc, registry)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get docker pull secret", "error", err)
+		return fmt.Errorf("failed to get docker pull secret: %w", err)
+	}
+	return kc.UpdateDockerPullSecret(ctx, ldc, registry)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/nikumar1206/loco/shared/config"
 	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -34,7 +36,74 @@ func (kc *Client) CreateService(ctx context.Context, ldc *LocoDeploymentContext)
 		return nil, fmt.Errorf("service already exists: %s", ldc.ServiceName())
 	}
 
-	service := &v1.Service{
+	service := buildService(ldc)
+
+	result, err := kc.ClientSet.CoreV1().Services(ldc.Namespace()).Create(ctx, service, metaV1.CreateOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create service", "name", ldc.ServiceName(), "error", err)
+		return nil, fmt.Errorf("failed to create service: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Service created", "service", result.Name)
+	return result, nil
+}
+
+// ApplyService creates ldc's Service, or resets its spec when it already exists. It reports whether it created it.
+func (kc *Client) ApplyService(ctx context.Context, ldc *LocoDeploymentContext) (bool, error) {
+	servicesClient := kc.ClientSet.CoreV1().Services(ldc.Namespace())
+	existing, err := servicesClient.Get(ctx, ldc.ServiceName(), metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		if _, err := kc.CreateService(ctx, ldc); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get service", "name", ldc.ServiceName(), "error", err)
+		return false, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	want := buildService(ldc)
+	// the cluster IP is immutable, keep the one already assigned
+	want.Spec.ClusterIP = existing.Spec.ClusterIP
+	want.Spec.ClusterIPs = existing.Spec.ClusterIPs
+	existing.Spec = want.Spec
+	if _, err := servicesClient.Update(ctx, existing, metaV1.UpdateOptions{}); err != nil {
+		slog.ErrorContext(ctx, "Failed to update service", "name", ldc.ServiceName(), "error", err)
+		return false, fmt.Errorf("failed to update service: %w", err)
+	}
+	slog.InfoContext(ctx, "Service updated", "service", ldc.ServiceName())
+	return false, nil
+}
+
+// buildService renders the Service loco wants for ldc.
+// grpc apps' ports are marked h2c so the gateway speaks HTTP/2 to pods without TLS.
+func buildService(ldc *LocoDeploymentContext) *v1.Service {
+	var appProtocol *string
+	if ldc.Protocol() == config.ProtocolGRPC {
+		appProtocol = ptrToString(AppProtocolH2C)
+	}
+
+	// routing.ports keep their number on the Service, so in-cluster clients use the port the app listens on
+	ports := []v1.ServicePort{
+		{
+			Name:        ldc.ServicePort(),
+			Protocol:    v1.ProtocolTCP,
+			AppProtocol: appProtocol,
+			Port:        DefaultServicePort,
+			TargetPort:  intstr.FromInt32(ldc.Config.Routing.Port),
+		},
+	}
+	for _, port := range ldc.Config.Routing.Ports {
+		ports = append(ports, v1.ServicePort{
+			Name:       port.Name,
+			Protocol:   v1.Protocol(port.Protocol),
+			Port:       port.Port,
+			TargetPort: intstr.FromInt32(port.Port),
+		})
+	}
+
+	return &v1.Service{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name:      ldc.ServiceName(),
 			Namespace: ldc.Namespace(),
@@ -51,23 +120,7 @@ func (kc *Client) CreateService(ctx context.Context, ldc *LocoDeploymentContext)
 					TimeoutSeconds: ptrToInt32(SessionAffinityTimeout),
 				},
 			},
-			Ports: []v1.ServicePort{
-				{
-					Name:       ldc.ServicePort(),
-					Protocol:   v1.ProtocolTCP,
-					Port:       DefaultServicePort,
-					TargetPort: intstr.FromInt32(ldc.Config.Routing.Port),
-				},
-			},
+			Ports: ports,
 		},
 	}
-
-	result, err := kc.ClientSet.CoreV1().Services(ldc.Namespace()).Create(ctx, service, metaV1.CreateOptions{})
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create service", "name", ldc.ServiceName(), "error", err)
-		return nil, fmt.Errorf("failed to create service: %w", err)
-	}
-
-	slog.InfoContext(ctx, "Service created", "service", result.Name)
-	return result, nil
 }
@@ -0,0 +1,200 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	appsV1 "k8s.io/api/apps/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	v1Gateway "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// Sleeping apps' routes send requests to loco-api, which wakes the app and redirects back
+const (
+	WakeServiceName = "loco-api"
+	WakeServicePort = 80
+	// WakeTimeout bounds how long a wake waits for the app's pods to become ready
+	WakeTimeout = 2 * time.Minute
+)
+
+var ErrWakeUnavailable = errors.New("loco-api is not reachable from the cluster's gateway")
+
+// WakePath is the loco-api path a sleeping app's requests are rewritten to. The rest of the request path follows it.
+func WakePath(appID int64) string {
+	return fmt.Sprintf("/wake/%d", appID)
+}
+
+// WakeGrantName names the ReferenceGrant that lets a sleeping app's route reach loco-api
+func WakeGrantName(appID int64) string {
+	return fmt.Sprintf("wake-app-%d", appID)
+}
+
+// SleepApp scales the app to zero and points its HTTPRoute at loco-api's wake endpoint.
+// The route moves first so no request is sent to an app with no pods.
+func (kc *Client) SleepApp(ctx context.Context, ldc *LocoDeploymentContext) error {
+	slog.InfoContext(ctx, "Putting app to sleep", "namespace", ldc.Namespace(), "app", ldc.App.Name)
+
+	exists, err := kc.CheckServiceExists(ctx, LocoNS, WakeServiceName)
+	if err != nil {
+		return fmt.Errorf("failed to check wake service: %w", err)
+	}
+	if !exists {
+		return ErrWakeUnavailable
+	}
+
+	grant := buildWakeGrant(ldc)
+	grants := kc.GatewaySet.GatewayV1beta1().ReferenceGrants(LocoNS)
+	if _, err := grants.Create(ctx, grant, metaV1.CreateOptions{}); err != nil && !apiErrors.IsAlreadyExists(err) {
+		slog.ErrorContext(ctx, "Failed to create wake ReferenceGrant", "name", grant.Name, "error", err)
+		return fmt.Errorf("failed to create wake ReferenceGrant: %w", err)
+	}
+
+	if err := kc.updateHTTPRouteSpec(ctx, buildWakeHTTPRoute(ldc)); err != nil {
+		slog.ErrorContext(ctx, "Failed to point HTTPRoute at wake endpoint", "name", ldc.HTTPRouteName(), "error", err)
+		return fmt.Errorf("failed to point HTTPRoute at wake endpoint: %w", err)
+	}
+
+	if err := kc.ScaleDeployment(ctx, ldc.Namespace(), ldc.DeploymentName(), ptrToInt32(0), nil, nil); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "App is asleep", "namespace", ldc.Namespace(), "app", ldc.App.Name)
+	return nil
+}
+
+// WakeApp scales a sleeping app back to its deployment's replicas, waits for a ready pod and
+// routes its hostname to it again
+func (kc *Client) WakeApp(ctx context.Context, ldc *LocoDeploymentContext) error {
+	slog.InfoContext(ctx, "Waking app", "namespace", ldc.Namespace(), "app", ldc.App.Name)
+
+	replicas := ldc.Deployment.Replicas
+	if replicas < 1 {
+		replicas = DefaultReplicas
+	}
+	if err := kc.ScaleDeployment(ctx, ldc.Namespace(), ldc.DeploymentName(), &replicas, nil, nil); err != nil {
+		return err
+	}
+
+	deploymentsClient := kc.ClientSet.AppsV1().Deployments(ldc.Namespace())
+	err := wait.PollUntilContextTimeout(ctx, time.Second, WakeTimeout, true, func(ctx context.Context) (bool, error) {
+		deployment, err := deploymentsClient.Get(ctx, ldc.DeploymentName(), metaV1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return isAwake(deployment), nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "App did not become ready", "namespace", ldc.Namespace(), "app", ldc.App.Name, "error", err)
+		return fmt.Errorf("app did not become ready: %w", err)
+	}
+
+	if err := kc.updateHTTPRouteSpec(ctx, buildHTTPRoute(ldc)); err != nil {
+		slog.ErrorContext(ctx, "Failed to restore HTTPRoute", "name", ldc.HTTPRouteName(), "error", err)
+		return fmt.Errorf("failed to restore HTTPRoute: %w", err)
+	}
+
+	if err := kc.DeleteWakeGrant(ctx, ldc.App.ID); err != nil {
+		// the app is up and routed, a leftover grant only lets its namespace reach loco-api
+		slog.WarnContext(ctx, "Failed to delete wake ReferenceGrant", "app_id", ldc.App.ID, "error", err)
+	}
+
+	slog.InfoContext(ctx, "App is awake", "namespace", ldc.Namespace(), "app", ldc.App.Name)
+	return nil
+}
+
+// DeleteWakeGrant removes the ReferenceGrant of an app that no longer sleeps
+func (kc *Client) DeleteWakeGrant(ctx context.Context, appID int64) error {
+	err := kc.GatewaySet.GatewayV1beta1().ReferenceGrants(LocoNS).Delete(ctx, WakeGrantName(appID), metaV1.DeleteOptions{})
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete wake ReferenceGrant: %w", err)
+	}
+	return nil
+}
+
+func (kc *Client) updateHTTPRouteSpec(ctx context.Context, route *v1Gateway.HTTPRoute) error {
+	routesClient := kc.GatewaySet.GatewayV1().HTTPRoutes(route.Namespace)
+	have, err := routesClient.Get(ctx, route.Name, metaV1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	have.Spec = route.Spec
+	_, err = routesClient.Update(ctx, have, metaV1.UpdateOptions{})
+	return err
+}
+
+// isAwake is true once a pod of the deployment's current template is ready
+func isAwake(deployment *appsV1.Deployment) bool {
+	return deployment.Status.ObservedGeneration >= deployment.Generation && deployment.Status.ReadyReplicas > 0
+}
+
+// buildWakeHTTPRoute renders the app's HTTPRoute while it sleeps: the same matches, sent to loco-api with
+// the path prefix rewritten to the app's wake path, so /api/users becomes /wake/<app id>/users
+func buildWakeHTTPRoute(ldc *LocoDeploymentContext) *v1Gateway.HTTPRoute {
+	route := buildHTTPRoute(ldc)
+	wakePath := WakePath(ldc.App.ID)
+	for i := range route.Spec.Rules {
+		rule := &route.Spec.Rules[i]
+		rule.Filters = []v1Gateway.HTTPRouteFilter{
+			{
+				Type: v1Gateway.HTTPRouteFilterURLRewrite,
+				URLRewrite: &v1Gateway.HTTPURLRewriteFilter{
+					Path: &v1Gateway.HTTPPathModifier{
+						Type:               v1Gateway.PrefixMatchHTTPPathModifier,
+						ReplacePrefixMatch: &wakePath,
+					},
+				},
+			},
+		}
+		// waking takes longer than the app's own request timeout
+		rule.Timeouts = &v1Gateway.HTTPRouteTimeouts{
+			Request: ptrToDuration(fmt.Sprintf("%ds", int((WakeTimeout + 30*time.Second).Seconds()))),
+		}
+		rule.BackendRefs = []v1Gateway.HTTPBackendRef{
+			{
+				BackendRef: v1Gateway.BackendRef{
+					BackendObjectReference: v1Gateway.BackendObjectReference{
+						Name:      v1Gateway.ObjectName(WakeServiceName),
+						Namespace: ptrToNamespace(LocoNS),
+						Port:      ptrToPortNumber(WakeServicePort),
+						Kind:      ptrToKind("Service"),
+					},
+				},
+			},
+		}
+	}
+	return route
+}
+
+// buildWakeGrant lets the app's HTTPRoute send requests to the loco-api Service across namespaces
+func buildWakeGrant(ldc *LocoDeploymentContext) *v1beta1.ReferenceGrant {
+	serviceName := v1Gateway.ObjectName(WakeServiceName)
+	return &v1beta1.ReferenceGrant{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      WakeGrantName(ldc.App.ID),
+			Namespace: LocoNS,
+			Labels:    map[string]string{LabelAppManagedBy: "loco"},
+		},
+		Spec: v1beta1.ReferenceGrantSpec{
+			From: []v1beta1.ReferenceGrantFrom{
+				{
+					Group:     v1Gateway.GroupName,
+					Kind:      "HTTPRoute",
+					Namespace: v1Gateway.Namespace(ldc.Namespace()),
+				},
+			},
+			To: []v1beta1.ReferenceGrantTo{
+				{
+					Group: "",
+					Kind:  "Service",
+					Name:  &serviceName,
+				},
+			},
+		},
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/klogmux"
 	"github.com/nikumar1206/loco/shared/config"
 )
 
@@ -14,6 +15,12 @@ type LocoDeploymentContext struct {
 	App        *genDb.App
 	Deployment *genDb.Deployment
 	Config     *config.AppConfig
+	// Quota is applied to the namespace when set
+	Quota *NamespaceQuota
+	// Domains are the app's verified custom hostnames, routed alongside Hostname
+	Domains []string
+	// ReleaseLogs receives each line the release command prints, when set
+	ReleaseLogs func(klogmux.LogEntry)
 }
 
 // DockerRegistryConfig for creating docker pull secrets
@@ -44,6 +51,7 @@ const (
 	SessionAffinityTimeout = 10800 // 3 hours
 	TerminationGracePeriod = 30
 	LocoGatewayName        = "loco-gateway"
+	AppProtocolH2C         = "kubernetes.io/h2c"
 	LocoNS                 = "loco-system"
 
 	// Probe constants
@@ -133,6 +141,48 @@ func (ldc *LocoDeploymentContext) HTTPRouteName() string {
 	return ldc.App.Name
 }
 
+// GRPCRouteName returns the K8s gateway GRPCRoute name, used instead of the HTTPRoute by grpc apps
+func (ldc *LocoDeploymentContext) GRPCRouteName() string {
+	return ldc.App.Name
+}
+
+// Hostname returns the hostname the app is served on
+func (ldc *LocoDeploymentContext) Hostname() string {
+	return fmt.Sprintf("%s.%s", ldc.App.Subdomain, ldc.App.Domain)
+}
+
+// Hostnames returns every hostname the app is routed on, its own first
+func (ldc *LocoDeploymentContext) Hostnames() []string {
+	return append([]string{ldc.Hostname()}, ldc.Domains...)
+}
+
+// PathPrefix returns the path the app is served under on its hostname.
+// Deployments made before apps could share a hostname don't record one and are served from /.
+func (ldc *LocoDeploymentContext) PathPrefix() string {
+	if ldc.Config.Routing.PathPrefix == "" {
+		return "/"
+	}
+	return ldc.Config.Routing.PathPrefix
+}
+
+// Protocol returns how the app is routed, config.ProtocolHTTP or config.ProtocolGRPC
+func (ldc *LocoDeploymentContext) Protocol() string {
+	if ldc.Config.Routing.Protocol == "" {
+		return config.ProtocolHTTP
+	}
+	return ldc.Config.Routing.Protocol
+}
+
+// ResourceQuotaName returns the K8s resource quota name
+func (ldc *LocoDeploymentContext) ResourceQuotaName() string {
+	return ldc.App.Name
+}
+
+// LimitRangeName returns the K8s limit range name
+func (ldc *LocoDeploymentContext) LimitRangeName() string {
+	return ldc.App.Name
+}
+
 // ContainerName returns the container name
 func (ldc *LocoDeploymentContext) ContainerName() string {
 	return ldc.App.Name
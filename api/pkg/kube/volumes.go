@@ -0,0 +1,264 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/nikumar1206/loco/shared/config"
+	v1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LabelRetainedFrom marks a PersistentVolume kept after its app was deleted with the app's namespace
+const LabelRetainedFrom = "app.loco.io/retained-from"
+
+// VolumeClaimName returns the K8s PersistentVolumeClaim name of a persistent volume
+func (ldc *LocoDeploymentContext) VolumeClaimName(volumeName string) string {
+	return fmt.Sprintf("%s-%s", ldc.App.Name, volumeName)
+}
+
+// PersistentVolumes returns the volumes backed by a PersistentVolumeClaim
+func (ldc *LocoDeploymentContext) PersistentVolumes() []config.Volume {
+	var volumes []config.Volume
+	for _, volume := range ldc.Config.Volumes {
+		if volume.Type == config.VolumePersistent {
+			volumes = append(volumes, volume)
+		}
+	}
+	return volumes
+}
+
+// ApplyVolumeClaims creates the claims of ldc's persistent volumes and grows the ones whose size went up.
+// Claims of volumes the app no longer declares are kept, along with their data, until the app is deleted.
+func (kc *Client) ApplyVolumeClaims(ctx context.Context, ldc *LocoDeploymentContext) error {
+	claimsClient := kc.ClientSet.CoreV1().PersistentVolumeClaims(ldc.Namespace())
+
+	for _, volume := range ldc.PersistentVolumes() {
+		want, err := buildVolumeClaim(ldc, volume)
+		if err != nil {
+			return err
+		}
+
+		have, err := claimsClient.Get(ctx, want.Name, metaV1.GetOptions{})
+		if apiErrors.IsNotFound(err) {
+			slog.InfoContext(ctx, "Creating persistent volume claim", "namespace", ldc.Namespace(), "name", want.Name, "size", volume.Size)
+			if _, err := claimsClient.Create(ctx, want, metaV1.CreateOptions{}); err != nil {
+				slog.ErrorContext(ctx, "Failed to create persistent volume claim", "name", want.Name, "error", err)
+				return fmt.Errorf("failed to create persistent volume claim: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get persistent volume claim", "name", want.Name, "error", err)
+			return fmt.Errorf("failed to get persistent volume claim: %w", err)
+		}
+
+		wantSize := want.Spec.Resources.Requests[v1.ResourceStorage]
+		haveSize := have.Spec.Resources.Requests[v1.ResourceStorage]
+		switch wantSize.Cmp(haveSize) {
+		case 0:
+			continue
+		case -1:
+			return fmt.Errorf("volume %s can't shrink from %s to %s", volume.Name, haveSize.String(), volume.Size)
+		}
+
+		// only storage classes that allow volume expansion accept this
+		slog.InfoContext(ctx, "Expanding persistent volume claim", "name", want.Name, "from", haveSize.String(), "to", volume.Size)
+		have.Spec.Resources.Requests[v1.ResourceStorage] = wantSize
+		if _, err := claimsClient.Update(ctx, have, metaV1.UpdateOptions{}); err != nil {
+			slog.ErrorContext(ctx, "Failed to expand persistent volume claim", "name", want.Name, "error", err)
+			return fmt.Errorf("failed to expand persistent volume claim: %w", err)
+		}
+	}
+	return nil
+}
+
+// RetainVolumes keeps the PersistentVolumes bound to the namespace's claims when the namespace is deleted,
+// by setting their reclaim policy to Retain. It returns the names of the kept volumes.
+func (kc *Client) RetainVolumes(ctx context.Context, namespace string) ([]string, error) {
+	claims, err := kc.ClientSet.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list persistent volume claims", "namespace", namespace, "error", err)
+		return nil, fmt.Errorf("failed to list persistent volume claims: %w", err)
+	}
+
+	volumesClient := kc.ClientSet.CoreV1().PersistentVolumes()
+	var retained []string
+	for _, claim := range claims.Items {
+		if claim.Spec.VolumeName == "" {
+			// never bound, so no data was written
+			continue
+		}
+		volume, err := volumesClient.Get(ctx, claim.Spec.VolumeName, metaV1.GetOptions{})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get persistent volume", "name", claim.Spec.VolumeName, "error", err)
+			return retained, fmt.Errorf("failed to get persistent volume: %w", err)
+		}
+
+		volume.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimRetain
+		if volume.Labels == nil {
+			volume.Labels = map[string]string{}
+		}
+		volume.Labels[LabelRetainedFrom] = namespace
+		if _, err := volumesClient.Update(ctx, volume, metaV1.UpdateOptions{}); err != nil {
+			slog.ErrorContext(ctx, "Failed to retain persistent volume", "name", volume.Name, "error", err)
+			return retained, fmt.Errorf("failed to retain persistent volume: %w", err)
+		}
+		slog.InfoContext(ctx, "Retained persistent volume", "name", volume.Name, "claim", claim.Name, "namespace", namespace)
+		retained = append(retained, volume.Name)
+	}
+	return retained, nil
+}
+
+// VolumeUsage is how much of a volume's space an app uses
+type VolumeUsage struct {
+	Volume config.Volume
+	// HasUsage is false while no running pod reports the volume
+	HasUsage      bool
+	UsedBytes     int64
+	CapacityBytes int64
+}
+
+// statsSummary is the part of the kubelet's /stats/summary loco reads
+type statsSummary struct {
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		Volumes []struct {
+			Name          string  `json:"name"`
+			UsedBytes     *uint64 `json:"usedBytes"`
+			CapacityBytes *uint64 `json:"capacityBytes"`
+		} `json:"volume"`
+	} `json:"pods"`
+}
+
+// VolumeUsageOf returns the usage of ldc's volumes as reported by the kubelets running its pods, the most any
+// pod uses for ephemeral volumes. Volumes are listed even when no kubelet reports them, only without usage.
+func (kc *Client) VolumeUsageOf(ctx context.Context, ldc *LocoDeploymentContext) ([]VolumeUsage, error) {
+	usage := make([]VolumeUsage, 0, len(ldc.Config.Volumes))
+	for _, volume := range ldc.Config.Volumes {
+		usage = append(usage, VolumeUsage{Volume: volume})
+	}
+	if len(usage) == 0 {
+		return usage, nil
+	}
+
+	pods, err := kc.ClientSet.CoreV1().Pods(ldc.Namespace()).List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", LabelAppName, ldc.App.Name),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list pods", "namespace", ldc.Namespace(), "error", err)
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	podNames := map[string]bool{}
+	nodes := map[string]bool{}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" && pod.Status.Phase == v1.PodRunning {
+			podNames[pod.Name] = true
+			nodes[pod.Spec.NodeName] = true
+		}
+	}
+
+	for node := range nodes {
+		raw, err := kc.ClientSet.CoreV1().RESTClient().Get().
+			Resource("nodes").Name(node).SubResource("proxy").Suffix("stats", "summary").
+			DoRaw(ctx)
+		if err != nil {
+			// a node that can't be reached only loses the usage of its pods
+			slog.WarnContext(ctx, "Failed to read kubelet stats", "node", node, "error", err)
+			continue
+		}
+		var summary statsSummary
+		if err := json.Unmarshal(raw, &summary); err != nil {
+			slog.WarnContext(ctx, "Skipping unreadable kubelet stats", "node", node, "error", err)
+			continue
+		}
+
+		for _, pod := range summary.Pods {
+			if pod.PodRef.Namespace != ldc.Namespace() || !podNames[pod.PodRef.Name] {
+				continue
+			}
+			for _, stats := range pod.Volumes {
+				for i := range usage {
+					u := &usage[i]
+					if u.Volume.Name != stats.Name || stats.UsedBytes == nil {
+						continue
+					}
+					used := int64(*stats.UsedBytes)
+					if !u.HasUsage || used > u.UsedBytes {
+						u.UsedBytes = used
+						if stats.CapacityBytes != nil {
+							u.CapacityBytes = int64(*stats.CapacityBytes)
+						}
+					}
+					u.HasUsage = true
+				}
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+// buildVolumeClaim renders the PersistentVolumeClaim of a persistent volume on the cluster's default storage class
+func buildVolumeClaim(ldc *LocoDeploymentContext, volume config.Volume) (*v1.PersistentVolumeClaim, error) {
+	size, err := resource.ParseQuantity(volume.Size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size for volume %s: %w", volume.Name, err)
+	}
+
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      ldc.VolumeClaimName(volume.Name),
+			Namespace: ldc.Namespace(),
+			Labels:    ldc.Labels(),
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: size},
+			},
+		},
+	}, nil
+}
+
+// buildPodVolumes renders the pod volumes and container mounts of ldc's volumes
+func buildPodVolumes(ldc *LocoDeploymentContext) ([]v1.Volume, []v1.VolumeMount, error) {
+	var volumes []v1.Volume
+	var mounts []v1.VolumeMount
+	for _, volume := range ldc.Config.Volumes {
+		source := v1.VolumeSource{}
+		if volume.Type == config.VolumePersistent {
+			source.PersistentVolumeClaim = &v1.PersistentVolumeClaimVolumeSource{ClaimName: ldc.VolumeClaimName(volume.Name)}
+		} else {
+			emptyDir, err := buildEmptyDir(volume)
+			if err != nil {
+				return nil, nil, err
+			}
+			source.EmptyDir = emptyDir
+		}
+		volumes = append(volumes, v1.Volume{Name: volume.Name, VolumeSource: source})
+		mounts = append(mounts, v1.VolumeMount{Name: volume.Name, MountPath: volume.MountPath})
+	}
+	return volumes, mounts, nil
+}
+
+// buildEmptyDir renders an ephemeral volume, limited to its size when it has one
+func buildEmptyDir(volume config.Volume) (*v1.EmptyDirVolumeSource, error) {
+	emptyDir := &v1.EmptyDirVolumeSource{}
+	if volume.Size != "" {
+		size, err := resource.ParseQuantity(volume.Size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size for volume %s: %w", volume.Name, err)
+		}
+		emptyDir.SizeLimit = &size
+	}
+	return emptyDir, nil
+}
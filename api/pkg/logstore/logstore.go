@@ -0,0 +1,175 @@
+// Package logstore queries app logs shipped to ClickHouse by the OpenTelemetry collector.
+package logstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nikumar1206/loco/api/pkg/clickhouse"
+	"github.com/nikumar1206/loco/api/pkg/klogmux"
+)
+
+const (
+	// DefaultTable is where the otel collector's clickhouse exporter writes logs
+	DefaultTable = "otel_logs"
+	// DefaultLimit is the number of entries returned when a query sets no limit
+	DefaultLimit = 100
+	// MaxLimit bounds a single query
+	MaxLimit = 5000
+	// DefaultLookback is how far back a query without Since searches
+	DefaultLookback = 24 * time.Hour
+)
+
+var ErrInvalidRange = errors.New("since must be before until")
+
+// selectLogs reads one app's logs. Levels come from the severity when the app logged one,
+// then a JSON level key, a level=... pair or a level prefix, the same formats klogmux parses.
+// Lines with none of those are ERROR on stderr and INFO otherwise.
+// Severity is the level's 1-based position in klogmux.Levels.
+const selectLogs = `SELECT
+    toUnixTimestamp64Nano(Timestamp) AS TimestampNano,
+    upper(coalesce(
+        nullIf(SeverityText, ''),
+        nullIf(extract(Body, '"(?:level|lvl|severity)"\\s*:\\s*"(\\w+)"'), ''),
+        nullIf(extract(Body, '(?i)\\b(?:level|lvl|severity)="?(\\w+)'), ''),
+        nullIf(extract(Body, '^\\s*\\[?((?i:trace|debug|info|warn|warning|error|fatal|panic))\\]?[:\\s]'), '')
+    )) AS RawLevel,
+    multiIf(
+        RawLevel IN ('TRACE', 'DEBUG', 'DBG'), 1,
+        RawLevel IN ('WARN', 'WARNING', 'WRN'), 3,
+        RawLevel IN ('ERROR', 'ERR'), 4,
+        RawLevel IN ('FATAL', 'PANIC', 'DPANIC', 'CRITICAL', 'CRIT', 'ALERT', 'EMERG', 'EMERGENCY'), 5,
+        RawLevel IS NULL AND LogAttributes['log.iostream'] = 'stderr', 4,
+        2
+    ) AS Severity,
+    arrayElement(['DEBUG', 'INFO', 'WARN', 'ERROR', 'FATAL'], Severity) AS Level,
+    Body,
+    ResourceAttributes['k8s.pod.name'] AS PodName,
+    ResourceAttributes['k8s.container.name'] AS Container
+FROM {table:Identifier}
+WHERE ResourceAttributes['k8s.namespace.name'] = {namespace:String}
+    AND ResourceAttributes['app.loco.io/name'] = {app:String}
+    AND Timestamp >= {since:DateTime64(9, 'UTC')}
+    AND Timestamp < {until:DateTime64(9, 'UTC')}
+    AND ({query:String} = '' OR positionCaseInsensitiveUTF8(Body, {query:String}) > 0)
+    AND Severity >= {min_severity:UInt8}
+    AND ({pod:String} = '' OR match(PodName, {pod:String}))
+    AND (empty({containers:Array(String)}) OR has({containers:Array(String)}, Container))
+    AND ({grep:String} = '' OR match(Body, {grep:String}))
+    AND ({exclude:String} = '' OR NOT match(Body, {exclude:String}))
+ORDER BY Timestamp %s
+LIMIT {limit:UInt32}
+FORMAT JSONEachRow`
+
+// Query selects an app's logs. Zero values mean no filter.
+type Query struct {
+	Namespace string
+	AppName   string
+	Since     time.Time
+	Until     time.Time
+	// Contains matches a case insensitive substring of the log body
+	Contains string
+	// MinLevel is one of klogmux.Levels, entries below it are left out
+	MinLevel string
+	// Pod, Match and Exclude are regular expressions in re2 syntax
+	Pod        string
+	Containers []string
+	Match      string
+	Exclude    string
+	Limit      int
+	// Ascending returns the oldest entries first, by default the newest come first
+	Ascending bool
+}
+
+// Entry is a single stored log line
+type Entry struct {
+	Timestamp time.Time
+	PodName   string
+	Container string
+	Level     string
+	Message   string
+}
+
+// Client reads logs from ClickHouse
+type Client struct {
+	ch    *clickhouse.Client
+	table string
+}
+
+// NewClient creates a Client reading DefaultTable
+func NewClient(ch *clickhouse.Client) *Client {
+	return &Client{ch: ch, table: DefaultTable}
+}
+
+// Query returns the entries matching q, in the requested order
+func (c *Client) Query(ctx context.Context, q Query) ([]Entry, error) {
+	until := q.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	since := q.Since
+	if since.IsZero() {
+		since = until.Add(-DefaultLookback)
+	}
+	if !since.Before(until) {
+		return nil, ErrInvalidRange
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	// the direction is the only part of the statement not bound as a parameter, and it's never user text
+	order := "DESC"
+	if q.Ascending {
+		order = "ASC"
+	}
+
+	params := clickhouse.Params{
+		"table":        c.table,
+		"namespace":    clickhouse.String(q.Namespace),
+		"app":          clickhouse.String(q.AppName),
+		"since":        clickhouse.Time(since),
+		"until":        clickhouse.Time(until),
+		"query":        clickhouse.String(q.Contains),
+		"min_severity": strconv.Itoa(klogmux.LevelRank(q.MinLevel) + 1),
+		"pod":          clickhouse.String(q.Pod),
+		"containers":   clickhouse.Array(q.Containers),
+		"grep":         clickhouse.String(q.Match),
+		"exclude":      clickhouse.String(q.Exclude),
+		"limit":        strconv.Itoa(limit),
+	}
+
+	entries := make([]Entry, 0, limit)
+	err := c.ch.Select(ctx, fmt.Sprintf(selectLogs, order), params, func(line []byte) error {
+		var row struct {
+			TimestampNano int64
+			Level         string
+			Body          string
+			PodName       string
+			Container     string
+		}
+		if err := json.Unmarshal(line, &row); err != nil {
+			return fmt.Errorf("failed to decode log row: %w", err)
+		}
+
+		entries = append(entries, Entry{
+			Timestamp: time.Unix(0, row.TimestampNano),
+			PodName:   row.PodName,
+			Container: row.Container,
+			Level:     row.Level,
+			Message:   row.Body,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,230 @@
+package logstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikumar1206/loco/api/pkg/clickhouse"
+	"github.com/nikumar1206/loco/api/pkg/klogmux"
+)
+
+// testURLEnv names the ClickHouse the integration tests write to, they're skipped when it's unset.
+// Any server works, e.g. docker run -p 8123:8123 clickhouse/clickhouse-server with
+// LOCO_TEST_CLICKHOUSE_URL=http://default:@localhost:8123/
+const testURLEnv = "LOCO_TEST_CLICKHOUSE_URL"
+
+// createLogsTable holds the columns of the otel collector's logs table that queries read
+const createLogsTable = `CREATE TABLE %s (
+    Timestamp DateTime64(9, 'UTC'),
+    SeverityText LowCardinality(String),
+    Body String,
+    ResourceAttributes Map(LowCardinality(String), String),
+    LogAttributes Map(LowCardinality(String), String)
+) ENGINE = MergeTree ORDER BY Timestamp`
+
+// exec runs a statement that changes the server, the client only runs read-only selects
+func exec(t *testing.T, rawURL, statement string) {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("invalid %s: %v", testURLEnv, err)
+	}
+	req, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(statement))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to reach clickhouse: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("statement failed with %d: %s", resp.StatusCode, body)
+	}
+}
+
+type row struct {
+	at        time.Time
+	severity  string
+	body      string
+	namespace string
+	app       string
+	pod       string
+	container string
+	stream    string
+}
+
+// newTestClient creates a table holding rows and a Client reading it, the table is dropped after the test
+func newTestClient(t *testing.T, rows []row) *Client {
+	t.Helper()
+	rawURL := os.Getenv(testURLEnv)
+	if rawURL == "" {
+		t.Skipf("%s is not set", testURLEnv)
+	}
+
+	table := fmt.Sprintf("loco_test_logs_%d", time.Now().UnixNano())
+	exec(t, rawURL, fmt.Sprintf(createLogsTable, table))
+	t.Cleanup(func() { exec(t, rawURL, "DROP TABLE IF EXISTS "+table) })
+
+	values := make([]string, len(rows))
+	for i, r := range rows {
+		values[i] = fmt.Sprintf("(%s, %s, %s, map('k8s.namespace.name', %s, 'app.loco.io/name', %s, 'k8s.pod.name', %s, 'k8s.container.name', %s), map('log.iostream', %s))",
+			quote(r.at.UTC().Format("2006-01-02 15:04:05.000000000")), quote(r.severity), quote(r.body),
+			quote(r.namespace), quote(r.app), quote(r.pod), quote(r.container), quote(r.stream))
+	}
+	exec(t, rawURL, fmt.Sprintf("INSERT INTO %s VALUES %s", table, strings.Join(values, ", ")))
+
+	ch, err := clickhouse.NewClient(rawURL, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("clickhouse.NewClient() error = %v", err)
+	}
+	c := NewClient(ch)
+	c.table = table
+	return c
+}
+
+func quote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func TestQuery(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+	web := func(seconds int, severity, body, pod, stream string) row {
+		return row{at: at(seconds), severity: severity, body: body, namespace: "wks-1-app-1", app: "web", pod: pod, container: "web", stream: stream}
+	}
+
+	c := newTestClient(t, []row{
+		web(1, "", "server started", "web-a", "stdout"),
+		web(2, "", `{"level":"debug","msg":"cache miss"}`, "web-a", "stdout"),
+		web(3, "", "level=warn msg=\"slow request\"", "web-b", "stdout"),
+		web(4, "", "[ERROR] connection refused", "web-b", "stdout"),
+		web(5, "", "panic: nil map", "web-a", "stderr"),
+		web(6, "fatal", "out of memory", "web-b", "stdout"),
+		{at: at(7), body: "someone else's app", namespace: "wks-1-app-2", app: "api", pod: "api-a", container: "api", stream: "stdout"},
+		{at: at(8), body: "same name, other workspace", namespace: "wks-2-app-3", app: "web", pod: "web-z", container: "web", stream: "stdout"},
+	})
+
+	scope := Query{Namespace: "wks-1-app-1", AppName: "web", Since: base, Until: at(60)}
+	tests := []struct {
+		name   string
+		modify func(q *Query)
+		want   []string
+	}{
+		{
+			name: "newest first",
+			want: []string{"out of memory", "panic: nil map", "[ERROR] connection refused", "level=warn msg=\"slow request\"", `{"level":"debug","msg":"cache miss"}`, "server started"},
+		},
+		{
+			name:   "ascending with limit",
+			modify: func(q *Query) { q.Ascending = true; q.Limit = 2 },
+			want:   []string{"server started", `{"level":"debug","msg":"cache miss"}`},
+		},
+		{
+			name:   "time range",
+			modify: func(q *Query) { q.Since = at(3); q.Until = at(5) },
+			want:   []string{"[ERROR] connection refused", "level=warn msg=\"slow request\""},
+		},
+		{
+			name:   "min level",
+			modify: func(q *Query) { q.MinLevel = klogmux.LevelError },
+			want:   []string{"out of memory", "panic: nil map", "[ERROR] connection refused"},
+		},
+		{
+			name:   "contains is case insensitive",
+			modify: func(q *Query) { q.Contains = "CONNECTION" },
+			want:   []string{"[ERROR] connection refused"},
+		},
+		{
+			name:   "pod regex",
+			modify: func(q *Query) { q.Pod = `-b$` },
+			want:   []string{"out of memory", "[ERROR] connection refused", "level=warn msg=\"slow request\""},
+		},
+		{
+			name:   "match and exclude",
+			modify: func(q *Query) { q.Match = `\b(memory|map)\b`; q.Exclude = `^panic` },
+			want:   []string{"out of memory"},
+		},
+		{
+			name:   "other container",
+			modify: func(q *Query) { q.Containers = []string{"sidecar"} },
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := scope
+			if tt.modify != nil {
+				tt.modify(&q)
+			}
+			entries, err := c.Query(context.Background(), q)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Message)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Query() messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryLevels(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	bodies := []struct {
+		severity string
+		body     string
+		stream   string
+		want     string
+	}{
+		{body: "plain line", stream: "stdout", want: klogmux.LevelInfo},
+		{body: "plain line on stderr", stream: "stderr", want: klogmux.LevelError},
+		{severity: "WARN", body: "from the sdk", stream: "stdout", want: klogmux.LevelWarn},
+		{body: `{"lvl":"trace","msg":"x"}`, stream: "stdout", want: klogmux.LevelDebug},
+		{body: "severity=critical disk full", stream: "stdout", want: klogmux.LevelFatal},
+		{body: "warning: deprecated flag", stream: "stderr", want: klogmux.LevelWarn},
+	}
+
+	rows := make([]row, len(bodies))
+	for i, b := range bodies {
+		rows[i] = row{at: base.Add(time.Duration(i) * time.Second), severity: b.severity, body: b.body, namespace: "wks-1-app-1", app: "web", pod: "web-a", container: "web", stream: b.stream}
+	}
+	c := newTestClient(t, rows)
+
+	entries, err := c.Query(context.Background(), Query{Namespace: "wks-1-app-1", AppName: "web", Since: base, Until: base.Add(time.Minute), Ascending: true})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(entries) != len(bodies) {
+		t.Fatalf("Query() returned %d entries, want %d", len(entries), len(bodies))
+	}
+	for i, e := range entries {
+		if e.Level != bodies[i].want {
+			t.Errorf("level of %q = %q, want %q", e.Message, e.Level, bodies[i].want)
+		}
+		if !e.Timestamp.Equal(rows[i].at) || e.PodName != "web-a" || e.Container != "web" {
+			t.Errorf("entry %d = %+v, want it at %s from web-a/web", i, e, rows[i].at)
+		}
+	}
+}
+
+func TestQueryInvalidRange(t *testing.T) {
+	c := NewClient(nil)
+	now := time.Now()
+	if _, err := c.Query(context.Background(), Query{Since: now, Until: now}); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Query() error = %v, want ErrInvalidRange", err)
+	}
+}
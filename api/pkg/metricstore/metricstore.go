@@ -0,0 +1,420 @@
+// Package metricstore queries app metrics shipped to ClickHouse by the OpenTelemetry collector:
+// pod usage from the kubeletstats receiver and request stats scraped from the gateway's Envoy proxies.
+package metricstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/nikumar1206/loco/api/pkg/clickhouse"
+)
+
+// Series names
+const (
+	CPU         = "cpu_millicores"
+	Memory      = "memory_bytes"
+	Restarts    = "restarts"
+	RequestRate = "requests_per_second"
+	LatencyP95  = "latency_p95_ms"
+)
+
+const (
+	// DefaultLookback is how far back a query without Since reaches
+	DefaultLookback = time.Hour
+	// MaxPoints bounds the points in a series, the step is widened to stay under it
+	MaxPoints = 500
+	// MinStep matches the collector's default scrape interval, finer buckets would be mostly empty
+	MinStep = 30 * time.Second
+)
+
+var ErrInvalidRange = errors.New("since must be before until")
+
+// selectPodUsage reads the kubeletstats receiver's pod gauges. CPU is reported in cores.
+const selectPodUsage = `SELECT
+    toUnixTimestamp(toStartOfInterval(TimeUnix, toIntervalSecond({step:UInt32}))) AS Bucket,
+    ResourceAttributes['k8s.pod.name'] AS PodName,
+    MetricName,
+    if(MetricName = 'k8s.container.restarts', max(Value), avg(Value)) AS Value
+FROM otel_metrics_gauge
+WHERE ResourceAttributes['k8s.namespace.name'] = {namespace:String}
+    AND MetricName IN ('k8s.pod.cpu.usage', 'k8s.pod.memory.working_set', 'k8s.container.restarts')
+    AND TimeUnix >= {since:DateTime64(9, 'UTC')}
+    AND TimeUnix < {until:DateTime64(9, 'UTC')}
+GROUP BY Bucket, PodName, MetricName
+ORDER BY Bucket
+FORMAT JSONEachRow`
+
+// selectRequests reads Envoy's upstream request counter for the app's routes.
+// Source is one proxy's counter for one route rule, each is a separate cumulative series.
+const selectRequests = `SELECT
+    toUnixTimestamp(toStartOfInterval(TimeUnix, toIntervalSecond({step:UInt32}))) AS Bucket,
+    concat(ResourceAttributes['k8s.pod.name'], '/', Attributes['envoy.cluster_name']) AS Source,
+    max(Value) AS Value
+FROM otel_metrics_sum
+WHERE ServiceName = 'envoy'
+    AND MetricName = 'cluster.upstream_rq_total'
+    AND startsWith(Attributes['envoy.cluster_name'], {route_prefix:String})
+    AND TimeUnix >= {since:DateTime64(9, 'UTC')}
+    AND TimeUnix < {until:DateTime64(9, 'UTC')}
+GROUP BY Bucket, Source
+ORDER BY Source, Bucket
+FORMAT JSONEachRow`
+
+// selectLatency reads the last cumulative histogram of Envoy's upstream request time in each bucket
+const selectLatency = `SELECT
+    toUnixTimestamp(toStartOfInterval(TimeUnix, toIntervalSecond({step:UInt32}))) AS Bucket,
+    concat(ResourceAttributes['k8s.pod.name'], '/', Attributes['envoy.cluster_name']) AS Source,
+    argMax(BucketCounts, TimeUnix) AS BucketCounts,
+    argMax(ExplicitBounds, TimeUnix) AS ExplicitBounds
+FROM otel_metrics_histogram
+WHERE ServiceName = 'envoy'
+    AND MetricName = 'cluster.upstream_rq_time'
+    AND startsWith(Attributes['envoy.cluster_name'], {route_prefix:String})
+    AND TimeUnix >= {since:DateTime64(9, 'UTC')}
+    AND TimeUnix < {until:DateTime64(9, 'UTC')}
+GROUP BY Bucket, Source
+ORDER BY Source, Bucket
+FORMAT JSONEachRow`
+
+// selectRequestTotal sums how much each source's request counter grew in the range. A proxy restart
+// resets its counter, so the growth can be undercounted, but never to zero for a route in use.
+const selectRequestTotal = `SELECT
+    count() AS Sources,
+    sum(Increase) AS Requests
+FROM (
+    SELECT max(Value) - min(Value) AS Increase
+    FROM otel_metrics_sum
+    WHERE ServiceName = 'envoy'
+        AND MetricName = 'cluster.upstream_rq_total'
+        AND startsWith(Attributes['envoy.cluster_name'], {route_prefix:String})
+        AND TimeUnix >= {since:DateTime64(9, 'UTC')}
+        AND TimeUnix < {until:DateTime64(9, 'UTC')}
+    GROUP BY ResourceAttributes['k8s.pod.name'], Attributes['envoy.cluster_name']
+)
+FORMAT JSONEachRow`
+
+// Query selects one app's metrics
+type Query struct {
+	Namespace string
+	// RouteName is the app's HTTPRoute, Envoy Gateway names its clusters httproute/<namespace>/<name>/rule/<n>
+	RouteName string
+	Hostname  string
+	Since     time.Time
+	Until     time.Time
+	// Step is the bucket width, zero picks one from the range
+	Step time.Duration
+}
+
+// Point is a bucket's value, stamped with the bucket's start
+type Point struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// Series is one metric over time. Pod is empty for app wide series,
+// Hostname is only set on the request series.
+type Series struct {
+	Name     string
+	Pod      string
+	Hostname string
+	Points   []Point
+}
+
+// Result holds a query's series and the step they were bucketed by
+type Result struct {
+	Step   time.Duration
+	Series []Series
+}
+
+// Client reads metrics from ClickHouse
+type Client struct {
+	ch *clickhouse.Client
+}
+
+// NewClient creates a Client
+func NewClient(ch *clickhouse.Client) *Client {
+	return &Client{ch: ch}
+}
+
+// Query returns per pod usage series, their app wide totals, and the request rate and p95 latency of the app's hostname
+func (c *Client) Query(ctx context.Context, q Query) (*Result, error) {
+	until := q.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	since := q.Since
+	if since.IsZero() {
+		since = until.Add(-DefaultLookback)
+	}
+	if !since.Before(until) {
+		return nil, ErrInvalidRange
+	}
+
+	step := max(q.Step, MinStep, until.Sub(since)/MaxPoints).Truncate(time.Second)
+	params := clickhouse.Params{
+		"namespace":    clickhouse.String(q.Namespace),
+		"route_prefix": clickhouse.String(fmt.Sprintf("httproute/%s/%s/", q.Namespace, q.RouteName)),
+		"since":        clickhouse.Time(since),
+		"until":        clickhouse.Time(until),
+		"step":         strconv.Itoa(int(step.Seconds())),
+	}
+
+	usage, err := c.podUsage(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := c.requestRate(ctx, params, step)
+	if err != nil {
+		return nil, err
+	}
+	latency, err := c.latencyP95(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Step: step, Series: usage}
+	result.Series = append(result.Series, totals(usage)...)
+	result.Series = append(result.Series,
+		Series{Name: RequestRate, Hostname: q.Hostname, Points: requests},
+		Series{Name: LatencyP95, Hostname: q.Hostname, Points: latency},
+	)
+	return result, nil
+}
+
+// RequestCount returns about how many requests an app's HTTPRoute served between since and until.
+// found is false when the gateway reported nothing for the route, which doesn't mean it was idle.
+func (c *Client) RequestCount(ctx context.Context, namespace, routeName string, since, until time.Time) (count float64, found bool, err error) {
+	params := clickhouse.Params{
+		"route_prefix": clickhouse.String(fmt.Sprintf("httproute/%s/%s/", namespace, routeName)),
+		"since":        clickhouse.Time(since),
+		"until":        clickhouse.Time(until),
+	}
+
+	err = c.ch.Select(ctx, selectRequestTotal, params, func(line []byte) error {
+		var row struct {
+			Sources  int64 `json:",string"`
+			Requests float64
+		}
+		if err := json.Unmarshal(line, &row); err != nil {
+			return fmt.Errorf("failed to decode request total row: %w", err)
+		}
+		count, found = row.Requests, row.Sources > 0
+		return nil
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to query request count: %w", err)
+	}
+	return count, found, nil
+}
+
+// podUsage returns a series per pod and metric, with CPU converted to millicores
+func (c *Client) podUsage(ctx context.Context, params clickhouse.Params) ([]Series, error) {
+	names := map[string]string{
+		"k8s.pod.cpu.usage":          CPU,
+		"k8s.pod.memory.working_set": Memory,
+		"k8s.container.restarts":     Restarts,
+	}
+
+	index := map[[2]string]int{}
+	var series []Series
+	err := c.ch.Select(ctx, selectPodUsage, params, func(line []byte) error {
+		var row struct {
+			Bucket     int64
+			PodName    string
+			MetricName string
+			Value      float64
+		}
+		if err := json.Unmarshal(line, &row); err != nil {
+			return fmt.Errorf("failed to decode usage row: %w", err)
+		}
+
+		name := names[row.MetricName]
+		if name == CPU {
+			row.Value *= 1000
+		}
+		key := [2]string{row.PodName, name}
+		i, ok := index[key]
+		if !ok {
+			i = len(series)
+			index[key] = i
+			series = append(series, Series{Name: name, Pod: row.PodName})
+		}
+		series[i].Points = append(series[i].Points, Point{Timestamp: time.Unix(row.Bucket, 0), Value: row.Value})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pod usage: %w", err)
+	}
+	return series, nil
+}
+
+// totals sums the pod series of each metric into an app wide series
+func totals(podSeries []Series) []Series {
+	sums := map[string]map[int64]float64{}
+	for _, s := range podSeries {
+		if sums[s.Name] == nil {
+			sums[s.Name] = map[int64]float64{}
+		}
+		for _, p := range s.Points {
+			sums[s.Name][p.Timestamp.Unix()] += p.Value
+		}
+	}
+
+	var series []Series
+	for _, name := range []string{CPU, Memory, Restarts} {
+		if sums[name] != nil {
+			series = append(series, Series{Name: name, Points: sortedPoints(sums[name])})
+		}
+	}
+	return series
+}
+
+// requestRate turns Envoy's cumulative counters into requests per second. A counter that went
+// down was reset by a proxy restart, so its whole value counts as new requests.
+func (c *Client) requestRate(ctx context.Context, params clickhouse.Params, step time.Duration) ([]Point, error) {
+	rates := map[int64]float64{}
+	var source string
+	var last float64
+	err := c.ch.Select(ctx, selectRequests, params, func(line []byte) error {
+		var row struct {
+			Bucket int64
+			Source string
+			Value  float64
+		}
+		if err := json.Unmarshal(line, &row); err != nil {
+			return fmt.Errorf("failed to decode request row: %w", err)
+		}
+
+		// the first bucket of a source has nothing to diff against
+		if row.Source != source {
+			source, last = row.Source, row.Value
+			return nil
+		}
+		delta := row.Value - last
+		if delta < 0 {
+			delta = row.Value
+		}
+		last = row.Value
+		rates[row.Bucket] += delta / step.Seconds()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query request rate: %w", err)
+	}
+	return sortedPoints(rates), nil
+}
+
+// latencyP95 diffs consecutive cumulative histograms of each source, merges the sources' buckets
+// and interpolates the 95th percentile in milliseconds
+func (c *Client) latencyP95(ctx context.Context, params clickhouse.Params) ([]Point, error) {
+	type histogram struct {
+		bounds []float64
+		counts []float64
+	}
+	merged := map[int64]*histogram{}
+
+	var source string
+	var last []float64
+	err := c.ch.Select(ctx, selectLatency, params, func(line []byte) error {
+		var row struct {
+			Bucket         int64
+			Source         string
+			BucketCounts   []float64
+			ExplicitBounds []float64
+		}
+		if err := json.Unmarshal(line, &row); err != nil {
+			return fmt.Errorf("failed to decode latency row: %w", err)
+		}
+
+		if row.Source != source || len(last) != len(row.BucketCounts) {
+			source, last = row.Source, row.BucketCounts
+			return nil
+		}
+		delta := make([]float64, len(row.BucketCounts))
+		for i := range delta {
+			delta[i] = row.BucketCounts[i] - last[i]
+			if delta[i] < 0 {
+				delta = slices.Clone(row.BucketCounts)
+				break
+			}
+		}
+		last = row.BucketCounts
+
+		h, ok := merged[row.Bucket]
+		if !ok {
+			merged[row.Bucket] = &histogram{bounds: row.ExplicitBounds, counts: delta}
+			return nil
+		}
+		// Envoy uses the same bounds on every proxy, anything else can't be merged
+		if slices.Equal(h.bounds, row.ExplicitBounds) {
+			for i := range h.counts {
+				h.counts[i] += delta[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latency: %w", err)
+	}
+
+	values := make(map[int64]float64, len(merged))
+	for bucket, h := range merged {
+		if v, ok := quantile(0.95, h.bounds, h.counts); ok {
+			values[bucket] = v
+		}
+	}
+	return sortedPoints(values), nil
+}
+
+// quantile interpolates within the bucket holding the q-th observation, like Prometheus' histogram_quantile.
+// counts has one more entry than bounds, for observations above the last bound.
+func quantile(q float64, bounds, counts []float64) (float64, bool) {
+	if len(bounds) == 0 || len(counts) != len(bounds)+1 {
+		return 0, false
+	}
+	var total float64
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 {
+		return 0, false
+	}
+
+	rank := q * total
+	var seen float64
+	for i, c := range counts {
+		if seen+c < rank {
+			seen += c
+			continue
+		}
+		if i == len(bounds) {
+			// the overflow bucket has no upper bound, report the highest known one
+			return bounds[len(bounds)-1], true
+		}
+		lower := 0.0
+		if i > 0 {
+			lower = bounds[i-1]
+		}
+		return lower + (bounds[i]-lower)*(rank-seen)/c, true
+	}
+	return bounds[len(bounds)-1], true
+}
+
+func sortedPoints(values map[int64]float64) []Point {
+	buckets := make([]int64, 0, len(values))
+	for b := range values {
+		buckets = append(buckets, b)
+	}
+	slices.Sort(buckets)
+
+	points := make([]Point, len(buckets))
+	for i, b := range buckets {
+		points[i] = Point{Timestamp: time.Unix(b, 0), Value: values[b]}
+	}
+	return points
+}
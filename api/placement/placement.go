@@ -0,0 +1,103 @@
+package placement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+)
+
+var (
+	ErrNoCluster          = errors.New("no cluster available")
+	ErrClusterUnavailable = errors.New("workspace cluster is unavailable")
+)
+
+// Scheduler picks the cluster a new app runs on
+type Scheduler struct {
+	queries *genDb.Queries
+	pool    *kube.Pool
+}
+
+// NewScheduler creates a Scheduler
+func NewScheduler(queries *genDb.Queries, pool *kube.Pool) *Scheduler {
+	return &Scheduler{queries: queries, pool: pool}
+}
+
+// Place returns the cluster for a new app in the workspace.
+// Every app in a workspace shares a cluster, so once a workspace has apps their cluster is reused
+// and region is ignored. Otherwise the schedulable cluster in region with the most free capacity wins.
+// An empty region matches every cluster.
+func (s *Scheduler) Place(ctx context.Context, workspaceID int64, region string) (genDb.Cluster, error) {
+	clusterID, err := s.queries.GetWorkspaceClusterID(ctx, workspaceID)
+	if err == nil {
+		cluster, err := s.queries.GetClusterByID(ctx, clusterID)
+		if err != nil {
+			return genDb.Cluster{}, err
+		}
+		if !Schedulable(cluster) {
+			return genDb.Cluster{}, fmt.Errorf("%w: cluster %s is %s", ErrClusterUnavailable, cluster.Name, status(cluster))
+		}
+		return cluster, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return genDb.Cluster{}, err
+	}
+
+	clusters, err := s.queries.ListActiveClusters(ctx)
+	if err != nil {
+		return genDb.Cluster{}, err
+	}
+
+	var best genDb.Cluster
+	var bestFree int64 = -1
+	for _, cluster := range clusters {
+		if !Schedulable(cluster) || (region != "" && cluster.Region != region) {
+			continue
+		}
+		free, err := s.freeCapacity(ctx, cluster.ID)
+		if err != nil {
+			slog.WarnContext(ctx, "skipping cluster, capacity unknown", "cluster_id", cluster.ID, "error", err)
+			continue
+		}
+		if free > bestFree {
+			best, bestFree = cluster, free
+		}
+	}
+
+	if bestFree < 0 {
+		if region != "" {
+			return genDb.Cluster{}, fmt.Errorf("%w in region %s", ErrNoCluster, region)
+		}
+		return genDb.Cluster{}, ErrNoCluster
+	}
+	return best, nil
+}
+
+// freeCapacity scores a cluster by its free cpu, in millicores
+func (s *Scheduler) freeCapacity(ctx context.Context, clusterID int64) (int64, error) {
+	kc, err := s.pool.Get(ctx, clusterID)
+	if err != nil {
+		return 0, err
+	}
+	capacity, err := kc.ClusterCapacity(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return capacity.FreeCPU(), nil
+}
+
+// Schedulable reports whether a cluster can take new apps. Degraded clusters still can, unhealthy ones can't.
+func Schedulable(cluster genDb.Cluster) bool {
+	return cluster.IsActive.Bool && cluster.HealthStatus.String != kube.HealthUnhealthy
+}
+
+func status(cluster genDb.Cluster) string {
+	if !cluster.IsActive.Bool {
+		return "inactive"
+	}
+	return cluster.HealthStatus.String
+}
@@ -11,11 +11,24 @@ SELECT * FROM apps WHERE id = $1;
 -- name: GetAppByNameAndWorkspace :one
 SELECT * FROM apps WHERE workspace_id = $1 AND name = $2;
 
+-- name: ListAppsForCluster :many
+SELECT * FROM apps
+WHERE cluster_id = $1
+ORDER BY id;
+
 -- name: ListAppsForWorkspace :many
 SELECT * FROM apps
 WHERE workspace_id = $1
 ORDER BY created_at DESC;
 
+-- name: MoveAppToCluster :exec
+WITH moved AS (
+    UPDATE apps SET cluster_id = $2, updated_at = NOW() WHERE apps.id = $1
+)
+UPDATE deployments
+SET cluster_id = $2, updated_at = NOW()
+WHERE app_id = $1 AND is_current = true;
+
 -- name: UpdateApp :one
 UPDATE apps
 SET name = COALESCE(sqlc.narg('name'), name),
@@ -29,9 +42,10 @@ RETURNING *;
 DELETE FROM apps WHERE id = $1;
 
 -- name: CheckSubdomainAvailability :one
+-- a subdomain is available to the workspace that already owns it
 SELECT COUNT(*) = 0 AS available
-FROM apps
-WHERE subdomain = $1 AND domain = $2;
+FROM hostnames
+WHERE subdomain = $1 AND domain = $2 AND workspace_id <> $3;
 
 -- name: GetClusterDetails :one
 SELECT id, is_active, health_status
@@ -0,0 +1,40 @@
+-- Audit queries
+
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (
+    actor_id, actor, procedure, org_id, workspace_id, app_id, resource_type, resource_id,
+    request, before, after, success, error, request_id, source_ip
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15);
+
+-- name: StartAuditEvent :one
+-- Records a long streaming call as it starts, FinishAuditEvent fills in how it ended.
+INSERT INTO audit_events (
+    actor_id, actor, procedure, org_id, workspace_id, app_id, resource_type, resource_id,
+    request, before, after, success, error, request_id, source_ip
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+RETURNING id;
+
+-- name: FinishAuditEvent :exec
+UPDATE audit_events
+SET request = $2, after = $3, success = $4, error = $5
+WHERE id = $1;
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+WHERE workspace_id = sqlc.arg('workspace_id')::BIGINT
+  AND (sqlc.narg('app_id')::BIGINT IS NULL OR app_id = sqlc.narg('app_id'))
+  AND (sqlc.narg('actor_id')::BIGINT IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('since')::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg('since'))
+  AND (sqlc.narg('until')::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg('until'))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('row_limit') OFFSET sqlc.arg('row_offset');
+
+-- name: CountAuditEvents :one
+SELECT COUNT(*) FROM audit_events
+WHERE workspace_id = sqlc.arg('workspace_id')::BIGINT
+  AND (sqlc.narg('app_id')::BIGINT IS NULL OR app_id = sqlc.narg('app_id'))
+  AND (sqlc.narg('actor_id')::BIGINT IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('since')::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg('since'))
+  AND (sqlc.narg('until')::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg('until'));
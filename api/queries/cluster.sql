@@ -0,0 +1,45 @@
+-- Cluster queries
+
+-- name: CreateCluster :one
+INSERT INTO clusters (name, region, provider, endpoint, credentials, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetClusterByID :one
+SELECT * FROM clusters WHERE id = $1;
+
+-- name: ListClusters :many
+SELECT * FROM clusters ORDER BY id;
+
+-- name: ListActiveClusters :many
+SELECT * FROM clusters WHERE is_active = true ORDER BY id;
+
+-- name: DeactivateCluster :one
+UPDATE clusters
+SET is_active = false, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetClusterCredentials :one
+SELECT credentials FROM clusters WHERE id = $1;
+
+-- name: GetWorkspaceClusterID :one
+SELECT cluster_id FROM apps WHERE workspace_id = $1 ORDER BY id LIMIT 1;
+
+-- name: UpdateClusterHealth :exec
+UPDATE clusters
+SET health_status = $2, last_health_check = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CreateClusterHealthCheck :exec
+INSERT INTO cluster_health_checks (cluster_id, status, checks, checked_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListClusterHealthChecks :many
+SELECT * FROM cluster_health_checks
+WHERE cluster_id = $1
+ORDER BY checked_at DESC
+LIMIT $2;
+
+-- name: DeleteClusterHealthChecksBefore :exec
+DELETE FROM cluster_health_checks WHERE checked_at < $1;
@@ -8,6 +8,9 @@ RETURNING *;
 -- name: GetDeploymentByID :one
 SELECT * FROM deployments WHERE id = $1;
 
+-- name: GetCurrentDeploymentForApp :one
+SELECT * FROM deployments WHERE app_id = $1 AND is_current = true;
+
 -- name: ListDeploymentsForApp :many
 SELECT * FROM deployments
 WHERE app_id = $1
@@ -22,6 +25,11 @@ UPDATE deployments
 SET is_current = false, updated_at = NOW()
 WHERE app_id = $1 AND is_current = true;
 
+-- name: MarkDeploymentCurrent :exec
+UPDATE deployments
+SET is_current = true, updated_at = NOW()
+WHERE id = $1;
+
 -- name: GetDeploymentAppID :one
 SELECT app_id FROM deployments WHERE id = $1;
 
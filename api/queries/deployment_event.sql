@@ -0,0 +1,13 @@
+-- Deployment event queries
+
+-- name: CreateDeploymentEvent :exec
+INSERT INTO deployment_events (deployment_id, reason, message, pod_name, created_by)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListDeploymentEventsForApp :many
+-- the newest events of every deployment of the app
+SELECT e.* FROM deployment_events e
+JOIN deployments d ON d.id = e.deployment_id
+WHERE d.app_id = $1
+ORDER BY e.created_at DESC
+LIMIT $2;
@@ -0,0 +1,41 @@
+-- App domain queries
+
+-- name: CreateAppDomain :one
+INSERT INTO app_domains (app_id, hostname, verification_token, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetAppDomainForApp :one
+SELECT * FROM app_domains WHERE app_id = $1 AND hostname = $2;
+
+-- name: GetVerifiedAppDomainByHostname :one
+SELECT * FROM app_domains WHERE hostname = $1 AND verification_status = 'verified';
+
+-- name: ListAppDomains :many
+SELECT * FROM app_domains WHERE app_id = $1 ORDER BY hostname;
+
+-- name: ListVerifiedHostnamesForApp :many
+SELECT hostname FROM app_domains
+WHERE app_id = $1 AND verification_status = 'verified'
+ORDER BY hostname;
+
+-- name: UpdateAppDomainVerification :one
+UPDATE app_domains
+SET verification_status = $2,
+    verification_message = $3,
+    verified_at = CASE WHEN $2 = 'verified' THEN NOW() ELSE verified_at END,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateAppDomainCertificate :exec
+UPDATE app_domains
+SET certificate_status = $2, certificate_message = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteAppDomain :exec
+DELETE FROM app_domains WHERE id = $1;
+
+-- name: DeleteUnverifiedAppDomains :exec
+-- Drops the other claims on a hostname once an app has verified it.
+DELETE FROM app_domains WHERE hostname = $1 AND verification_status != 'verified';
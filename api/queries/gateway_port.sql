@@ -0,0 +1,17 @@
+-- Gateway port queries
+
+-- name: ListGatewayPortsForApp :many
+SELECT * FROM gateway_ports WHERE app_id = $1 ORDER BY name;
+
+-- name: AllocateGatewayPort :one
+-- takes the lowest free port in the range, returns no rows when the range is exhausted
+INSERT INTO gateway_ports (app_id, name, protocol, port)
+SELECT $1, $2, $3, p
+FROM generate_series(sqlc.arg(min_port)::int, sqlc.arg(max_port)::int) AS p
+WHERE NOT EXISTS (SELECT 1 FROM gateway_ports g WHERE g.port = p)
+ORDER BY p
+LIMIT 1
+RETURNING *;
+
+-- name: DeleteGatewayPort :exec
+DELETE FROM gateway_ports WHERE id = $1;
@@ -0,0 +1,45 @@
+-- Quota queries
+
+-- name: GetOrgQuota :one
+SELECT * FROM org_quotas WHERE org_id = $1;
+
+-- name: GetWorkspaceQuota :one
+SELECT * FROM workspace_quotas WHERE workspace_id = $1;
+
+-- name: UpsertWorkspaceQuota :one
+INSERT INTO workspace_quotas (workspace_id, max_apps, max_cpu_millicores, max_memory_mb, max_replicas, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    max_apps = EXCLUDED.max_apps,
+    max_cpu_millicores = EXCLUDED.max_cpu_millicores,
+    max_memory_mb = EXCLUDED.max_memory_mb,
+    max_replicas = EXCLUDED.max_replicas,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: CountAppsInOrg :one
+SELECT COUNT(*) FROM apps a
+JOIN workspaces w ON w.id = a.workspace_id
+WHERE w.org_id = $1;
+
+-- name: ListActiveDeploymentsForWorkspace :many
+-- deployments still rolling out count too, they replace the current one once they're done
+SELECT d.app_id, d.replicas, d.config
+FROM deployments d
+JOIN apps a ON a.id = d.app_id
+WHERE a.workspace_id = $1 AND (d.is_current = true OR d.status IN ('pending', 'in_progress'));
+
+-- name: ListActiveDeploymentsForOrg :many
+SELECT d.app_id, d.replicas, d.config
+FROM deployments d
+JOIN apps a ON a.id = d.app_id
+JOIN workspaces w ON w.id = a.workspace_id
+WHERE w.org_id = $1 AND (d.is_current = true OR d.status IN ('pending', 'in_progress'));
+
+-- name: LockWorkspaceQuota :exec
+-- serializes quota checks of a workspace and its org until the transaction that creates what was checked ends
+SELECT w.id FROM workspaces w
+JOIN organizations o ON o.id = w.org_id
+WHERE w.id = $1
+FOR UPDATE OF w, o;
@@ -0,0 +1,12 @@
+-- Release log queries
+
+-- name: CreateReleaseLog :exec
+INSERT INTO release_logs (deployment_id, line, logged_at)
+VALUES ($1, $2, $3);
+
+-- name: ListReleaseLogsAfter :many
+-- the lines logged after the one with id after_id, 0 for all of them
+SELECT * FROM release_logs
+WHERE deployment_id = $1 AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT 500;
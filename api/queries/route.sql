@@ -0,0 +1,30 @@
+-- Hostname and route claim queries
+
+-- name: ReserveHostname :one
+-- returns the existing row when the hostname is already reserved, check its workspace_id
+INSERT INTO hostnames (workspace_id, subdomain, domain, created_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (subdomain, domain) DO UPDATE SET subdomain = EXCLUDED.subdomain
+RETURNING *;
+
+-- name: ReleaseHostname :exec
+DELETE FROM hostnames h
+WHERE h.subdomain = $1 AND h.domain = $2
+  AND NOT EXISTS (SELECT 1 FROM apps a WHERE a.subdomain = h.subdomain AND a.domain = h.domain);
+
+-- name: ListRouteClaimsForHostname :many
+SELECT rc.app_id, rc.path_prefix, a.name AS app_name
+FROM route_claims rc
+JOIN apps a ON a.id = rc.app_id
+WHERE rc.hostname_id = $1
+ORDER BY length(rc.path_prefix) DESC, rc.path_prefix;
+
+-- name: ClaimRoute :one
+INSERT INTO route_claims (hostname_id, app_id, path_prefix)
+VALUES ($1, $2, $3)
+ON CONFLICT (app_id) DO UPDATE
+SET hostname_id = EXCLUDED.hostname_id, path_prefix = EXCLUDED.path_prefix, updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteRouteClaimForApp :exec
+DELETE FROM route_claims WHERE app_id = $1;
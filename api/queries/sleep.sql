@@ -0,0 +1,14 @@
+-- App sleep queries
+
+-- name: GetAppSleep :one
+SELECT * FROM app_sleep WHERE app_id = $1;
+
+-- name: MarkAppSleeping :exec
+INSERT INTO app_sleep (app_id, slept_at)
+VALUES ($1, NOW())
+ON CONFLICT (app_id) DO UPDATE SET slept_at = NOW();
+
+-- name: MarkAppAwake :exec
+INSERT INTO app_sleep (app_id, woke_at)
+VALUES ($1, NOW())
+ON CONFLICT (app_id) DO UPDATE SET slept_at = NULL, woke_at = NOW();
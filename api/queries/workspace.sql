@@ -61,9 +61,8 @@ SELECT workspace_id, user_id, role, created_at
 FROM workspace_members
 WHERE workspace_id = $1;
 
--- TODO: Uncomment when apps table exists
--- -- name: CountAppsInWorkspace :one
--- SELECT COUNT(*) FROM apps WHERE workspace_id = $1;
+-- name: CountAppsInWorkspace :one
+SELECT COUNT(*) FROM apps WHERE workspace_id = $1;
 
 -- name: GetWorkspaceOrgID :one
 SELECT org_id FROM workspaces WHERE id = $1;
@@ -0,0 +1,405 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	ErrQuotaExceeded   = errors.New("quota exceeded")
+	ErrInvalidResource = errors.New("invalid resource quantity")
+)
+
+const mebibyte = 1024 * 1024
+
+// Limits caps what a tenant can run. CPU is in millicores and memory in MiB. MaxReplicas caps a single app.
+type Limits struct {
+	MaxApps     int32
+	MaxCPU      int64
+	MaxMemory   int64
+	MaxReplicas int32
+}
+
+// Usage is what a tenant's apps currently request, summed over the current or rolling out deployment of each app
+type Usage struct {
+	Apps   int64
+	CPU    int64
+	Memory int64
+}
+
+// Footprint is what a single deployment requests across all of its replicas
+type Footprint struct {
+	Replicas int32
+	CPU      int64
+	Memory   int64
+}
+
+// Shape is what a deployment asks for. CPU and memory are per replica, empty values fall back to the kube defaults.
+type Shape struct {
+	Replicas int32
+	// MaxReplicas is the most replicas the app may be scaled to, Replicas when lower
+	MaxReplicas int32
+	CPU         string
+	Memory      string
+	// Release runs a pod of the new image next to the app's current ones before the deployment rolls out
+	Release bool
+}
+
+// ShapeOf reads the shape of a stored deployment config
+func ShapeOf(replicas int32, config []byte) Shape {
+	var cfg struct {
+		Resources struct {
+			CPU         string `json:"cpu"`
+			Memory      string `json:"memory"`
+			MaxReplicas int32  `json:"max_replicas"`
+		} `json:"resources"`
+		Deploy struct {
+			Release string `json:"release"`
+		} `json:"deploy"`
+	}
+	_ = json.Unmarshal(config, &cfg)
+
+	return Shape{
+		Replicas:    replicas,
+		MaxReplicas: cfg.Resources.MaxReplicas,
+		CPU:         cfg.Resources.CPU,
+		Memory:      cfg.Resources.Memory,
+		Release:     cfg.Deploy.Release != "",
+	}
+}
+
+// replicas is what the shape counts towards the replica quota
+func (s Shape) replicas() int32 {
+	return max(s.Replicas, s.MaxReplicas)
+}
+
+// DefaultOrgLimits apply to orgs without a row in org_quotas
+var DefaultOrgLimits = Limits{
+	MaxApps:     25,
+	MaxCPU:      16000,
+	MaxMemory:   32768,
+	MaxReplicas: 3,
+}
+
+// DefaultWorkspaceLimits apply to workspaces without a row in workspace_quotas
+var DefaultWorkspaceLimits = Limits{
+	MaxApps:     10,
+	MaxCPU:      4000,
+	MaxMemory:   8192,
+	MaxReplicas: 3,
+}
+
+// Report is a workspace's and its org's limits alongside their current usage
+type Report struct {
+	WorkspaceID    int64
+	OrgID          int64
+	Workspace      Limits
+	WorkspaceUsage Usage
+	Org            Limits
+	OrgUsage       Usage
+}
+
+// Enforcer checks requests against org and workspace quotas
+type Enforcer struct {
+	queries *genDb.Queries
+}
+
+// NewEnforcer creates an Enforcer
+func NewEnforcer(queries *genDb.Queries) *Enforcer {
+	return &Enforcer{queries: queries}
+}
+
+// WithTx returns an Enforcer that checks within tx. Checks lock the workspace and its org until tx ends,
+// so running them in the transaction that creates the app or deployment keeps concurrent requests from both
+// fitting in what's left.
+func (e *Enforcer) WithTx(tx pgx.Tx) *Enforcer {
+	return &Enforcer{queries: e.queries.WithTx(tx)}
+}
+
+// OrgLimits returns the org's limits, falling back to the defaults
+func (e *Enforcer) OrgLimits(ctx context.Context, orgID int64) (Limits, error) {
+	q, err := e.queries.GetOrgQuota(ctx, orgID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DefaultOrgLimits, nil
+	}
+	if err != nil {
+		return Limits{}, err
+	}
+	return Limits{
+		MaxApps:     q.MaxApps,
+		MaxCPU:      q.MaxCpuMillicores,
+		MaxMemory:   q.MaxMemoryMb,
+		MaxReplicas: q.MaxReplicas,
+	}, nil
+}
+
+// WorkspaceLimits returns the workspace's own limits, falling back to the defaults.
+// These are not capped by the org; see Effective for that.
+func (e *Enforcer) WorkspaceLimits(ctx context.Context, workspaceID int64) (Limits, error) {
+	q, err := e.queries.GetWorkspaceQuota(ctx, workspaceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DefaultWorkspaceLimits, nil
+	}
+	if err != nil {
+		return Limits{}, err
+	}
+	return Limits{
+		MaxApps:     q.MaxApps,
+		MaxCPU:      q.MaxCpuMillicores,
+		MaxMemory:   q.MaxMemoryMb,
+		MaxReplicas: q.MaxReplicas,
+	}, nil
+}
+
+// Effective returns the limits that actually apply to a workspace, the tighter of its own and its org's
+func (e *Enforcer) Effective(ctx context.Context, workspaceID int64) (Limits, error) {
+	report, err := e.Report(ctx, workspaceID)
+	if err != nil {
+		return Limits{}, err
+	}
+	return Limits{
+		MaxApps:     min(report.Workspace.MaxApps, report.Org.MaxApps),
+		MaxCPU:      min(report.Workspace.MaxCPU, report.Org.MaxCPU),
+		MaxMemory:   min(report.Workspace.MaxMemory, report.Org.MaxMemory),
+		MaxReplicas: min(report.Workspace.MaxReplicas, report.Org.MaxReplicas),
+	}, nil
+}
+
+// Report loads the limits and usage of a workspace and its org
+func (e *Enforcer) Report(ctx context.Context, workspaceID int64) (Report, error) {
+	orgID, err := e.queries.GetWorkspaceOrgID(ctx, workspaceID)
+	if err != nil {
+		return Report{}, err
+	}
+	report := Report{WorkspaceID: workspaceID, OrgID: orgID}
+
+	if report.Workspace, err = e.WorkspaceLimits(ctx, workspaceID); err != nil {
+		return Report{}, err
+	}
+	if report.Org, err = e.OrgLimits(ctx, orgID); err != nil {
+		return Report{}, err
+	}
+
+	if report.WorkspaceUsage.Apps, err = e.queries.CountAppsInWorkspace(ctx, workspaceID); err != nil {
+		return Report{}, err
+	}
+	workspaceDeployments, err := e.queries.ListActiveDeploymentsForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return Report{}, err
+	}
+	workspaceApps := map[int64]Footprint{}
+	for _, d := range workspaceDeployments {
+		addDeployment(workspaceApps, d.AppID, footprintOf(d.Replicas, d.Config))
+	}
+	report.WorkspaceUsage.addApps(workspaceApps)
+
+	if report.OrgUsage.Apps, err = e.queries.CountAppsInOrg(ctx, orgID); err != nil {
+		return Report{}, err
+	}
+	orgDeployments, err := e.queries.ListActiveDeploymentsForOrg(ctx, orgID)
+	if err != nil {
+		return Report{}, err
+	}
+	orgApps := map[int64]Footprint{}
+	for _, d := range orgDeployments {
+		addDeployment(orgApps, d.AppID, footprintOf(d.Replicas, d.Config))
+	}
+	report.OrgUsage.addApps(orgApps)
+
+	return report, nil
+}
+
+// CheckNewApp returns a ResourceExhausted error if the workspace or org has no room for another app.
+// Run it with WithTx in the transaction that creates the app.
+func (e *Enforcer) CheckNewApp(ctx context.Context, workspaceID int64) error {
+	if err := e.queries.LockWorkspaceQuota(ctx, workspaceID); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	report, err := e.Report(ctx, workspaceID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	if report.WorkspaceUsage.Apps >= int64(report.Workspace.MaxApps) {
+		return exceeded("workspace allows at most %d apps", report.Workspace.MaxApps)
+	}
+	if report.OrgUsage.Apps >= int64(report.Org.MaxApps) {
+		return exceeded("organization allows at most %d apps", report.Org.MaxApps)
+	}
+	return nil
+}
+
+// CheckDeployment returns a ResourceExhausted error if replacing what the app runs with a deployment of the
+// given shape would take the workspace or org over quota. Apps are counted at the most replicas they may be
+// scaled to, and a release pod counts while it runs next to the app's current deployment.
+// Run it with WithTx in the transaction that creates the deployment.
+func (e *Enforcer) CheckDeployment(ctx context.Context, workspaceID, appID int64, shape Shape) error {
+	replicas := shape.replicas()
+	next, err := NewFootprint(replicas, shape.CPU, shape.Memory)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := e.queries.LockWorkspaceQuota(ctx, workspaceID); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	report, err := e.Report(ctx, workspaceID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	current, err := e.currentFootprint(ctx, workspaceID, appID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	if replicas > report.Workspace.MaxReplicas {
+		return exceeded("workspace allows at most %d replicas per app, requested %d", report.Workspace.MaxReplicas, replicas)
+	}
+	if replicas > report.Org.MaxReplicas {
+		return exceeded("organization allows at most %d replicas per app, requested %d", report.Org.MaxReplicas, replicas)
+	}
+
+	if shape.Release {
+		release, err := NewFootprint(1, shape.CPU, shape.Memory)
+		if err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if err := checkUsage("workspace", report.Workspace, report.WorkspaceUsage, Footprint{}, release); err != nil {
+			return err
+		}
+		if err := checkUsage("organization", report.Org, report.OrgUsage, Footprint{}, release); err != nil {
+			return err
+		}
+	}
+
+	if err := checkUsage("workspace", report.Workspace, report.WorkspaceUsage, current, next); err != nil {
+		return err
+	}
+	return checkUsage("organization", report.Org, report.OrgUsage, current, next)
+}
+
+// CheckRun returns a ResourceExhausted error if a one-off command's pod doesn't fit next to what the
+// workspace's apps already run. Run it with WithTx in a transaction held until the command's Job is created.
+func (e *Enforcer) CheckRun(ctx context.Context, workspaceID int64, cpu, memory string) error {
+	run, err := NewFootprint(1, cpu, memory)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := e.queries.LockWorkspaceQuota(ctx, workspaceID); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	report, err := e.Report(ctx, workspaceID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	if err := checkUsage("workspace", report.Workspace, report.WorkspaceUsage, Footprint{}, run); err != nil {
+		return err
+	}
+	return checkUsage("organization", report.Org, report.OrgUsage, Footprint{}, run)
+}
+
+// currentFootprint is what the app counts towards usage, which the deployment being checked replaces
+func (e *Enforcer) currentFootprint(ctx context.Context, workspaceID, appID int64) (Footprint, error) {
+	deployments, err := e.queries.ListActiveDeploymentsForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return Footprint{}, err
+	}
+	apps := map[int64]Footprint{}
+	for _, d := range deployments {
+		if d.AppID == appID {
+			addDeployment(apps, d.AppID, footprintOf(d.Replicas, d.Config))
+		}
+	}
+	return apps[appID], nil
+}
+
+func checkUsage(scope string, limits Limits, usage Usage, current, next Footprint) error {
+	cpu := usage.CPU - current.CPU + next.CPU
+	if cpu > limits.MaxCPU {
+		return exceeded("%s cpu limit is %dm, this change would use %dm", scope, limits.MaxCPU, cpu)
+	}
+	memory := usage.Memory - current.Memory + next.Memory
+	if memory > limits.MaxMemory {
+		return exceeded("%s memory limit is %dMi, this change would use %dMi", scope, limits.MaxMemory, memory)
+	}
+	return nil
+}
+
+// NewFootprint parses per-replica cpu and memory quantities into a deployment footprint
+func NewFootprint(replicas int32, cpu, memory string) (Footprint, error) {
+	if cpu == "" {
+		cpu = kube.DefaultCPU
+	}
+	if memory == "" {
+		memory = kube.DefaultMemory
+	}
+
+	cpuQuantity, err := resource.ParseQuantity(cpu)
+	if err != nil {
+		return Footprint{}, fmt.Errorf("%w: cpu %q", ErrInvalidResource, cpu)
+	}
+	memoryQuantity, err := resource.ParseQuantity(memory)
+	if err != nil {
+		return Footprint{}, fmt.Errorf("%w: memory %q", ErrInvalidResource, memory)
+	}
+
+	// round memory up so a 1.5Mi request isn't undercounted
+	memoryMb := (memoryQuantity.Value() + mebibyte - 1) / mebibyte
+
+	return Footprint{
+		Replicas: replicas,
+		CPU:      int64(replicas) * cpuQuantity.MilliValue(),
+		Memory:   int64(replicas) * memoryMb,
+	}, nil
+}
+
+// footprintOf reads the footprint of a stored deployment. Unparseable configs count as the defaults,
+// so a bad row can't lock a workspace out of deploying.
+func footprintOf(replicas int32, config []byte) Footprint {
+	shape := ShapeOf(replicas, config)
+	f, err := NewFootprint(shape.replicas(), shape.CPU, shape.Memory)
+	if err != nil {
+		f, _ = NewFootprint(shape.replicas(), "", "")
+	}
+	return f
+}
+
+// addDeployment counts a deployment towards its app. An app rolling out a deployment counts the larger of
+// it and the one it replaces.
+func addDeployment(apps map[int64]Footprint, appID int64, f Footprint) {
+	have := apps[appID]
+	apps[appID] = Footprint{
+		Replicas: max(have.Replicas, f.Replicas),
+		CPU:      max(have.CPU, f.CPU),
+		Memory:   max(have.Memory, f.Memory),
+	}
+}
+
+func (u *Usage) addApps(apps map[int64]Footprint) {
+	for _, f := range apps {
+		u.CPU += f.CPU
+		u.Memory += f.Memory
+	}
+}
+
+func exceeded(format string, args ...any) error {
+	return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("%w: "+format, append([]any{ErrQuotaExceeded}, args...)...))
+}
+
+// Namespace converts the limits into the ceiling applied to each app namespace
+func (l Limits) Namespace() kube.NamespaceQuota {
+	return kube.NamespaceQuota{
+		CPUMillicores: l.MaxCPU,
+		MemoryMb:      l.MaxMemory,
+		MaxReplicas:   l.MaxReplicas,
+	}
+}
@@ -0,0 +1,258 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+)
+
+// DefaultInterval is how often every cluster is scanned for drift
+const DefaultInterval = 5 * time.Minute
+
+// AppDrift is the drift found for one app's current deployment
+type AppDrift struct {
+	AppID        int64
+	WorkspaceID  int64
+	ClusterID    int64
+	AppName      string
+	Namespace    string
+	DeploymentID int64
+	Drift        []kube.Drift
+	// Repaired is true when the drift was re-applied during the scan
+	Repaired bool
+	// Error is set when the app couldn't be checked or repaired
+	Error string
+}
+
+// Orphan is a loco-managed namespace with no app row behind it
+type Orphan struct {
+	ClusterID int64
+	Namespace string
+	CreatedAt time.Time
+}
+
+// Report is the result of a scan. Apps lists only apps with drift or errors.
+type Report struct {
+	CheckedAt time.Time
+	Apps      []AppDrift
+	Orphans   []Orphan
+}
+
+// Reconciler compares each app's current deployment in Postgres with the cluster it runs on.
+// Postgres is the source of truth, so drift is only ever repaired towards it.
+type Reconciler struct {
+	queries  *genDb.Queries
+	clusters *kube.Pool
+	interval time.Duration
+	repair   bool
+
+	// one scan at a time, so a manual scan can't race the loop's repairs
+	scanMu sync.Mutex
+	mu     sync.Mutex
+	last   *Report
+}
+
+// NewReconciler creates a Reconciler. When repair is set, the periodic scan re-applies drifted objects.
+// An interval of zero or less falls back to DefaultInterval.
+func NewReconciler(queries *genDb.Queries, clusters *kube.Pool, interval time.Duration, repair bool) *Reconciler {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Reconciler{
+		queries:  queries,
+		clusters: clusters,
+		interval: interval,
+		repair:   repair,
+	}
+}
+
+// Run scans every cluster on each interval until ctx is done
+func (r *Reconciler) Run(ctx context.Context) {
+	slog.InfoContext(ctx, "Starting drift reconciler", "interval", r.interval, "repair", r.repair)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		report, err := r.Scan(ctx, 0, r.repair)
+		if err != nil {
+			slog.ErrorContext(ctx, "Drift scan failed", "error", err)
+			continue
+		}
+		if len(report.Apps) > 0 || len(report.Orphans) > 0 {
+			slog.WarnContext(ctx, "Drift detected", "apps", len(report.Apps), "orphaned_namespaces", len(report.Orphans))
+		}
+	}
+}
+
+// Last returns the most recent report, or nil before the first scan
+func (r *Reconciler) Last() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+// Scan checks the apps on a cluster, or on every cluster when clusterID is 0.
+// Orphaned namespaces are reported but never deleted.
+func (r *Reconciler) Scan(ctx context.Context, clusterID int64, repair bool) (*Report, error) {
+	r.scanMu.Lock()
+	defer r.scanMu.Unlock()
+
+	var clusters []genDb.Cluster
+	if clusterID != 0 {
+		cluster, err := r.queries.GetClusterByID(ctx, clusterID)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, cluster)
+	} else {
+		var err error
+		if clusters, err = r.queries.ListClusters(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &Report{CheckedAt: time.Now()}
+	for _, cluster := range clusters {
+		if err := r.scanCluster(ctx, cluster, repair, report); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	r.last = report
+	r.mu.Unlock()
+	return report, nil
+}
+
+func (r *Reconciler) scanCluster(ctx context.Context, cluster genDb.Cluster, repair bool, report *Report) error {
+	apps, err := r.queries.ListAppsForCluster(ctx, cluster.ID)
+	if err != nil {
+		return err
+	}
+
+	kc, err := r.clusters.Get(ctx, cluster.ID)
+	if err != nil {
+		slog.WarnContext(ctx, "Skipping drift scan, cluster unreachable", "cluster_id", cluster.ID, "error", err)
+		return nil
+	}
+
+	expected := map[string]bool{}
+	for _, app := range apps {
+		ldc := &kube.LocoDeploymentContext{App: &app}
+		expected[ldc.Namespace()] = true
+
+		if result := r.scanApp(ctx, kc, app, repair); result != nil {
+			report.Apps = append(report.Apps, *result)
+		}
+	}
+
+	namespaces, err := kc.ListManagedNamespaces(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Skipping orphan scan", "cluster_id", cluster.ID, "error", err)
+		return nil
+	}
+	for _, ns := range namespaces {
+		if !expected[ns.Name] {
+			report.Orphans = append(report.Orphans, Orphan{
+				ClusterID: cluster.ID,
+				Namespace: ns.Name,
+				CreatedAt: ns.CreationTimestamp.Time,
+			})
+		}
+	}
+	return nil
+}
+
+// scanApp returns nil when the app has no drift
+func (r *Reconciler) scanApp(ctx context.Context, kc *kube.Client, app genDb.App, repair bool) *AppDrift {
+	deployment, err := r.queries.GetCurrentDeploymentForApp(ctx, app.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// never deployed, nothing should exist yet
+		return nil
+	}
+
+	result := &AppDrift{
+		AppID:        app.ID,
+		WorkspaceID:  app.WorkspaceID,
+		ClusterID:    app.ClusterID,
+		AppName:      app.Name,
+		DeploymentID: deployment.ID,
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	// deployments still rolling out or that failed aren't expected to match
+	if deployment.Status != genDb.DeploymentStatusSucceeded {
+		return nil
+	}
+
+	// sleeping apps are scaled to zero and routed to the wake endpoint on purpose
+	sleepState, err := r.queries.GetAppSleep(ctx, app.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		result.Error = err.Error()
+		return result
+	}
+	if sleepState.SleptAt.Valid {
+		return nil
+	}
+
+	ldc, err := kube.NewLocoDeploymentContext(&app, &deployment)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Namespace = ldc.Namespace()
+	envVars := envFromConfig(deployment.Config)
+
+	// verified custom domains are part of the route, without them they'd be reported and repaired away
+	ldc.Domains, err = r.queries.ListVerifiedHostnamesForApp(ctx, app.ID)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Drift, err = kc.DetectDrift(ctx, ldc, envVars)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if len(result.Drift) == 0 {
+		return nil
+	}
+
+	slog.WarnContext(ctx, "App drifted from its deployment", "app_id", app.ID, "namespace", result.Namespace, "drift", result.Drift)
+
+	if repair {
+		if err := kc.RepairDrift(ctx, ldc, envVars, result.Drift); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Repaired = true
+	}
+	return result
+}
+
+// envFromConfig reads the env vars a deployment was created with
+func envFromConfig(config []byte) map[string]string {
+	var cfg struct {
+		Env map[string]string `json:"env"`
+	}
+	_ = json.Unmarshal(config, &cfg)
+	return cfg.Env
+}
@@ -0,0 +1,312 @@
+package resurrect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/nikumar1206/loco/api/domains"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+	"github.com/nikumar1206/loco/api/placement"
+	"github.com/nikumar1206/loco/api/quota"
+)
+
+const (
+	// DefaultConcurrency is how many apps are rebuilt at once
+	DefaultConcurrency = 4
+	// MaxConcurrency bounds a caller supplied concurrency so a rebuild can't flood the target API server
+	MaxConcurrency = 16
+	// DefaultReadyTimeout is how long an app's deployment gets to become ready on the target
+	DefaultReadyTimeout = 5 * time.Minute
+	// readyPollInterval is how often a rebuilt deployment is checked for readiness
+	readyPollInterval = 2 * time.Second
+)
+
+// Phases an app moves through during a rebuild
+const (
+	PhaseQueued     = "queued"
+	PhaseAllocating = "allocating"
+	PhaseWaiting    = "waiting"
+	PhaseDone       = "done"
+	PhaseSkipped    = "skipped"
+	PhaseFailed     = "failed"
+)
+
+var (
+	ErrTargetUnavailable = errors.New("target cluster is unavailable")
+	ErrSameCluster       = errors.New("source and target cluster are the same")
+)
+
+// Store is the part of genDb.Queries a rebuild needs, so it can run against a fake
+type Store interface {
+	GetClusterByID(ctx context.Context, id int64) (genDb.Cluster, error)
+	GetCurrentDeploymentForApp(ctx context.Context, appID int64) (genDb.Deployment, error)
+	ListAppDomains(ctx context.Context, appID int64) ([]genDb.AppDomain, error)
+	ListAppsForCluster(ctx context.Context, clusterID int64) ([]genDb.App, error)
+	MoveAppToCluster(ctx context.Context, arg genDb.MoveAppToClusterParams) error
+}
+
+// Clusters resolves a cluster ID to a client, *kube.Pool implements it
+type Clusters interface {
+	Get(ctx context.Context, clusterID int64) (*kube.Client, error)
+}
+
+// Quotas returns the limits applied to a workspace's namespaces, *quota.Enforcer implements it
+type Quotas interface {
+	Effective(ctx context.Context, workspaceID int64) (quota.Limits, error)
+}
+
+// PullCredentials issues registry credentials for the rebuilt namespaces.
+// Returning nil skips creating pull secrets.
+type PullCredentials func(ctx context.Context) (*kube.DockerRegistryConfig, error)
+
+// Progress is an update on one app. Completed and Total count apps that have finished, whatever the outcome.
+type Progress struct {
+	AppID     int64
+	AppName   string
+	Namespace string
+	Phase     string
+	Message   string
+	Completed int
+	Total     int
+}
+
+// Report summarises a finished rebuild
+type Report struct {
+	SourceClusterID int64
+	TargetClusterID int64
+	Total           int
+	Rebuilt         int
+	Skipped         int
+	Failed          int
+}
+
+// Resurrector rebuilds a cluster's apps on another cluster from their current deployments in Postgres
+type Resurrector struct {
+	store        Store
+	clusters     Clusters
+	quotas       Quotas
+	credentials  PullCredentials
+	readyTimeout time.Duration
+}
+
+// NewResurrector creates a Resurrector. credentials may be nil when images are public.
+func NewResurrector(store Store, clusters Clusters, quotas Quotas, credentials PullCredentials) *Resurrector {
+	return &Resurrector{
+		store:        store,
+		clusters:     clusters,
+		quotas:       quotas,
+		credentials:  credentials,
+		readyTimeout: DefaultReadyTimeout,
+	}
+}
+
+// Rebuild re-creates every app on sourceID whose current deployment succeeded on targetID, concurrency apps at a time.
+// Each app's objects are created in dependency order, and the app is only moved to the target once its deployment is ready,
+// so a failed rebuild leaves the remaining apps pointing at the source and can simply be run again.
+// progress is called for each phase change and never concurrently.
+func (r *Resurrector) Rebuild(ctx context.Context, sourceID, targetID int64, concurrency int, progress func(Progress)) (*Report, error) {
+	if sourceID == targetID {
+		return nil, ErrSameCluster
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	concurrency = min(concurrency, MaxConcurrency)
+
+	if _, err := r.store.GetClusterByID(ctx, sourceID); err != nil {
+		return nil, err
+	}
+	target, err := r.store.GetClusterByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if !placement.Schedulable(target) {
+		return nil, fmt.Errorf("%w: cluster %s is not active and healthy", ErrTargetUnavailable, target.Name)
+	}
+	kc, err := r.clusters.Get(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTargetUnavailable, err)
+	}
+
+	apps, err := r.store.ListAppsForCluster(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var registry *kube.DockerRegistryConfig
+	if r.credentials != nil && len(apps) > 0 {
+		if registry, err = r.credentials(ctx); err != nil {
+			return nil, fmt.Errorf("failed to issue pull credentials: %w", err)
+		}
+	}
+
+	slog.InfoContext(ctx, "Starting cluster rebuild", "source_cluster_id", sourceID, "target_cluster_id", targetID, "apps", len(apps), "concurrency", concurrency)
+
+	report := &Report{SourceClusterID: sourceID, TargetClusterID: targetID, Total: len(apps)}
+	var mu sync.Mutex
+	emit := func(p Progress, final bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if final {
+			switch p.Phase {
+			case PhaseDone:
+				report.Rebuilt++
+			case PhaseSkipped:
+				report.Skipped++
+			default:
+				report.Failed++
+			}
+		}
+		p.Completed = report.Rebuilt + report.Skipped + report.Failed
+		p.Total = report.Total
+		if progress != nil {
+			progress(p)
+		}
+	}
+
+	for _, app := range apps {
+		emit(Progress{AppID: app.ID, AppName: app.Name, Phase: PhaseQueued}, false)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, app := range apps {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return report, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(app genDb.App) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			r.rebuildApp(ctx, kc, app, targetID, registry, emit)
+		}(app)
+	}
+	wg.Wait()
+
+	slog.InfoContext(ctx, "Cluster rebuild finished", "source_cluster_id", sourceID, "target_cluster_id", targetID,
+		"rebuilt", report.Rebuilt, "skipped", report.Skipped, "failed", report.Failed)
+	return report, ctx.Err()
+}
+
+// rebuildApp allocates one app on the target, waits for it to become ready, then moves it over.
+// It always ends by emitting a final phase.
+func (r *Resurrector) rebuildApp(
+	ctx context.Context,
+	kc *kube.Client,
+	app genDb.App,
+	targetID int64,
+	registry *kube.DockerRegistryConfig,
+	emit func(Progress, bool),
+) {
+	p := Progress{AppID: app.ID, AppName: app.Name}
+	fail := func(err error) {
+		slog.ErrorContext(ctx, "Failed to rebuild app", "app_id", app.ID, "target_cluster_id", targetID, "error", err)
+		p.Phase = PhaseFailed
+		p.Message = err.Error()
+		emit(p, true)
+	}
+
+	deployment, err := r.store.GetCurrentDeploymentForApp(ctx, app.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		p.Phase = PhaseSkipped
+		p.Message = "app has never been deployed"
+		emit(p, true)
+		return
+	}
+	if err != nil {
+		fail(err)
+		return
+	}
+	if deployment.Status != genDb.DeploymentStatusSucceeded {
+		p.Phase = PhaseSkipped
+		p.Message = fmt.Sprintf("current deployment %d is %s", deployment.ID, deployment.Status)
+		emit(p, true)
+		return
+	}
+
+	ldc, err := kube.NewLocoDeploymentContext(&app, &deployment)
+	if err != nil {
+		fail(err)
+		return
+	}
+	p.Namespace = ldc.Namespace()
+
+	if r.quotas != nil {
+		limits, err := r.quotas.Effective(ctx, app.WorkspaceID)
+		if err != nil {
+			fail(fmt.Errorf("failed to load workspace quota: %w", err))
+			return
+		}
+		namespaceQuota := limits.Namespace()
+		ldc.Quota = &namespaceQuota
+	}
+
+	appDomains, err := r.store.ListAppDomains(ctx, app.ID)
+	if err != nil {
+		fail(fmt.Errorf("failed to load custom domains: %w", err))
+		return
+	}
+	var verified []genDb.AppDomain
+	for _, d := range appDomains {
+		if d.VerificationStatus == domains.StatusVerified {
+			verified = append(verified, d)
+			ldc.Domains = append(ldc.Domains, d.Hostname)
+		}
+	}
+
+	p.Phase = PhaseAllocating
+	emit(p, false)
+	// a namespace left over from an earlier attempt is updated in place, so the rebuild can be retried
+	if err := kc.AllocateResources(ctx, ldc, envFromConfig(deployment.Config), registry); err != nil {
+		fail(err)
+		return
+	}
+	// the target's gateway needs its own listeners and certificates for the app's custom domains
+	for _, d := range verified {
+		if err := kc.AttachDomain(ctx, d.ID, d.Hostname, ldc.Namespace()); err != nil {
+			fail(err)
+			return
+		}
+	}
+
+	p.Phase = PhaseWaiting
+	emit(p, false)
+	readyCtx, cancel := context.WithTimeout(ctx, r.readyTimeout)
+	err = kc.WaitForDeploymentReady(readyCtx, ldc.Namespace(), ldc.DeploymentName(), readyPollInterval)
+	cancel()
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	if err := r.store.MoveAppToCluster(ctx, genDb.MoveAppToClusterParams{ID: app.ID, ClusterID: targetID}); err != nil {
+		fail(fmt.Errorf("app is running on the target but could not be moved: %w", err))
+		return
+	}
+
+	slog.InfoContext(ctx, "App rebuilt", "app_id", app.ID, "namespace", ldc.Namespace(), "target_cluster_id", targetID)
+	p.Phase = PhaseDone
+	p.Message = fmt.Sprintf("deployment %d is running", deployment.ID)
+	emit(p, true)
+}
+
+// envFromConfig reads the env vars a deployment was created with
+func envFromConfig(config []byte) map[string]string {
+	var cfg struct {
+		Env map[string]string `json:"env"`
+	}
+	_ = json.Unmarshal(config, &cfg)
+	return cfg.Env
+}
@@ -0,0 +1,268 @@
+package resurrect
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	gatewayFake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+const (
+	sourceID = 1
+	targetID = 2
+)
+
+const appConfig = `{
+	"metadata": {"name": "web"},
+	"resources": {"cpu": "100m", "memory": "128Mi", "replicas": {"min": 2, "max": 2}},
+	"routing": {"port": 8080, "subdomain": "web"},
+	"health": {"path": "/healthz", "interval": 10, "timeout": 5},
+	"env": {"GREETING": "hello"}
+}`
+
+// fakeStore keeps the rows a rebuild reads in memory and records the apps it moved
+type fakeStore struct {
+	clusters    map[int64]genDb.Cluster
+	apps        []genDb.App
+	deployments map[int64]genDb.Deployment
+
+	mu    sync.Mutex
+	moved map[int64]int64
+}
+
+func (s *fakeStore) GetClusterByID(_ context.Context, id int64) (genDb.Cluster, error) {
+	cluster, ok := s.clusters[id]
+	if !ok {
+		return genDb.Cluster{}, pgx.ErrNoRows
+	}
+	return cluster, nil
+}
+
+func (s *fakeStore) GetCurrentDeploymentForApp(_ context.Context, appID int64) (genDb.Deployment, error) {
+	deployment, ok := s.deployments[appID]
+	if !ok {
+		return genDb.Deployment{}, pgx.ErrNoRows
+	}
+	return deployment, nil
+}
+
+func (s *fakeStore) ListAppDomains(context.Context, int64) ([]genDb.AppDomain, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) ListAppsForCluster(_ context.Context, clusterID int64) ([]genDb.App, error) {
+	var apps []genDb.App
+	for _, app := range s.apps {
+		if app.ClusterID == clusterID {
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
+
+func (s *fakeStore) MoveAppToCluster(_ context.Context, arg genDb.MoveAppToClusterParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.moved[arg.ID] = arg.ClusterID
+	return nil
+}
+
+// fakeClusters hands out one client for every cluster
+type fakeClusters struct {
+	kc *kube.Client
+}
+
+func (c fakeClusters) Get(context.Context, int64) (*kube.Client, error) {
+	return c.kc, nil
+}
+
+func newStore() *fakeStore {
+	active := pgtype.Bool{Bool: true, Valid: true}
+	return &fakeStore{
+		clusters: map[int64]genDb.Cluster{
+			sourceID: {ID: sourceID, Name: "source", IsActive: active},
+			targetID: {ID: targetID, Name: "target", IsActive: active},
+		},
+		apps: []genDb.App{
+			{ID: 10, WorkspaceID: 3, ClusterID: sourceID, Name: "web"},
+			{ID: 11, WorkspaceID: 3, ClusterID: sourceID, Name: "never-deployed"},
+			{ID: 12, WorkspaceID: 3, ClusterID: sourceID, Name: "broken"},
+		},
+		deployments: map[int64]genDb.Deployment{
+			10: {ID: 100, AppID: 10, Image: "registry.example.com/web:1", Replicas: 2, Status: genDb.DeploymentStatusSucceeded, IsCurrent: true, Config: []byte(appConfig)},
+			12: {ID: 120, AppID: 12, Image: "registry.example.com/broken:1", Replicas: 1, Status: genDb.DeploymentStatusFailed, IsCurrent: true, Config: []byte(appConfig)},
+		},
+		moved: map[int64]int64{},
+	}
+}
+
+func newClient() *kube.Client {
+	return &kube.Client{
+		ClientSet:  fake.NewSimpleClientset(),
+		GatewaySet: gatewayFake.NewSimpleClientset(),
+	}
+}
+
+// markReady reports a deployment's pods as rolled out, the fake has no controller doing it
+func markReady(t *testing.T, kc *kube.Client, namespace, name string) {
+	t.Helper()
+	deployments := kc.ClientSet.AppsV1().Deployments(namespace)
+	deployment, err := deployments.Get(context.Background(), name, metaV1.GetOptions{})
+	if err != nil {
+		t.Errorf("deployment %s/%s was not created: %v", namespace, name, err)
+		return
+	}
+	replicas := *deployment.Spec.Replicas
+	deployment.Status.ObservedGeneration = deployment.Generation
+	deployment.Status.UpdatedReplicas = replicas
+	deployment.Status.AvailableReplicas = replicas
+	if _, err := deployments.UpdateStatus(context.Background(), deployment, metaV1.UpdateOptions{}); err != nil {
+		t.Errorf("failed to mark deployment %s/%s ready: %v", namespace, name, err)
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	store := newStore()
+	kc := newClient()
+	r := NewResurrector(store, fakeClusters{kc: kc}, nil, nil)
+
+	final := map[int64]Progress{}
+	report, err := r.Rebuild(context.Background(), sourceID, targetID, 2, func(p Progress) {
+		switch p.Phase {
+		case PhaseWaiting:
+			markReady(t, kc, p.Namespace, p.AppName)
+		case PhaseDone, PhaseSkipped, PhaseFailed:
+			final[p.AppID] = p
+		}
+	})
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+
+	if report.Total != 3 || report.Rebuilt != 1 || report.Skipped != 2 || report.Failed != 0 {
+		t.Errorf("report = %+v, want 1 rebuilt and 2 skipped of 3", *report)
+	}
+	for appID, phase := range map[int64]string{10: PhaseDone, 11: PhaseSkipped, 12: PhaseSkipped} {
+		if got := final[appID].Phase; got != phase {
+			t.Errorf("app %d ended %q, want %q", appID, got, phase)
+		}
+	}
+
+	ctx := context.Background()
+	namespace := "wks-3-app-10"
+	if _, err := kc.ClientSet.CoreV1().Namespaces().Get(ctx, namespace, metaV1.GetOptions{}); err != nil {
+		t.Errorf("namespace %s was not created: %v", namespace, err)
+	}
+	secret, err := kc.ClientSet.CoreV1().Secrets(namespace).Get(ctx, "web", metaV1.GetOptions{})
+	if err != nil {
+		t.Errorf("env secret was not created: %v", err)
+	} else if got := string(secret.Data["GREETING"]) + secret.StringData["GREETING"]; got != "hello" {
+		t.Errorf("env secret GREETING = %q, want the deployment's %q", got, "hello")
+	}
+	if _, err := kc.GatewaySet.GatewayV1().HTTPRoutes(namespace).Get(ctx, "web", metaV1.GetOptions{}); err != nil {
+		t.Errorf("HTTPRoute was not created: %v", err)
+	}
+	for _, skipped := range []string{"wks-3-app-11", "wks-3-app-12"} {
+		if _, err := kc.ClientSet.CoreV1().Namespaces().Get(ctx, skipped, metaV1.GetOptions{}); err == nil {
+			t.Errorf("namespace %s of a skipped app was created", skipped)
+		}
+	}
+
+	if got, ok := store.moved[10]; !ok || got != targetID {
+		t.Errorf("app 10 moved to %d (moved=%v), want cluster %d", got, ok, targetID)
+	}
+	if len(store.moved) != 1 {
+		t.Errorf("moved apps = %v, want only app 10", store.moved)
+	}
+}
+
+func TestRebuildLeavesAppsThatDoNotBecomeReady(t *testing.T) {
+	store := newStore()
+	kc := newClient()
+	r := NewResurrector(store, fakeClusters{kc: kc}, nil, nil)
+	r.readyTimeout = 50 * time.Millisecond
+
+	var failed Progress
+	report, err := r.Rebuild(context.Background(), sourceID, targetID, 1, func(p Progress) {
+		if p.Phase == PhaseFailed {
+			failed = p
+		}
+	})
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+
+	if report.Failed != 1 || report.Rebuilt != 0 {
+		t.Errorf("report = %+v, want the unready app failed", *report)
+	}
+	if failed.AppID != 10 {
+		t.Errorf("failed app = %d, want 10", failed.AppID)
+	}
+	if len(store.moved) != 0 {
+		t.Errorf("moved apps = %v, want none, an unready app stays on the source", store.moved)
+	}
+}
+
+func TestRebuildRejectsTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  int64
+		cluster genDb.Cluster
+		wantErr error
+	}{
+		{
+			name:    "same cluster",
+			target:  sourceID,
+			wantErr: ErrSameCluster,
+		},
+		{
+			name:    "inactive",
+			target:  targetID,
+			cluster: genDb.Cluster{ID: targetID, Name: "target"},
+			wantErr: ErrTargetUnavailable,
+		},
+		{
+			name:   "unhealthy",
+			target: targetID,
+			cluster: genDb.Cluster{
+				ID:           targetID,
+				Name:         "target",
+				IsActive:     pgtype.Bool{Bool: true, Valid: true},
+				HealthStatus: pgtype.Text{String: kube.HealthUnhealthy, Valid: true},
+			},
+			wantErr: ErrTargetUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStore()
+			if tt.cluster.ID != 0 {
+				store.clusters[tt.cluster.ID] = tt.cluster
+			}
+			kc := newClient()
+			r := NewResurrector(store, fakeClusters{kc: kc}, nil, nil)
+
+			_, err := r.Rebuild(context.Background(), sourceID, tt.target, 1, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Rebuild() error = %v, want %v", err, tt.wantErr)
+			}
+			namespaces, err := kc.ClientSet.CoreV1().Namespaces().List(context.Background(), metaV1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to list namespaces: %v", err)
+			}
+			if len(namespaces.Items) != 0 {
+				t.Errorf("created %d namespaces on a rejected target", len(namespaces.Items))
+			}
+		})
+	}
+}
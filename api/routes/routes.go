@@ -0,0 +1,55 @@
+// Package routes decides which app serves each path of a hostname shared by several apps.
+//
+// Every app claims one path prefix on its hostname. Prefixes match whole path segments, so /api
+// serves /api and /api/users but not /apis. Nested prefixes may belong to different apps, the
+// gateway sends each request to the longest matching one across all of a hostname's HTTPRoutes,
+// but two apps can't claim the same prefix since the gateway would pick between them by route age.
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// RootPrefix serves every path not claimed by a longer prefix
+const RootPrefix = "/"
+
+var (
+	ErrInvalidPrefix = errors.New("invalid path prefix")
+	ErrPrefixTaken   = errors.New("path prefix already claimed")
+)
+
+// Claim is a path prefix served by an app
+type Claim struct {
+	AppID      int64
+	AppName    string
+	PathPrefix string
+}
+
+// NormalizePrefix cleans a path prefix so equivalent prefixes compare equal,
+// e.g. "", "/" and "//" are all "/", and "/api/" and "/api/v1/.." are "/api"
+func NormalizePrefix(prefix string) (string, error) {
+	if prefix == "" {
+		return RootPrefix, nil
+	}
+	if !strings.HasPrefix(prefix, "/") {
+		return "", fmt.Errorf("%w: %q must start with /", ErrInvalidPrefix, prefix)
+	}
+	if strings.ContainsAny(prefix, "?#*% \t\n") {
+		return "", fmt.Errorf("%w: %q may only contain a path", ErrInvalidPrefix, prefix)
+	}
+	return path.Clean(prefix), nil
+}
+
+// Conflict returns the claim by another app for the same prefix, if there is one.
+// claims and prefix must be normalized.
+func Conflict(claims []Claim, appID int64, prefix string) (Claim, bool) {
+	for _, claim := range claims {
+		if claim.AppID != appID && claim.PathPrefix == prefix {
+			return claim, true
+		}
+	}
+	return Claim{}, false
+}
@@ -2,20 +2,30 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"regexp"
 	"sort"
+	"sync"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nikumar1206/loco/api/contextkeys"
 	genDb "github.com/nikumar1206/loco/api/gen/db"
 	"github.com/nikumar1206/loco/api/pkg/klogmux"
 	"github.com/nikumar1206/loco/api/pkg/kube"
+	"github.com/nikumar1206/loco/api/pkg/logstore"
+	"github.com/nikumar1206/loco/api/pkg/metricstore"
+	"github.com/nikumar1206/loco/api/placement"
+	"github.com/nikumar1206/loco/api/quota"
 	"github.com/nikumar1206/loco/api/timeutil"
+	sharedConfig "github.com/nikumar1206/loco/shared/config"
 	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,21 +39,48 @@ var (
 	ErrClusterNotFound       = errors.New("cluster not found")
 	ErrClusterNotHealthy     = errors.New("cluster is not healthy")
 	ErrInvalidAppType        = errors.New("invalid app type")
+	ErrInvalidLogFilter      = errors.New("invalid log filter")
+	ErrEmptyCommand          = errors.New("command must be set")
+	ErrPortForwardClosed     = errors.New("port-forward to the pod closed")
+	// persistent volumes are ReadWriteOnce, only one pod can mount them
+	ErrPersistentVolumeReplicas = errors.New("apps with persistent volumes run a single replica")
+)
+
+const (
+	// restartPollInterval is how often a restart waited on checks its rollout
+	restartPollInterval = 2 * time.Second
+	// restartWaitTimeout is how long a restart waited on gets to become ready
+	restartWaitTimeout = 10 * time.Minute
+	// maxAppEvents is how many of an app's own events GetEvents lists next to the cluster's
+	maxAppEvents = 100
 )
 
 type AppServer struct {
-	db         *pgxpool.Pool
-	queries    *genDb.Queries
-	kubeClient *kube.Client
+	db        *pgxpool.Pool
+	queries   *genDb.Queries
+	clusters  *kube.Pool
+	scheduler *placement.Scheduler
+	quotas    *quota.Enforcer
+	logs      *logstore.Client
+	metrics   *metricstore.Client
+	// deployments allocates the deployments ScaleApp and UpdateAppEnv create
+	deployments *DeploymentServer
 }
 
-// NewAppServer creates a new AppServer instance
-func NewAppServer(db *pgxpool.Pool, queries *genDb.Queries, kubeClient *kube.Client) *AppServer {
+// NewAppServer creates a new AppServer instance.
+// logs may be nil, in which case StreamLogs always tails live pods,
+// and metrics may be nil, in which case GetAppMetrics only returns live values.
+func NewAppServer(db *pgxpool.Pool, queries *genDb.Queries, clusters *kube.Pool, scheduler *placement.Scheduler, quotas *quota.Enforcer, logs *logstore.Client, metrics *metricstore.Client, deployments *DeploymentServer) *AppServer {
 	// todo: move this out.
 	return &AppServer{
-		db:         db,
-		queries:    queries,
-		kubeClient: kubeClient,
+		db:          db,
+		queries:     queries,
+		clusters:    clusters,
+		scheduler:   scheduler,
+		quotas:      quotas,
+		logs:        logs,
+		metrics:     metrics,
+		deployments: deployments,
 	}
 }
 
@@ -54,25 +91,24 @@ func (s *AppServer) CreateApp(
 ) (*connect.Response[appv1.CreateAppResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
 	}
 
-	// todo: revisit validating roles
-	role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-		WorkspaceID: r.WorkspaceId,
-		UserID:      userID,
-	})
+	// the quota is checked in the transaction that creates the app, so concurrent requests can't both fit
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", r.WorkspaceId, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
+		slog.ErrorContext(ctx, "failed to begin transaction", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
 	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
 
-	if role != "admin" && role != "deploy" {
-		slog.WarnContext(ctx, "user does not have permission to create app", "workspaceId", r.WorkspaceId, "userId", userID, "role", role)
-		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("must be workspace admin or have deploy role"))
+	if err := s.quotas.WithTx(tx).CheckNewApp(ctx, r.WorkspaceId); err != nil {
+		slog.WarnContext(ctx, "app quota check failed", "workspace_id", r.WorkspaceId, "error", err)
+		return nil, err
 	}
 
 	domain := r.GetDomain()
@@ -80,36 +116,29 @@ func (s *AppServer) CreateApp(
 		domain = "loco.deploy-app.com"
 	}
 
-	available, err := s.queries.CheckSubdomainAvailability(ctx, genDb.CheckSubdomainAvailabilityParams{
-		Subdomain: r.Subdomain,
-		Domain:    domain,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to check subdomain availability", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	// the workspace's other apps may already serve other paths of this hostname
+	if _, err := reserveHostname(ctx, qtx, r.WorkspaceId, r.Subdomain, domain, userID); err != nil {
+		return nil, err
 	}
 
-	if !available {
-		slog.WarnContext(ctx, "subdomain not available", "subdomain", r.Subdomain, "domain", domain)
-		return nil, connect.NewError(connect.CodeAlreadyExists, ErrSubdomainNotAvailable)
+	cluster, err := s.scheduler.Place(ctx, r.WorkspaceId, r.GetRegion())
+	if errors.Is(err, placement.ErrNoCluster) {
+		slog.WarnContext(ctx, "no cluster available", "workspace_id", r.WorkspaceId, "region", r.GetRegion())
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("%w: %w", ErrClusterNotFound, err))
+	}
+	if errors.Is(err, placement.ErrClusterUnavailable) {
+		slog.WarnContext(ctx, "workspace cluster is not healthy or active", "workspace_id", r.WorkspaceId, "error", err)
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("%w: %w", ErrClusterNotHealthy, err))
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to place app", "workspace_id", r.WorkspaceId, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
 	}
-
-	// todo: Get cluster details and validate health
-	// clusterDetails, err := s.queries.GetClusterDetails(ctx, r.ClusterId)
-	// if err != nil {
-	// 	slog.WarnContext(ctx, "cluster not found", "cluster_id", r.ClusterId)
-	// 	return nil, connect.NewError(connect.CodeNotFound, ErrClusterNotFound)
-	// }
-
-	// if !clusterDetails.IsActive.Bool || clusterDetails.HealthStatus.String != "healthy" {
-	// 	slog.WarnContext(ctx, "cluster is not healthy or active", "cluster_id", r.ClusterId, "is_active", clusterDetails.IsActive.Bool, "health_status", clusterDetails.HealthStatus.String)
-	// 	return nil, connect.NewError(connect.CodeFailedPrecondition, ErrClusterNotHealthy)
-	// }
 
 	// todo: set namepsace after creating and saving app. or perhaps its set after first deployment on the app.
-	app, err := s.queries.CreateApp(ctx, genDb.CreateAppParams{
+	app, err := qtx.CreateApp(ctx, genDb.CreateAppParams{
 		WorkspaceID: r.WorkspaceId,
-		ClusterID:   1,
+		ClusterID:   cluster.ID,
 		Name:        r.Name,
 		Type:        int32(r.Type.Number()),
 		Subdomain:   r.Subdomain,
@@ -123,6 +152,11 @@ func (s *AppServer) CreateApp(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "failed to commit transaction", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
 	return connect.NewResponse(&appv1.CreateAppResponse{
 		App: dbAppToProto(app),
 	}), nil
@@ -135,28 +169,12 @@ func (s *AppServer) GetApp(
 ) (*connect.Response[appv1.GetAppResponse], error) {
 	r := req.Msg
 
-	// todo: role checks should actually be done first.
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
 	app, err := s.queries.GetAppByID(ctx, r.Id)
 	if err != nil {
 		slog.WarnContext(ctx, "app not found", "id", r.Id)
 		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
 
-	_, err = s.queries.GetWorkspaceMember(ctx, genDb.GetWorkspaceMemberParams{
-		WorkspaceID: app.WorkspaceID,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of app's workspace", "workspaceId", app.WorkspaceID, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	return connect.NewResponse(&appv1.GetAppResponse{
 		App: dbAppToProto(app),
 	}), nil
@@ -169,21 +187,6 @@ func (s *AppServer) GetAppByName(
 ) (*connect.Response[appv1.GetAppByNameResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	_, err := s.queries.GetWorkspaceMember(ctx, genDb.GetWorkspaceMemberParams{
-		WorkspaceID: r.WorkspaceId,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", r.WorkspaceId, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	app, err := s.queries.GetAppByNameAndWorkspace(ctx, genDb.GetAppByNameAndWorkspaceParams{
 		WorkspaceID: r.WorkspaceId,
 		Name:        r.Name,
@@ -205,21 +208,6 @@ func (s *AppServer) ListApps(
 ) (*connect.Response[appv1.ListAppsResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	_, err := s.queries.GetWorkspaceMember(ctx, genDb.GetWorkspaceMemberParams{
-		WorkspaceID: r.WorkspaceId,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", r.WorkspaceId, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	dbApps, err := s.queries.ListAppsForWorkspace(ctx, r.WorkspaceId)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to list apps", "error", err)
@@ -243,32 +231,16 @@ func (s *AppServer) UpdateApp(
 ) (*connect.Response[appv1.UpdateAppResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
 	}
 
-	workspaceID, err := s.queries.GetAppWorkspaceID(ctx, r.Id)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
-		}
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-		WorkspaceID: workspaceID,
-		UserID:      userID,
-	})
+	current, err := s.queries.GetAppByID(ctx, r.Id)
 	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", fmt.Sprintf("%d", workspaceID), "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
-	if role != genDb.WorkspaceRoleAdmin && role != genDb.WorkspaceRoleDeploy {
-		slog.WarnContext(ctx, "user does not have permission to update app", "workspaceId", fmt.Sprintf("%d", workspaceID), "userId", userID, "role", string(role))
-		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("must be workspace admin or deploy role to update app"))
+		slog.WarnContext(ctx, "app not found", "id", r.Id)
+		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
 
 	updateParams := genDb.UpdateAppParams{
@@ -287,12 +259,35 @@ func (s *AppServer) UpdateApp(
 		updateParams.Domain = pgtype.Text{String: r.GetDomain(), Valid: true}
 	}
 
+	subdomain, domain := current.Subdomain, current.Domain
+	if updateParams.Subdomain.Valid {
+		subdomain = updateParams.Subdomain.String
+	}
+	if updateParams.Domain.Valid {
+		domain = updateParams.Domain.String
+	}
+	moved := subdomain != current.Subdomain || domain != current.Domain
+	if moved {
+		if _, err := reserveHostname(ctx, s.queries, current.WorkspaceID, subdomain, domain, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	app, err := s.queries.UpdateApp(ctx, updateParams)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to update app", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
 	}
 
+	// the app claims a path on its new hostname when it's next deployed
+	if moved {
+		if err := s.queries.DeleteRouteClaimForApp(ctx, app.ID); err != nil {
+			slog.ErrorContext(ctx, "failed to delete route claim", "app_id", app.ID, "error", err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		}
+		releaseHostname(ctx, s.queries, current.Subdomain, current.Domain)
+	}
+
 	return connect.NewResponse(&appv1.UpdateAppResponse{
 		App: dbAppToProto(app),
 	}), nil
@@ -305,32 +300,32 @@ func (s *AppServer) DeleteApp(
 ) (*connect.Response[appv1.DeleteAppResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	workspaceID, err := s.queries.GetAppWorkspaceID(ctx, r.Id)
+	app, err := s.queries.GetAppByID(ctx, r.Id)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
-		}
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		slog.WarnContext(ctx, "app not found", "id", r.Id)
+		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
 
-	role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-		WorkspaceID: workspaceID,
-		UserID:      userID,
-	})
+	gatewayPorts, err := s.queries.ListGatewayPortsForApp(ctx, app.ID)
 	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", fmt.Sprintf("%d", workspaceID), "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
+		slog.ErrorContext(ctx, "failed to list gateway ports", "app_id", app.ID, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
 	}
 
-	if role != genDb.WorkspaceRoleAdmin {
-		slog.WarnContext(ctx, "user is not an admin of workspace", "workspaceId", fmt.Sprintf("%d", workspaceID), "userId", userID, "role", string(role))
-		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("must be workspace admin to delete app"))
+	// retained volumes outlive the namespace, so they must be retained before anything is deleted
+	namespace := (&kube.LocoDeploymentContext{App: &app}).Namespace()
+	var retained []string
+	if r.RetainVolumes {
+		kc, err := s.clusters.Get(ctx, app.ClusterID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to get cluster client", "cluster_id", app.ClusterID, "error", err)
+			return nil, connect.NewError(connect.CodeUnavailable, err)
+		}
+		retained, err = kc.RetainVolumes(ctx, namespace)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to retain volumes", "app_id", app.ID, "error", err)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
 	}
 
 	err = s.queries.DeleteApp(ctx, r.Id)
@@ -339,8 +334,31 @@ func (s *AppServer) DeleteApp(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
 	}
 
+	if kc, err := s.clusters.Get(ctx, app.ClusterID); err != nil {
+		slog.ErrorContext(ctx, "failed to get cluster client", "cluster_id", app.ClusterID, "error", err)
+	} else {
+		// takes the data of persistent volumes that weren't retained with it
+		if err := kc.DeleteNS(ctx, namespace); err != nil {
+			slog.ErrorContext(ctx, "failed to delete app namespace", "namespace", namespace, "error", err)
+		}
+
+		// the app's gateway ports are freed with it, drop its listeners before another app is handed them
+		if len(gatewayPorts) > 0 {
+			if err := kc.UnexposePorts(ctx, app.ID); err != nil {
+				slog.ErrorContext(ctx, "failed to remove gateway listeners", "app_id", app.ID, "error", err)
+			}
+		}
+		// the wake grant of an app that slept lives in loco-system, not the app's namespace
+		if err := kc.DeleteWakeGrant(ctx, app.ID); err != nil {
+			slog.ErrorContext(ctx, "failed to delete wake grant", "app_id", app.ID, "error", err)
+		}
+	}
+
+	releaseHostname(ctx, s.queries, app.Subdomain, app.Domain)
+
 	return connect.NewResponse(&appv1.DeleteAppResponse{
-		Success: true,
+		Success:         true,
+		RetainedVolumes: retained,
 	}), nil
 }
 
@@ -352,8 +370,9 @@ func (s *AppServer) CheckSubdomainAvailability(
 	r := req.Msg
 
 	available, err := s.queries.CheckSubdomainAvailability(ctx, genDb.CheckSubdomainAvailabilityParams{
-		Subdomain: r.Subdomain,
-		Domain:    r.Domain,
+		Subdomain:   r.Subdomain,
+		Domain:      r.Domain,
+		WorkspaceID: r.GetWorkspaceId(),
 	})
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to check subdomain availability", "error", err)
@@ -372,27 +391,12 @@ func (s *AppServer) GetAppStatus(
 ) (*connect.Response[appv1.GetAppStatusResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
 	app, err := s.queries.GetAppByID(ctx, r.AppId)
 	if err != nil {
 		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
 		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
 
-	_, err = s.queries.GetWorkspaceMember(ctx, genDb.GetWorkspaceMemberParams{
-		WorkspaceID: app.WorkspaceID,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of app's workspace", "workspaceId", app.WorkspaceID, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	deploymentList, err := s.queries.ListDeploymentsForApp(ctx, genDb.ListDeploymentsForAppParams{
 		AppID:  r.AppId,
 		Limit:  1,
@@ -404,6 +408,8 @@ func (s *AppServer) GetAppStatus(
 	}
 
 	var deploymentStatus *appv1.DeploymentStatus
+	var endpoints []*appv1.Endpoint
+	var volumes []*appv1.VolumeStatus
 	if len(deploymentList) > 0 {
 		deployment := deploymentList[0]
 		deploymentStatus = &appv1.DeploymentStatus{
@@ -417,12 +423,77 @@ func (s *AppServer) GetAppStatus(
 		if deployment.ErrorMessage.Valid {
 			deploymentStatus.ErrorMessage = &deployment.ErrorMessage.String
 		}
+
+		ldc, err := kube.NewLocoDeploymentContext(&app, &deployment)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to read deployment config", "deployment_id", deployment.ID, "error", err)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, endpoint := range ldc.Endpoints() {
+			endpoints = append(endpoints, &appv1.Endpoint{
+				Name:     endpoint.Name,
+				Protocol: endpoint.Protocol,
+				Port:     endpoint.Port,
+				Public:   endpoint.Public,
+				Address:  endpoint.Address,
+			})
+		}
+
+		volumes = s.volumeStatus(ctx, &app, ldc)
+	}
+
+	sleepState, err := s.queries.GetAppSleep(ctx, app.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to get app sleep state", "app_id", app.ID, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
 	}
 
-	return connect.NewResponse(&appv1.GetAppStatusResponse{
+	resp := &appv1.GetAppStatusResponse{
 		App:               dbAppToProto(app),
 		CurrentDeployment: deploymentStatus,
-	}), nil
+		Endpoints:         endpoints,
+		Sleeping:          sleepState.SleptAt.Valid,
+		Volumes:           volumes,
+	}
+	if sleepState.SleptAt.Valid {
+		resp.SleptAt = timestamppb.New(sleepState.SleptAt.Time)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// volumeStatus lists the app's volumes with their usage. Usage is left out when the cluster can't be reached.
+func (s *AppServer) volumeStatus(ctx context.Context, app *genDb.App, ldc *kube.LocoDeploymentContext) []*appv1.VolumeStatus {
+	if len(ldc.Config.Volumes) == 0 {
+		return nil
+	}
+
+	usage := make([]kube.VolumeUsage, 0, len(ldc.Config.Volumes))
+	for _, volume := range ldc.Config.Volumes {
+		usage = append(usage, kube.VolumeUsage{Volume: volume})
+	}
+	if kc, err := s.clusters.Get(ctx, app.ClusterID); err != nil {
+		slog.WarnContext(ctx, "failed to get cluster client", "cluster_id", app.ClusterID, "error", err)
+	} else if reported, err := kc.VolumeUsageOf(ctx, ldc); err != nil {
+		slog.WarnContext(ctx, "failed to get volume usage", "app_id", app.ID, "error", err)
+	} else {
+		usage = reported
+	}
+
+	volumes := make([]*appv1.VolumeStatus, 0, len(usage))
+	for _, u := range usage {
+		volume := &appv1.VolumeStatus{
+			Name:      u.Volume.Name,
+			Type:      u.Volume.Type,
+			MountPath: u.Volume.MountPath,
+			Size:      u.Volume.Size,
+		}
+		if u.HasUsage {
+			volume.UsedBytes = &u.UsedBytes
+			volume.CapacityBytes = &u.CapacityBytes
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes
 }
 
 // StreamLogs streams logs for an app
@@ -433,46 +504,70 @@ func (s *AppServer) StreamLogs(
 ) error {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
 	app, err := s.queries.GetAppByID(ctx, r.AppId)
 	if err != nil {
 		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
 		return connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
 
-	_, err = s.queries.GetWorkspaceMember(ctx, genDb.GetWorkspaceMemberParams{
-		WorkspaceID: app.WorkspaceID,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of app's workspace", "workspaceId", app.WorkspaceID, "userId", userID)
-		return connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	if app.Namespace == "" {
 		slog.WarnContext(ctx, "app has no namespace assigned", "app_id", r.AppId)
 		return connect.NewError(connect.CodeFailedPrecondition, errors.New("app has not been deployed yet"))
 	}
 
+	for _, pattern := range []string{r.GetPod(), r.GetGrep(), r.GetExclude()} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %w", ErrInvalidLogFilter, err))
+		}
+	}
+
+	minLevel := klogmux.NormalizeLevel(r.GetLevel())
+	if r.GetLevel() != "" && minLevel == "" {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: unknown level %q", ErrInvalidLogFilter, r.GetLevel()))
+	}
+
+	// apps that declared structured logging have their lines split into a message and fields
+	parse := klogmux.ParseLevel
+	if s.structuredLogs(ctx, app.ID) {
+		parse = klogmux.ParseStructured
+	}
+
+	// history comes from the log store so pods that are gone are included, only live tails go to the cluster
+	if !r.GetFollow() && !r.GetPrevious() && s.logs != nil {
+		return s.queryLogs(ctx, app, r, minLevel, parse, stream)
+	}
+
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
+	if err != nil {
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+
 	slog.InfoContext(ctx, "streaming logs for app", "app_id", r.AppId, "app_namespace", app.Namespace)
 
 	// build label selector to find pods for this app
-	selector := labels.SelectorFromSet(labels.Set{"app": app.Name})
+	selector := labels.SelectorFromSet(labels.Set{kube.LabelAppName: app.Name})
 
 	// build the log stream
-	builder := klogmux.NewBuilder(s.kubeClient.ClientSet).
+	builder := klogmux.NewBuilder(kc.ClientSet).
 		Namespace(app.Namespace).
 		LabelSelector(selector.String()).
-		Follow(r.GetFollow())
+		Follow(r.GetFollow()).
+		Previous(r.GetPrevious()).
+		ContainerSelector(r.Containers...).
+		PodSelector(r.GetPod()).
+		MessageFilter(r.GetGrep()).
+		ExcludePattern(r.GetExclude()).
+		Transform(parse)
 
 	if r.Limit != nil {
 		builder.TailLines(int64(*r.Limit))
 	}
+	if r.Since != nil {
+		builder.Since(time.Since(r.Since.AsTime()))
+	}
+	if r.GetQuery() != "" {
+		builder.MessageFilter("(?i)" + regexp.QuoteMeta(r.GetQuery()))
+	}
 
 	logStream := builder.Build()
 
@@ -484,18 +579,18 @@ func (s *AppServer) StreamLogs(
 
 	// stream log entries to client
 	for entry := range logStream.Entries() {
+		if minLevel != "" && !klogmux.AtLeast(entry.Level, minLevel) {
+			continue
+		}
+
 		logProto := &appv1.LogEntry{
 			PodName:   entry.PodName,
 			Namespace: entry.Namespace,
 			Container: entry.Container,
 			Timestamp: timestamppb.New(entry.Timestamp),
 			Log:       entry.Message,
-		}
-
-		if entry.IsError {
-			logProto.Level = "ERROR"
-		} else {
-			logProto.Level = "INFO"
+			Level:     entry.Level,
+			Fields:    entry.Fields,
 		}
 
 		if err := stream.Send(logProto); err != nil {
@@ -516,32 +611,648 @@ func (s *AppServer) StreamLogs(
 	return nil
 }
 
-// GetEvents retrieves Kubernetes events for an app
-func (s *AppServer) GetEvents(
+// queryLogs sends an app's stored logs matching the request
+func (s *AppServer) queryLogs(
 	ctx context.Context,
-	req *connect.Request[appv1.GetEventsRequest],
-) (*connect.Response[appv1.GetEventsResponse], error) {
+	app genDb.App,
+	r *appv1.StreamLogsRequest,
+	minLevel string,
+	parse klogmux.TransformFunc,
+	stream *connect.ServerStream[appv1.LogEntry],
+) error {
+	q := logstore.Query{
+		Namespace:  app.Namespace,
+		AppName:    app.Name,
+		Contains:   r.GetQuery(),
+		MinLevel:   minLevel,
+		Pod:        r.GetPod(),
+		Containers: r.Containers,
+		Match:      r.GetGrep(),
+		Exclude:    r.GetExclude(),
+		Limit:      int(r.GetLimit()),
+		Ascending:  r.GetOrder() == appv1.LogOrder_LOG_ORDER_ASC,
+	}
+	if r.Since != nil {
+		q.Since = r.Since.AsTime()
+	}
+	if r.Until != nil {
+		q.Until = r.Until.AsTime()
+	}
+
+	slog.InfoContext(ctx, "querying stored logs for app", "app_id", app.ID, "since", q.Since, "until", q.Until)
+
+	entries, err := s.logs.Query(ctx, q)
+	if errors.Is(err, logstore.ErrInvalidRange) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query stored logs", "app_id", app.ID, "error", err)
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to query logs: %w", err))
+	}
+
+	for _, entry := range entries {
+		// the store already derived the level, parsing only splits out the message and fields
+		parsed := parse(klogmux.LogEntry{Message: entry.Message})
+		if err := stream.Send(&appv1.LogEntry{
+			PodName:   entry.PodName,
+			Namespace: app.Namespace,
+			Container: entry.Container,
+			Timestamp: timestamppb.New(entry.Timestamp),
+			Log:       parsed.Message,
+			Level:     entry.Level,
+			Fields:    parsed.Fields,
+		}); err != nil {
+			slog.ErrorContext(ctx, "failed to send log entry", "error", err)
+			return err
+		}
+	}
+
+	slog.DebugContext(ctx, "stored log query completed", "app_id", app.ID, "entries", len(entries))
+	return nil
+}
+
+// RunCommand runs a one-off command in a Job from the app's current deployment and streams its logs until it exits.
+// The Job is deleted afterwards, also when the client goes away.
+func (s *AppServer) RunCommand(
+	ctx context.Context,
+	req *connect.Request[appv1.RunCommandRequest],
+	stream *connect.ServerStream[appv1.RunCommandResponse],
+) error {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
+	if len(r.Command) == 0 || r.Command[0] == "" {
+		return connect.NewError(connect.CodeInvalidArgument, ErrEmptyCommand)
 	}
 
+	kc, ldc, err := s.currentDeployment(ctx, r.AppId)
+	if err != nil {
+		return err
+	}
+
+	cpu, memory := r.GetCpu(), r.GetMemory()
+	if cpu == "" {
+		cpu = ldc.Config.Resources.CPU
+	}
+	if memory == "" {
+		memory = ldc.Config.Resources.Memory
+	}
+
+	// the workspace quota stays locked until the Job exists, so a concurrent deploy can't fit in the same room
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to begin transaction", "error", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.quotas.WithTx(tx).CheckRun(ctx, ldc.App.WorkspaceID, cpu, memory); err != nil {
+		slog.WarnContext(ctx, "run quota check failed", "app_id", r.AppId, "error", err)
+		return err
+	}
+
+	job, err := kc.CreateRunJob(ctx, ldc, r.Command, r.GetCpu(), r.GetMemory())
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	defer func() {
+		// the client may be gone already, the Job still has to go
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := kc.DeleteRunJob(cleanupCtx, ldc.Namespace(), job.Name); err != nil {
+			slog.WarnContext(ctx, "failed to delete run job, the cluster deletes it later", "job", job.Name, "error", err)
+		}
+	}()
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "failed to commit transaction", "error", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	slog.InfoContext(ctx, "running command", "app_id", r.AppId, "job", job.Name)
+	if err := stream.Send(&appv1.RunCommandResponse{Job: &job.Name}); err != nil {
+		return err
+	}
+
+	pod, err := kc.WaitForRunPod(ctx, ldc.Namespace(), job.Name)
+	if err != nil {
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+
+	result, err := kc.FollowRun(ctx, ldc.Namespace(), job.Name, pod, func(entry klogmux.LogEntry) error {
+		return stream.Send(&appv1.RunCommandResponse{
+			Log: &appv1.LogEntry{
+				PodName:   entry.PodName,
+				Namespace: entry.Namespace,
+				Container: entry.Container,
+				Timestamp: timestamppb.New(entry.Timestamp),
+				Log:       entry.Message,
+				Level:     entry.Level,
+			},
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to follow command", "job", job.Name, "error", err)
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	slog.InfoContext(ctx, "command exited", "app_id", r.AppId, "job", job.Name, "exit_code", result.ExitCode, "reason", result.Reason)
+	resp := &appv1.RunCommandResponse{ExitCode: &result.ExitCode}
+	if result.Reason != "" {
+		resp.Reason = &result.Reason
+	}
+	return stream.Send(resp)
+}
+
+// Exec runs a command in one of an app's running containers and proxies its stdin, output and terminal
+// resizes over the stream until it exits
+func (s *AppServer) Exec(
+	ctx context.Context,
+	stream *connect.BidiStream[appv1.ExecRequest, appv1.ExecResponse],
+) error {
+	r, err := stream.Receive()
+	if err != nil {
+		return err
+	}
+
+	if len(r.Command) == 0 || r.Command[0] == "" {
+		return connect.NewError(connect.CodeInvalidArgument, ErrEmptyCommand)
+	}
+
+	kc, ldc, err := s.currentDeployment(ctx, r.AppId)
+	if err != nil {
+		return err
+	}
+
+	pod, err := kc.RunningPod(ctx, ldc, r.GetPod())
+	if err != nil {
+		return runningPodError(err)
+	}
+
+	slog.InfoContext(ctx, "exec started", "app_id", r.AppId, "pod", pod, "tty", r.Tty)
+	if err := stream.Send(&appv1.ExecResponse{Pod: &pod}); err != nil {
+		return err
+	}
+
+	// cancelled when the client goes away, ending the command's streams
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stdin, stdinWriter := io.Pipe()
+	// unblocks a client write still waiting on stdin once the command has exited
+	defer stdin.Close()
+	sizes := make(chan kube.TerminalSize, 1)
+
+	go func() {
+		defer close(sizes)
+		for msg := r; ; {
+			if len(msg.Stdin) > 0 {
+				// fails once stdin is closed, later input has nowhere to go
+				_, _ = stdinWriter.Write(msg.Stdin)
+			}
+			if msg.Size != nil && r.Tty {
+				select {
+				case sizes <- kube.TerminalSize{Width: uint16(msg.Size.Width), Height: uint16(msg.Size.Height)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			next, err := stream.Receive()
+			if errors.Is(err, io.EOF) {
+				stdinWriter.Close()
+				return
+			}
+			if err != nil {
+				cancel()
+				stdinWriter.CloseWithError(err)
+				return
+			}
+			msg = next
+		}
+	}()
+
+	var sendMu sync.Mutex
+	exitCode, err := kc.Exec(ctx, ldc, pod, kube.ExecOptions{
+		Command: r.Command,
+		TTY:     r.Tty,
+		Stdin:   stdin,
+		Stdout:  &execOutput{mu: &sendMu, stream: stream},
+		Stderr:  &execOutput{mu: &sendMu, stream: stream, stderr: true},
+		Sizes:   sizes,
+	})
+	if err != nil {
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	slog.InfoContext(ctx, "exec exited", "app_id", r.AppId, "pod", pod, "exit_code", exitCode)
+	sendMu.Lock()
+	defer sendMu.Unlock()
+	return stream.Send(&appv1.ExecResponse{ExitCode: &exitCode})
+}
+
+// PortForward tunnels the client's connections to a port of one of an app's running pods, all over one stream
+func (s *AppServer) PortForward(
+	ctx context.Context,
+	stream *connect.BidiStream[appv1.PortForwardRequest, appv1.PortForwardResponse],
+) error {
+	r, err := stream.Receive()
+	if err != nil {
+		return err
+	}
+
+	if r.Port < 1 || r.Port > 65535 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %d", ErrInvalidPort, r.Port))
+	}
+
+	kc, ldc, err := s.currentDeployment(ctx, r.AppId)
+	if err != nil {
+		return err
+	}
+
+	pod, err := kc.RunningPod(ctx, ldc, r.GetPod())
+	if err != nil {
+		return runningPodError(err)
+	}
+
+	pf, err := kc.PortForward(ctx, ldc, pod, r.Port)
+	if err != nil {
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	var sendMu sync.Mutex
+	send := func(resp *appv1.PortForwardResponse) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(resp)
+	}
+
+	var connsMu sync.Mutex
+	conns := map[uint32]*forwardQueue{}
+	// ids only increase, data that arrives for a connection after it closed never opens a new one
+	var lastID uint32
+	var wg sync.WaitGroup
+	defer func() {
+		// closing the tunnel ends every connection over it
+		pf.Close()
+		wg.Wait()
+	}()
+
+	slog.InfoContext(ctx, "port-forward started", "app_id", r.AppId, "pod", pod, "port", r.Port)
+	if err := send(&appv1.PortForwardResponse{Pod: &pod}); err != nil {
+		return err
+	}
+
+	// the stream is read apart from the loop below, so a tunnel that closes under it ends the call
+	received := make(chan *appv1.PortForwardRequest)
+	receiveErr := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Receive()
+			if err != nil {
+				receiveErr <- err
+				return
+			}
+			select {
+			case received <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// the loop only queues, dialing and writing happen on each connection's own goroutine, so a connection the
+	// pod is slow to accept or read holds up only itself
+	for {
+		var msg *appv1.PortForwardRequest
+		select {
+		case <-pf.Done():
+			return connect.NewError(connect.CodeUnavailable, ErrPortForwardClosed)
+		case err := <-receiveErr:
+			if errors.Is(err, io.EOF) {
+				slog.InfoContext(ctx, "port-forward ended", "app_id", r.AppId, "pod", pod, "port", r.Port)
+				return nil
+			}
+			return err
+		case msg = <-received:
+		}
+
+		connsMu.Lock()
+		queue, ok := conns[msg.ConnectionId]
+		if !ok && !msg.Close && msg.ConnectionId > lastID {
+			lastID = msg.ConnectionId
+			queue = &forwardQueue{writes: make(chan []byte, forwardQueueSize), dropped: make(chan struct{})}
+			conns[msg.ConnectionId] = queue
+			ok = true
+
+			wg.Add(1)
+			go func(id uint32) {
+				defer wg.Done()
+				forwardConn(pf, id, queue, send)
+				connsMu.Lock()
+				if conns[id] == queue {
+					delete(conns, id)
+				}
+				connsMu.Unlock()
+			}(msg.ConnectionId)
+		}
+		if !ok {
+			connsMu.Unlock()
+			continue
+		}
+
+		switch {
+		case len(msg.Data) > 0 && !queue.push(msg.Data):
+			// the pod stopped reading, the connection is dropped rather than let it hold up the others
+			close(queue.dropped)
+			delete(conns, msg.ConnectionId)
+			connsMu.Unlock()
+			errMsg := "connection dropped, the pod stopped reading from it"
+			if err := send(&appv1.PortForwardResponse{ConnectionId: msg.ConnectionId, Close: true, Error: &errMsg}); err != nil {
+				return err
+			}
+			continue
+		case msg.Close:
+			close(queue.writes)
+			delete(conns, msg.ConnectionId)
+		}
+		connsMu.Unlock()
+	}
+}
+
+// forwardQueueSize is how many chunks from the client queue up for a forwarded connection before it counts as stalled
+const forwardQueueSize = 256
+
+// forwardQueue holds what the client sent a forwarded connection until its goroutine writes it to the pod.
+// writes is closed once the client closed its side, dropped when the connection is given up on.
+type forwardQueue struct {
+	writes  chan []byte
+	dropped chan struct{}
+}
+
+// push queues data without waiting, false means the queue is full
+func (q *forwardQueue) push(data []byte) bool {
+	select {
+	case q.writes <- data:
+		return true
+	default:
+		return false
+	}
+}
+
+// forwardConn opens a connection to the forwarded port and writes what's queued for it, while forwardToClient
+// sends back what the pod writes. It returns once both sides are done.
+func forwardConn(pf *kube.PortForward, id uint32, queue *forwardQueue, send func(*appv1.PortForwardResponse) error) {
+	conn, err := pf.Dial()
+	if err != nil {
+		errMsg := err.Error()
+		_ = send(&appv1.PortForwardResponse{ConnectionId: id, Close: true, Error: &errMsg})
+		return
+	}
+
+	read := make(chan struct{})
+	go func() {
+		defer close(read)
+		forwardToClient(id, conn, send)
+	}()
+	defer func() { <-read }()
+	go func() {
+		select {
+		case <-queue.dropped:
+			// resetting the stream also fails a write the pod stopped reading and ends forwardToClient's read
+			conn.Close()
+		case <-read:
+		}
+	}()
+
+	for {
+		select {
+		case data, ok := <-queue.writes:
+			if !ok {
+				_ = conn.CloseWrite()
+				return
+			}
+			// a write that fails means the pod closed the connection, which the client hears about from forwardToClient
+			_, _ = conn.Write(data)
+		case <-queue.dropped:
+			return
+		case <-pf.Done():
+			return
+		case <-read:
+			// the pod closed the connection, what's still queued has nowhere to go
+			return
+		}
+	}
+}
+
+// forwardToClient sends what the pod writes to a forwarded connection over the stream, then its end
+func forwardToClient(id uint32, conn *kube.ForwardedConn, send func(*appv1.PortForwardResponse) error) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			if err := send(&appv1.PortForwardResponse{ConnectionId: id, Data: buf[:n]}); err != nil {
+				conn.Close()
+				return
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	resp := &appv1.PortForwardResponse{ConnectionId: id, Close: true}
+	if err := conn.Close(); err != nil {
+		errMsg := err.Error()
+		resp.Error = &errMsg
+	}
+	_ = send(resp)
+}
+
+// currentDeployment loads the cluster client and deployment context of an app's current deployment
+func (s *AppServer) currentDeployment(ctx context.Context, appID int64) (*kube.Client, *kube.LocoDeploymentContext, error) {
+	app, err := s.queries.GetAppByID(ctx, appID)
+	if err != nil {
+		slog.WarnContext(ctx, "app not found", "app_id", appID)
+		return nil, nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
+	}
+
+	deployment, err := s.queries.GetCurrentDeploymentForApp(ctx, app.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, connect.NewError(connect.CodeFailedPrecondition, ErrNoDeployment)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get current deployment", "app_id", app.ID, "error", err)
+		return nil, nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	ldc, err := kube.NewLocoDeploymentContext(&app, &deployment)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read deployment config", "deployment_id", deployment.ID, "error", err)
+		return nil, nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	return kc, ldc, nil
+}
+
+// runningPodError maps the errors of picking a running pod to connect errors
+func runningPodError(err error) error {
+	switch {
+	case errors.Is(err, kube.ErrPodNotInApp):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, kube.ErrNoRunningPod):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	default:
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+}
+
+// execOutput sends what a command writes to its stdout or stderr over an exec stream.
+// Both share mu, a stream can't send from two goroutines at once.
+type execOutput struct {
+	mu     *sync.Mutex
+	stream *connect.BidiStream[appv1.ExecRequest, appv1.ExecResponse]
+	stderr bool
+}
+
+func (w *execOutput) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	resp := &appv1.ExecResponse{}
+	if w.stderr {
+		resp.Stderr = p
+	} else {
+		resp.Stdout = p
+	}
+	if err := w.stream.Send(resp); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// structuredLogs reports whether the app's current deployment declared structured logging in loco.toml
+func (s *AppServer) structuredLogs(ctx context.Context, appID int64) bool {
+	deployment, err := s.queries.GetCurrentDeploymentForApp(ctx, appID)
+	if err != nil {
+		return false
+	}
+	cfg, err := kube.UnmarshalConfig(deployment.Config)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to parse deployment config", "deployment_id", deployment.ID, "error", err)
+		return false
+	}
+	return cfg.Obs.Logging.Structured
+}
+
+// GetAppMetrics returns the live usage of an app's pods and, when a metrics store is configured, their history
+// along with the request rate and latency of the app's hostname
+func (s *AppServer) GetAppMetrics(
+	ctx context.Context,
+	req *connect.Request[appv1.GetAppMetricsRequest],
+) (*connect.Response[appv1.GetAppMetricsResponse], error) {
+	r := req.Msg
+
 	app, err := s.queries.GetAppByID(ctx, r.AppId)
 	if err != nil {
 		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
 		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
 
-	_, err = s.queries.GetWorkspaceMember(ctx, genDb.GetWorkspaceMemberParams{
-		WorkspaceID: app.WorkspaceID,
-		UserID:      userID,
-	})
+	if app.Namespace == "" {
+		slog.WarnContext(ctx, "app has no namespace assigned", "app_id", r.AppId)
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("app has not been deployed yet"))
+	}
+
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{kube.LabelAppName: app.Name})
+	pods, err := kc.PodMetrics(ctx, app.Namespace, selector.String())
 	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of app's workspace", "workspaceId", app.WorkspaceID, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
+		slog.ErrorContext(ctx, "failed to read pod metrics", "app_id", app.ID, "error", err)
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to read pod metrics: %w", err))
+	}
+
+	resp := &appv1.GetAppMetricsResponse{CollectedAt: timestamppb.Now()}
+	for _, pod := range pods {
+		pm := &appv1.PodMetrics{
+			Pod:      pod.Name,
+			Phase:    pod.Phase,
+			Ready:    pod.Ready,
+			Restarts: pod.Restarts,
+		}
+		if pod.HasUsage {
+			pm.CpuMillicores = &pod.CPUMillicores
+			pm.MemoryBytes = &pod.MemoryBytes
+			resp.CpuMillicores += pod.CPUMillicores
+			resp.MemoryBytes += pod.MemoryBytes
+		}
+		resp.Restarts += pod.Restarts
+		resp.Pods = append(resp.Pods, pm)
+	}
+
+	if s.metrics == nil {
+		return connect.NewResponse(resp), nil
+	}
+
+	q := metricstore.Query{
+		Namespace: app.Namespace,
+		RouteName: app.Name,
+		Hostname:  fmt.Sprintf("%s.%s", app.Subdomain, app.Domain),
+		Step:      time.Duration(r.GetStepSeconds()) * time.Second,
+	}
+	if r.Since != nil {
+		q.Since = r.Since.AsTime()
+	}
+	if r.Until != nil {
+		q.Until = r.Until.AsTime()
+	}
+
+	result, err := s.metrics.Query(ctx, q)
+	if errors.Is(err, metricstore.ErrInvalidRange) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to query stored metrics", "app_id", app.ID, "error", err)
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to query metrics: %w", err))
+	}
+
+	resp.StepSeconds = int32(result.Step.Seconds())
+	for _, series := range result.Series {
+		ms := &appv1.MetricSeries{Name: series.Name}
+		if series.Pod != "" {
+			ms.Pod = &series.Pod
+		}
+		if series.Hostname != "" {
+			ms.Hostname = &series.Hostname
+		}
+		for _, p := range series.Points {
+			ms.Points = append(ms.Points, &appv1.MetricPoint{Timestamp: timestamppb.New(p.Timestamp), Value: p.Value})
+		}
+		resp.Series = append(resp.Series, ms)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// GetEvents retrieves Kubernetes events for an app
+func (s *AppServer) GetEvents(
+	ctx context.Context,
+	req *connect.Request[appv1.GetEventsRequest],
+) (*connect.Response[appv1.GetEventsResponse], error) {
+	r := req.Msg
+
+	app, err := s.queries.GetAppByID(ctx, r.AppId)
+	if err != nil {
+		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
+		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
 
 	if app.Namespace == "" {
@@ -549,9 +1260,14 @@ func (s *AppServer) GetEvents(
 		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("app has not been deployed yet"))
 	}
 
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
 	slog.InfoContext(ctx, "fetching events for app", "app_id", r.AppId, "app_namespace", app.Namespace)
 
-	eventList, err := s.kubeClient.ClientSet.CoreV1().Events(app.Namespace).List(ctx, metav1.ListOptions{})
+	eventList, err := kc.ClientSet.CoreV1().Events(app.Namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to list events from kubernetes", "error", err, "namespace", app.Namespace)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to fetch events: %w", err))
@@ -574,6 +1290,25 @@ func (s *AppServer) GetEvents(
 		protoEvents = append(protoEvents, protoEvent)
 	}
 
+	// loco's own events, like restarts, aren't in the cluster's
+	appEvents, err := s.queries.ListDeploymentEventsForApp(ctx, genDb.ListDeploymentEventsForAppParams{
+		AppID: app.ID,
+		Limit: maxAppEvents,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list deployment events", "app_id", app.ID, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	for _, e := range appEvents {
+		protoEvents = append(protoEvents, &appv1.Event{
+			Timestamp: timestamppb.New(e.CreatedAt.Time),
+			Reason:    e.Reason,
+			Message:   e.Message,
+			Type:      "Normal",
+			PodName:   e.PodName.String,
+		})
+	}
+
 	// sort by timestamp descending (newest first)
 	sort.Slice(protoEvents, func(i, j int) bool {
 		return protoEvents[i].Timestamp.AsTime().After(protoEvents[j].Timestamp.AsTime())
@@ -598,7 +1333,7 @@ func (s *AppServer) ScaleApp(
 ) (*connect.Response[appv1.ScaleAppResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
@@ -617,70 +1352,17 @@ func (s *AppServer) ScaleApp(
 		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
 		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
-	workspaceID := app.WorkspaceID
 
-	role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-		WorkspaceID: workspaceID,
-		UserID:      userID,
-	})
+	currentDeployment, config, err := s.currentConfig(ctx, app.ID)
 	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", workspaceID, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
+		return nil, err
 	}
 
-	if role != genDb.WorkspaceRoleAdmin && role != genDb.WorkspaceRoleDeploy {
-		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("must be workspace admin or have deploy role"))
-	}
-
-	deploymentList, err := s.queries.ListDeploymentsForApp(ctx, genDb.ListDeploymentsForAppParams{
-		AppID:  r.AppId,
-		Limit:  1,
-		Offset: 0,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to list deployments", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if len(deploymentList) == 0 {
-		return nil, connect.NewError(connect.CodeNotFound, errors.New("no existing deployment found for app"))
-	}
-
-	currentDeployment := deploymentList[0]
-
-	var config map[string]any
-	if len(currentDeployment.Config) > 0 {
-		if err := json.Unmarshal(currentDeployment.Config, &config); err != nil {
-			slog.ErrorContext(ctx, "failed to parse deployment config", "error", err)
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %w", err))
-		}
-	} else {
-		config = make(map[string]any)
-	}
-
-	var env map[string]string
+	env := make(map[string]string)
 	if envData, ok := config["env"].(map[string]any); ok {
-		env = make(map[string]string)
 		for k, v := range envData {
 			env[k] = v.(string)
 		}
-	} else {
-		env = make(map[string]string)
-	}
-
-	var cpu, memory *string
-	if resourceData, ok := config["resources"].(map[string]any); ok {
-		if cpuVal, ok := resourceData["cpu"].(string); ok && cpuVal != "" {
-			cpu = &cpuVal
-		}
-		if memoryVal, ok := resourceData["memory"].(string); ok && memoryVal != "" {
-			memory = &memoryVal
-		}
-	}
-
-	var ports []any
-	if portData, ok := config["ports"].([]any); ok {
-		ports = portData
 	}
 
 	replicas := currentDeployment.Replicas
@@ -688,57 +1370,33 @@ func (s *AppServer) ScaleApp(
 		replicas = *r.Replicas
 	}
 
-	if r.Cpu != nil {
-		cpu = r.Cpu
-	}
-
-	if r.Memory != nil {
-		memory = r.Memory
-	}
-
-	resources := map[string]any{}
-	if cpu != nil {
-		resources["cpu"] = *cpu
+	currentConfig, err := kube.UnmarshalConfig(currentDeployment.Config)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to parse deployment config", "error", err)
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %w", err))
 	}
-	if memory != nil {
-		resources["memory"] = *memory
+	if replicas > 1 && sharedConfig.HasPersistentVolumes(currentConfig.Volumes) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, ErrPersistentVolumeReplicas)
 	}
 
-	updatedConfig := map[string]any{
-		"env":       env,
-		"ports":     ports,
-		"resources": resources,
+	// only what's scaled changes, the rest of the app's config is carried over as is
+	resources, ok := config["resources"].(map[string]any)
+	if !ok {
+		resources = map[string]any{}
 	}
-	configJSON, err := json.Marshal(updatedConfig)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to marshal config", "error", err)
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %w", err))
+	if r.Cpu != nil {
+		resources["cpu"] = *r.Cpu
 	}
-
-	err = s.queries.MarkPreviousDeploymentsNotCurrent(ctx, r.AppId)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to mark previous deployments not current", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	if r.Memory != nil {
+		resources["memory"] = *r.Memory
 	}
+	config["resources"] = resources
 
-	deployment, err := s.queries.CreateDeployment(ctx, genDb.CreateDeploymentParams{
-		AppID:         r.AppId,
-		ClusterID:     1,
-		Image:         currentDeployment.Image,
-		Replicas:      replicas,
-		Status:        genDb.DeploymentStatusPending,
-		IsCurrent:     true,
-		CreatedBy:     userID,
-		Config:        configJSON,
-		SchemaVersion: pgtype.Int4{Int32: 1, Valid: true},
-	})
+	deployment, err := s.deployments.redeploy(ctx, &app, currentDeployment.Image, replicas, config, env, userID)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to create deployment", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		return nil, err
 	}
 
-	go s.allocateDeployment(context.Background(), &app, &deployment, env)
-
 	deploymentStatus := &appv1.DeploymentStatus{
 		Id:       deployment.ID,
 		Status:   string(deployment.Status),
@@ -765,7 +1423,7 @@ func (s *AppServer) UpdateAppEnv(
 ) (*connect.Response[appv1.UpdateAppEnvResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
@@ -780,105 +1438,19 @@ func (s *AppServer) UpdateAppEnv(
 		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
 		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
-	workspaceID := app.WorkspaceID
-
-	role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-		WorkspaceID: workspaceID,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", workspaceID, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
-	if role != genDb.WorkspaceRoleAdmin && role != genDb.WorkspaceRoleDeploy {
-		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("must be workspace admin or have deploy role"))
-	}
-
-	deploymentList, err := s.queries.ListDeploymentsForApp(ctx, genDb.ListDeploymentsForAppParams{
-		AppID:  r.AppId,
-		Limit:  1,
-		Offset: 0,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to list deployments", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if len(deploymentList) == 0 {
-		return nil, connect.NewError(connect.CodeNotFound, errors.New("no existing deployment found for app"))
-	}
 
-	currentDeployment := deploymentList[0]
-
-	var config map[string]any
-	if len(currentDeployment.Config) > 0 {
-		if err := json.Unmarshal(currentDeployment.Config, &config); err != nil {
-			slog.ErrorContext(ctx, "failed to parse deployment config", "error", err)
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %w", err))
-		}
-	} else {
-		config = make(map[string]any)
-	}
-
-	var cpu, memory *string
-	if resourceData, ok := config["resources"].(map[string]any); ok {
-		if cpuVal, ok := resourceData["cpu"].(string); ok && cpuVal != "" {
-			cpu = &cpuVal
-		}
-		if memoryVal, ok := resourceData["memory"].(string); ok && memoryVal != "" {
-			memory = &memoryVal
-		}
-	}
-
-	var ports []any
-	if portData, ok := config["ports"].([]any); ok {
-		ports = portData
-	}
-
-	resources := map[string]any{}
-	if cpu != nil {
-		resources["cpu"] = *cpu
-	}
-	if memory != nil {
-		resources["memory"] = *memory
-	}
-
-	updatedConfig := map[string]any{
-		"env":       r.Env,
-		"ports":     ports,
-		"resources": resources,
-	}
-	configJSON, err := json.Marshal(updatedConfig)
+	currentDeployment, config, err := s.currentConfig(ctx, app.ID)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to marshal config", "error", err)
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %w", err))
-	}
-
-	err = s.queries.MarkPreviousDeploymentsNotCurrent(ctx, r.AppId)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to mark previous deployments not current", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		return nil, err
 	}
+	// only the env changes, the rest of the app's config is carried over as is
+	config["env"] = r.Env
 
-	deployment, err := s.queries.CreateDeployment(ctx, genDb.CreateDeploymentParams{
-		AppID:         r.AppId,
-		ClusterID:     1,
-		Image:         currentDeployment.Image,
-		Replicas:      currentDeployment.Replicas,
-		Status:        genDb.DeploymentStatusPending,
-		IsCurrent:     true,
-		CreatedBy:     userID,
-		Config:        configJSON,
-		SchemaVersion: pgtype.Int4{Int32: 1, Valid: true},
-	})
+	deployment, err := s.deployments.redeploy(ctx, &app, currentDeployment.Image, currentDeployment.Replicas, config, r.Env, userID)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to create deployment", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		return nil, err
 	}
 
-	go s.allocateDeployment(context.Background(), &app, &deployment, r.Env)
-
 	deploymentStatus := &appv1.DeploymentStatus{
 		Id:       deployment.ID,
 		Status:   string(deployment.Status),
@@ -898,54 +1470,117 @@ func (s *AppServer) UpdateAppEnv(
 	}), nil
 }
 
-// allocateDeployment runs as a background goroutine that allocates Kubernetes resources
-func (s *AppServer) allocateDeployment(
-	ctx context.Context,
-	app *genDb.App,
-	deployment *genDb.Deployment,
-	envVars map[string]string,
-) {
-	slog.InfoContext(ctx, "Starting deployment allocation", "deployment_id", deployment.ID, "app_id", app.ID)
+// currentConfig loads an app's current deployment and its config as a map, for a new deployment to change
+// part of it
+func (s *AppServer) currentConfig(ctx context.Context, appID int64) (genDb.Deployment, map[string]any, error) {
+	deployment, err := s.queries.GetCurrentDeploymentForApp(ctx, appID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return genDb.Deployment{}, nil, connect.NewError(connect.CodeNotFound, errors.New("no existing deployment found for app"))
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get current deployment", "app_id", appID, "error", err)
+		return genDb.Deployment{}, nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
 
-	var config map[string]any
+	config := make(map[string]any)
 	if len(deployment.Config) > 0 {
 		if err := json.Unmarshal(deployment.Config, &config); err != nil {
-			slog.ErrorContext(ctx, "Failed to parse deployment config", "deployment_id", deployment.ID, "error", err)
-			s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Failed to parse config: %v", err))
-			return
+			slog.ErrorContext(ctx, "failed to parse deployment config", "error", err)
+			return genDb.Deployment{}, nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %w", err))
 		}
 	}
+	return deployment, config, nil
+}
 
-	ldc, err := kube.NewLocoDeploymentContext(app, deployment)
+// RestartApp replaces an app's pods, or a single one, without changing its deployment. The rollout's progress is
+// reported until the pods are ready when wait is set.
+func (s *AppServer) RestartApp(
+	ctx context.Context,
+	req *connect.Request[appv1.RestartAppRequest],
+	stream *connect.ServerStream[appv1.RestartAppEvent],
+) error {
+	r := req.Msg
+
+	userID, ok := contextkeys.UserID(ctx)
+	if !ok {
+		slog.ErrorContext(ctx, "userId not found in context")
+		return connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
+	}
+
+	kc, ldc, err := s.currentDeployment(ctx, r.AppId)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create deployment context", "deployment_id", deployment.ID, "error", err)
-		s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Failed to create deployment context: %v", err))
-		return
+		return err
 	}
 
-	s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusInProgress, "Allocating Kubernetes resources...")
+	send := func(status genDb.DeploymentStatus, message string, failure error) error {
+		event := &appv1.RestartAppEvent{
+			Status:    string(status),
+			Message:   message,
+			Timestamp: timestamppb.Now(),
+		}
+		if failure != nil {
+			errMsg := failure.Error()
+			event.ErrorMessage = &errMsg
+		}
+		return stream.Send(event)
+	}
 
-	if err := s.kubeClient.AllocateResources(ctx, ldc, envVars, nil); err != nil {
-		slog.ErrorContext(ctx, "Failed to allocate Kubernetes resources", "deployment_id", deployment.ID, "error", err)
-		s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Failed to allocate resources: %v", err))
-		return
+	if r.Pod != nil {
+		err = kc.RestartPod(ctx, ldc, r.GetPod())
+		if errors.Is(err, kube.ErrPodNotInApp) {
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+		if err != nil {
+			return connect.NewError(connect.CodeUnavailable, err)
+		}
+		slog.InfoContext(ctx, "restarted pod", "app_id", r.AppId, "pod", r.GetPod())
+	} else {
+		if err := kc.RestartDeployment(ctx, ldc.Namespace(), ldc.DeploymentName()); err != nil {
+			return connect.NewError(connect.CodeUnavailable, err)
+		}
+		slog.InfoContext(ctx, "restarted app", "app_id", r.AppId)
 	}
 
-	s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusSucceeded, "Deployment successful")
-	slog.InfoContext(ctx, "Deployment allocation completed", "deployment_id", deployment.ID)
-}
+	// restarts are part of the app's history, listed with its events
+	message := "Pods are being replaced one at a time"
+	if r.Pod != nil {
+		message = fmt.Sprintf("Pod %s is being replaced", r.GetPod())
+	}
+	err = s.queries.CreateDeploymentEvent(ctx, genDb.CreateDeploymentEventParams{
+		DeploymentID: ldc.Deployment.ID,
+		Reason:       "Restarted",
+		Message:      message,
+		PodName:      pgtype.Text{String: r.GetPod(), Valid: r.Pod != nil},
+		CreatedBy:    userID,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to record restart", "app_id", r.AppId, "error", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	err = send(genDb.DeploymentStatusInProgress, message, nil)
+	if err != nil || !r.Wait {
+		return err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, restartWaitTimeout)
+	defer cancel()
+
+	if r.Pod != nil {
+		// the deployment only counts the replacement once the old pod is gone
+		if err := kc.WaitForPodDeleted(waitCtx, ldc.Namespace(), r.GetPod(), restartPollInterval); err != nil {
+			return send(genDb.DeploymentStatusFailed, "Pod did not shut down", err)
+		}
+	}
 
-// updateDeploymentStatus updates the deployment status in the database
-func (s *AppServer) updateDeploymentStatus(ctx context.Context, deploymentID int64, status genDb.DeploymentStatus, message string) {
-	messageParam := pgtype.Text{String: message, Valid: message != ""}
-	err := s.queries.UpdateDeploymentStatusWithMessage(ctx, genDb.UpdateDeploymentStatusWithMessageParams{
-		ID:      deploymentID,
-		Status:  status,
-		Message: messageParam,
+	err = kc.WatchRollout(waitCtx, ldc.Namespace(), ldc.DeploymentName(), restartPollInterval, func(p kube.RolloutProgress) error {
+		return send(genDb.DeploymentStatusInProgress, fmt.Sprintf("%d/%d pods restarted, %d available", p.Updated, p.Replicas, p.Available), nil)
 	})
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to update deployment status", "deployment_id", deploymentID, "error", err)
+		slog.WarnContext(ctx, "restart did not become ready", "app_id", r.AppId, "error", err)
+		return send(genDb.DeploymentStatusFailed, "Restarted pods did not become ready", err)
 	}
+	return send(genDb.DeploymentStatusSucceeded, "Restarted pods are ready", nil)
 }
 
 // dbAppToProto converts a database App to the proto App
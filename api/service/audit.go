@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/timeutil"
+	auditv1 "github.com/nikumar1206/loco/shared/proto/audit/v1"
+)
+
+// AuditServer implements the AuditService gRPC server
+type AuditServer struct {
+	db      *pgxpool.Pool
+	queries *genDb.Queries
+}
+
+// NewAuditServer creates a new AuditServer instance
+func NewAuditServer(db *pgxpool.Pool, queries *genDb.Queries) *AuditServer {
+	return &AuditServer{db: db, queries: queries}
+}
+
+// ListEvents lists audit events for a workspace, newest first
+func (s *AuditServer) ListEvents(
+	ctx context.Context,
+	req *connect.Request[auditv1.ListEventsRequest],
+) (*connect.Response[auditv1.ListEventsResponse], error) {
+	r := req.Msg
+
+	limit := r.GetLimit()
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	var appID, actorID pgtype.Int8
+	if r.AppId != nil {
+		appID = pgtype.Int8{Int64: r.GetAppId(), Valid: true}
+	}
+	if r.ActorId != nil {
+		actorID = pgtype.Int8{Int64: r.GetActorId(), Valid: true}
+	}
+
+	var since, until pgtype.Timestamptz
+	if r.Since != nil {
+		since = timeutil.ToPostgresTimestamp(r.Since)
+	}
+	if r.Until != nil {
+		until = timeutil.ToPostgresTimestamp(r.Until)
+	}
+
+	total, err := s.queries.CountAuditEvents(ctx, genDb.CountAuditEventsParams{
+		WorkspaceID: r.WorkspaceId,
+		AppID:       appID,
+		ActorID:     actorID,
+		Since:       since,
+		Until:       until,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to count audit events", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	eventList, err := s.queries.ListAuditEvents(ctx, genDb.ListAuditEventsParams{
+		WorkspaceID: r.WorkspaceId,
+		AppID:       appID,
+		ActorID:     actorID,
+		Since:       since,
+		Until:       until,
+		RowLimit:    limit,
+		RowOffset:   r.GetOffset(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list audit events", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	var events []*auditv1.AuditEvent
+	for _, e := range eventList {
+		events = append(events, dbAuditEventToProto(e))
+	}
+
+	return connect.NewResponse(&auditv1.ListEventsResponse{
+		Events: events,
+		Total:  total,
+	}), nil
+}
+
+func dbAuditEventToProto(e genDb.AuditEvent) *auditv1.AuditEvent {
+	event := &auditv1.AuditEvent{
+		Id:           e.ID,
+		Actor:        e.Actor,
+		Procedure:    e.Procedure,
+		ResourceType: e.ResourceType,
+		Success:      e.Success,
+		RequestId:    e.RequestID,
+		SourceIp:     e.SourceIp,
+		CreatedAt:    timeutil.ParsePostgresTimestamp(e.CreatedAt.Time),
+	}
+
+	if e.ActorID.Valid {
+		event.ActorId = &e.ActorID.Int64
+	}
+	if e.OrgID.Valid {
+		event.OrgId = &e.OrgID.Int64
+	}
+	if e.WorkspaceID.Valid {
+		event.WorkspaceId = &e.WorkspaceID.Int64
+	}
+	if e.AppID.Valid {
+		event.AppId = &e.AppID.Int64
+	}
+	if e.ResourceID.Valid {
+		event.ResourceId = &e.ResourceID.Int64
+	}
+	if len(e.Request) > 0 {
+		request := string(e.Request)
+		event.Request = &request
+	}
+	if len(e.Before) > 0 {
+		before := string(e.Before)
+		event.Before = &before
+	}
+	if len(e.After) > 0 {
+		after := string(e.After)
+		event.After = &after
+	}
+	if e.Error.Valid {
+		event.Error = &e.Error.String
+	}
+
+	return event
+}
@@ -0,0 +1,408 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nikumar1206/loco/api/contextkeys"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+	"github.com/nikumar1206/loco/api/pkg/secretbox"
+	"github.com/nikumar1206/loco/api/reconcile"
+	"github.com/nikumar1206/loco/api/resurrect"
+	"github.com/nikumar1206/loco/api/timeutil"
+	clusterv1 "github.com/nikumar1206/loco/shared/proto/cluster/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	ErrInvalidCluster        = errors.New("invalid cluster")
+	ErrCredentialsDisabled   = errors.New("cluster credentials require CLUSTER_CREDENTIALS_KEY to be set")
+	ErrClusterNotReachable   = errors.New("cluster is not reachable")
+	ErrClusterAlreadyRetired = errors.New("cluster is already deactivated")
+)
+
+const mebibyte = 1024 * 1024
+
+// ClusterServer implements the ClusterService gRPC server
+type ClusterServer struct {
+	db         *pgxpool.Pool
+	queries    *genDb.Queries
+	clusters   *kube.Pool
+	planner    *kube.CapacityPlanner
+	sealer     *secretbox.Sealer
+	reconciler *reconcile.Reconciler
+	rebuilder  *resurrect.Resurrector
+}
+
+// NewClusterServer creates a new ClusterServer instance.
+// sealer may be nil, in which case only clusters without their own credentials can be registered.
+func NewClusterServer(db *pgxpool.Pool, queries *genDb.Queries, clusters *kube.Pool, planner *kube.CapacityPlanner, sealer *secretbox.Sealer, reconciler *reconcile.Reconciler, rebuilder *resurrect.Resurrector) *ClusterServer {
+	return &ClusterServer{
+		db:         db,
+		queries:    queries,
+		clusters:   clusters,
+		planner:    planner,
+		sealer:     sealer,
+		reconciler: reconciler,
+		rebuilder:  rebuilder,
+	}
+}
+
+// RegisterCluster adds a cluster that apps can be placed on.
+// A kubeconfig is checked against the cluster before its sealed copy is saved.
+func (s *ClusterServer) RegisterCluster(
+	ctx context.Context,
+	req *connect.Request[clusterv1.RegisterClusterRequest],
+) (*connect.Response[clusterv1.RegisterClusterResponse], error) {
+	r := req.Msg
+
+	userID, ok := contextkeys.UserID(ctx)
+	if !ok {
+		slog.ErrorContext(ctx, "userId not found in context")
+		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
+	}
+
+	if r.Name == "" || r.Region == "" || r.Provider == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: name, region and provider are required", ErrInvalidCluster))
+	}
+
+	var credentials []byte
+	if r.GetKubeconfig() != "" {
+		if s.sealer == nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, ErrCredentialsDisabled)
+		}
+
+		kc, err := kube.NewClientFromKubeconfig([]byte(r.GetKubeconfig()))
+		if err != nil {
+			slog.WarnContext(ctx, "invalid kubeconfig", "name", r.Name, "error", err)
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %w", ErrInvalidCluster, err))
+		}
+		version, err := kc.ServerVersion(ctx)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("%w: %w", ErrClusterNotReachable, err))
+		}
+		slog.InfoContext(ctx, "verified cluster", "name", r.Name, "version", version)
+
+		credentials, err = s.sealer.Seal([]byte(r.GetKubeconfig()))
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to seal cluster credentials", "error", err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to seal credentials: %w", err))
+		}
+	}
+
+	endpoint := pgtype.Text{}
+	if r.Endpoint != nil {
+		endpoint = pgtype.Text{String: r.GetEndpoint(), Valid: true}
+	}
+
+	cluster, err := s.queries.CreateCluster(ctx, genDb.CreateClusterParams{
+		Name:        r.Name,
+		Region:      r.Region,
+		Provider:    r.Provider,
+		Endpoint:    endpoint,
+		Credentials: credentials,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create cluster", "name", r.Name, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	return connect.NewResponse(&clusterv1.RegisterClusterResponse{
+		Cluster: dbClusterToProto(cluster),
+	}), nil
+}
+
+// ListClusters lists every registered cluster, active or not
+func (s *ClusterServer) ListClusters(
+	ctx context.Context,
+	req *connect.Request[clusterv1.ListClustersRequest],
+) (*connect.Response[clusterv1.ListClustersResponse], error) {
+	dbClusters, err := s.queries.ListClusters(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list clusters", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	var clusters []*clusterv1.Cluster
+	for _, c := range dbClusters {
+		clusters = append(clusters, dbClusterToProto(c))
+	}
+
+	return connect.NewResponse(&clusterv1.ListClustersResponse{
+		Clusters: clusters,
+	}), nil
+}
+
+// DeactivateCluster stops new apps from being placed on a cluster
+func (s *ClusterServer) DeactivateCluster(
+	ctx context.Context,
+	req *connect.Request[clusterv1.DeactivateClusterRequest],
+) (*connect.Response[clusterv1.DeactivateClusterResponse], error) {
+	r := req.Msg
+
+	existing, err := s.queries.GetClusterByID(ctx, r.Id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeNotFound, ErrClusterNotFound)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get cluster", "cluster_id", r.Id, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	if !existing.IsActive.Bool {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, ErrClusterAlreadyRetired)
+	}
+
+	cluster, err := s.queries.DeactivateCluster(ctx, r.Id)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to deactivate cluster", "cluster_id", r.Id, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	return connect.NewResponse(&clusterv1.DeactivateClusterResponse{
+		Cluster: dbClusterToProto(cluster),
+	}), nil
+}
+
+// GetCapacity reports the cluster's allocatable and free resources
+func (s *ClusterServer) GetCapacity(
+	ctx context.Context,
+	req *connect.Request[clusterv1.GetCapacityRequest],
+) (*connect.Response[clusterv1.GetCapacityResponse], error) {
+	r := req.Msg
+
+	if _, err := s.queries.GetClusterByID(ctx, r.ClusterId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, ErrClusterNotFound)
+		}
+		slog.ErrorContext(ctx, "failed to get cluster", "cluster_id", r.ClusterId, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	kc, err := s.clusters.Get(ctx, r.ClusterId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	capacity, err := kc.ClusterCapacity(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get cluster capacity", "cluster_id", r.ClusterId, "error", err)
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to get cluster capacity: %w", err))
+	}
+
+	var nodes []*clusterv1.NodeCapacity
+	for _, n := range capacity.Nodes {
+		nodes = append(nodes, &clusterv1.NodeCapacity{
+			Name:                     n.Name,
+			AllocatableCpuMillicores: n.AllocatableCPU,
+			AllocatableMemoryMb:      n.AllocatableMemory / mebibyte,
+			RequestedCpuMillicores:   n.RequestedCPU,
+			RequestedMemoryMb:        n.RequestedMemory / mebibyte,
+		})
+	}
+
+	return connect.NewResponse(&clusterv1.GetCapacityResponse{
+		Nodes:                    nodes,
+		AllocatableCpuMillicores: capacity.AllocatableCPU(),
+		AllocatableMemoryMb:      capacity.AllocatableMemory() / mebibyte,
+		FreeCpuMillicores:        capacity.FreeCPU(),
+		FreeMemoryMb:             capacity.FreeMemory() / mebibyte,
+		PendingCpuMillicores:     capacity.PendingCPU,
+		PendingMemoryMb:          capacity.PendingMemory / mebibyte,
+		Headroom:                 s.planner.Headroom(),
+		ClusterId:                r.ClusterId,
+	}), nil
+}
+
+// GetClusterHealth returns a cluster's current health alongside its recent probe history
+func (s *ClusterServer) GetClusterHealth(
+	ctx context.Context,
+	req *connect.Request[clusterv1.GetClusterHealthRequest],
+) (*connect.Response[clusterv1.GetClusterHealthResponse], error) {
+	r := req.Msg
+
+	cluster, err := s.queries.GetClusterByID(ctx, r.ClusterId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeNotFound, ErrClusterNotFound)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get cluster", "cluster_id", r.ClusterId, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	limit := r.GetLimit()
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	runs, err := s.queries.ListClusterHealthChecks(ctx, genDb.ListClusterHealthChecksParams{
+		ClusterID: r.ClusterId,
+		Limit:     limit,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list cluster health checks", "cluster_id", r.ClusterId, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	var history []*clusterv1.HealthCheckRun
+	for _, run := range runs {
+		var checks []kube.HealthCheck
+		if err := json.Unmarshal(run.Checks, &checks); err != nil {
+			slog.WarnContext(ctx, "failed to parse health checks", "id", run.ID, "error", err)
+		}
+
+		protoRun := &clusterv1.HealthCheckRun{
+			Status:    run.Status,
+			CheckedAt: timeutil.ParsePostgresTimestamp(run.CheckedAt.Time),
+		}
+		for _, c := range checks {
+			protoRun.Checks = append(protoRun.Checks, &clusterv1.HealthCheck{
+				Name:     c.Name,
+				Ok:       c.OK,
+				Message:  c.Message,
+				Critical: c.Critical,
+			})
+		}
+		history = append(history, protoRun)
+	}
+
+	return connect.NewResponse(&clusterv1.GetClusterHealthResponse{
+		Cluster: dbClusterToProto(cluster),
+		History: history,
+	}), nil
+}
+
+// DetectDrift compares every app's current deployment with its cluster, optionally re-applying what drifted
+func (s *ClusterServer) DetectDrift(
+	ctx context.Context,
+	req *connect.Request[clusterv1.DetectDriftRequest],
+) (*connect.Response[clusterv1.DetectDriftResponse], error) {
+	r := req.Msg
+
+	report, err := s.reconciler.Scan(ctx, r.GetClusterId(), r.Repair)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeNotFound, ErrClusterNotFound)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to scan for drift", "cluster_id", r.GetClusterId(), "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	var apps []*clusterv1.AppDrift
+	for _, a := range report.Apps {
+		appDrift := &clusterv1.AppDrift{
+			AppId:        a.AppID,
+			WorkspaceId:  a.WorkspaceID,
+			ClusterId:    a.ClusterID,
+			AppName:      a.AppName,
+			Namespace:    a.Namespace,
+			DeploymentId: a.DeploymentID,
+			Repaired:     a.Repaired,
+		}
+		if a.Error != "" {
+			appDrift.Error = &a.Error
+		}
+		for _, d := range a.Drift {
+			appDrift.Objects = append(appDrift.Objects, &clusterv1.DriftedObject{
+				Kind:   d.Kind,
+				Name:   d.Name,
+				Reason: d.Reason,
+				Fields: d.Fields,
+			})
+		}
+		apps = append(apps, appDrift)
+	}
+
+	var orphans []*clusterv1.OrphanedNamespace
+	for _, o := range report.Orphans {
+		orphans = append(orphans, &clusterv1.OrphanedNamespace{
+			ClusterId: o.ClusterID,
+			Namespace: o.Namespace,
+			CreatedAt: timestamppb.New(o.CreatedAt),
+		})
+	}
+
+	return connect.NewResponse(&clusterv1.DetectDriftResponse{
+		Apps:               apps,
+		OrphanedNamespaces: orphans,
+		CheckedAt:          timestamppb.New(report.CheckedAt),
+	}), nil
+}
+
+// RebuildCluster re-creates every app on the source cluster onto the target, streaming each app's progress
+func (s *ClusterServer) RebuildCluster(
+	ctx context.Context,
+	req *connect.Request[clusterv1.RebuildClusterRequest],
+	stream *connect.ServerStream[clusterv1.RebuildClusterResponse],
+) error {
+	r := req.Msg
+
+	var sendErr error
+	report, err := s.rebuilder.Rebuild(ctx, r.SourceClusterId, r.TargetClusterId, int(r.GetConcurrency()), func(p resurrect.Progress) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&clusterv1.RebuildClusterResponse{
+			AppId:     p.AppID,
+			AppName:   p.AppName,
+			Namespace: p.Namespace,
+			Phase:     p.Phase,
+			Message:   p.Message,
+			Completed: int32(p.Completed),
+			Total:     int32(p.Total),
+		})
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return connect.NewError(connect.CodeNotFound, ErrClusterNotFound)
+	}
+	if errors.Is(err, resurrect.ErrSameCluster) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if errors.Is(err, resurrect.ErrTargetUnavailable) {
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to rebuild cluster", "source_cluster_id", r.SourceClusterId, "target_cluster_id", r.TargetClusterId, "error", err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+
+	if report.Failed > 0 {
+		return connect.NewError(connect.CodeAborted, fmt.Errorf("%d of %d apps failed to rebuild", report.Failed, report.Total))
+	}
+	return nil
+}
+
+// dbClusterToProto converts a database Cluster to the proto Cluster.
+// credentials are never included.
+func dbClusterToProto(c genDb.Cluster) *clusterv1.Cluster {
+	cluster := &clusterv1.Cluster{
+		Id:             c.ID,
+		Name:           c.Name,
+		Region:         c.Region,
+		Provider:       c.Provider,
+		IsActive:       c.IsActive.Bool,
+		HealthStatus:   c.HealthStatus.String,
+		HasCredentials: len(c.Credentials) > 0,
+		CreatedBy:      c.CreatedBy,
+		CreatedAt:      timeutil.ParsePostgresTimestamp(c.CreatedAt.Time),
+		UpdatedAt:      timeutil.ParsePostgresTimestamp(c.UpdatedAt.Time),
+	}
+	if c.Endpoint.Valid {
+		cluster.Endpoint = &c.Endpoint.String
+	}
+	if c.LastHealthCheck.Valid {
+		cluster.LastHealthCheck = timeutil.ParsePostgresTimestamp(c.LastHealthCheck.Time)
+	}
+	return cluster
+}
@@ -11,11 +11,17 @@ import (
 	"time"
 
 	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nikumar1206/loco/api/contextkeys"
 	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/klogmux"
 	"github.com/nikumar1206/loco/api/pkg/kube"
+	"github.com/nikumar1206/loco/api/quota"
+	"github.com/nikumar1206/loco/api/routes"
 	timeutil "github.com/nikumar1206/loco/api/timeutil"
+	sharedConfig "github.com/nikumar1206/loco/shared/config"
 	deploymentv1 "github.com/nikumar1206/loco/shared/proto/deployment/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -25,23 +31,44 @@ var (
 	ErrInvalidImage       = errors.New("invalid image reference")
 	ErrInvalidPort        = errors.New("invalid port")
 	ErrInvalidReplicas    = errors.New("replicas must be >= 1")
+	ErrClusterFull        = errors.New("cluster does not have enough capacity")
+	ErrInvalidProtocol    = errors.New("protocol must be http or grpc")
+)
+
+const (
+	// capacityPollInterval is how often a queued deployment re-checks cluster capacity
+	capacityPollInterval = 30 * time.Second
+	// capacityWaitTimeout is how long a deployment stays queued before it's failed
+	capacityWaitTimeout = 15 * time.Minute
+	// releaseLogPageSize is the LIMIT of ListReleaseLogsAfter
+	releaseLogPageSize = 500
+	// maxCronJobName is the longest name the cluster accepts for a CronJob
+	maxCronJobName = 52
+	// rolloutTimeout is how long a deployment's pods get to become ready before it's failed and rolled back
+	rolloutTimeout = 10 * time.Minute
+	// rolloutPollInterval is how often a rolling out deployment is checked for readiness
+	rolloutPollInterval = 5 * time.Second
 )
 
 var imagePattern = regexp.MustCompile(`^([a-z0-9\-._]+(/[a-z0-9\-._]+)*)(:[a-z0-9\-._]+|@sha256:[a-f0-9]{64})?$`)
 
 // DeploymentServer implements the DeploymentService gRPC server
 type DeploymentServer struct {
-	db         *pgxpool.Pool
-	queries    *genDb.Queries
-	kubeClient *kube.Client
+	db       *pgxpool.Pool
+	queries  *genDb.Queries
+	clusters *kube.Pool
+	quotas   *quota.Enforcer
+	planner  *kube.CapacityPlanner
 }
 
 // NewDeploymentServer creates a new DeploymentServer instance
-func NewDeploymentServer(db *pgxpool.Pool, queries *genDb.Queries, kubeClient *kube.Client) *DeploymentServer {
+func NewDeploymentServer(db *pgxpool.Pool, queries *genDb.Queries, clusters *kube.Pool, quotas *quota.Enforcer, planner *kube.CapacityPlanner) *DeploymentServer {
 	return &DeploymentServer{
-		db:         db,
-		queries:    queries,
-		kubeClient: kubeClient,
+		db:       db,
+		queries:  queries,
+		clusters: clusters,
+		quotas:   quotas,
+		planner:  planner,
 	}
 }
 
@@ -52,7 +79,7 @@ func (s *DeploymentServer) CreateDeployment(
 ) (*connect.Response[deploymentv1.CreateDeploymentResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
@@ -83,33 +110,160 @@ func (s *DeploymentServer) CreateDeployment(
 		}
 	}
 
+	protocol := r.GetProtocol()
+	switch protocol {
+	case "":
+		protocol = sharedConfig.ProtocolHTTP
+	case sharedConfig.ProtocolHTTP:
+	case sharedConfig.ProtocolGRPC:
+		if err := sharedConfig.ValidateGRPCServices(r.GrpcServices); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		// grpc routes match on service, not path, so the app takes the whole hostname
+		if prefix := r.GetPathPrefix(); prefix != "" && prefix != routes.RootPrefix {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: grpc apps are served from /", routes.ErrInvalidPrefix))
+		}
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrInvalidProtocol)
+	}
+	if protocol != sharedConfig.ProtocolGRPC && len(r.GrpcServices) > 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("grpc services require the grpc protocol"))
+	}
+
+	if sleepAfter := r.GetSleepAfter(); sleepAfter != "" {
+		if _, err := sharedConfig.ParseSleepAfter(sleepAfter); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if protocol != sharedConfig.ProtocolHTTP {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("sleep after only applies to http apps"))
+		}
+	}
+
+	health := healthFromProto(r.Health)
+	if health != nil {
+		if err := sharedConfig.ValidateProbes(*health, protocol); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	resources := r.GetResources()
+	if resources.GetMaxReplicas() < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("max replicas must be >= 0"))
+	}
+
+	// the autoscaler can run up to max replicas, so persistent volumes are checked against that too
+	volumes := volumesFromProto(r.Volumes)
+	if err := sharedConfig.ValidateVolumes(volumes, max(replicas, resources.GetMaxReplicas())); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	app, err := s.queries.GetAppByID(ctx, r.AppId)
 	if err != nil {
 		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
 		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
 	}
-	workspaceID := app.WorkspaceID
 
-	// todo: move membership check higher.
-	role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-		WorkspaceID: workspaceID,
-		UserID:      userID,
-	})
+	jobs := jobsFromProto(r.Jobs)
+	if err := sharedConfig.ValidateJobs(jobs); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	for _, job := range jobs {
+		// CronJob names are capped at 52 characters so the Jobs they create stay valid
+		if len(app.Name)+1+len(job.Name) > maxCronJobName {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("job name %q is too long for app %s", job.Name, app.Name))
+		}
+	}
+
+	// the first port is routed by the gateway on the app's hostname, the rest are raw TCP or UDP ports
+	extraPorts := make([]sharedConfig.Port, 0, len(r.Ports)-1)
+	for _, port := range r.Ports[1:] {
+		exposure := sharedConfig.ExposureInternal
+		if port.Public {
+			exposure = sharedConfig.ExposurePublic
+		}
+		extraPorts = append(extraPorts, sharedConfig.Port{
+			Name:     port.GetName(),
+			Port:     port.Port,
+			Protocol: port.Protocol,
+			Exposure: exposure,
+		})
+	}
+	if err := sharedConfig.ValidatePorts(extraPorts, r.Ports[0].Port, app.Name); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %w", ErrInvalidPort, err))
+	}
+
+	if err := checkClusterHealthy(ctx, s.queries, &app); err != nil {
+		return nil, err
+	}
+
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
 	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", workspaceID, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
+		slog.ErrorContext(ctx, "failed to get cluster client", "cluster_id", app.ClusterID, "error", err)
+		return nil, connect.NewError(connect.CodeUnavailable, err)
 	}
 
-	if role != genDb.WorkspaceRoleAdmin && role != genDb.WorkspaceRoleDeploy {
-		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("must be workspace admin or have deploy role"))
+	// deployments that can't fit right now are queued by allocateDeployment, only reject what can never fit
+	fit, err := s.planner.Check(ctx, kc, replicas, resources.GetCpu(), resources.GetMemory())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check cluster capacity", "error", err)
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to check cluster capacity: %w", err))
 	}
+	if !fit.Possible {
+		slog.WarnContext(ctx, "deployment can never fit in the cluster", "app_id", app.ID, "reason", fit.Reason)
+		return nil, connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("%w: %s", ErrClusterFull, fit.Reason))
+	}
+
+	// the quota, route and ports are taken in the transaction that inserts the deployment, so concurrent deploys
+	// can't both fit and a deploy that fails holds on to nothing
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to begin transaction", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
 
-	// todo: assign cluster and verify health
+	shape := quota.Shape{
+		Replicas:    replicas,
+		MaxReplicas: resources.GetMaxReplicas(),
+		CPU:         resources.GetCpu(),
+		Memory:      resources.GetMemory(),
+		Release:     r.GetRelease() != "",
+	}
+	if err := s.quotas.WithTx(tx).CheckDeployment(ctx, app.WorkspaceID, app.ID, shape); err != nil {
+		slog.WarnContext(ctx, "deployment quota check failed", "app_id", app.ID, "error", err)
+		return nil, err
+	}
+
+	pathPrefix, err := claimRoute(ctx, qtx, &app, r.GetPathPrefix(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := allocateGatewayPorts(ctx, tx, app.ID, extraPorts); err != nil {
+		return nil, err
+	}
 
 	config := map[string]any{
 		"env":       r.Env,
 		"ports":     r.Ports,
 		"resources": r.Resources,
+		"routing": map[string]any{
+			"port":         r.Ports[0].Port,
+			"pathPrefix":   pathPrefix,
+			"protocol":     protocol,
+			"grpcServices": r.GrpcServices,
+			"ports":        extraPorts,
+			"sleepAfter":   r.GetSleepAfter(),
+		},
+		"health":  health,
+		"deploy":  map[string]any{"release": r.GetRelease()},
+		"volumes": volumes,
+		"jobs":    jobs,
+		// shaped like loco.toml so kube.UnmarshalConfig reads it into Obs.Logging
+		"obs": map[string]any{
+			"logging": map[string]any{"structured": r.GetStructuredLogs()},
+		},
 	}
 	configJSON, err := json.Marshal(config)
 	if err != nil {
@@ -117,19 +271,14 @@ func (s *DeploymentServer) CreateDeployment(
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %w", err))
 	}
 
-	err = s.queries.MarkPreviousDeploymentsNotCurrent(ctx, r.AppId)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to mark previous deployments not current", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	deployment, err := s.queries.CreateDeployment(ctx, genDb.CreateDeploymentParams{
+	// the app's current deployment keeps serving until this one is rolled out, see activateDeployment
+	deployment, err := qtx.CreateDeployment(ctx, genDb.CreateDeploymentParams{
 		AppID:         r.AppId,
-		ClusterID:     1,
+		ClusterID:     app.ClusterID,
 		Image:         r.Image,
 		Replicas:      replicas,
 		Status:        genDb.DeploymentStatusPending,
-		IsCurrent:     true,
+		IsCurrent:     false,
 		CreatedBy:     userID,
 		Config:        configJSON,
 		SchemaVersion: pgtype.Int4{Int32: 1, Valid: true},
@@ -138,6 +287,14 @@ func (s *DeploymentServer) CreateDeployment(
 		slog.ErrorContext(ctx, "failed to create deployment", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
 	}
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "failed to commit transaction", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	if err := s.markAwake(ctx, app.ID); err != nil {
+		return nil, err
+	}
 
 	go s.allocateDeployment(context.Background(), &app, &deployment, r.Env)
 
@@ -176,38 +333,12 @@ func (s *DeploymentServer) GetDeployment(
 ) (*connect.Response[deploymentv1.GetDeploymentResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
 	deploymentData, err := s.queries.GetDeploymentByID(ctx, r.DeploymentId)
 	if err != nil {
 		slog.WarnContext(ctx, "deployment not found", "deployment_id", r.DeploymentId)
 		return nil, connect.NewError(connect.CodeNotFound, ErrDeploymentNotFound)
 	}
 
-	app, err := s.queries.GetAppByID(ctx, deploymentData.AppID)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to get app", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	isMember, err := s.queries.IsWorkspaceMember(ctx, genDb.IsWorkspaceMemberParams{
-		WorkspaceID: app.WorkspaceID,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to check workspace membership", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if !isMember {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", app.WorkspaceID, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	// todo: lets make status an enum.
 	deploymentResp := &deploymentv1.Deployment{
 		Id:        deploymentData.ID,
@@ -254,32 +385,6 @@ func (s *DeploymentServer) ListDeployments(
 ) (*connect.Response[deploymentv1.ListDeploymentsResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	app, err := s.queries.GetAppByID(ctx, r.AppId)
-	if err != nil {
-		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
-		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
-	}
-
-	isMember, err := s.queries.IsWorkspaceMember(ctx, genDb.IsWorkspaceMemberParams{
-		WorkspaceID: app.WorkspaceID,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to check workspace membership", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if !isMember {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", app.WorkspaceID, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	limit := r.GetLimit()
 	if limit == 0 {
 		limit = 50
@@ -354,43 +459,13 @@ func (s *DeploymentServer) StreamDeployment(
 ) error {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	appID, err := s.queries.GetDeploymentAppID(ctx, r.DeploymentId)
-	if err != nil {
-		slog.WarnContext(ctx, "deployment not found", "deployment_id", r.DeploymentId)
-		return connect.NewError(connect.CodeNotFound, ErrDeploymentNotFound)
-	}
-
-	app, err := s.queries.GetAppByID(ctx, appID)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to get app", "error", err)
-		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	isMember, err := s.queries.IsWorkspaceMember(ctx, genDb.IsWorkspaceMemberParams{
-		WorkspaceID: app.WorkspaceID,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to check workspace membership", "error", err)
-		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if !isMember {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", app.WorkspaceID, "userId", userID)
-		return connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	lastStatus := ""
+	lastMessage := ""
+	var lastLogID int64
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 
-	if err := s.sendDeploymentEvent(ctx, stream, fmt.Sprintf("%d", r.DeploymentId), &lastStatus); err != nil {
+	if err := s.sendDeploymentEvent(ctx, stream, fmt.Sprintf("%d", r.DeploymentId), &lastStatus, &lastMessage, &lastLogID); err != nil {
 		return err
 	}
 
@@ -399,7 +474,7 @@ func (s *DeploymentServer) StreamDeployment(
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			if err := s.sendDeploymentEvent(ctx, stream, fmt.Sprintf("%d", r.DeploymentId), &lastStatus); err != nil {
+			if err := s.sendDeploymentEvent(ctx, stream, fmt.Sprintf("%d", r.DeploymentId), &lastStatus, &lastMessage, &lastLogID); err != nil {
 				return err
 			}
 
@@ -415,6 +490,8 @@ func (s *DeploymentServer) sendDeploymentEvent(
 	stream *connect.ServerStream[deploymentv1.DeploymentEvent],
 	deploymentID string,
 	lastStatus *string,
+	lastMessage *string,
+	lastLogID *int64,
 ) error {
 	parsedDeploymentID, err := strconv.ParseInt(deploymentID, 10, 64)
 	if err != nil {
@@ -434,7 +511,35 @@ func (s *DeploymentServer) sendDeploymentEvent(
 		message = deployment.Message.String
 	}
 
-	if status != *lastStatus {
+	// read after the deployment, so every line of a release that failed is sent before the failure
+	for {
+		logs, err := s.queries.ListReleaseLogsAfter(ctx, genDb.ListReleaseLogsAfterParams{
+			DeploymentID: parsedDeploymentID,
+			AfterID:      *lastLogID,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to list release logs", "error", err)
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		}
+		for _, log := range logs {
+			if err := stream.Send(&deploymentv1.DeploymentEvent{
+				DeploymentId: parsedDeploymentID,
+				Status:       status,
+				Message:      message,
+				Timestamp:    timestamppb.New(log.LoggedAt.Time),
+				Log:          &log.Line,
+			}); err != nil {
+				return err
+			}
+			*lastLogID = log.ID
+		}
+		if len(logs) < releaseLogPageSize {
+			break
+		}
+	}
+
+	// the message changes without the status while a deployment is queued for capacity
+	if status != *lastStatus || message != *lastMessage {
 		event := &deploymentv1.DeploymentEvent{
 			DeploymentId: parsedDeploymentID,
 			Status:       status,
@@ -451,6 +556,7 @@ func (s *DeploymentServer) sendDeploymentEvent(
 		}
 
 		*lastStatus = status
+		*lastMessage = message
 		slog.InfoContext(ctx, "sent deployment event", "deployment_id", deploymentID, "status", status)
 	}
 
@@ -482,18 +588,275 @@ func (s *DeploymentServer) allocateDeployment(
 		return
 	}
 
+	limits, err := s.quotas.Effective(ctx, app.WorkspaceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load workspace quota", "deployment_id", deployment.ID, "error", err)
+		s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Failed to load workspace quota: %v", err))
+		return
+	}
+	namespaceQuota := limits.Namespace()
+	ldc.Quota = &namespaceQuota
+
+	ldc.Domains, err = s.queries.ListVerifiedHostnamesForApp(ctx, app.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load custom domains", "deployment_id", deployment.ID, "error", err)
+		s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Failed to load custom domains: %v", err))
+		return
+	}
+	ldc.ReleaseLogs = releaseLogWriter(s.queries, deployment.ID)
+
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get cluster client", "deployment_id", deployment.ID, "cluster_id", app.ClusterID, "error", err)
+		s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Failed to reach cluster: %v", err))
+		return
+	}
+
+	if !s.waitForCapacity(ctx, kc, deployment, ldc.Config.Resources.CPU, ldc.Config.Resources.Memory) {
+		return
+	}
+
 	s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusInProgress, "Allocating Kubernetes resources...")
 
-	if err := s.kubeClient.AllocateResources(ctx, ldc, envVars, nil); err != nil {
+	if err := kc.AllocateResources(ctx, ldc, envVars, nil); err != nil {
 		slog.ErrorContext(ctx, "Failed to allocate Kubernetes resources", "deployment_id", deployment.ID, "error", err)
 		s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Failed to allocate resources: %v", err))
 		return
 	}
 
-	s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusSucceeded, "Deployment successful")
+	// a deployment only becomes current once its pods are up, an image that crash loops never does
+	s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusInProgress, "Waiting for pods to become ready...")
+	rolloutCtx, cancel := context.WithTimeout(ctx, rolloutTimeout)
+	err = kc.WaitForDeploymentReady(rolloutCtx, ldc.Namespace(), ldc.DeploymentName(), rolloutPollInterval)
+	cancel()
+	if err != nil {
+		slog.ErrorContext(ctx, "Deployment did not roll out", "deployment_id", deployment.ID, "error", err)
+		message := fmt.Sprintf("Pods did not become ready: %v", err)
+		if rollbackErr := s.rollBack(ctx, kc, app, ldc); rollbackErr != nil {
+			slog.ErrorContext(ctx, "Failed to roll back deployment", "deployment_id", deployment.ID, "error", rollbackErr)
+			message = fmt.Sprintf("%s, rolling back failed: %v", message, rollbackErr)
+		}
+		s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, message)
+		return
+	}
+
+	if err := activateDeployment(context.Background(), s.db, s.queries, deployment); err != nil {
+		slog.ErrorContext(ctx, "Failed to activate deployment", "deployment_id", deployment.ID, "error", err)
+		s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Failed to activate deployment: %v", err))
+		return
+	}
 	slog.InfoContext(ctx, "Deployment allocation completed", "deployment_id", deployment.ID)
 }
 
+// rollBack puts the app's current deployment back in place of failed, whose pods never became ready. An app
+// that never rolled out before has nothing to go back to, its objects are left for its logs and events.
+func (s *DeploymentServer) rollBack(ctx context.Context, kc *kube.Client, app *genDb.App, failed *kube.LocoDeploymentContext) error {
+	current, err := s.queries.GetCurrentDeploymentForApp(ctx, app.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get current deployment: %w", err)
+	}
+
+	ldc, err := kube.NewLocoDeploymentContext(app, &current)
+	if err != nil {
+		return err
+	}
+	ldc.Quota = failed.Quota
+	ldc.Domains = failed.Domains
+	// its release ran when it was deployed
+	ldc.Config.Deploy.Release = ""
+
+	slog.InfoContext(ctx, "Rolling back to current deployment", "app_id", app.ID, "deployment_id", current.ID)
+	return kc.AllocateResources(ctx, ldc, deploymentEnv(current.Config), nil)
+}
+
+// deploymentEnv reads the env vars a deployment was created with
+func deploymentEnv(config []byte) map[string]string {
+	var cfg struct {
+		Env map[string]string `json:"env"`
+	}
+	_ = json.Unmarshal(config, &cfg)
+	return cfg.Env
+}
+
+// redeploy creates a deployment of an image the app already ran with a changed config, for ScaleApp and
+// UpdateAppEnv, and allocates it the way CreateDeployment's are. The image's release command isn't run again.
+func (s *DeploymentServer) redeploy(
+	ctx context.Context,
+	app *genDb.App,
+	image string,
+	replicas int32,
+	config map[string]any,
+	envVars map[string]string,
+	userID int64,
+) (genDb.Deployment, error) {
+	// the release ran when the image was deployed, scaling or changing env must not migrate again
+	delete(config, "deploy")
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to marshal config", "error", err)
+		return genDb.Deployment{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %w", err))
+	}
+	cfg, err := kube.UnmarshalConfig(configJSON)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to parse deployment config", "error", err)
+		return genDb.Deployment{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %w", err))
+	}
+
+	// the same checks as CreateDeployment's, the quota is checked with the insert
+	if err := checkClusterHealthy(ctx, s.queries, app); err != nil {
+		return genDb.Deployment{}, err
+	}
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get cluster client", "cluster_id", app.ClusterID, "error", err)
+		return genDb.Deployment{}, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	// deployments that can't fit right now are queued by allocateDeployment, only reject what can never fit
+	fit, err := s.planner.Check(ctx, kc, replicas, cfg.Resources.CPU, cfg.Resources.Memory)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check cluster capacity", "error", err)
+		return genDb.Deployment{}, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to check cluster capacity: %w", err))
+	}
+	if !fit.Possible {
+		slog.WarnContext(ctx, "deployment can never fit in the cluster", "app_id", app.ID, "reason", fit.Reason)
+		return genDb.Deployment{}, connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("%w: %s", ErrClusterFull, fit.Reason))
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to begin transaction", "error", err)
+		return genDb.Deployment{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.quotas.WithTx(tx).CheckDeployment(ctx, app.WorkspaceID, app.ID, quota.ShapeOf(replicas, configJSON)); err != nil {
+		slog.WarnContext(ctx, "deployment quota check failed", "app_id", app.ID, "error", err)
+		return genDb.Deployment{}, err
+	}
+
+	deployment, err := s.queries.WithTx(tx).CreateDeployment(ctx, genDb.CreateDeploymentParams{
+		AppID:         app.ID,
+		ClusterID:     app.ClusterID,
+		Image:         image,
+		Replicas:      replicas,
+		Status:        genDb.DeploymentStatusPending,
+		IsCurrent:     false,
+		CreatedBy:     userID,
+		Config:        configJSON,
+		SchemaVersion: pgtype.Int4{Int32: 1, Valid: true},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create deployment", "error", err)
+		return genDb.Deployment{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "failed to commit transaction", "error", err)
+		return genDb.Deployment{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	if err := s.markAwake(ctx, app.ID); err != nil {
+		return genDb.Deployment{}, err
+	}
+
+	go s.allocateDeployment(context.Background(), app, &deployment, envVars)
+	return deployment, nil
+}
+
+// markAwake records a sleeping app as awake, as every new deployment rolls out with its replicas and route.
+// Its wake grant is left in place until then and reused by the next sleep.
+func (s *DeploymentServer) markAwake(ctx context.Context, appID int64) error {
+	sleepState, err := s.queries.GetAppSleep(ctx, appID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to get app sleep state", "app_id", appID, "error", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	if !sleepState.SleptAt.Valid {
+		return nil
+	}
+	if err := s.queries.MarkAppAwake(ctx, appID); err != nil {
+		slog.ErrorContext(ctx, "failed to mark app awake", "app_id", appID, "error", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	return nil
+}
+
+// checkClusterHealthy refuses new deployments of an app whose cluster is unhealthy. Deactivated clusters still
+// take deployments of the apps already on them.
+func checkClusterHealthy(ctx context.Context, queries *genDb.Queries, app *genDb.App) error {
+	cluster, err := queries.GetClusterByID(ctx, app.ClusterID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get cluster", "cluster_id", app.ClusterID, "error", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	if cluster.HealthStatus.String == kube.HealthUnhealthy {
+		slog.WarnContext(ctx, "refusing deployment to unhealthy cluster", "app_id", app.ID, "cluster_id", cluster.ID)
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("%w: %s", ErrClusterNotHealthy, cluster.Name))
+	}
+	return nil
+}
+
+// activateDeployment makes a rolled out deployment its app's current one and marks it succeeded.
+// Until then the previous deployment stays current, so a deploy that fails, like on its release command,
+// leaves the app on what it was running.
+func activateDeployment(ctx context.Context, db *pgxpool.Pool, queries *genDb.Queries, deployment *genDb.Deployment) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := queries.WithTx(tx)
+	if err := qtx.MarkPreviousDeploymentsNotCurrent(ctx, deployment.AppID); err != nil {
+		return fmt.Errorf("failed to mark previous deployments not current: %w", err)
+	}
+	if err := qtx.MarkDeploymentCurrent(ctx, deployment.ID); err != nil {
+		return fmt.Errorf("failed to mark deployment current: %w", err)
+	}
+	err = qtx.UpdateDeploymentStatusWithMessage(ctx, genDb.UpdateDeploymentStatusWithMessageParams{
+		ID:      deployment.ID,
+		Status:  genDb.DeploymentStatusSucceeded,
+		Message: pgtype.Text{String: "Deployment successful", Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update deployment status: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// waitForCapacity holds a deployment in pending until the cluster can take it, recording why in its message.
+// Returns false if the deployment was failed instead.
+func (s *DeploymentServer) waitForCapacity(ctx context.Context, kc *kube.Client, deployment *genDb.Deployment, cpu, memory string) bool {
+	deadline := time.Now().Add(capacityWaitTimeout)
+	for {
+		fit, err := s.planner.Check(ctx, kc, deployment.Replicas, cpu, memory)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to check cluster capacity", "deployment_id", deployment.ID, "error", err)
+			s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Failed to check cluster capacity: %v", err))
+			return false
+		}
+		if fit.Fits {
+			return true
+		}
+		if !fit.Possible {
+			s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Cluster cannot fit deployment: %s", fit.Reason))
+			return false
+		}
+		if time.Now().After(deadline) {
+			s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusFailed, fmt.Sprintf("Timed out waiting for cluster capacity: %s", fit.Reason))
+			return false
+		}
+
+		slog.InfoContext(ctx, "Deployment queued for capacity", "deployment_id", deployment.ID, "reason", fit.Reason)
+		s.updateDeploymentStatus(context.Background(), deployment.ID, genDb.DeploymentStatusPending, fmt.Sprintf("Queued, waiting for cluster capacity: %s", fit.Reason))
+		time.Sleep(capacityPollInterval)
+	}
+}
+
 // updateDeploymentStatus updates the deployment status in the database
 // todo: should we move these to the app service?
 // technically we are getting app logs, app status, and updating app env/scale.
@@ -508,3 +871,81 @@ func (s *DeploymentServer) updateDeploymentStatus(ctx context.Context, deploymen
 		slog.ErrorContext(ctx, "Failed to update deployment status", "deployment_id", deploymentID, "error", err)
 	}
 }
+
+// releaseLogWriter stores each line of a deployment's release command, for StreamDeployment to send
+func releaseLogWriter(queries *genDb.Queries, deploymentID int64) func(klogmux.LogEntry) {
+	return func(entry klogmux.LogEntry) {
+		loggedAt := entry.Timestamp
+		if loggedAt.IsZero() {
+			loggedAt = time.Now()
+		}
+		err := queries.CreateReleaseLog(context.Background(), genDb.CreateReleaseLogParams{
+			DeploymentID: deploymentID,
+			Line:         entry.Message,
+			LoggedAt:     pgtype.Timestamptz{Time: loggedAt, Valid: true},
+		})
+		if err != nil {
+			slog.Error("Failed to store release log", "deployment_id", deploymentID, "error", err)
+		}
+	}
+}
+
+// volumesFromProto reads the volumes of a deployment request into their loco.toml shape
+func volumesFromProto(volumes []*deploymentv1.Volume) []sharedConfig.Volume {
+	result := make([]sharedConfig.Volume, 0, len(volumes))
+	for _, v := range volumes {
+		result = append(result, sharedConfig.Volume{
+			Name:      v.Name,
+			MountPath: v.MountPath,
+			Size:      v.Size,
+			Type:      v.Type,
+		})
+	}
+	return result
+}
+
+// jobsFromProto reads the scheduled jobs of a deployment request into their loco.toml shape
+func jobsFromProto(jobs []*deploymentv1.Job) []sharedConfig.Job {
+	result := make([]sharedConfig.Job, 0, len(jobs))
+	for _, j := range jobs {
+		result = append(result, sharedConfig.Job{
+			Name:     j.Name,
+			Schedule: j.Schedule,
+			Command:  j.Command,
+			CPU:      j.GetCpu(),
+			Memory:   j.GetMemory(),
+		})
+	}
+	return result
+}
+
+// healthFromProto reads a deployment's probes, nil when the client sent none
+func healthFromProto(h *deploymentv1.HealthChecks) *sharedConfig.Health {
+	if h == nil {
+		return nil
+	}
+	return &sharedConfig.Health{
+		Liveness:  probeFromProto(h.Liveness),
+		Readiness: probeFromProto(h.Readiness),
+		Startup:   probeFromProto(h.Startup),
+	}
+}
+
+func probeFromProto(p *deploymentv1.Probe) *sharedConfig.Probe {
+	if p == nil {
+		return nil
+	}
+	return &sharedConfig.Probe{
+		Type:             p.Type,
+		Port:             p.Port,
+		Path:             p.Path,
+		Headers:          p.Headers,
+		Command:          p.Command,
+		Service:          p.Service,
+		InitialDelay:     p.InitialDelay,
+		Interval:         p.Interval,
+		Timeout:          p.Timeout,
+		FailThreshold:    p.FailThreshold,
+		SuccessThreshold: p.SuccessThreshold,
+	}
+}
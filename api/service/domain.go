@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nikumar1206/loco/api/contextkeys"
+	"github.com/nikumar1206/loco/api/domains"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+	"github.com/nikumar1206/loco/api/timeutil"
+	domainv1 "github.com/nikumar1206/loco/shared/proto/domain/v1"
+)
+
+var (
+	ErrDomainNotFound = errors.New("domain not found")
+	ErrDomainTaken    = errors.New("domain is already added to an app")
+	ErrDomainReserved = errors.New("domain is served by loco, set the app's subdomain instead")
+)
+
+// DomainServer implements the DomainService gRPC server
+type DomainServer struct {
+	db       *pgxpool.Pool
+	queries  *genDb.Queries
+	clusters *kube.Pool
+	verifier *domains.Verifier
+}
+
+// NewDomainServer creates a new DomainServer instance
+func NewDomainServer(db *pgxpool.Pool, queries *genDb.Queries, clusters *kube.Pool, verifier *domains.Verifier) *DomainServer {
+	return &DomainServer{
+		db:       db,
+		queries:  queries,
+		clusters: clusters,
+		verifier: verifier,
+	}
+}
+
+// AddDomain adds a custom hostname to an app. It isn't routed until VerifyDomain finds its TXT record.
+func (s *DomainServer) AddDomain(
+	ctx context.Context,
+	req *connect.Request[domainv1.AddDomainRequest],
+) (*connect.Response[domainv1.AddDomainResponse], error) {
+	r := req.Msg
+
+	userID, ok := contextkeys.UserID(ctx)
+	if !ok {
+		slog.ErrorContext(ctx, "userId not found in context")
+		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
+	}
+
+	hostname, err := domains.NormalizeHostname(r.Hostname)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	app, err := s.queries.GetAppByID(ctx, r.AppId)
+	if err != nil {
+		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
+		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
+	}
+
+	if hostname == app.Domain || strings.HasSuffix(hostname, "."+app.Domain) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, ErrDomainReserved)
+	}
+
+	// only a verified domain holds its hostname, unverified claims of other apps don't block this one
+	_, err = s.queries.GetVerifiedAppDomainByHostname(ctx, hostname)
+	if err == nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, ErrDomainTaken)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to check domain", "hostname", hostname, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	token, err := domains.NewToken()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	domain, err := s.queries.CreateAppDomain(ctx, genDb.CreateAppDomainParams{
+		AppID:             app.ID,
+		Hostname:          hostname,
+		VerificationToken: token,
+		CreatedBy:         userID,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return nil, connect.NewError(connect.CodeAlreadyExists, ErrDomainTaken)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create domain", "hostname", hostname, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	slog.InfoContext(ctx, "domain added", "app_id", app.ID, "hostname", hostname)
+
+	return connect.NewResponse(&domainv1.AddDomainResponse{
+		Domain: dbDomainToProto(domain),
+	}), nil
+}
+
+// VerifyDomain checks a domain's TXT record, then issues its certificate and routes it to the app.
+// Verified domains skip the lookup, so calling it again retries attaching them.
+func (s *DomainServer) VerifyDomain(
+	ctx context.Context,
+	req *connect.Request[domainv1.VerifyDomainRequest],
+) (*connect.Response[domainv1.VerifyDomainResponse], error) {
+	r := req.Msg
+
+	app, domain, err := s.getDomain(ctx, r.AppId, r.Hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.VerificationStatus != domains.StatusVerified {
+		status, message := domains.StatusVerified, pgtype.Text{}
+		err := s.verifier.Verify(ctx, domain.Hostname, domain.VerificationToken)
+		if errors.Is(err, domains.ErrChallengeNotFound) {
+			status, message = domains.StatusFailed, pgtype.Text{String: err.Error(), Valid: true}
+		} else if err != nil {
+			slog.WarnContext(ctx, "failed to verify domain", "hostname", domain.Hostname, "error", err)
+			return nil, connect.NewError(connect.CodeUnavailable, err)
+		}
+
+		domain, err = s.recordVerification(ctx, domain, status, message)
+		if err != nil {
+			return nil, err
+		}
+		if status != domains.StatusVerified {
+			slog.InfoContext(ctx, "domain verification failed", "hostname", domain.Hostname, "reason", message.String)
+			return connect.NewResponse(&domainv1.VerifyDomainResponse{Domain: dbDomainToProto(domain)}), nil
+		}
+		slog.InfoContext(ctx, "domain verified", "app_id", app.ID, "hostname", domain.Hostname)
+	}
+
+	certStatus, certMessage := domains.CertificateIssuing, pgtype.Text{}
+	if err := s.attach(ctx, app, domain); err != nil {
+		certStatus, certMessage = domains.CertificateFailed, pgtype.Text{String: err.Error(), Valid: true}
+	}
+	if err := s.queries.UpdateAppDomainCertificate(ctx, genDb.UpdateAppDomainCertificateParams{
+		ID:                 domain.ID,
+		CertificateStatus:  certStatus,
+		CertificateMessage: certMessage,
+	}); err != nil {
+		slog.ErrorContext(ctx, "failed to update domain certificate", "hostname", domain.Hostname, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	domain.CertificateStatus, domain.CertificateMessage = certStatus, certMessage
+
+	return connect.NewResponse(&domainv1.VerifyDomainResponse{
+		Domain: dbDomainToProto(domain),
+	}), nil
+}
+
+// ListDomains lists an app's domains, refreshing the certificate status of verified ones from the cluster
+func (s *DomainServer) ListDomains(
+	ctx context.Context,
+	req *connect.Request[domainv1.ListDomainsRequest],
+) (*connect.Response[domainv1.ListDomainsResponse], error) {
+	r := req.Msg
+
+	app, err := s.queries.GetAppByID(ctx, r.AppId)
+	if err != nil {
+		slog.WarnContext(ctx, "app not found", "app_id", r.AppId)
+		return nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
+	}
+
+	domainList, err := s.queries.ListAppDomains(ctx, app.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list domains", "app_id", app.ID, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	s.refreshCertificates(ctx, app, domainList)
+
+	protoDomains := make([]*domainv1.Domain, len(domainList))
+	for i, d := range domainList {
+		protoDomains[i] = dbDomainToProto(d)
+	}
+
+	return connect.NewResponse(&domainv1.ListDomainsResponse{
+		Domains: protoDomains,
+	}), nil
+}
+
+// RemoveDomain stops routing a domain to its app and deletes its certificate
+func (s *DomainServer) RemoveDomain(
+	ctx context.Context,
+	req *connect.Request[domainv1.RemoveDomainRequest],
+) (*connect.Response[domainv1.RemoveDomainResponse], error) {
+	r := req.Msg
+
+	app, domain, err := s.getDomain(ctx, r.AppId, r.Hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	// cluster objects go first, so a failure leaves the domain listed and removable again
+	if domain.VerificationStatus == domains.StatusVerified {
+		if err := s.detach(ctx, app, domain); err != nil {
+			return nil, connect.NewError(connect.CodeUnavailable, err)
+		}
+	}
+
+	if err := s.queries.DeleteAppDomain(ctx, domain.ID); err != nil {
+		slog.ErrorContext(ctx, "failed to delete domain", "hostname", domain.Hostname, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	slog.InfoContext(ctx, "domain removed", "app_id", app.ID, "hostname", domain.Hostname)
+	return connect.NewResponse(&domainv1.RemoveDomainResponse{}), nil
+}
+
+// recordVerification stores a domain's verification result. Verifying it drops the other apps' claims on
+// its hostname, and fails with ErrDomainTaken when another app verified the hostname first.
+func (s *DomainServer) recordVerification(ctx context.Context, domain genDb.AppDomain, status string, message pgtype.Text) (genDb.AppDomain, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to begin transaction", "error", err)
+		return genDb.AppDomain{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	updated, err := qtx.UpdateAppDomainVerification(ctx, genDb.UpdateAppDomainVerificationParams{
+		ID:                  domain.ID,
+		VerificationStatus:  status,
+		VerificationMessage: message,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return genDb.AppDomain{}, connect.NewError(connect.CodeAlreadyExists, ErrDomainTaken)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update domain verification", "hostname", domain.Hostname, "error", err)
+		return genDb.AppDomain{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	if status == domains.StatusVerified {
+		if err := qtx.DeleteUnverifiedAppDomains(ctx, domain.Hostname); err != nil {
+			slog.ErrorContext(ctx, "failed to drop other claims on domain", "hostname", domain.Hostname, "error", err)
+			return genDb.AppDomain{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "failed to commit transaction", "error", err)
+		return genDb.AppDomain{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	return updated, nil
+}
+
+// getDomain loads an app and one of its domains, a domain of another app is reported as not found
+func (s *DomainServer) getDomain(ctx context.Context, appID int64, hostname string) (genDb.App, genDb.AppDomain, error) {
+	app, err := s.queries.GetAppByID(ctx, appID)
+	if err != nil {
+		slog.WarnContext(ctx, "app not found", "app_id", appID)
+		return genDb.App{}, genDb.AppDomain{}, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
+	}
+
+	normalized, err := domains.NormalizeHostname(hostname)
+	if err != nil {
+		return genDb.App{}, genDb.AppDomain{}, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	domain, err := s.queries.GetAppDomainForApp(ctx, genDb.GetAppDomainForAppParams{AppID: app.ID, Hostname: normalized})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return genDb.App{}, genDb.AppDomain{}, connect.NewError(connect.CodeNotFound, ErrDomainNotFound)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get domain", "hostname", normalized, "error", err)
+		return genDb.App{}, genDb.AppDomain{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+	return app, domain, nil
+}
+
+// attach issues the domain's certificate, adds its gateway listener and routes it to the app
+func (s *DomainServer) attach(ctx context.Context, app genDb.App, domain genDb.AppDomain) error {
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	// the namespace, route name and hostname only depend on the app
+	ldc := &kube.LocoDeploymentContext{App: &app}
+	if err := kc.AttachDomain(ctx, domain.ID, domain.Hostname, ldc.Namespace()); err != nil {
+		return err
+	}
+	return s.syncRouteHostnames(ctx, kc, ldc)
+}
+
+// detach unroutes the domain, then removes its gateway listener and certificate
+func (s *DomainServer) detach(ctx context.Context, app genDb.App, domain genDb.AppDomain) error {
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	ldc := &kube.LocoDeploymentContext{App: &app}
+	hostnames, err := s.queries.ListVerifiedHostnamesForApp(ctx, app.ID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	for _, h := range hostnames {
+		if h != domain.Hostname {
+			ldc.Domains = append(ldc.Domains, h)
+		}
+	}
+	if err := kc.SetRouteHostnames(ctx, ldc.Namespace(), ldc.HTTPRouteName(), ldc.Hostnames()); err != nil {
+		return err
+	}
+	return kc.DetachDomain(ctx, domain.ID)
+}
+
+func (s *DomainServer) syncRouteHostnames(ctx context.Context, kc *kube.Client, ldc *kube.LocoDeploymentContext) error {
+	hostnames, err := s.queries.ListVerifiedHostnamesForApp(ctx, ldc.App.ID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	ldc.Domains = hostnames
+	return kc.SetRouteHostnames(ctx, ldc.Namespace(), ldc.HTTPRouteName(), ldc.Hostnames())
+}
+
+// refreshCertificates updates the certificate status of verified domains in place and in the database.
+// When the cluster can't be reached the stored statuses are returned as they are.
+func (s *DomainServer) refreshCertificates(ctx context.Context, app genDb.App, domainList []genDb.AppDomain) {
+	var kc *kube.Client
+	for i, d := range domainList {
+		if d.VerificationStatus != domains.StatusVerified {
+			continue
+		}
+		if kc == nil {
+			var err error
+			if kc, err = s.clusters.Get(ctx, app.ClusterID); err != nil {
+				slog.WarnContext(ctx, "failed to reach cluster for certificate status", "cluster_id", app.ClusterID, "error", err)
+				return
+			}
+		}
+
+		state, message, err := kc.DomainCertificateStatus(ctx, d.ID)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to read certificate status", "hostname", d.Hostname, "error", err)
+			continue
+		}
+		status := certificateStatus(state)
+		certMessage := pgtype.Text{String: message, Valid: message != ""}
+		if status == d.CertificateStatus && certMessage == d.CertificateMessage {
+			continue
+		}
+
+		if err := s.queries.UpdateAppDomainCertificate(ctx, genDb.UpdateAppDomainCertificateParams{
+			ID:                 d.ID,
+			CertificateStatus:  status,
+			CertificateMessage: certMessage,
+		}); err != nil {
+			slog.WarnContext(ctx, "failed to store certificate status", "hostname", d.Hostname, "error", err)
+			continue
+		}
+		domainList[i].CertificateStatus, domainList[i].CertificateMessage = status, certMessage
+	}
+}
+
+// certificateStatus maps a kube certificate state to the stored status
+func certificateStatus(state string) string {
+	switch state {
+	case kube.CertificateReady:
+		return domains.CertificateReady
+	case kube.CertificateFailed:
+		return domains.CertificateFailed
+	case kube.CertificateIssuing:
+		return domains.CertificateIssuing
+	}
+	return domains.CertificateNone
+}
+
+func dbDomainToProto(d genDb.AppDomain) *domainv1.Domain {
+	domain := &domainv1.Domain{
+		Id:                 d.ID,
+		AppId:              d.AppID,
+		Hostname:           d.Hostname,
+		VerificationStatus: d.VerificationStatus,
+		ChallengeName:      domains.ChallengeName(d.Hostname),
+		ChallengeValue:     domains.ChallengeValue(d.VerificationToken),
+		CertificateStatus:  d.CertificateStatus,
+		CreatedAt:          timeutil.ParsePostgresTimestamp(d.CreatedAt.Time),
+	}
+	if d.VerificationMessage.Valid {
+		domain.VerificationMessage = &d.VerificationMessage.String
+	}
+	if d.VerifiedAt.Valid {
+		domain.VerifiedAt = timeutil.ParsePostgresTimestamp(d.VerifiedAt.Time)
+	}
+	if d.CertificateMessage.Valid {
+		domain.CertificateMessage = &d.CertificateMessage.String
+	}
+	return domain
+}
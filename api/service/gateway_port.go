@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+	sharedConfig "github.com/nikumar1206/loco/shared/config"
+)
+
+// maxPortAllocationAttempts bounds retries when concurrent deploys race for the same free port
+const maxPortAllocationAttempts = 3
+
+var ErrGatewayPortsExhausted = errors.New("no free gateway ports")
+
+// allocateGatewayPorts sets the listener port of each public port, keeping the ones the app already holds
+// and freeing the ones it no longer exposes, so an app's public addresses survive redeploys.
+// It runs in tx, the transaction that inserts the deployment, so a deploy that fails holds on to nothing.
+func allocateGatewayPorts(ctx context.Context, tx pgx.Tx, appID int64, ports []sharedConfig.Port) error {
+	queries := genDb.New(tx)
+	held, err := queries.ListGatewayPortsForApp(ctx, appID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list gateway ports", "app_id", appID, "error", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	wanted := make(map[string]string, len(ports))
+	for _, port := range ports {
+		if port.Exposure == sharedConfig.ExposurePublic {
+			wanted[port.Name] = port.Protocol
+		}
+	}
+
+	kept := make(map[string]int32, len(held))
+	for _, gp := range held {
+		if wanted[gp.Name] == gp.Protocol {
+			kept[gp.Name] = gp.Port
+			continue
+		}
+		if err := queries.DeleteGatewayPort(ctx, gp.ID); err != nil {
+			slog.ErrorContext(ctx, "failed to free gateway port", "app_id", appID, "port", gp.Port, "error", err)
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+		}
+	}
+
+	for i := range ports {
+		port := &ports[i]
+		if port.Exposure != sharedConfig.ExposurePublic {
+			continue
+		}
+		if listenerPort, ok := kept[port.Name]; ok {
+			port.ListenerPort = listenerPort
+			continue
+		}
+
+		gp, err := allocateGatewayPort(ctx, tx, appID, port)
+		if err != nil {
+			return err
+		}
+		port.ListenerPort = gp.Port
+	}
+	return nil
+}
+
+// allocateGatewayPort takes a free listener port. Each attempt runs in a savepoint of tx, a lost race
+// would otherwise abort the whole transaction.
+func allocateGatewayPort(ctx context.Context, tx pgx.Tx, appID int64, port *sharedConfig.Port) (genDb.GatewayPort, error) {
+	params := genDb.AllocateGatewayPortParams{
+		AppID:    appID,
+		Name:     port.Name,
+		Protocol: port.Protocol,
+		MinPort:  kube.MinListenerPort,
+		MaxPort:  kube.MaxListenerPort,
+	}
+
+	var err error
+	for range maxPortAllocationAttempts {
+		var gp genDb.GatewayPort
+		gp, err = allocateGatewayPortAttempt(ctx, tx, params)
+		if errors.Is(err, pgx.ErrNoRows) {
+			slog.ErrorContext(ctx, "gateway ports exhausted", "app_id", appID, "min", kube.MinListenerPort, "max", kube.MaxListenerPort)
+			return genDb.GatewayPort{}, connect.NewError(connect.CodeResourceExhausted, ErrGatewayPortsExhausted)
+		}
+		// another deploy took the same free port, try the next one
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			continue
+		}
+		if err == nil {
+			slog.InfoContext(ctx, "allocated gateway port", "app_id", appID, "name", port.Name, "port", gp.Port)
+			return gp, nil
+		}
+		break
+	}
+
+	slog.ErrorContext(ctx, "failed to allocate gateway port", "app_id", appID, "name", port.Name, "error", err)
+	return genDb.GatewayPort{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+}
+
+func allocateGatewayPortAttempt(ctx context.Context, tx pgx.Tx, params genDb.AllocateGatewayPortParams) (genDb.GatewayPort, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return genDb.GatewayPort{}, err
+	}
+	defer savepoint.Rollback(ctx)
+
+	gp, err := genDb.New(savepoint).AllocateGatewayPort(ctx, params)
+	if err != nil {
+		return genDb.GatewayPort{}, err
+	}
+	return gp, savepoint.Commit(ctx)
+}
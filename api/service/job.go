@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+	sharedConfig "github.com/nikumar1206/loco/shared/config"
+	jobv1 "github.com/nikumar1206/loco/shared/proto/job/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrNoDeployment = errors.New("no existing deployment found for app")
+)
+
+// JobServer implements the JobService gRPC server
+type JobServer struct {
+	db       *pgxpool.Pool
+	queries  *genDb.Queries
+	clusters *kube.Pool
+}
+
+// NewJobServer creates a new JobServer instance
+func NewJobServer(db *pgxpool.Pool, queries *genDb.Queries, clusters *kube.Pool) *JobServer {
+	return &JobServer{
+		db:       db,
+		queries:  queries,
+		clusters: clusters,
+	}
+}
+
+// ListJobs lists the scheduled jobs of an app's current deployment, with when they last ran
+func (s *JobServer) ListJobs(
+	ctx context.Context,
+	req *connect.Request[jobv1.ListJobsRequest],
+) (*connect.Response[jobv1.ListJobsResponse], error) {
+	r := req.Msg
+
+	kc, ldc, err := s.currentDeployment(ctx, r.AppId)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses map[string]kube.CronJobStatus
+	if len(ldc.Config.Jobs) > 0 {
+		statuses, err = kc.CronJobStatuses(ctx, ldc)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeUnavailable, err)
+		}
+	}
+
+	jobs := make([]*jobv1.Job, 0, len(ldc.Config.Jobs))
+	for _, job := range ldc.Config.Jobs {
+		j := &jobv1.Job{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Command:  job.Command,
+		}
+		if status, ok := statuses[job.Name]; ok {
+			if status.LastScheduledAt != nil {
+				j.LastScheduledAt = timestamppb.New(*status.LastScheduledAt)
+			}
+			if status.LastSucceededAt != nil {
+				j.LastSucceededAt = timestamppb.New(*status.LastSucceededAt)
+			}
+		}
+		jobs = append(jobs, j)
+	}
+
+	return connect.NewResponse(&jobv1.ListJobsResponse{
+		Jobs: jobs,
+	}), nil
+}
+
+// ListJobRuns lists the runs the cluster still keeps of an app's jobs, newest first
+func (s *JobServer) ListJobRuns(
+	ctx context.Context,
+	req *connect.Request[jobv1.ListJobRunsRequest],
+) (*connect.Response[jobv1.ListJobRunsResponse], error) {
+	r := req.Msg
+
+	kc, ldc, err := s.currentDeployment(ctx, r.AppId)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Job != nil && !hasJob(ldc.Config.Jobs, r.GetJob()) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("%w: %s", ErrJobNotFound, r.GetJob()))
+	}
+
+	runs, err := kc.ListJobRuns(ctx, ldc, r.GetJob())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	result := make([]*jobv1.JobRun, 0, len(runs))
+	for _, run := range runs {
+		result = append(result, jobRunToProto(run))
+	}
+
+	return connect.NewResponse(&jobv1.ListJobRunsResponse{
+		Runs: result,
+	}), nil
+}
+
+// TriggerJob starts a run of a scheduled job now, outside its schedule
+func (s *JobServer) TriggerJob(
+	ctx context.Context,
+	req *connect.Request[jobv1.TriggerJobRequest],
+) (*connect.Response[jobv1.TriggerJobResponse], error) {
+	r := req.Msg
+
+	kc, ldc, err := s.currentDeployment(ctx, r.AppId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasJob(ldc.Config.Jobs, r.Job) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("%w: %s", ErrJobNotFound, r.Job))
+	}
+
+	run, err := kc.TriggerJob(ctx, ldc, r.Job)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+	slog.InfoContext(ctx, "triggered job", "app_id", r.AppId, "job", r.Job, "run", run.Name)
+
+	return connect.NewResponse(&jobv1.TriggerJobResponse{
+		Run: jobRunToProto(run),
+	}), nil
+}
+
+// currentDeployment loads the cluster client and deployment context of an app's current deployment
+func (s *JobServer) currentDeployment(ctx context.Context, appID int64) (*kube.Client, *kube.LocoDeploymentContext, error) {
+	app, err := s.queries.GetAppByID(ctx, appID)
+	if err != nil {
+		slog.WarnContext(ctx, "app not found", "app_id", appID)
+		return nil, nil, connect.NewError(connect.CodeNotFound, ErrAppNotFound)
+	}
+
+	deployment, err := s.queries.GetCurrentDeploymentForApp(ctx, appID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, connect.NewError(connect.CodeNotFound, ErrNoDeployment)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get current deployment", "app_id", appID, "error", err)
+		return nil, nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	ldc, err := kube.NewLocoDeploymentContext(&app, &deployment)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read deployment config", "deployment_id", deployment.ID, "error", err)
+		return nil, nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	kc, err := s.clusters.Get(ctx, app.ClusterID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get cluster client", "cluster_id", app.ClusterID, "error", err)
+		return nil, nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	return kc, ldc, nil
+}
+
+func hasJob(jobs []sharedConfig.Job, name string) bool {
+	return slices.ContainsFunc(jobs, func(j sharedConfig.Job) bool { return j.Name == name })
+}
+
+func jobRunToProto(run kube.JobRun) *jobv1.JobRun {
+	result := &jobv1.JobRun{
+		Name:      run.Name,
+		Job:       run.Job,
+		Trigger:   run.Trigger,
+		Status:    run.Status,
+		CreatedAt: timestamppb.New(run.CreatedAt),
+	}
+	if run.Message != "" {
+		result.Message = &run.Message
+	}
+	if run.StartedAt != nil {
+		result.StartedAt = timestamppb.New(*run.StartedAt)
+	}
+	if run.FinishedAt != nil {
+		result.FinishedAt = timestamppb.New(*run.FinishedAt)
+	}
+	return result
+}
@@ -8,6 +8,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nikumar1206/loco/api/contextkeys"
 	genDb "github.com/nikumar1206/loco/api/gen/db"
 	"github.com/nikumar1206/loco/api/timeutil"
 	orgv1 "github.com/nikumar1206/loco/shared/proto/org/v1"
@@ -17,8 +18,6 @@ var (
 	ErrOrgNotFound              = errors.New("organization not found")
 	ErrOrgNameNotUnique         = errors.New("organization name already exists")
 	ErrOrgHasWorkspacesWithApps = errors.New("organization has workspaces with apps")
-	ErrNotOrgMember             = errors.New("user is not a member of this organization")
-	ErrNotOrgAdmin              = errors.New("user is not an admin of this organization")
 )
 
 // OrgServer implements the OrgService gRPC server
@@ -39,14 +38,14 @@ func (s *OrgServer) CreateOrg(
 ) (*connect.Response[orgv1.CreateOrgResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
 	}
-	externalId, ok := ctx.Value("external_user_id").(string)
+	externalId, ok := contextkeys.ExternalUsername(ctx)
 	if !ok {
-		slog.ErrorContext(ctx, "extnernal id not found in context")
+		slog.ErrorContext(ctx, "externalUsername not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
 	}
 
@@ -103,26 +102,6 @@ func (s *OrgServer) GetOrg(
 ) (*connect.Response[orgv1.GetOrgResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	isMember, err := s.queries.IsOrgMember(ctx, genDb.IsOrgMemberParams{
-		OrganizationID: r.Id,
-		UserID:         userID,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to check org membership", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if !isMember {
-		slog.WarnContext(ctx, "user is not a member of org", "orgId", r.Id, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotOrgMember)
-	}
-
 	org, err := s.queries.GetOrgByID(ctx, r.Id)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to query org", "error", err)
@@ -145,7 +124,7 @@ func (s *OrgServer) GetCurrentUserOrgs(
 	ctx context.Context,
 	req *connect.Request[orgv1.GetCurrentUserOrgsRequest],
 ) (*connect.Response[orgv1.GetCurrentUserOrgsResponse], error) {
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
@@ -173,17 +152,13 @@ func (s *OrgServer) GetCurrentUserOrgs(
 	}), nil
 }
 
-// ListOrgs lists organizations for a specified user (admin endpoint)
-// todo: implement loco management endpoints?
+// ListOrgs lists the organizations a user is a member of. authz only lets users list their own.
 func (s *OrgServer) ListOrgs(
 	ctx context.Context,
 	req *connect.Request[orgv1.ListOrgsRequest],
 ) (*connect.Response[orgv1.ListOrgsResponse], error) {
 	r := req.Msg
 
-	// TODO: Add admin check here
-	// For now, any authenticated user can list
-
 	page := r.Page
 	if page < 1 {
 		page = 1
@@ -241,26 +216,6 @@ func (s *OrgServer) UpdateOrg(
 ) (*connect.Response[orgv1.UpdateOrgResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	role, err := s.queries.GetOrgMemberRole(ctx, genDb.GetOrgMemberRoleParams{
-		OrganizationID: r.Id,
-		UserID:         userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of org", "orgId", r.Id, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotOrgMember)
-	}
-
-	if role != genDb.OrganizationRoleAdmin {
-		slog.WarnContext(ctx, "user is not an admin of org", "orgId", r.Id, "userId", userID, "role", string(role))
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotOrgAdmin)
-	}
-
 	isUnique, err := s.queries.IsOrgNameUnique(ctx, r.NewName)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to check org name uniqueness", "error", err)
@@ -302,26 +257,6 @@ func (s *OrgServer) DeleteOrg(
 ) (*connect.Response[orgv1.DeleteOrgResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	role, err := s.queries.GetOrgMemberRole(ctx, genDb.GetOrgMemberRoleParams{
-		OrganizationID: r.Id,
-		UserID:         userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of org", "orgId", r.Id, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotOrgMember)
-	}
-
-	if role != genDb.OrganizationRoleAdmin {
-		slog.WarnContext(ctx, "user is not an admin of org", "orgId", r.Id, "userId", userID, "role", string(role))
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotOrgAdmin)
-	}
-
 	// TODO: Check if org has workspaces with apps or not.
 	// var hasApps bool
 	hasApps, err := s.queries.OrgHasWorkspacesWithApps(ctx, r.Id)
@@ -353,26 +288,6 @@ func (s *OrgServer) ListWorkspaces(
 ) (*connect.Response[orgv1.ListWorkspacesResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	isMember, err := s.queries.IsOrgMember(ctx, genDb.IsOrgMemberParams{
-		OrganizationID: r.OrgId,
-		UserID:         userID,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to check org membership", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if !isMember {
-		slog.WarnContext(ctx, "user is not a member of org", "orgId", r.OrgId, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotOrgMember)
-	}
-
 	workspaces, err := s.queries.ListWorkspacesForOrg(ctx, r.OrgId)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to list workspaces", "error", err)
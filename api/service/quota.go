@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nikumar1206/loco/api/contextkeys"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+	"github.com/nikumar1206/loco/api/quota"
+	quotav1 "github.com/nikumar1206/loco/shared/proto/quota/v1"
+)
+
+var ErrInvalidQuota = errors.New("invalid quota")
+
+// QuotaServer implements the QuotaService gRPC server
+type QuotaServer struct {
+	db       *pgxpool.Pool
+	queries  *genDb.Queries
+	clusters *kube.Pool
+	quotas   *quota.Enforcer
+}
+
+// NewQuotaServer creates a new QuotaServer instance
+func NewQuotaServer(db *pgxpool.Pool, queries *genDb.Queries, clusters *kube.Pool, quotas *quota.Enforcer) *QuotaServer {
+	return &QuotaServer{
+		db:       db,
+		queries:  queries,
+		clusters: clusters,
+		quotas:   quotas,
+	}
+}
+
+// GetQuota returns a workspace's and its org's limits alongside current usage
+func (s *QuotaServer) GetQuota(
+	ctx context.Context,
+	req *connect.Request[quotav1.GetQuotaRequest],
+) (*connect.Response[quotav1.GetQuotaResponse], error) {
+	r := req.Msg
+
+	report, err := s.quotas.Report(ctx, r.WorkspaceId)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load quota report", "workspace_id", r.WorkspaceId, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	return connect.NewResponse(&quotav1.GetQuotaResponse{
+		WorkspaceId: report.WorkspaceID,
+		OrgId:       report.OrgID,
+		Workspace: &quotav1.Quota{
+			Limits: limitsToProto(report.Workspace),
+			Usage:  usageToProto(report.WorkspaceUsage),
+		},
+		Org: &quotav1.Quota{
+			Limits: limitsToProto(report.Org),
+			Usage:  usageToProto(report.OrgUsage),
+		},
+	}), nil
+}
+
+// SetWorkspaceQuota sets a workspace's limits. They can't exceed the org's.
+// Namespaces of existing apps are updated to match on a best effort basis.
+func (s *QuotaServer) SetWorkspaceQuota(
+	ctx context.Context,
+	req *connect.Request[quotav1.SetWorkspaceQuotaRequest],
+) (*connect.Response[quotav1.SetWorkspaceQuotaResponse], error) {
+	r := req.Msg
+
+	userID, ok := contextkeys.UserID(ctx)
+	if !ok {
+		slog.ErrorContext(ctx, "userId not found in context")
+		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
+	}
+
+	limits := r.GetLimits()
+	if limits == nil || limits.MaxApps < 1 || limits.MaxCpuMillicores < 1 || limits.MaxMemoryMb < 1 || limits.MaxReplicas < 1 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: every limit must be at least 1", ErrInvalidQuota))
+	}
+
+	orgID, err := s.queries.GetWorkspaceOrgID(ctx, r.WorkspaceId)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get workspace org", "workspace_id", r.WorkspaceId, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	orgLimits, err := s.quotas.OrgLimits(ctx, orgID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get org quota", "org_id", orgID, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	if limits.MaxApps > orgLimits.MaxApps ||
+		limits.MaxCpuMillicores > orgLimits.MaxCPU ||
+		limits.MaxMemoryMb > orgLimits.MaxMemory ||
+		limits.MaxReplicas > orgLimits.MaxReplicas {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf(
+			"%w: organization allows at most %d apps, %dm cpu, %dMi memory and %d replicas per app",
+			ErrInvalidQuota, orgLimits.MaxApps, orgLimits.MaxCPU, orgLimits.MaxMemory, orgLimits.MaxReplicas,
+		))
+	}
+
+	saved, err := s.queries.UpsertWorkspaceQuota(ctx, genDb.UpsertWorkspaceQuotaParams{
+		WorkspaceID:      r.WorkspaceId,
+		MaxApps:          limits.MaxApps,
+		MaxCpuMillicores: limits.MaxCpuMillicores,
+		MaxMemoryMb:      limits.MaxMemoryMb,
+		MaxReplicas:      limits.MaxReplicas,
+		UpdatedBy:        pgtype.Int8{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to save workspace quota", "workspace_id", r.WorkspaceId, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	s.syncNamespaceQuotas(ctx, r.WorkspaceId)
+
+	return connect.NewResponse(&quotav1.SetWorkspaceQuotaResponse{
+		Limits: &quotav1.Limits{
+			MaxApps:          saved.MaxApps,
+			MaxCpuMillicores: saved.MaxCpuMillicores,
+			MaxMemoryMb:      saved.MaxMemoryMb,
+			MaxReplicas:      saved.MaxReplicas,
+		},
+	}), nil
+}
+
+// syncNamespaceQuotas re-applies the workspace's effective limits to the namespace of every app in it.
+// Apps that haven't been deployed yet have no namespace and are skipped.
+func (s *QuotaServer) syncNamespaceQuotas(ctx context.Context, workspaceID int64) {
+	limits, err := s.quotas.Effective(ctx, workspaceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load effective quota", "workspace_id", workspaceID, "error", err)
+		return
+	}
+	namespaceQuota := limits.Namespace()
+
+	apps, err := s.queries.ListAppsForWorkspace(ctx, workspaceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list apps", "workspace_id", workspaceID, "error", err)
+		return
+	}
+
+	for _, app := range apps {
+		kc, err := s.clusters.Get(ctx, app.ClusterID)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to get cluster client", "app_id", app.ID, "cluster_id", app.ClusterID, "error", err)
+			continue
+		}
+		ldc := &kube.LocoDeploymentContext{App: &app}
+		exists, err := kc.CheckNSExists(ctx, ldc.Namespace())
+		if err != nil || !exists {
+			continue
+		}
+		if _, err := kc.ApplyResourceQuota(ctx, ldc, namespaceQuota); err != nil {
+			slog.WarnContext(ctx, "failed to sync resource quota", "app_id", app.ID, "error", err)
+		}
+		if _, err := kc.ApplyLimitRange(ctx, ldc, namespaceQuota); err != nil {
+			slog.WarnContext(ctx, "failed to sync limit range", "app_id", app.ID, "error", err)
+		}
+	}
+}
+
+func limitsToProto(l quota.Limits) *quotav1.Limits {
+	return &quotav1.Limits{
+		MaxApps:          l.MaxApps,
+		MaxCpuMillicores: l.MaxCPU,
+		MaxMemoryMb:      l.MaxMemory,
+		MaxReplicas:      l.MaxReplicas,
+	}
+}
+
+func usageToProto(u quota.Usage) *quotav1.Usage {
+	return &quotav1.Usage{
+		Apps:          u.Apps,
+		CpuMillicores: u.CPU,
+		MemoryMb:      u.Memory,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"connectrpc.com/connect"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nikumar1206/loco/api/client"
+	"github.com/nikumar1206/loco/api/contextkeys"
 	"github.com/nikumar1206/loco/api/gen/db"
 	registryv1 "github.com/nikumar1206/loco/shared/proto/registry/v1"
 )
@@ -54,7 +55,7 @@ func (s *RegistryServer) GitlabToken(
 	ctx context.Context,
 	req *connect.Request[registryv1.GitlabTokenRequest],
 ) (*connect.Response[registryv1.GitlabTokenResponse], error) {
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgconn"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/routes"
+)
+
+// pgUniqueViolation is the Postgres error code for a unique constraint violation
+const pgUniqueViolation = "23505"
+
+// reserveHostname reserves subdomain.domain for the workspace. Hostnames are shared by a workspace's apps,
+// so it only fails if another workspace owns it.
+func reserveHostname(ctx context.Context, queries *genDb.Queries, workspaceID int64, subdomain, domain string, userID int64) (genDb.Hostname, error) {
+	hostname, err := queries.ReserveHostname(ctx, genDb.ReserveHostnameParams{
+		WorkspaceID: workspaceID,
+		Subdomain:   subdomain,
+		Domain:      domain,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to reserve hostname", "subdomain", subdomain, "domain", domain, "error", err)
+		return genDb.Hostname{}, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	if hostname.WorkspaceID != workspaceID {
+		slog.WarnContext(ctx, "subdomain owned by another workspace", "subdomain", subdomain, "domain", domain)
+		return genDb.Hostname{}, connect.NewError(connect.CodeAlreadyExists, ErrSubdomainNotAvailable)
+	}
+	return hostname, nil
+}
+
+// claimRoute claims the path prefix on the app's hostname for it, replacing its previous claim.
+// It returns the normalized prefix.
+func claimRoute(ctx context.Context, queries *genDb.Queries, app *genDb.App, prefix string, userID int64) (string, error) {
+	prefix, err := routes.NormalizePrefix(prefix)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	hostname, err := reserveHostname(ctx, queries, app.WorkspaceID, app.Subdomain, app.Domain, userID)
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := queries.ListRouteClaimsForHostname(ctx, hostname.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list route claims", "hostname_id", hostname.ID, "error", err)
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	claims := make([]routes.Claim, 0, len(rows))
+	for _, row := range rows {
+		claims = append(claims, routes.Claim{AppID: row.AppID, AppName: row.AppName, PathPrefix: row.PathPrefix})
+	}
+	if claim, ok := routes.Conflict(claims, app.ID, prefix); ok {
+		slog.WarnContext(ctx, "path prefix already claimed", "app_id", app.ID, "prefix", prefix, "claimed_by", claim.AppID)
+		return "", connect.NewError(connect.CodeAlreadyExists,
+			fmt.Errorf("%w: %s%s is served by app '%s'", routes.ErrPrefixTaken, hostnameOf(app), prefix, claim.AppName))
+	}
+
+	_, err = queries.ClaimRoute(ctx, genDb.ClaimRouteParams{
+		HostnameID: hostname.ID,
+		AppID:      app.ID,
+		PathPrefix: prefix,
+	})
+	// lost a race with another deploy claiming the same prefix
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return "", connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("%w: %s%s", routes.ErrPrefixTaken, hostnameOf(app), prefix))
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to claim route", "app_id", app.ID, "prefix", prefix, "error", err)
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	return prefix, nil
+}
+
+// releaseHostname frees subdomain.domain once none of its workspace's apps use it
+func releaseHostname(ctx context.Context, queries *genDb.Queries, subdomain, domain string) {
+	err := queries.ReleaseHostname(ctx, genDb.ReleaseHostnameParams{
+		Subdomain: subdomain,
+		Domain:    domain,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to release hostname", "subdomain", subdomain, "domain", domain, "error", err)
+	}
+}
+
+func hostnameOf(app *genDb.App) string {
+	return fmt.Sprintf("%s.%s", app.Subdomain, app.Domain)
+}
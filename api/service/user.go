@@ -9,6 +9,7 @@ import (
 	"connectrpc.com/connect"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nikumar1206/loco/api/contextkeys"
 	genDb "github.com/nikumar1206/loco/api/gen/db"
 	"github.com/nikumar1206/loco/api/timeutil"
 	userv1 "github.com/nikumar1206/loco/shared/proto/user/v1"
@@ -142,7 +143,7 @@ func (s *UserServer) GetCurrentUser(
 	ctx context.Context,
 	req *connect.Request[userv1.GetCurrentUserRequest],
 ) (*connect.Response[userv1.GetCurrentUserResponse], error) {
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
@@ -165,17 +166,6 @@ func (s *UserServer) UpdateUser(
 ) (*connect.Response[userv1.UpdateUserResponse], error) {
 	r := req.Msg
 
-	currentUserID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	if r.Id != currentUserID {
-		slog.WarnContext(ctx, "user attempted to update another user", "target_user", r.Id, "currentUser", currentUserID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrUnauthorized)
-	}
-
 	avatarURL := pgtype.Text{String: r.GetAvatarUrl(), Valid: r.GetAvatarUrl() != ""}
 
 	user, err := s.queries.UpdateUserAvatarURL(ctx, genDb.UpdateUserAvatarURLParams{
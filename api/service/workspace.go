@@ -10,6 +10,7 @@ import (
 	"connectrpc.com/connect"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nikumar1206/loco/api/contextkeys"
 	genDb "github.com/nikumar1206/loco/api/gen/db"
 	"github.com/nikumar1206/loco/api/timeutil"
 	workspacev1 "github.com/nikumar1206/loco/shared/proto/workspace/v1"
@@ -19,8 +20,6 @@ var (
 	ErrWorkspaceNotFound      = errors.New("workspace not found")
 	ErrWorkspaceNameNotUnique = errors.New("workspace name already exists in this organization")
 	ErrInvalidWorkspaceName   = errors.New("workspace name must be DNS-safe: lowercase alphanumeric and hyphens only")
-	ErrNotWorkspaceMember     = errors.New("user is not a member of this workspace")
-	ErrNotWorkspaceAdmin      = errors.New("user is not an admin of this workspace")
 	ErrWorkspaceHasApps       = errors.New("workspace has apps - must confirm deletion")
 	ErrInvalidRole            = errors.New("invalid role - must be admin, deploy, or read")
 )
@@ -45,7 +44,7 @@ func (s *WorkspaceServer) CreateWorkspace(
 ) (*connect.Response[workspacev1.CreateWorkspaceResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
@@ -56,20 +55,6 @@ func (s *WorkspaceServer) CreateWorkspace(
 		return nil, connect.NewError(connect.CodeInvalidArgument, ErrInvalidWorkspaceName)
 	}
 
-	role, err := s.queries.GetOrgMemberRole(ctx, genDb.GetOrgMemberRoleParams{
-		OrganizationID: r.OrgId,
-		UserID:         userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of org", "orgId", r.OrgId, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotOrgMember)
-	}
-
-	if role != genDb.OrganizationRoleAdmin {
-		slog.WarnContext(ctx, "user is not an admin of org", "orgId", r.OrgId, "userId", userID, "role", string(role))
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotOrgAdmin)
-	}
-
 	isUnique, err := s.queries.IsWorkspaceNameUniqueInOrg(ctx, genDb.IsWorkspaceNameUniqueInOrgParams{
 		OrgID: r.OrgId,
 		Name:  r.Name,
@@ -127,26 +112,6 @@ func (s *WorkspaceServer) GetWorkspace(
 ) (*connect.Response[workspacev1.GetWorkspaceResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	isMember, err := s.queries.IsWorkspaceMember(ctx, genDb.IsWorkspaceMemberParams{
-		WorkspaceID: r.Id,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to check workspace membership", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if !isMember {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", r.Id, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	ws, err := s.queries.GetWorkspaceByIDQuery(ctx, r.Id)
 	if err != nil {
 		slog.WarnContext(ctx, "workspace not found", "id", r.Id)
@@ -171,7 +136,7 @@ func (s *WorkspaceServer) GetUserWorkspaces(
 	ctx context.Context,
 	req *connect.Request[workspacev1.GetUserWorkspacesRequest],
 ) (*connect.Response[workspacev1.GetUserWorkspacesResponse], error) {
-	userID, ok := ctx.Value("userId").(int64)
+	userID, ok := contextkeys.UserID(ctx)
 	if !ok {
 		slog.ErrorContext(ctx, "userId not found in context")
 		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
@@ -208,26 +173,6 @@ func (s *WorkspaceServer) ListWorkspaces(
 ) (*connect.Response[workspacev1.ListWorkspacesResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	isMember, err := s.queries.IsOrgMember(ctx, genDb.IsOrgMemberParams{
-		OrganizationID: r.OrgId,
-		UserID:         userID,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to check org membership", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if !isMember {
-		slog.WarnContext(ctx, "user is not a member of org", "orgId", r.OrgId, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotOrgMember)
-	}
-
 	workspaceList, err := s.queries.ListWorkspacesInOrg(ctx, r.OrgId)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to list workspaces", "error", err)
@@ -259,26 +204,6 @@ func (s *WorkspaceServer) UpdateWorkspace(
 ) (*connect.Response[workspacev1.UpdateWorkspaceResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-		WorkspaceID: r.Id,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", r.Id, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
-	if role != genDb.WorkspaceRoleAdmin {
-		slog.WarnContext(ctx, "user is not an admin of workspace", "workspaceId", r.Id, "userId", userID, "role", string(role))
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceAdmin)
-	}
-
 	if r.GetName() != "" {
 		if !workspaceNamePattern.MatchString(r.GetName()) {
 			slog.WarnContext(ctx, "invalid workspace name", "name", r.GetName())
@@ -339,30 +264,10 @@ func (s *WorkspaceServer) DeleteWorkspace(
 ) (*connect.Response[workspacev1.DeleteWorkspaceResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-		WorkspaceID: r.Id,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", r.Id, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
-	if role != genDb.WorkspaceRoleAdmin {
-		slog.WarnContext(ctx, "user is not an admin of workspace", "workspaceId", r.Id, "userId", userID, "role", string(role))
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceAdmin)
-	}
-
 	// TODO: Check if workspace has apps (when apps table exists)
 	// For now, skip this check
 
-	err = s.queries.RemoveWorkspace(ctx, r.Id)
+	err := s.queries.RemoveWorkspace(ctx, r.Id)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to delete workspace", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
@@ -380,12 +285,6 @@ func (s *WorkspaceServer) AddMember(
 ) (*connect.Response[workspacev1.AddMemberResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
 	var wsRole genDb.WorkspaceRole
 	switch r.Role {
 	case "admin":
@@ -399,20 +298,6 @@ func (s *WorkspaceServer) AddMember(
 		return nil, connect.NewError(connect.CodeInvalidArgument, ErrInvalidRole)
 	}
 
-	role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-		WorkspaceID: r.WorkspaceId,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", r.WorkspaceId, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
-	if role != genDb.WorkspaceRoleAdmin {
-		slog.WarnContext(ctx, "user is not an admin of workspace", "workspaceId", r.WorkspaceId, "userId", userID, "role", string(role))
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceAdmin)
-	}
-
 	member, err := s.queries.UpsertWorkspaceMember(ctx, genDb.UpsertWorkspaceMemberParams{
 		WorkspaceID: r.WorkspaceId,
 		UserID:      r.UserId,
@@ -440,37 +325,13 @@ func (s *WorkspaceServer) RemoveMember(
 ) (*connect.Response[workspacev1.RemoveMemberResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	isSelfRemoval := userID == r.UserId
-
-	if !isSelfRemoval {
-		role, err := s.queries.GetWorkspaceMemberRole(ctx, genDb.GetWorkspaceMemberRoleParams{
-			WorkspaceID: r.WorkspaceId,
-			UserID:      userID,
-		})
-		if err != nil {
-			slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", r.WorkspaceId, "userId", userID)
-			return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-		}
-
-		if role != genDb.WorkspaceRoleAdmin {
-			slog.WarnContext(ctx, "user is not an admin of workspace", "workspaceId", r.WorkspaceId, "userId", userID, "role", string(role))
-			return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceAdmin)
-		}
-	}
-
-	deleteErr := s.queries.DeleteWorkspaceMember(ctx, genDb.DeleteWorkspaceMemberParams{
+	err := s.queries.DeleteWorkspaceMember(ctx, genDb.DeleteWorkspaceMemberParams{
 		WorkspaceID: r.WorkspaceId,
 		UserID:      r.UserId,
 	})
-	if deleteErr != nil {
-		slog.ErrorContext(ctx, "failed to remove member", "error", deleteErr)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", deleteErr))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to remove member", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
 	}
 
 	return connect.NewResponse(&workspacev1.RemoveMemberResponse{
@@ -485,26 +346,6 @@ func (s *WorkspaceServer) ListMembers(
 ) (*connect.Response[workspacev1.ListMembersResponse], error) {
 	r := req.Msg
 
-	userID, ok := ctx.Value("userId").(int64)
-	if !ok {
-		slog.ErrorContext(ctx, "userId not found in context")
-		return nil, connect.NewError(connect.CodeUnauthenticated, ErrUnauthorized)
-	}
-
-	isMember, err := s.queries.IsWorkspaceMember(ctx, genDb.IsWorkspaceMemberParams{
-		WorkspaceID: r.WorkspaceId,
-		UserID:      userID,
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to check workspace membership", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
-	}
-
-	if !isMember {
-		slog.WarnContext(ctx, "user is not a member of workspace", "workspaceId", r.WorkspaceId, "userId", userID)
-		return nil, connect.NewError(connect.CodePermissionDenied, ErrNotWorkspaceMember)
-	}
-
 	memberList, err := s.queries.GetWorkspaceMembers(ctx, r.WorkspaceId)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to list members", "error", err)
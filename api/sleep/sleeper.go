@@ -0,0 +1,284 @@
+// Package sleep scales apps with routing.sleepAfter to zero once the gateway stops seeing requests for them,
+// and wakes them when the next request arrives.
+//
+// A sleeping app's HTTPRoute sends its requests to loco-api's wake endpoint instead of the app. The endpoint
+// scales the app back up, waits for a ready pod, restores the route and redirects to the original URL.
+package sleep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	genDb "github.com/nikumar1206/loco/api/gen/db"
+	"github.com/nikumar1206/loco/api/pkg/kube"
+	"github.com/nikumar1206/loco/api/pkg/metricstore"
+	"github.com/nikumar1206/loco/shared/config"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultInterval is how often apps are checked for idleness
+	DefaultInterval = 15 * time.Minute
+	// routeSettleDelay gives the gateway time to apply a restored route before redirecting, so the
+	// redirect doesn't land on the wake endpoint again
+	routeSettleDelay = time.Second
+)
+
+// Sleeper puts idle apps to sleep and serves the wake endpoint
+type Sleeper struct {
+	queries  *genDb.Queries
+	clusters *kube.Pool
+	metrics  *metricstore.Client
+	interval time.Duration
+
+	// concurrent requests to a sleeping app share one wake
+	wakes singleflight.Group
+}
+
+// NewSleeper creates a Sleeper. Without metrics, idleness can't be detected and apps are never put to sleep.
+// An interval of zero or less falls back to DefaultInterval.
+func NewSleeper(queries *genDb.Queries, clusters *kube.Pool, metrics *metricstore.Client, interval time.Duration) *Sleeper {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Sleeper{
+		queries:  queries,
+		clusters: clusters,
+		metrics:  metrics,
+		interval: interval,
+	}
+}
+
+// Run checks every app on each interval until ctx is done
+func (s *Sleeper) Run(ctx context.Context) {
+	if s.metrics == nil {
+		slog.InfoContext(ctx, "Idle app detection disabled, no metric store configured")
+		return
+	}
+	slog.InfoContext(ctx, "Starting idle app detection", "interval", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := s.sweep(ctx); err != nil {
+			slog.ErrorContext(ctx, "Idle app sweep failed", "error", err)
+		}
+	}
+}
+
+func (s *Sleeper) sweep(ctx context.Context) error {
+	clusters, err := s.queries.ListClusters(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		apps, err := s.queries.ListAppsForCluster(ctx, cluster.ID)
+		if err != nil {
+			return err
+		}
+		kc, err := s.clusters.Get(ctx, cluster.ID)
+		if err != nil {
+			slog.WarnContext(ctx, "Skipping idle app sweep, cluster unreachable", "cluster_id", cluster.ID, "error", err)
+			continue
+		}
+		for _, app := range apps {
+			if err := s.sleepIfIdle(ctx, kc, app); err != nil {
+				slog.ErrorContext(ctx, "Failed to put idle app to sleep", "app_id", app.ID, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+// sleepIfIdle puts the app to sleep when it has sleepAfter set and served no requests for that long
+func (s *Sleeper) sleepIfIdle(ctx context.Context, kc *kube.Client, app genDb.App) error {
+	deployment, err := s.queries.GetCurrentDeploymentForApp(ctx, app.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if deployment.Status != genDb.DeploymentStatusSucceeded {
+		return nil
+	}
+
+	ldc, err := kube.NewLocoDeploymentContext(&app, &deployment)
+	if err != nil {
+		return err
+	}
+	if ldc.Config.Routing.SleepAfter == "" || ldc.Protocol() != config.ProtocolHTTP {
+		return nil
+	}
+	sleepAfter, err := config.ParseSleepAfter(ldc.Config.Routing.SleepAfter)
+	if err != nil {
+		return err
+	}
+
+	state, err := s.queries.GetAppSleep(ctx, app.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if state.SleptAt.Valid {
+		return nil
+	}
+
+	// the idle clock starts at the later of the deploy and the last wake
+	activeSince := deployment.CreatedAt.Time
+	if state.WokeAt.Valid && state.WokeAt.Time.After(activeSince) {
+		activeSince = state.WokeAt.Time
+	}
+	now := time.Now()
+	if now.Sub(activeSince) < sleepAfter {
+		return nil
+	}
+
+	requests, found, err := s.metrics.RequestCount(ctx, ldc.Namespace(), ldc.HTTPRouteName(), now.Add(-sleepAfter), now)
+	if err != nil {
+		return err
+	}
+	if !found || requests > 0 {
+		return nil
+	}
+
+	// verified custom domains are part of the route, and keep waking the app while it sleeps
+	ldc.Domains, err = s.queries.ListVerifiedHostnamesForApp(ctx, app.ID)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "App is idle, putting it to sleep", "app_id", app.ID, "sleep_after", sleepAfter)
+	if err := kc.SleepApp(ctx, ldc); err != nil {
+		return err
+	}
+	return s.queries.MarkAppSleeping(ctx, app.ID)
+}
+
+// Wake handles requests sent to a sleeping app, rewritten by its route to /wake/{appID}/{rest...}.
+// It wakes the app and redirects to the URL that was requested.
+func (s *Sleeper) Wake(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	appID, err := strconv.ParseInt(r.PathValue("appID"), 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	app, err := s.queries.GetAppByID(ctx, appID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	deployment, err := s.queries.GetCurrentDeploymentForApp(ctx, appID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	ldc, err := kube.NewLocoDeploymentContext(&app, &deployment)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to read deployment config", "deployment_id", deployment.ID, "error", err)
+		http.Error(w, "failed to wake app", http.StatusInternalServerError)
+		return
+	}
+	ldc.Domains, err = s.queries.ListVerifiedHostnamesForApp(ctx, appID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list verified hostnames", "app_id", appID, "error", err)
+		http.Error(w, "failed to wake app", http.StatusInternalServerError)
+		return
+	}
+	// the gateway keeps the Host of requests it rewrites. Anything else reached the endpoint directly, app IDs
+	// are sequential and must not be enough to wake apps.
+	host := requestHost(r)
+	if !slices.Contains(ldc.Hostnames(), host) {
+		http.NotFound(w, r)
+		return
+	}
+
+	// the wake outlives the request that started it, other requests may be waiting on it
+	result := s.wakes.DoChan(strconv.FormatInt(appID, 10), func() (any, error) {
+		return nil, s.wake(context.WithoutCancel(ctx), ldc)
+	})
+	select {
+	case <-ctx.Done():
+		return
+	case res := <-result:
+		if res.Err != nil {
+			slog.ErrorContext(ctx, "Failed to wake app", "app_id", appID, "error", res.Err)
+			w.Header().Set("Retry-After", "10")
+			http.Error(w, "app is waking up, try again shortly", http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	time.Sleep(routeSettleDelay)
+	http.Redirect(w, r, originalURL(r, host, ldc), http.StatusTemporaryRedirect)
+}
+
+// wake is a no-op for apps already awake, requests can reach the endpoint until the gateway applies the restored route
+func (s *Sleeper) wake(ctx context.Context, ldc *kube.LocoDeploymentContext) error {
+	state, err := s.queries.GetAppSleep(ctx, ldc.App.ID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !state.SleptAt.Valid) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	kc, err := s.clusters.Get(ctx, ldc.App.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, kube.WakeTimeout+30*time.Second)
+	defer cancel()
+	if err := kc.WakeApp(ctx, ldc); err != nil {
+		return err
+	}
+	return s.queries.MarkAppAwake(ctx, ldc.App.ID)
+}
+
+// requestHost is the hostname a request was sent to, without its port
+func requestHost(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.Host); err == nil {
+		return host
+	}
+	return r.Host
+}
+
+// originalURL rebuilds the URL the wake request was rewritten from. host is one of the app's own hostnames,
+// so the endpoint can't be used to redirect anywhere else.
+func originalURL(r *http.Request, host string, ldc *kube.LocoDeploymentContext) string {
+	scheme := "https"
+	if r.Header.Get("X-Forwarded-Proto") == "http" {
+		scheme = "http"
+	}
+
+	path := ldc.PathPrefix()
+	if rest := r.PathValue("rest"); rest != "" {
+		path = strings.TrimSuffix(path, "/") + "/" + rest
+	}
+
+	target := fmt.Sprintf("%s://%s%s", scheme, host, path)
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+	return target
+}
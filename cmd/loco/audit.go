@@ -0,0 +1,204 @@
+package loco
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/client"
+	"github.com/nikumar1206/loco/internal/ui"
+	auditv1 "github.com/nikumar1206/loco/shared/proto/audit/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func init() {
+	auditCmd.Flags().StringP("app", "a", "", "Only show events for this application")
+	auditCmd.Flags().String("org", "", "organization ID")
+	auditCmd.Flags().String("workspace", "", "workspace ID")
+	auditCmd.Flags().Int64("actor", 0, "Only show events performed by this user ID")
+	auditCmd.Flags().Duration("since", 0, "Only show events newer than a relative duration like 1h or 24h")
+	auditCmd.Flags().Int32("limit", 50, "Maximum number of events to display")
+	auditCmd.Flags().String("output", "table", "Output format (table, json). Defaults to table.")
+	auditCmd.Flags().String("host", "", "Set the host URL")
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the audit log for a workspace",
+	Long: `Display who changed what in a workspace: deploys, scaling, env changes, deletions and membership changes.
+Requires workspace admin.`,
+	Example: `  loco audit
+  loco audit --app myapp --since 24h
+  loco audit --actor 42 --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return auditCmdFunc(cmd)
+	},
+}
+
+func auditCmdFunc(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	host, err := getHost(cmd)
+	if err != nil {
+		return err
+	}
+
+	workspaceID, err := getWorkspaceId(cmd)
+	if err != nil {
+		return err
+	}
+
+	appName, err := cmd.Flags().GetString("app")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	actorID, err := cmd.Flags().GetInt64("actor")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	since, err := cmd.Flags().GetDuration("since")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	limit, err := cmd.Flags().GetInt32("limit")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	locoToken, err := getLocoToken()
+	if err != nil {
+		return ErrLoginRequired
+	}
+
+	apiClient := client.NewClient(host, locoToken.Token)
+
+	req := &auditv1.ListEventsRequest{
+		WorkspaceId: workspaceID,
+		Limit:       &limit,
+	}
+
+	if appName != "" {
+		slog.Debug("fetching app by name", "workspaceId", workspaceID, "app_name", appName)
+		app, err := apiClient.GetAppByName(ctx, workspaceID, appName)
+		if err != nil {
+			slog.Debug("failed to get app by name", "error", err)
+			return fmt.Errorf("failed to get app '%s': %w", appName, err)
+		}
+		req.AppId = &app.Id
+	}
+	if actorID != 0 {
+		req.ActorId = &actorID
+	}
+	if since > 0 {
+		req.Since = timestamppb.New(time.Now().Add(-since))
+	}
+
+	resp, err := apiClient.ListAuditEvents(ctx, req)
+	if err != nil {
+		slog.Error("failed to fetch audit events", "error", err)
+		return fmt.Errorf("failed to fetch audit events: %w", err)
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(resp.Events)
+	}
+
+	printAuditTable(resp.Events)
+	if int64(len(resp.Events)) < resp.Total {
+		fmt.Printf("  Showing %d of %d events. Use --limit to see more.\n", len(resp.Events), resp.Total)
+	}
+	return nil
+}
+
+func printAuditTable(events []*auditv1.AuditEvent) {
+	if len(events) == 0 {
+		fmt.Println("No audit events found.")
+		return
+	}
+
+	columns := []table.Column{
+		{Title: "TIME", Width: 20},
+		{Title: "ACTOR", Width: 16},
+		{Title: "ACTION", Width: 22},
+		{Title: "TARGET", Width: 24},
+		{Title: "RESULT", Width: 8},
+		{Title: "SOURCE", Width: 22},
+	}
+
+	var rows []table.Row
+	for _, event := range events {
+		result := "ok"
+		if !event.Success {
+			result = "failed"
+		}
+		rows = append(rows, table.Row{
+			event.CreatedAt.AsTime().Local().Format(time.RFC3339),
+			auditActor(event),
+			auditAction(event.Procedure),
+			auditTarget(event),
+			result,
+			event.SourceIp,
+		})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithHeight(len(rows)),
+	)
+
+	s := table.Styles{
+		Header: lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(ui.LocoMuted).
+			BorderBottom(true).
+			Bold(false),
+		Cell: lipgloss.NewStyle().Padding(0, 1),
+	}
+	t.SetStyles(s)
+
+	tableStyle := lipgloss.NewStyle().Margin(1, 2)
+	fmt.Println(tableStyle.Render(t.View()))
+}
+
+func auditActor(event *auditv1.AuditEvent) string {
+	if event.Actor != "" {
+		return event.Actor
+	}
+	if event.ActorId != nil {
+		return fmt.Sprintf("user %d", event.GetActorId())
+	}
+	return "unknown"
+}
+
+// auditAction turns "/loco.app.v1.AppService/ScaleApp" into "ScaleApp"
+func auditAction(procedure string) string {
+	if i := strings.LastIndex(procedure, "/"); i >= 0 {
+		return procedure[i+1:]
+	}
+	return procedure
+}
+
+func auditTarget(event *auditv1.AuditEvent) string {
+	if event.ResourceId == nil {
+		return event.ResourceType
+	}
+	return fmt.Sprintf("%s %d", event.ResourceType, event.GetResourceId())
+}
@@ -136,6 +136,9 @@ func deployCmdFunc(cmd *cobra.Command) error {
 			Type:      appv1.AppType_SERVICE,
 			Subdomain: loadedCfg.Config.Routing.Subdomain,
 		})
+		if region := loadedCfg.Config.Metadata.Region; region != "" {
+			createAppReq.Msg.Region = &region
+		}
 		createAppReq.Header().Set("Authorization", fmt.Sprintf("Bearer %s", locoToken.Token))
 
 		createAppResp, err := appClient.CreateApp(ctx, createAppReq)
@@ -257,13 +260,60 @@ func deployApp(ctx context.Context,
 			Protocol: "TCP",
 		},
 	}
+	for _, port := range cfg.Routing.Ports {
+		ports = append(ports, &deploymentv1.Port{
+			Name:     &port.Name,
+			Port:     port.Port,
+			Protocol: port.Protocol,
+			Public:   port.Exposure == config.ExposurePublic,
+		})
+	}
+
+	volumes := make([]*deploymentv1.Volume, 0, len(cfg.Volumes))
+	for _, volume := range cfg.Volumes {
+		volumes = append(volumes, &deploymentv1.Volume{
+			Name:      volume.Name,
+			MountPath: volume.MountPath,
+			Size:      volume.Size,
+			Type:      volume.Type,
+		})
+	}
+
+	jobs := make([]*deploymentv1.Job, 0, len(cfg.Jobs))
+	for _, job := range cfg.Jobs {
+		jobs = append(jobs, &deploymentv1.Job{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Command:  job.Command,
+			Cpu:      &job.CPU,
+			Memory:   &job.Memory,
+		})
+	}
 
 	createDeploymentReq := connect.NewRequest(&deploymentv1.CreateDeploymentRequest{
-		AppId:    appID,
-		Image:    imageName,
-		Replicas: &replicas,
-		Env:      cfg.Env.Variables,
-		Ports:    ports,
+		AppId:          appID,
+		Image:          imageName,
+		Replicas:       &replicas,
+		Env:            cfg.Env.Variables,
+		Ports:          ports,
+		StructuredLogs: &cfg.Obs.Logging.Structured,
+		PathPrefix:     &cfg.Routing.PathPrefix,
+		Protocol:       &cfg.Routing.Protocol,
+		GrpcServices:   cfg.Routing.GRPCServices,
+		SleepAfter:     &cfg.Routing.SleepAfter,
+		Health: &deploymentv1.HealthChecks{
+			Liveness:  probeToProto(cfg.Health.Liveness),
+			Readiness: probeToProto(cfg.Health.Readiness),
+			Startup:   probeToProto(cfg.Health.Startup),
+		},
+		Volumes: volumes,
+		Jobs:    jobs,
+		Release: &cfg.Deploy.Release,
+		Resources: &deploymentv1.ResourceSpec{
+			Cpu:         &cfg.Resources.CPU,
+			Memory:      &cfg.Resources.Memory,
+			MaxReplicas: &cfg.Resources.Replicas.Max,
+		},
 	})
 	createDeploymentReq.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
@@ -279,6 +329,10 @@ func deployApp(ctx context.Context,
 	if wait {
 		logf("Waiting for deployment to complete...")
 		if err := apiClient.StreamDeployment(ctx, fmt.Sprintf("%d", deploymentID), func(event *deploymentv1.DeploymentEvent) error {
+			if event.Log != nil {
+				logf(fmt.Sprintf("[release] %s", event.GetLog()))
+				return nil
+			}
 			logf(fmt.Sprintf("[%s] %s", event.Status, event.Message))
 			if event.ErrorMessage != nil && *event.ErrorMessage != "" {
 				logf(fmt.Sprintf("ERROR: %s", *event.ErrorMessage))
@@ -292,3 +346,22 @@ func deployApp(ctx context.Context,
 
 	return nil
 }
+
+func probeToProto(p *config.Probe) *deploymentv1.Probe {
+	if p == nil {
+		return nil
+	}
+	return &deploymentv1.Probe{
+		Type:             p.Type,
+		Port:             p.Port,
+		Path:             p.Path,
+		Headers:          p.Headers,
+		Command:          p.Command,
+		Service:          p.Service,
+		InitialDelay:     p.InitialDelay,
+		Interval:         p.Interval,
+		Timeout:          p.Timeout,
+		FailThreshold:    p.FailThreshold,
+		SuccessThreshold: p.SuccessThreshold,
+	}
+}
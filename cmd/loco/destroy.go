@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/nikumar1206/loco/internal/ui"
 	"github.com/nikumar1206/loco/shared"
+	"github.com/nikumar1206/loco/shared/config"
 	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
 	appv1connect "github.com/nikumar1206/loco/shared/proto/app/v1/appv1connect"
 	"github.com/spf13/cobra"
@@ -19,6 +21,7 @@ func init() {
 	destroyCmd.Flags().String("org", "", "organization ID")
 	destroyCmd.Flags().String("workspace", "", "workspace ID")
 	destroyCmd.Flags().BoolP("yes", "y", false, "Assume yes to all prompts")
+	destroyCmd.Flags().Bool("keep-volumes", false, "Keep the data of the app's persistent volumes")
 	destroyCmd.Flags().String("host", "", "Set the host URL")
 }
 
@@ -56,6 +59,11 @@ func destroyCmdFunc(cmd *cobra.Command) error {
 		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
 	}
 
+	keepVolumes, err := cmd.Flags().GetBool("keep-volumes")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
 	locoToken, err := getLocoToken()
 	if err != nil {
 		return ErrLoginRequired
@@ -91,14 +99,38 @@ func destroyCmdFunc(cmd *cobra.Command) error {
 		}
 	}
 
-	slog.Debug("destroying app", "app_id", appID, "app_name", appName)
+	if !yes && !cmd.Flags().Changed("keep-volumes") {
+		statusReq := connect.NewRequest(&appv1.GetAppStatusRequest{AppId: appID})
+		statusReq.Header().Set("Authorization", fmt.Sprintf("Bearer %s", locoToken.Token))
+		statusResp, err := appClient.GetAppStatus(ctx, statusReq)
+		if err != nil {
+			slog.Debug("failed to get app status", "error", err)
+			return fmt.Errorf("failed to get status of app '%s': %w", appName, err)
+		}
+
+		var persistent []string
+		for _, volume := range statusResp.Msg.Volumes {
+			if volume.Type == config.VolumePersistent {
+				persistent = append(persistent, volume.Name)
+			}
+		}
+		if len(persistent) > 0 {
+			keepVolumes, err = ui.AskYesNo(fmt.Sprintf("Keep the data of its persistent volumes (%s)?", strings.Join(persistent, ", ")))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	slog.Debug("destroying app", "app_id", appID, "app_name", appName, "keep_volumes", keepVolumes)
 
 	destroyReq := connect.NewRequest(&appv1.DeleteAppRequest{
-		Id: appID,
+		Id:            appID,
+		RetainVolumes: keepVolumes,
 	})
 	destroyReq.Header().Set("Authorization", fmt.Sprintf("Bearer %s", locoToken.Token))
 
-	_, err = appClient.DeleteApp(ctx, destroyReq)
+	destroyResp, err := appClient.DeleteApp(ctx, destroyReq)
 	if err != nil {
 		slog.Error("failed to destroy app", "error", err)
 		return fmt.Errorf("failed to destroy app '%s': %w", appName, err)
@@ -112,5 +144,9 @@ func destroyCmdFunc(cmd *cobra.Command) error {
 
 	fmt.Println(s)
 
+	if retained := destroyResp.Msg.RetainedVolumes; len(retained) > 0 {
+		fmt.Printf("Kept persistent volumes: %s\n", strings.Join(retained, ", "))
+	}
+
 	return nil
 }
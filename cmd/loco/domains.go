@@ -0,0 +1,227 @@
+package loco
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/ui"
+	domainv1 "github.com/nikumar1206/loco/shared/proto/domain/v1"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	domainsCmd.PersistentFlags().StringP("app", "a", "", "Application name")
+	domainsCmd.PersistentFlags().String("org", "", "organization ID")
+	domainsCmd.PersistentFlags().String("workspace", "", "workspace ID")
+	domainsCmd.PersistentFlags().String("host", "", "Set the host URL")
+
+	domainsListCmd.Flags().StringP("output", "o", "table", "Output format (table, json). Defaults to table.")
+	domainsRemoveCmd.Flags().BoolP("yes", "y", false, "Assume yes to all prompts")
+
+	domainsCmd.AddCommand(domainsListCmd, domainsAddCmd, domainsVerifyCmd, domainsRemoveCmd)
+}
+
+var domainsCmd = &cobra.Command{
+	Use:   "domains",
+	Short: "Manage an application's custom domains",
+	Long: `Serve an application on your own hostnames. After adding a domain, create the TXT record loco prints,
+then verify it. Verified domains get a certificate and are routed to the app.`,
+	Example: `  loco domains add www.example.com --app myapp
+  loco domains verify www.example.com --app myapp
+  loco domains list --app myapp`,
+}
+
+var domainsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List an application's domains with their verification and certificate status",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return domainsListCmdFunc(cmd)
+	},
+}
+
+var domainsAddCmd = &cobra.Command{
+	Use:   "add <hostname>",
+	Short: "Add a custom domain and print the TXT record that proves ownership",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return domainsAddCmdFunc(cmd, args[0])
+	},
+}
+
+var domainsVerifyCmd = &cobra.Command{
+	Use:   "verify <hostname>",
+	Short: "Check a domain's TXT record and start issuing its certificate",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return domainsVerifyCmdFunc(cmd, args[0])
+	},
+}
+
+var domainsRemoveCmd = &cobra.Command{
+	Use:   "remove <hostname>",
+	Short: "Stop serving an application on a custom domain",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return domainsRemoveCmdFunc(cmd, args[0])
+	},
+}
+
+func domainsListCmdFunc(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	domains, err := apiClient.ListDomains(ctx, app.Id)
+	if err != nil {
+		return fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(domains)
+	}
+
+	if len(domains) == 0 {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Margin(1, 2).Render(
+			fmt.Sprintf("No custom domains for '%s'. Add one with `loco domains add <hostname>`.", app.Name)))
+		return nil
+	}
+
+	printDomainsTable(domains)
+	return nil
+}
+
+func domainsAddCmdFunc(cmd *cobra.Command, hostname string) error {
+	ctx := context.Background()
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	domain, err := apiClient.AddDomain(ctx, app.Id, hostname)
+	if err != nil {
+		return fmt.Errorf("failed to add domain '%s': %w", hostname, err)
+	}
+
+	fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(ui.LocoLightGreen).Render(
+		fmt.Sprintf("\nAdded %s to '%s'.", domain.GetHostname(), app.Name)))
+	printChallenge(domain)
+	return nil
+}
+
+func domainsVerifyCmdFunc(cmd *cobra.Command, hostname string) error {
+	ctx := context.Background()
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	domain, err := apiClient.VerifyDomain(ctx, app.Id, hostname)
+	if err != nil {
+		return fmt.Errorf("failed to verify domain '%s': %w", hostname, err)
+	}
+
+	if domain.GetVerificationStatus() != "verified" {
+		fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(ui.LocoRed).Render(
+			fmt.Sprintf("\nCould not verify %s: %s", domain.GetHostname(), domain.GetVerificationMessage())))
+		printChallenge(domain)
+		return nil
+	}
+
+	fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(ui.LocoLightGreen).Render(
+		fmt.Sprintf("\n%s is verified.", domain.GetHostname())))
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Render(
+		fmt.Sprintf("Certificate: %s. Point a CNAME for %s at the loco gateway, then check `loco domains list`.",
+			domain.GetCertificateStatus(), domain.GetHostname())))
+	return nil
+}
+
+func domainsRemoveCmdFunc(cmd *cobra.Command, hostname string) error {
+	ctx := context.Background()
+
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	if !yes {
+		confirmed, err := ui.AskYesNo(fmt.Sprintf("Are you sure you want to remove '%s' from '%s'?", hostname, app.Name))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if err := apiClient.RemoveDomain(ctx, app.Id, hostname); err != nil {
+		return fmt.Errorf("failed to remove domain '%s': %w", hostname, err)
+	}
+
+	fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(ui.LocoLightGreen).Render(
+		fmt.Sprintf("\nRemoved %s from '%s'.", hostname, app.Name)))
+	return nil
+}
+
+// printChallenge tells the user which TXT record proves they own the domain
+func printChallenge(domain *domainv1.Domain) {
+	fmt.Println("\nCreate this DNS record, then run `loco domains verify " + domain.GetHostname() + "`:")
+	fmt.Println(tableStyle().Render(fmt.Sprintf("Type:  TXT\nName:  %s\nValue: %s",
+		domain.GetChallengeName(), domain.GetChallengeValue())))
+}
+
+func printDomainsTable(domains []*domainv1.Domain) {
+	columns := []table.Column{
+		{Title: "HOSTNAME", Width: 36},
+		{Title: "VERIFICATION", Width: 12},
+		{Title: "CERTIFICATE", Width: 11},
+		{Title: "DETAILS", Width: 50},
+	}
+
+	rows := make([]table.Row, 0, len(domains))
+	for _, domain := range domains {
+		details := domain.GetCertificateMessage()
+		if domain.GetVerificationStatus() != "verified" {
+			details = domain.GetVerificationMessage()
+			if details == "" {
+				details = fmt.Sprintf("TXT %s = %s", domain.GetChallengeName(), domain.GetChallengeValue())
+			}
+		}
+		rows = append(rows, table.Row{
+			domain.GetHostname(),
+			domain.GetVerificationStatus(),
+			domain.GetCertificateStatus(),
+			details,
+		})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithHeight(len(rows)),
+	)
+	t.SetStyles(metricsTableStyles())
+	fmt.Println(tableStyle().Render(t.View()))
+}
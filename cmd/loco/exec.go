@@ -0,0 +1,169 @@
+package loco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/ui"
+	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func init() {
+	execCmd.Flags().StringP("app", "a", "", "Application name")
+	execCmd.Flags().String("org", "", "organization ID")
+	execCmd.Flags().String("workspace", "", "workspace ID")
+	execCmd.Flags().String("host", "", "Set the host URL")
+	execCmd.Flags().String("pod", "", "Pod to run the command in. Defaults to one of the app's running pods")
+
+	// everything after the command's name belongs to the command, flags included
+	execCmd.Flags().SetInterspersed(false)
+}
+
+var execCmd = &cobra.Command{
+	Use:   "exec [flags] -- <command> [args...]",
+	Short: "Run a command in a running container of an application",
+	Long: `Run a command, like a shell, inside one of an application's running containers. When run from a terminal
+the command gets one too. Only workspace admins can exec, and every session is recorded in the audit log.`,
+	Example: `  loco exec --app myapp -- sh
+  loco exec --app myapp --pod myapp-6d5f7c9b8-x2k4q -- cat /etc/hosts`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execCmdFunc(cmd, args)
+	},
+}
+
+func execCmdFunc(cmd *cobra.Command, command []string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pod, err := cmd.Flags().GetString("pod")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	stdinFd := int(os.Stdin.Fd())
+	tty := term.IsTerminal(stdinFd) && term.IsTerminal(int(os.Stdout.Fd()))
+
+	req := &appv1.ExecRequest{
+		AppId:   app.Id,
+		Command: command,
+		Tty:     tty,
+	}
+	if pod != "" {
+		req.Pod = &pod
+	}
+	if tty {
+		req.Size = terminalSize(stdinFd)
+	}
+
+	stream := apiClient.Exec(ctx)
+	defer stream.CloseResponse()
+	if err := stream.Send(req); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to exec: %w", err)
+	}
+
+	// the first response names the pod, errors like a missing permission arrive instead of it
+	resp, err := stream.Receive()
+	if err != nil {
+		return fmt.Errorf("failed to exec: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Render(
+		fmt.Sprintf("Connected to %s of '%s'.", resp.GetPod(), app.Name)))
+
+	// the stdin and resize forwarders send concurrently
+	var sendMu sync.Mutex
+	send := func(req *appv1.ExecRequest) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(req)
+	}
+
+	// the terminal's state before raw mode, restored on return and before exiting with the command's exit code
+	var state *term.State
+	if tty {
+		state, err = term.MakeRaw(stdinFd)
+		if err != nil {
+			return fmt.Errorf("failed to put the terminal in raw mode: %w", err)
+		}
+		defer term.Restore(stdinFd, state)
+
+		stopResize := watchResize(func() {
+			if size := terminalSize(stdinFd); size != nil {
+				_ = send(&appv1.ExecRequest{Size: size})
+			}
+		})
+		defer stopResize()
+	}
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				if sendErr := send(&appv1.ExecRequest{Stdin: buf[:n]}); sendErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				// ends the command's stdin, like ctrl-d would
+				sendMu.Lock()
+				_ = stream.CloseRequest()
+				sendMu.Unlock()
+				return
+			}
+		}
+	}()
+
+	var exitCode *int32
+	for {
+		resp, err := stream.Receive()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("exec failed: %w", err)
+		}
+		if len(resp.Stdout) > 0 {
+			os.Stdout.Write(resp.Stdout)
+		}
+		if len(resp.Stderr) > 0 {
+			os.Stderr.Write(resp.Stderr)
+		}
+		if resp.ExitCode != nil {
+			exitCode = resp.ExitCode
+		}
+	}
+	if exitCode == nil {
+		return fmt.Errorf("%w: the command's exit code never arrived", ErrCommandFailed)
+	}
+
+	if *exitCode != 0 {
+		if state != nil {
+			// os.Exit skips the deferred restore
+			_ = term.Restore(stdinFd, state)
+		}
+		os.Exit(int(*exitCode))
+	}
+	return nil
+}
+
+// terminalSize reads the size of the terminal on fd, nil when it can't be read
+func terminalSize(fd int) *appv1.TerminalSize {
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		return nil
+	}
+	return &appv1.TerminalSize{Width: uint32(width), Height: uint32(height)}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package loco
+
+// watchResize is a no-op, Windows consoles don't signal resizes. The terminal keeps the size it started with.
+func watchResize(onResize func()) (stop func()) {
+	return func() {}
+}
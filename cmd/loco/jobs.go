@@ -0,0 +1,232 @@
+package loco
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/ui"
+	jobv1 "github.com/nikumar1206/loco/shared/proto/job/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func init() {
+	jobsCmd.PersistentFlags().StringP("app", "a", "", "Application name")
+	jobsCmd.PersistentFlags().String("org", "", "organization ID")
+	jobsCmd.PersistentFlags().String("workspace", "", "workspace ID")
+	jobsCmd.PersistentFlags().String("host", "", "Set the host URL")
+
+	jobsListCmd.Flags().StringP("output", "o", "table", "Output format (table, json). Defaults to table.")
+	jobsRunsCmd.Flags().StringP("output", "o", "table", "Output format (table, json). Defaults to table.")
+
+	jobsCmd.AddCommand(jobsListCmd, jobsRunsCmd, jobsTriggerCmd)
+}
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Inspect and trigger an application's scheduled jobs",
+	Long: `Scheduled jobs are declared under [[Jobs]] in loco.toml and run the app's image on a cron schedule, in UTC.
+Each deploy updates them. The cluster keeps the last runs of every job.`,
+	Example: `  loco jobs list --app myapp
+  loco jobs runs cleanup --app myapp
+  loco jobs trigger cleanup --app myapp`,
+}
+
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List an application's jobs with when they last ran",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return jobsListCmdFunc(cmd)
+	},
+}
+
+var jobsRunsCmd = &cobra.Command{
+	Use:   "runs [job]",
+	Short: "List the recent runs of an application's jobs, or of one job",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		job := ""
+		if len(args) > 0 {
+			job = args[0]
+		}
+		return jobsRunsCmdFunc(cmd, job)
+	},
+}
+
+var jobsTriggerCmd = &cobra.Command{
+	Use:   "trigger <job>",
+	Short: "Run a job now, outside its schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return jobsTriggerCmdFunc(cmd, args[0])
+	},
+}
+
+func jobsListCmdFunc(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := apiClient.ListJobs(ctx, app.Id)
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(jobs)
+	}
+
+	if len(jobs) == 0 {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Margin(1, 2).Render(
+			fmt.Sprintf("No jobs for '%s'. Declare them under [[Jobs]] in loco.toml and deploy.", app.Name)))
+		return nil
+	}
+
+	printJobsTable(jobs)
+	return nil
+}
+
+func jobsRunsCmdFunc(cmd *cobra.Command, job string) error {
+	ctx := context.Background()
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	runs, err := apiClient.ListJobRuns(ctx, app.Id, job)
+	if err != nil {
+		return fmt.Errorf("failed to list job runs: %w", err)
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(runs)
+	}
+
+	if len(runs) == 0 {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Margin(1, 2).Render(
+			fmt.Sprintf("No job runs for '%s' yet.", app.Name)))
+		return nil
+	}
+
+	printJobRunsTable(runs)
+	return nil
+}
+
+func jobsTriggerCmdFunc(cmd *cobra.Command, job string) error {
+	ctx := context.Background()
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	run, err := apiClient.TriggerJob(ctx, app.Id, job)
+	if err != nil {
+		return fmt.Errorf("failed to trigger job '%s': %w", job, err)
+	}
+
+	fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(ui.LocoLightGreen).Render(
+		fmt.Sprintf("\nStarted %s of job '%s'.", run.GetName(), job)))
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Render(
+		fmt.Sprintf("Follow it with `loco jobs runs %s --app %s`.", job, app.Name)))
+	return nil
+}
+
+func printJobsTable(jobs []*jobv1.Job) {
+	columns := []table.Column{
+		{Title: "NAME", Width: 20},
+		{Title: "SCHEDULE", Width: 16},
+		{Title: "COMMAND", Width: 36},
+		{Title: "LAST SCHEDULED", Width: 19},
+		{Title: "LAST SUCCEEDED", Width: 19},
+	}
+
+	rows := make([]table.Row, 0, len(jobs))
+	for _, job := range jobs {
+		rows = append(rows, table.Row{
+			job.GetName(),
+			job.GetSchedule(),
+			strings.Join(job.GetCommand(), " "),
+			formatJobTime(job.GetLastScheduledAt()),
+			formatJobTime(job.GetLastSucceededAt()),
+		})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithHeight(len(rows)),
+	)
+	t.SetStyles(metricsTableStyles())
+	fmt.Println(tableStyle().Render(t.View()))
+}
+
+func printJobRunsTable(runs []*jobv1.JobRun) {
+	columns := []table.Column{
+		{Title: "RUN", Width: 36},
+		{Title: "JOB", Width: 20},
+		{Title: "TRIGGER", Width: 9},
+		{Title: "STATUS", Width: 9},
+		{Title: "STARTED", Width: 19},
+		{Title: "DURATION", Width: 10},
+		{Title: "MESSAGE", Width: 40},
+	}
+
+	rows := make([]table.Row, 0, len(runs))
+	for _, run := range runs {
+		duration := "-"
+		if run.StartedAt != nil && run.FinishedAt != nil {
+			duration = run.GetFinishedAt().AsTime().Sub(run.GetStartedAt().AsTime()).Round(time.Second).String()
+		}
+		rows = append(rows, table.Row{
+			run.GetName(),
+			run.GetJob(),
+			run.GetTrigger(),
+			run.GetStatus(),
+			formatJobTime(run.GetStartedAt()),
+			duration,
+			run.GetMessage(),
+		})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithHeight(len(rows)),
+	)
+	t.SetStyles(metricsTableStyles())
+	fmt.Println(tableStyle().Render(t.View()))
+}
+
+// formatJobTime renders a job time in local time, "-" when it never happened
+func formatJobTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().Local().Format(time.DateTime)
+}
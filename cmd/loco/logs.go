@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
@@ -15,12 +16,13 @@ import (
 	"github.com/nikumar1206/loco/internal/ui"
 	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var logsCmd = &cobra.Command{
 	Use:   "logs",
 	Short: "View application logs",
-	Long:  "Stream logs from an application's running deployment.",
+	Long:  "Show an application's recent logs, including pods that have since been replaced. Use --follow to tail running pods.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		output, err := cmd.Flags().GetString("output")
 		if err != nil {
@@ -29,7 +31,9 @@ var logsCmd = &cobra.Command{
 
 		switch output {
 		case "json":
-			return streamLogsAsJson(cmd)
+			return streamLogsPlain(cmd, printLogJson)
+		case "text":
+			return streamLogsPlain(cmd, printLogText)
 		case "table":
 			return streamLogsInteractive(cmd)
 		case "": // default
@@ -40,7 +44,8 @@ var logsCmd = &cobra.Command{
 	},
 }
 
-func streamLogsAsJson(cmd *cobra.Command) error {
+// streamLogsPlain writes each entry to stdout with printEntry, for piping or when a TTY isn't wanted
+func streamLogsPlain(cmd *cobra.Command, printEntry func(*appv1.LogEntry)) error {
 	ctx := context.Background()
 
 	host, err := getHost(cmd)
@@ -61,16 +66,6 @@ func streamLogsAsJson(cmd *cobra.Command) error {
 		return fmt.Errorf("app name is required. Use --app flag")
 	}
 
-	lines, err := cmd.Flags().GetInt32("lines")
-	if err != nil {
-		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
-	}
-
-	follow, err := cmd.Flags().GetBool("follow")
-	if err != nil {
-		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
-	}
-
 	locoToken, err := getLocoToken()
 	if err != nil {
 		return ErrLoginRequired
@@ -89,26 +84,15 @@ func streamLogsAsJson(cmd *cobra.Command) error {
 	appID := app.Id
 	slog.Debug("found app by name", "app_name", appName, "app_id", appID)
 
-	slog.Debug("streaming logs as json", "app_id", appID, "app_name", appName)
+	slog.Debug("streaming logs", "app_id", appID, "app_name", appName)
 
-	var linesPtr *int32
-	if lines > 0 {
-		linesPtr = &lines
-	}
-
-	var followPtr *bool
-	if follow {
-		followPtr = &follow
+	logsReq, err := buildLogsRequest(cmd, appID)
+	if err != nil {
+		return err
 	}
 
-	err = apiClient.StreamLogs(ctx, appID, linesPtr, followPtr, func(logEntry *appv1.LogEntry) error {
-		jsonLog, err := json.Marshal(logEntry)
-		if err != nil {
-			slog.Debug("failed to marshal log entry to json", "error", err)
-			fmt.Fprintf(os.Stderr, "Error marshaling log: %v\n", err)
-			return nil
-		}
-		fmt.Println(string(jsonLog))
+	err = apiClient.StreamLogs(ctx, logsReq, func(logEntry *appv1.LogEntry) error {
+		printEntry(logEntry)
 		return nil
 	})
 	if err != nil {
@@ -119,6 +103,53 @@ func streamLogsAsJson(cmd *cobra.Command) error {
 	return nil
 }
 
+// printLogJson prints an entry as a JSON object, including any parsed fields
+func printLogJson(logEntry *appv1.LogEntry) {
+	jsonLog, err := json.Marshal(logEntry)
+	if err != nil {
+		slog.Debug("failed to marshal log entry to json", "error", err)
+		fmt.Fprintf(os.Stderr, "Error marshaling log: %v\n", err)
+		return
+	}
+	fmt.Println(string(jsonLog))
+}
+
+// printLogText prints an entry as a line with its level colored, followed by any parsed fields
+func printLogText(logEntry *appv1.LogEntry) {
+	line := fmt.Sprintf("%s %s %s %s",
+		logEntry.Timestamp.AsTime().Format(time.RFC3339),
+		lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Render(logEntry.PodName),
+		levelStyle(logEntry.Level).Render(fmt.Sprintf("%-5s", logEntry.Level)),
+		logEntry.Log,
+	)
+
+	keys := make([]string, 0, len(logEntry.Fields))
+	for k := range logEntry.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		line += " " + lipgloss.NewStyle().Foreground(ui.LocoCyan).Render(k+"=") + logEntry.Fields[k]
+	}
+
+	fmt.Println(line)
+}
+
+// levelStyle colors a log level by severity
+func levelStyle(level string) lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(true)
+	switch level {
+	case "DEBUG":
+		return style.Foreground(ui.LocoDimGrey)
+	case "WARN":
+		return style.Foreground(ui.LocoOrange)
+	case "ERROR", "FATAL":
+		return style.Foreground(ui.LocoRed)
+	default:
+		return style.Foreground(ui.LocoGreen)
+	}
+}
+
 func streamLogsInteractive(cmd *cobra.Command) error {
 	ctx := context.Background()
 
@@ -140,16 +171,6 @@ func streamLogsInteractive(cmd *cobra.Command) error {
 		return fmt.Errorf("app name is required. Use --app flag")
 	}
 
-	lines, err := cmd.Flags().GetInt32("lines")
-	if err != nil {
-		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
-	}
-
-	follow, err := cmd.Flags().GetBool("follow")
-	if err != nil {
-		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
-	}
-
 	locoToken, err := getLocoToken()
 	if err != nil {
 		return ErrLoginRequired
@@ -171,6 +192,7 @@ func streamLogsInteractive(cmd *cobra.Command) error {
 	columns := []table.Column{
 		{Title: "Time", Width: 20},
 		{Title: "Pod", Width: 30},
+		{Title: "Level", Width: 5},
 		{Title: "Message", Width: 80},
 	}
 
@@ -193,21 +215,16 @@ func streamLogsInteractive(cmd *cobra.Command) error {
 		Bold(false)
 	t.SetStyles(s)
 
-	logsChan := make(chan *appv1.LogEntry)
-	errChan := make(chan error)
-
-	var linesPtr *int32
-	if lines > 0 {
-		linesPtr = &lines
+	logsReq, err := buildLogsRequest(cmd, appID)
+	if err != nil {
+		return err
 	}
 
-	var followPtr *bool
-	if follow {
-		followPtr = &follow
-	}
+	logsChan := make(chan *appv1.LogEntry)
+	errChan := make(chan error)
 
 	go func() {
-		err := apiClient.StreamLogs(ctx, appID, linesPtr, followPtr, func(logEntry *appv1.LogEntry) error {
+		err := apiClient.StreamLogs(ctx, logsReq, func(logEntry *appv1.LogEntry) error {
 			logsChan <- logEntry
 			return nil
 		})
@@ -238,9 +255,80 @@ func streamLogsInteractive(cmd *cobra.Command) error {
 	return nil
 }
 
+// buildLogsRequest reads the filter flags shared by every output format into a StreamLogsRequest
+func buildLogsRequest(cmd *cobra.Command, appID int64) (*appv1.StreamLogsRequest, error) {
+	lines, err := cmd.Flags().GetInt32("lines")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	previous, err := cmd.Flags().GetBool("previous")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	since, err := cmd.Flags().GetDuration("since")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	pod, err := cmd.Flags().GetString("pod")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	grep, err := cmd.Flags().GetString("grep")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	exclude, err := cmd.Flags().GetString("exclude")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	level, err := cmd.Flags().GetString("level")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	req := &appv1.StreamLogsRequest{AppId: appID}
+	if lines > 0 {
+		req.Limit = &lines
+	}
+	if follow {
+		req.Follow = &follow
+	}
+	if previous {
+		req.Previous = &previous
+	}
+	if since > 0 {
+		req.Since = timestamppb.New(time.Now().Add(-since))
+	}
+	if pod != "" {
+		req.Pod = &pod
+	}
+	if grep != "" {
+		req.Grep = &grep
+	}
+	if exclude != "" {
+		req.Exclude = &exclude
+	}
+	if level != "" {
+		req.Level = &level
+	}
+	return req, nil
+}
+
 type logMsg struct {
 	Time    string
 	PodName string
+	Level   string
 	Message string
 }
 
@@ -267,6 +355,7 @@ func (m logModel) waitForLog() tea.Cmd {
 			return logMsg{
 				Time:    log.Timestamp.AsTime().Format(time.RFC3339),
 				PodName: log.PodName,
+				Level:   log.Level,
 				Message: log.Log,
 			}
 		case err := <-m.errChan:
@@ -284,7 +373,7 @@ func (m logModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case logMsg:
-		newRow := table.Row{msg.Time, msg.PodName, msg.Message}
+		newRow := table.Row{msg.Time, msg.PodName, msg.Level, msg.Message}
 		m.logs = append(m.logs, newRow)
 		m.table.SetRows(m.logs)
 		return m, m.waitForLog()
@@ -326,6 +415,12 @@ func init() {
 	logsCmd.Flags().String("workspace", "", "workspace ID")
 	logsCmd.Flags().BoolP("follow", "f", false, "Follow log output (tail -f style)")
 	logsCmd.Flags().Int32P("lines", "n", 0, "Number of lines to show (0 = all)")
-	logsCmd.Flags().StringP("output", "o", "", "Output format (json, table). Defaults to table.")
+	logsCmd.Flags().Duration("since", 0, "Only show logs newer than a relative duration like 5m or 2h")
+	logsCmd.Flags().String("pod", "", "Only show logs from pods whose name matches this regex")
+	logsCmd.Flags().String("grep", "", "Only show lines matching this regex")
+	logsCmd.Flags().String("exclude", "", "Hide lines matching this regex")
+	logsCmd.Flags().Bool("previous", false, "Show logs from the previous container of each pod, e.g. after a crash")
+	logsCmd.Flags().String("level", "", "Only show lines at or above this level (debug, info, warn, error, fatal)")
+	logsCmd.Flags().StringP("output", "o", "", "Output format (json, text, table). Defaults to table.")
 	logsCmd.Flags().String("host", "", "Set the host URL")
 }
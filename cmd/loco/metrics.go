@@ -0,0 +1,306 @@
+package loco
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/client"
+	"github.com/nikumar1206/loco/internal/ui"
+	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func init() {
+	metricsCmd.Flags().StringP("app", "a", "", "Application name")
+	metricsCmd.Flags().String("org", "", "organization ID")
+	metricsCmd.Flags().String("workspace", "", "workspace ID")
+	metricsCmd.Flags().Duration("since", time.Hour, "How far back to show metrics, like 30m or 6h")
+	metricsCmd.Flags().Duration("step", 0, "Width of each data point, like 1m. Picked from --since when unset")
+	metricsCmd.Flags().StringP("output", "o", "table", "Output format (table, json). Defaults to table.")
+	metricsCmd.Flags().String("host", "", "Set the host URL")
+}
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Show application resource usage and request metrics",
+	Long: `Display an application's CPU, memory and restarts per pod, along with the request rate and p95 latency
+of its hostname over time. Live values come from the cluster, history from the metrics store.`,
+	Example: `  loco metrics --app myapp
+  loco metrics --app myapp --since 6h -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return metricsCmdFunc(cmd)
+	},
+}
+
+func metricsCmdFunc(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	since, err := cmd.Flags().GetDuration("since")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	step, err := cmd.Flags().GetDuration("step")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	req := &appv1.GetAppMetricsRequest{AppId: app.Id}
+	if since > 0 {
+		req.Since = timestamppb.New(time.Now().Add(-since))
+	}
+	if step > 0 {
+		stepSeconds := int32(step.Seconds())
+		req.StepSeconds = &stepSeconds
+	}
+
+	resp, err := apiClient.GetAppMetrics(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to get metrics: %w", err)
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(resp)
+	}
+
+	printPodMetricsTable(resp)
+	printSeriesTable(resp)
+	return nil
+}
+
+// resolveApp looks up the --app flag's app in the current workspace
+func resolveApp(ctx context.Context, cmd *cobra.Command) (*client.Client, *appv1.App, error) {
+	host, err := getHost(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workspaceID, err := getWorkspaceId(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appName, err := cmd.Flags().GetString("app")
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+	if appName == "" {
+		return nil, nil, fmt.Errorf("app name is required. Use --app flag")
+	}
+
+	locoToken, err := getLocoToken()
+	if err != nil {
+		return nil, nil, ErrLoginRequired
+	}
+
+	apiClient := client.NewClient(host, locoToken.Token)
+
+	app, err := apiClient.GetAppByName(ctx, workspaceID, appName)
+	if err != nil {
+		slog.Debug("failed to get app by name", "error", err)
+		return nil, nil, fmt.Errorf("failed to get app '%s': %w", appName, err)
+	}
+	return apiClient, app, nil
+}
+
+func printPodMetricsTable(resp *appv1.GetAppMetricsResponse) {
+	fmt.Println(tableStyle().Render(podMetricsTable(resp).View()))
+}
+
+// podMetricsTable lists each pod's live usage, followed by the app's totals
+func podMetricsTable(resp *appv1.GetAppMetricsResponse) table.Model {
+	columns := []table.Column{
+		{Title: "POD", Width: 40},
+		{Title: "STATUS", Width: 10},
+		{Title: "READY", Width: 6},
+		{Title: "CPU", Width: 8},
+		{Title: "MEMORY", Width: 10},
+		{Title: "RESTARTS", Width: 8},
+	}
+
+	rows := make([]table.Row, 0, len(resp.GetPods())+1)
+	for _, pod := range resp.GetPods() {
+		ready := "no"
+		if pod.GetReady() {
+			ready = "yes"
+		}
+		cpu, memory := "-", "-"
+		if pod.CpuMillicores != nil {
+			cpu = formatMillicores(pod.GetCpuMillicores())
+		}
+		if pod.MemoryBytes != nil {
+			memory = formatBytes(pod.GetMemoryBytes())
+		}
+		rows = append(rows, table.Row{pod.GetPod(), pod.GetPhase(), ready, cpu, memory, fmt.Sprint(pod.GetRestarts())})
+	}
+	rows = append(rows, table.Row{
+		fmt.Sprintf("Total (%d pods)", len(resp.GetPods())),
+		"",
+		"",
+		formatMillicores(resp.GetCpuMillicores()),
+		formatBytes(resp.GetMemoryBytes()),
+		fmt.Sprint(resp.GetRestarts()),
+	})
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithHeight(len(rows)),
+	)
+	t.SetStyles(metricsTableStyles())
+	return t
+}
+
+// printSeriesTable summarises the app wide series, per pod series are only in the JSON output
+func printSeriesTable(resp *appv1.GetAppMetricsResponse) {
+	if len(resp.GetSeries()) == 0 {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Margin(0, 2).Render("No metric history, the metrics store isn't configured."))
+		return
+	}
+
+	columns := []table.Column{
+		{Title: "METRIC", Width: 20},
+		{Title: "HOSTNAME", Width: 30},
+		{Title: "LATEST", Width: 10},
+		{Title: "AVG", Width: 10},
+		{Title: "MAX", Width: 10},
+		{Title: "TREND", Width: 32},
+	}
+
+	var rows []table.Row
+	for _, series := range resp.GetSeries() {
+		if series.Pod != nil {
+			continue
+		}
+		values := make([]float64, len(series.GetPoints()))
+		for i, p := range series.GetPoints() {
+			values[i] = p.GetValue()
+		}
+		latest, avg, peak := summarize(values)
+		format := metricFormatter(series.GetName())
+		rows = append(rows, table.Row{
+			series.GetName(),
+			series.GetHostname(),
+			format(latest),
+			format(avg),
+			format(peak),
+			sparkline(values, 30),
+		})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithHeight(len(rows)),
+	)
+	t.SetStyles(metricsTableStyles())
+	fmt.Println(tableStyle().Render(t.View()))
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Margin(0, 2).Render(fmt.Sprintf("%ds per point", resp.GetStepSeconds())))
+}
+
+func metricsTableStyles() table.Styles {
+	return table.Styles{
+		Header: lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(ui.LocoMuted).
+			BorderBottom(true).
+			Bold(false),
+		Cell: lipgloss.NewStyle().Padding(0, 1),
+	}
+}
+
+func tableStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Margin(1, 2)
+}
+
+func summarize(values []float64) (latest, avg, peak float64) {
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+		peak = max(peak, v)
+	}
+	return values[len(values)-1], sum / float64(len(values)), peak
+}
+
+func metricFormatter(name string) func(float64) string {
+	switch name {
+	case "cpu_millicores":
+		return func(v float64) string { return formatMillicores(int64(v)) }
+	case "memory_bytes":
+		return func(v float64) string { return formatBytes(int64(v)) }
+	case "requests_per_second":
+		return func(v float64) string { return fmt.Sprintf("%.1f/s", v) }
+	case "latency_p95_ms":
+		return func(v float64) string { return fmt.Sprintf("%.0fms", v) }
+	}
+	return func(v float64) string { return fmt.Sprintf("%.0f", v) }
+}
+
+// sparkline draws the last width values scaled to their max
+func sparkline(values []float64, width int) string {
+	bars := []rune("▁▂▃▄▅▆▇█")
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	var peak float64
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if peak > 0 {
+			i = int(v / peak * float64(len(bars)-1))
+		}
+		b.WriteRune(bars[i])
+	}
+	return b.String()
+}
+
+// formatMillicores renders e.g. "250m" or "1.50"
+func formatMillicores(m int64) string {
+	if m >= 1000 {
+		return fmt.Sprintf("%.2f", float64(m)/1000)
+	}
+	return fmt.Sprintf("%dm", m)
+}
+
+// formatBytes renders a size in binary units, e.g. "128Mi"
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	value := float64(b)
+	for _, suffix := range []string{"Ki", "Mi", "Gi", "Ti"} {
+		value /= unit
+		if value < unit || suffix == "Ti" {
+			return fmt.Sprintf("%.0f%s", value, suffix)
+		}
+	}
+	return fmt.Sprintf("%dB", b)
+}
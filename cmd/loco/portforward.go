@@ -0,0 +1,255 @@
+package loco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/ui"
+	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	portForwardCmd.Flags().StringP("app", "a", "", "Application name")
+	portForwardCmd.Flags().String("org", "", "organization ID")
+	portForwardCmd.Flags().String("workspace", "", "workspace ID")
+	portForwardCmd.Flags().String("host", "", "Set the host URL")
+	portForwardCmd.Flags().String("pod", "", "Pod to forward to. Defaults to one of the app's running pods")
+	portForwardCmd.Flags().String("address", "127.0.0.1", "Local address to listen on")
+}
+
+var portForwardCmd = &cobra.Command{
+	Use:   "port-forward [flags] [local-port:]port",
+	Short: "Forward a local port to a port of an application's pod",
+	Long: `Listen on a local port and tunnel its connections through loco to a port of one of an application's pods,
+like a metrics endpoint or a debug server that isn't exposed. The local port defaults to the pod's, leave it empty
+(:9090) to pick a free one. Runs until interrupted.`,
+	Example: `  loco port-forward --app myapp 9090
+  loco port-forward --app myapp 8080:9090
+  loco port-forward --app myapp --pod myapp-6d5f7c9b8-x2k4q :6060`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return portForwardCmdFunc(cmd, args[0])
+	},
+}
+
+func portForwardCmdFunc(cmd *cobra.Command, mapping string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	localPort, port, err := parsePortMapping(mapping)
+	if err != nil {
+		return err
+	}
+	pod, err := cmd.Flags().GetString("pod")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+	address, err := cmd.Flags().GetString("address")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	req := &appv1.PortForwardRequest{
+		AppId: app.Id,
+		Port:  port,
+	}
+	if pod != "" {
+		req.Pod = &pod
+	}
+
+	stream := apiClient.PortForward(ctx)
+	defer stream.CloseResponse()
+	if err := stream.Send(req); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to port-forward: %w", err)
+	}
+
+	// the first response names the pod, errors like a missing permission arrive instead of it
+	resp, err := stream.Receive()
+	if err != nil {
+		return fmt.Errorf("failed to port-forward: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(int(localPort))))
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", net.JoinHostPort(address, strconv.Itoa(int(localPort))), err)
+	}
+	defer listener.Close()
+
+	fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(ui.LocoLightGreen).Render(
+		fmt.Sprintf("Forwarding from %s to %s:%d of '%s'", listener.Addr(), resp.GetPod(), port, app.Name)))
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Render("Press Ctrl+C to stop."))
+
+	// the accept loop and every connection's reader send concurrently
+	var sendMu sync.Mutex
+	send := func(req *appv1.PortForwardRequest) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(req)
+	}
+
+	var connsMu sync.Mutex
+	conns := map[uint32]*localConn{}
+	// queue hands data to a connection's writer without waiting, false means its queue is full.
+	// Data for a connection that already ended is dropped.
+	queue := func(id uint32, data []byte) bool {
+		connsMu.Lock()
+		defer connsMu.Unlock()
+		lc, ok := conns[id]
+		if !ok {
+			return true
+		}
+		select {
+		case lc.writes <- data:
+			return true
+		default:
+			lc.Close()
+			return false
+		}
+	}
+	// end removes a connection, its writer closes it once what's queued is written
+	end := func(id uint32) {
+		connsMu.Lock()
+		defer connsMu.Unlock()
+		if lc, ok := conns[id]; ok {
+			close(lc.writes)
+			delete(conns, id)
+		}
+	}
+
+	go func() {
+		var nextID uint32
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			nextID++
+			id := nextID
+
+			lc := &localConn{Conn: conn, writes: make(chan []byte, connWriteBuffer)}
+			go lc.writeLoop()
+			connsMu.Lock()
+			conns[id] = lc
+			connsMu.Unlock()
+
+			// an empty message opens the connection to the pod, for servers that speak first
+			if err := send(&appv1.PortForwardRequest{ConnectionId: id}); err != nil {
+				end(id)
+				return
+			}
+			go forwardToServer(id, conn, send)
+		}
+	}()
+
+	for {
+		resp, err := stream.Receive()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w: the port-forward was closed", ErrNetworkError)
+		}
+		if err != nil {
+			return fmt.Errorf("port-forward failed: %w", err)
+		}
+
+		if len(resp.Data) > 0 && !queue(resp.ConnectionId, resp.Data) {
+			// a local client that stopped reading would hold up every other connection, so it's dropped.
+			// Its reader then tells the pod.
+			fmt.Fprintln(os.Stderr, lipgloss.NewStyle().Foreground(ui.LocoRed).Render(
+				fmt.Sprintf("Closing connection %d, the local client stopped reading", resp.ConnectionId)))
+			end(resp.ConnectionId)
+			continue
+		}
+		if resp.Close {
+			if resp.Error != nil {
+				fmt.Fprintln(os.Stderr, lipgloss.NewStyle().Foreground(ui.LocoRed).Render(resp.GetError()))
+			}
+			end(resp.ConnectionId)
+		}
+	}
+}
+
+// connWriteBuffer is how many chunks from the pod queue up for a local connection before it counts as stalled
+const connWriteBuffer = 256
+
+// localConn is a connection accepted on the local port. What the pod sends it is queued on writes, so a slow
+// local client only holds up its own connection.
+type localConn struct {
+	net.Conn
+	writes chan []byte
+}
+
+// writeLoop writes what's queued to the connection, then closes it once writes is closed
+func (lc *localConn) writeLoop() {
+	failed := false
+	for data := range lc.writes {
+		if failed {
+			continue
+		}
+		if _, err := lc.Write(data); err != nil {
+			// the local side went away, its reader tells the pod
+			lc.Close()
+			failed = true
+		}
+	}
+	lc.Close()
+}
+
+// forwardToServer sends what's written to a local connection over the stream, then its end
+func forwardToServer(id uint32, conn net.Conn, send func(*appv1.PortForwardRequest) error) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			if err := send(&appv1.PortForwardRequest{ConnectionId: id, Data: buf[:n]}); err != nil {
+				return
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	_ = send(&appv1.PortForwardRequest{ConnectionId: id, Close: true})
+}
+
+// parsePortMapping reads [local-port:]port. The local port is the pod's when left out, 0 when left empty.
+func parsePortMapping(mapping string) (int32, int32, error) {
+	local, remote, found := strings.Cut(mapping, ":")
+	if !found {
+		local = mapping
+		remote = mapping
+	}
+
+	port, err := parsePort(remote)
+	if err != nil {
+		return 0, 0, err
+	}
+	if local == "" {
+		return 0, port, nil
+	}
+	localPort, err := parsePort(local)
+	if err != nil {
+		return 0, 0, err
+	}
+	return localPort, port, nil
+}
+
+func parsePort(s string) (int32, error) {
+	port, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("%w: invalid port %q", ErrValidation, s)
+	}
+	return int32(port), nil
+}
@@ -0,0 +1,135 @@
+package loco
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/client"
+	"github.com/nikumar1206/loco/internal/ui"
+	quotav1 "github.com/nikumar1206/loco/shared/proto/quota/v1"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	quotaCmd.Flags().String("org", "", "organization ID")
+	quotaCmd.Flags().String("workspace", "", "workspace ID")
+	quotaCmd.Flags().String("output", "table", "Output format (table, json). Defaults to table.")
+	quotaCmd.Flags().String("host", "", "Set the host URL")
+}
+
+var quotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "Show resource usage against workspace and organization limits",
+	Long: `Display how many apps, and how much CPU and memory, the workspace and its organization are using
+against their quotas. Deploys and scale-ups that would exceed a quota are rejected.`,
+	Example: `  loco quota
+  loco quota --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return quotaCmdFunc(cmd)
+	},
+}
+
+func quotaCmdFunc(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	host, err := getHost(cmd)
+	if err != nil {
+		return err
+	}
+
+	workspaceID, err := getWorkspaceId(cmd)
+	if err != nil {
+		return err
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	locoToken, err := getLocoToken()
+	if err != nil {
+		return ErrLoginRequired
+	}
+
+	apiClient := client.NewClient(host, locoToken.Token)
+
+	resp, err := apiClient.GetQuota(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get quota: %w", err)
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(resp)
+	}
+
+	printQuotaTable(resp)
+	return nil
+}
+
+func printQuotaTable(resp *quotav1.GetQuotaResponse) {
+	ws := resp.GetWorkspace()
+	org := resp.GetOrg()
+
+	columns := []table.Column{
+		{Title: "RESOURCE", Width: 16},
+		{Title: "WORKSPACE", Width: 22},
+		{Title: "ORGANIZATION", Width: 22},
+	}
+
+	rows := []table.Row{
+		{
+			"Apps",
+			usageOf(ws.GetUsage().GetApps(), int64(ws.GetLimits().GetMaxApps()), ""),
+			usageOf(org.GetUsage().GetApps(), int64(org.GetLimits().GetMaxApps()), ""),
+		},
+		{
+			"CPU",
+			usageOf(ws.GetUsage().GetCpuMillicores(), ws.GetLimits().GetMaxCpuMillicores(), "m"),
+			usageOf(org.GetUsage().GetCpuMillicores(), org.GetLimits().GetMaxCpuMillicores(), "m"),
+		},
+		{
+			"Memory",
+			usageOf(ws.GetUsage().GetMemoryMb(), ws.GetLimits().GetMaxMemoryMb(), "Mi"),
+			usageOf(org.GetUsage().GetMemoryMb(), org.GetLimits().GetMaxMemoryMb(), "Mi"),
+		},
+		{
+			"Replicas / app",
+			fmt.Sprintf("max %d", ws.GetLimits().GetMaxReplicas()),
+			fmt.Sprintf("max %d", org.GetLimits().GetMaxReplicas()),
+		},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithHeight(len(rows)),
+	)
+
+	s := table.Styles{
+		Header: lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(ui.LocoMuted).
+			BorderBottom(true).
+			Bold(false),
+		Cell: lipgloss.NewStyle().Padding(0, 1),
+	}
+	t.SetStyles(s)
+
+	tableStyle := lipgloss.NewStyle().Margin(1, 2)
+	fmt.Println(tableStyle.Render(t.View()))
+}
+
+// usageOf renders e.g. "1500m / 4000m (37%)"
+func usageOf(used, limit int64, unit string) string {
+	if limit <= 0 {
+		return fmt.Sprintf("%d%s / -", used, unit)
+	}
+	return fmt.Sprintf("%d%s / %d%s (%d%%)", used, unit, limit, unit, used*100/limit)
+}
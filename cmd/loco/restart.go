@@ -0,0 +1,84 @@
+package loco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/ui"
+	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	restartCmd.Flags().StringP("app", "a", "", "Application name")
+	restartCmd.Flags().String("org", "", "organization ID")
+	restartCmd.Flags().String("workspace", "", "workspace ID")
+	restartCmd.Flags().String("host", "", "Set the host URL")
+	restartCmd.Flags().String("pod", "", "Restart only this pod")
+	restartCmd.Flags().Bool("wait", false, "Wait for the restarted pods to be ready")
+}
+
+var restartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart an application's pods, or a single pod",
+	Long: `Replace an application's pods with fresh ones from the current deployment, one at a time so the app keeps
+serving. With --pod only that pod is replaced. Restarts are listed by loco events and recorded in the audit log.`,
+	Example: `  loco restart --app myapp --wait
+  loco restart --app myapp --pod myapp-6d5f7c9b8-x2k4q`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return restartCmdFunc(cmd)
+	},
+}
+
+func restartCmdFunc(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	pod, err := cmd.Flags().GetString("pod")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	req := &appv1.RestartAppRequest{
+		AppId: app.Id,
+		Wait:  wait,
+	}
+	if pod != "" {
+		req.Pod = &pod
+	}
+
+	progress := lipgloss.NewStyle().Foreground(ui.LocoMidGrey)
+	err = apiClient.RestartApp(ctx, req, func(event *appv1.RestartAppEvent) error {
+		if event.ErrorMessage != nil {
+			fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(ui.LocoRed).Render(
+				fmt.Sprintf("%s: %s", event.GetMessage(), event.GetErrorMessage())))
+			return errors.New(event.GetErrorMessage())
+		}
+		fmt.Println(progress.Render(fmt.Sprintf("[%s] %s", event.GetStatus(), event.GetMessage())))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restart '%s': %w", app.Name, err)
+	}
+
+	if wait {
+		fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(ui.LocoLightGreen).Render(
+			fmt.Sprintf("\nRestarted '%s'.", app.Name)))
+		return nil
+	}
+	fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(ui.LocoLightGreen).Render(
+		fmt.Sprintf("\nRestart of '%s' started.", app.Name)))
+	fmt.Println(progress.Render(fmt.Sprintf("Follow it with `loco status --app %s`, or pass --wait.", app.Name)))
+	return nil
+}
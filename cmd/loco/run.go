@@ -0,0 +1,97 @@
+package loco
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/ui"
+	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	runCmd.Flags().StringP("app", "a", "", "Application name")
+	runCmd.Flags().String("org", "", "organization ID")
+	runCmd.Flags().String("workspace", "", "workspace ID")
+	runCmd.Flags().String("host", "", "Set the host URL")
+	runCmd.Flags().String("cpu", "", "CPU for the command's pod (e.g. 500m). Defaults to the app's")
+	runCmd.Flags().String("memory", "", "Memory for the command's pod (e.g. 1Gi). Defaults to the app's")
+
+	// everything after the command's name belongs to the command, flags included
+	runCmd.Flags().SetInterspersed(false)
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run [flags] -- <command> [args...]",
+	Short: "Run a one-off command in an application's image",
+	Long: `Run a command once, like a database migration or an admin script, in a pod with the current deployment's
+image, env and service account. Its output is streamed back until it exits, and loco exits with its exit code.`,
+	Example: `  loco run --app myapp -- ./manage.py migrate
+  loco run --app myapp --memory 2Gi -- ./bin/backfill --since 2024-01-01`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCmdFunc(cmd, args)
+	},
+}
+
+func runCmdFunc(cmd *cobra.Command, command []string) error {
+	ctx := context.Background()
+
+	cpu, err := cmd.Flags().GetString("cpu")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+	memory, err := cmd.Flags().GetString("memory")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	req := &appv1.RunCommandRequest{
+		AppId:   app.Id,
+		Command: command,
+	}
+	if cpu != "" {
+		req.Cpu = &cpu
+	}
+	if memory != "" {
+		req.Memory = &memory
+	}
+
+	status := lipgloss.NewStyle().Foreground(ui.LocoMidGrey)
+	var exit *appv1.RunCommandResponse
+	err = apiClient.RunCommand(ctx, req, func(resp *appv1.RunCommandResponse) error {
+		switch {
+		case resp.Job != nil:
+			fmt.Fprintln(os.Stderr, status.Render(fmt.Sprintf("Running `%s` on '%s' as %s...", strings.Join(command, " "), app.Name, resp.GetJob())))
+		case resp.Log != nil:
+			fmt.Println(resp.GetLog().GetLog())
+		case resp.ExitCode != nil:
+			exit = resp
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run command: %w", err)
+	}
+	if exit == nil {
+		return fmt.Errorf("%w: the command's exit code never arrived", ErrCommandFailed)
+	}
+
+	if exit.GetExitCode() != 0 {
+		message := fmt.Sprintf("Command exited with code %d", exit.GetExitCode())
+		if exit.Reason != nil {
+			message += fmt.Sprintf(" (%s)", exit.GetReason())
+		}
+		fmt.Fprintln(os.Stderr, lipgloss.NewStyle().Bold(true).Foreground(ui.LocoRed).Render(message))
+		os.Exit(int(exit.GetExitCode()))
+	}
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/charmbracelet/lipgloss"
@@ -139,6 +141,9 @@ func (m statusModel) View() string {
 		status = m.response.CurrentDeployment.Status
 		replicas = fmt.Sprintf("%d", m.response.CurrentDeployment.Replicas)
 		deploymentID = fmt.Sprintf("%d", m.response.CurrentDeployment.Id)
+		if m.response.Sleeping {
+			status = fmt.Sprintf("sleeping since %s, wakes on the next request", m.response.SleptAt.AsTime().Local().Format(time.DateTime))
+		}
 	} else {
 		status = "no deployment"
 		replicas = "0"
@@ -158,5 +163,26 @@ func (m statusModel) View() string {
 		labelStyle.Render("URL:"), valueStyle.Render(url),
 	)
 
+	for _, endpoint := range m.response.Endpoints {
+		exposure := "internal"
+		if endpoint.Public {
+			exposure = "public"
+		}
+		label := fmt.Sprintf("%s (%s):", endpoint.Name, strings.ToLower(endpoint.Protocol))
+		content += fmt.Sprintf("\n%s %s %s", labelStyle.Render(label), valueStyle.Render(endpoint.Address), labelStyle.UnsetWidth().Render(exposure))
+	}
+
+	for _, volume := range m.response.Volumes {
+		usage := "usage unknown"
+		if volume.UsedBytes != nil {
+			usage = fmt.Sprintf("%s used", formatBytes(volume.GetUsedBytes()))
+			if volume.Size != "" {
+				usage += " of " + volume.Size
+			}
+		}
+		label := fmt.Sprintf("%s (%s):", volume.Name, volume.Type)
+		content += fmt.Sprintf("\n%s %s %s", labelStyle.Render(label), valueStyle.Render(volume.MountPath), labelStyle.UnsetWidth().Render(usage))
+	}
+
 	return titleStyle.Render("Application Status") + "\n" + blockStyle.Render(content)
 }
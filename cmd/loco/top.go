@@ -0,0 +1,177 @@
+package loco
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nikumar1206/loco/internal/client"
+	"github.com/nikumar1206/loco/internal/ui"
+	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// topWindow is how much history each refresh asks for, enough for the latest request rate and latency
+const topWindow = 5 * time.Minute
+
+func init() {
+	topCmd.Flags().StringP("app", "a", "", "Application name")
+	topCmd.Flags().String("org", "", "organization ID")
+	topCmd.Flags().String("workspace", "", "workspace ID")
+	topCmd.Flags().Duration("interval", 2*time.Second, "How often to refresh")
+	topCmd.Flags().String("host", "", "Set the host URL")
+}
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show live resource usage of an application's pods",
+	Long:  "Display each pod's CPU, memory and restarts, refreshed until you quit, along with the app's current request rate and p95 latency.",
+	Example: `  loco top --app myapp
+  loco top --app myapp --interval 5s`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return topCmdFunc(cmd)
+	},
+}
+
+func topCmdFunc(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFlagParsing, err)
+	}
+	if interval < time.Second {
+		return fmt.Errorf("interval must be at least 1s")
+	}
+
+	apiClient, app, err := resolveApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	m := topModel{
+		ctx:       ctx,
+		apiClient: apiClient,
+		app:       app,
+		interval:  interval,
+	}
+
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running top: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+type metricsMsg struct {
+	resp *appv1.GetAppMetricsResponse
+	err  error
+}
+
+type refreshMsg struct{}
+
+type topModel struct {
+	ctx       context.Context
+	apiClient *client.Client
+	app       *appv1.App
+	interval  time.Duration
+	resp      *appv1.GetAppMetricsResponse
+	// err is the last refresh's error, the previous values stay on screen
+	err error
+}
+
+func (m topModel) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m topModel) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, max(m.interval, 10*time.Second))
+		defer cancel()
+
+		resp, err := m.apiClient.GetAppMetrics(ctx, &appv1.GetAppMetricsRequest{
+			AppId: m.app.Id,
+			Since: timestamppb.New(time.Now().Add(-topWindow)),
+		})
+		return metricsMsg{resp: resp, err: err}
+	}
+}
+
+func (m topModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case metricsMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.resp = msg.resp
+		}
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg { return refreshMsg{} })
+
+	case refreshMsg:
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m topModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(ui.LocoGreen).Render(m.app.Name)
+	view := lipgloss.NewStyle().Margin(1, 2, 0).Render(title)
+
+	if m.resp == nil && m.err == nil {
+		return view + "\n\n  Loading..."
+	}
+
+	if m.resp != nil {
+		view += "\n" + tableStyle().Render(podMetricsTable(m.resp).View())
+		if traffic := latestTraffic(m.resp); traffic != "" {
+			view += "\n  " + traffic
+		}
+		view += "\n  " + lipgloss.NewStyle().Foreground(ui.LocoMidGrey).Render(
+			"Updated "+m.resp.GetCollectedAt().AsTime().Local().Format(time.TimeOnly))
+	}
+	if m.err != nil {
+		view += "\n  " + lipgloss.NewStyle().Foreground(ui.LocoRed).Render(fmt.Sprintf("Refresh failed: %v", m.err))
+	}
+
+	return view + "\n\n  [q] Quit"
+}
+
+// latestTraffic renders the last request rate and latency points, or "" without a metrics store
+func latestTraffic(resp *appv1.GetAppMetricsResponse) string {
+	var rate, latency string
+	var hostname string
+	for _, series := range resp.GetSeries() {
+		points := series.GetPoints()
+		if len(points) == 0 {
+			continue
+		}
+		last := points[len(points)-1].GetValue()
+		switch series.GetName() {
+		case "requests_per_second":
+			rate = metricFormatter(series.GetName())(last)
+			hostname = series.GetHostname()
+		case "latency_p95_ms":
+			latency = metricFormatter(series.GetName())(last)
+		}
+	}
+	if rate == "" && latency == "" {
+		return ""
+	}
+	if rate == "" {
+		rate = "-"
+	}
+	if latency == "" {
+		latency = "-"
+	}
+	return fmt.Sprintf("%s  %s  p95 %s",
+		lipgloss.NewStyle().Foreground(ui.LocoCyan).Render(hostname), rate, latency)
+}
@@ -3,6 +3,7 @@ package loco
 import (
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/nikumar1206/loco/internal/ui"
@@ -45,7 +46,28 @@ func validateCmdFunc(cmd *cobra.Command) error {
 	fmt.Printf("Configuration loaded from: %s\n", loadedCfg.ProjectPath)
 	fmt.Printf("Application name: %s\n", loadedCfg.Config.Metadata.Name)
 	fmt.Printf("Subdomain: %s\n", loadedCfg.Config.Routing.Subdomain)
+	fmt.Printf("Path Prefix: %s\n", loadedCfg.Config.Routing.PathPrefix)
+	fmt.Printf("Protocol: %s\n", loadedCfg.Config.Routing.Protocol)
 	fmt.Printf("Port: %d\n", loadedCfg.Config.Routing.Port)
+	if loadedCfg.Config.Routing.SleepAfter != "" {
+		fmt.Printf("Sleep After: %s\n", loadedCfg.Config.Routing.SleepAfter)
+	}
+	if loadedCfg.Config.Deploy.Release != "" {
+		fmt.Printf("Release: %s\n", loadedCfg.Config.Deploy.Release)
+	}
+	for _, port := range loadedCfg.Config.Routing.Ports {
+		fmt.Printf("Port %s: %d/%s (%s)\n", port.Name, port.Port, port.Protocol, port.Exposure)
+	}
+	for _, volume := range loadedCfg.Config.Volumes {
+		fmt.Printf("Volume %s: %s (%s", volume.Name, volume.MountPath, volume.Type)
+		if volume.Size != "" {
+			fmt.Printf(", %s", volume.Size)
+		}
+		fmt.Println(")")
+	}
+	for _, job := range loadedCfg.Config.Jobs {
+		fmt.Printf("Job %s: %s, %s\n", job.Name, job.Schedule, strings.Join(job.Command, " "))
+	}
 
 	return nil
 }
@@ -16,10 +16,18 @@ import (
 	"github.com/nikumar1206/loco/shared"
 	appv1 "github.com/nikumar1206/loco/shared/proto/app/v1"
 	"github.com/nikumar1206/loco/shared/proto/app/v1/appv1connect"
+	auditv1 "github.com/nikumar1206/loco/shared/proto/audit/v1"
+	"github.com/nikumar1206/loco/shared/proto/audit/v1/auditv1connect"
 	deploymentv1 "github.com/nikumar1206/loco/shared/proto/deployment/v1"
 	"github.com/nikumar1206/loco/shared/proto/deployment/v1/deploymentv1connect"
+	domainv1 "github.com/nikumar1206/loco/shared/proto/domain/v1"
+	"github.com/nikumar1206/loco/shared/proto/domain/v1/domainv1connect"
+	jobv1 "github.com/nikumar1206/loco/shared/proto/job/v1"
+	"github.com/nikumar1206/loco/shared/proto/job/v1/jobv1connect"
 	orgv1 "github.com/nikumar1206/loco/shared/proto/org/v1"
 	"github.com/nikumar1206/loco/shared/proto/org/v1/orgv1connect"
+	quotav1 "github.com/nikumar1206/loco/shared/proto/quota/v1"
+	"github.com/nikumar1206/loco/shared/proto/quota/v1/quotav1connect"
 	userv1 "github.com/nikumar1206/loco/shared/proto/user/v1"
 	"github.com/nikumar1206/loco/shared/proto/user/v1/userv1connect"
 	workspacev1 "github.com/nikumar1206/loco/shared/proto/workspace/v1"
@@ -39,6 +47,10 @@ type Client struct {
 	Workspace  workspacev1connect.WorkspaceServiceClient
 	App        appv1connect.AppServiceClient
 	Deployment deploymentv1connect.DeploymentServiceClient
+	Audit      auditv1connect.AuditServiceClient
+	Quota      quotav1connect.QuotaServiceClient
+	Domain     domainv1connect.DomainServiceClient
+	Job        jobv1connect.JobServiceClient
 }
 
 func NewClient(host, token string) *Client {
@@ -53,6 +65,10 @@ func NewClient(host, token string) *Client {
 		Workspace:  workspacev1connect.NewWorkspaceServiceClient(httpClient, host),
 		App:        appv1connect.NewAppServiceClient(httpClient, host),
 		Deployment: deploymentv1connect.NewDeploymentServiceClient(httpClient, host),
+		Audit:      auditv1connect.NewAuditServiceClient(httpClient, host),
+		Quota:      quotav1connect.NewQuotaServiceClient(httpClient, host),
+		Domain:     domainv1connect.NewDomainServiceClient(httpClient, host),
+		Job:        jobv1connect.NewJobServiceClient(httpClient, host),
 	}
 }
 
@@ -341,12 +357,8 @@ func (c *Client) GetAppStatus(ctx context.Context, appID int64) (*appv1.GetAppSt
 	return resp.Msg, nil
 }
 
-func (c *Client) StreamLogs(ctx context.Context, appID int64, limit *int32, follow *bool, logHandler func(*appv1.LogEntry) error) error {
-	req := connect.NewRequest(&appv1.StreamLogsRequest{
-		AppId:  appID,
-		Limit:  limit,
-		Follow: follow,
-	})
+func (c *Client) StreamLogs(ctx context.Context, logsReq *appv1.StreamLogsRequest, logHandler func(*appv1.LogEntry) error) error {
+	req := connect.NewRequest(logsReq)
 	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
 
 	stream, err := c.App.StreamLogs(ctx, req)
@@ -370,6 +382,69 @@ func (c *Client) StreamLogs(ctx context.Context, appID int64, limit *int32, foll
 	return nil
 }
 
+// RunCommand runs a one-off command in an app's image, calling handler with each message the command streams back
+func (c *Client) RestartApp(ctx context.Context, restartReq *appv1.RestartAppRequest, handler func(*appv1.RestartAppEvent) error) error {
+	req := connect.NewRequest(restartReq)
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	stream, err := c.App.RestartApp(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to restart app")
+		return err
+	}
+
+	for stream.Receive() {
+		if err := handler(stream.Msg()); err != nil {
+			return err
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		logRequestID(ctx, err, "failed to restart app")
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) RunCommand(ctx context.Context, runReq *appv1.RunCommandRequest, handler func(*appv1.RunCommandResponse) error) error {
+	req := connect.NewRequest(runReq)
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	stream, err := c.App.RunCommand(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to run command")
+		return err
+	}
+
+	for stream.Receive() {
+		if err := handler(stream.Msg()); err != nil {
+			return err
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		logRequestID(ctx, err, "failed to run command")
+		return err
+	}
+
+	return nil
+}
+
+// Exec opens an exec stream. The caller sends the ExecRequest picking the pod and command first.
+func (c *Client) Exec(ctx context.Context) *connect.BidiStreamForClient[appv1.ExecRequest, appv1.ExecResponse] {
+	stream := c.App.Exec(ctx)
+	stream.RequestHeader().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	return stream
+}
+
+// PortForward opens a port-forward stream. The caller sends the PortForwardRequest picking the pod and port first.
+func (c *Client) PortForward(ctx context.Context) *connect.BidiStreamForClient[appv1.PortForwardRequest, appv1.PortForwardResponse] {
+	stream := c.App.PortForward(ctx)
+	stream.RequestHeader().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	return stream
+}
+
 func (c *Client) GetEvents(ctx context.Context, appID int64, limit *int32) ([]*appv1.Event, error) {
 	req := connect.NewRequest(&appv1.GetEventsRequest{
 		AppId: appID,
@@ -385,6 +460,159 @@ func (c *Client) GetEvents(ctx context.Context, appID int64, limit *int32) ([]*a
 	return resp.Msg.Events, nil
 }
 
+func (c *Client) GetAppMetrics(ctx context.Context, metricsReq *appv1.GetAppMetricsRequest) (*appv1.GetAppMetricsResponse, error) {
+	req := connect.NewRequest(metricsReq)
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.App.GetAppMetrics(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to get app metrics")
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+func (c *Client) ListAuditEvents(ctx context.Context, req *auditv1.ListEventsRequest) (*auditv1.ListEventsResponse, error) {
+	connectReq := connect.NewRequest(req)
+	connectReq.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.Audit.ListEvents(ctx, connectReq)
+	if err != nil {
+		logRequestID(ctx, err, "failed to list audit events")
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+func (c *Client) AddDomain(ctx context.Context, appID int64, hostname string) (*domainv1.Domain, error) {
+	req := connect.NewRequest(&domainv1.AddDomainRequest{
+		AppId:    appID,
+		Hostname: hostname,
+	})
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.Domain.AddDomain(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to add domain")
+		return nil, err
+	}
+
+	return resp.Msg.Domain, nil
+}
+
+func (c *Client) VerifyDomain(ctx context.Context, appID int64, hostname string) (*domainv1.Domain, error) {
+	req := connect.NewRequest(&domainv1.VerifyDomainRequest{
+		AppId:    appID,
+		Hostname: hostname,
+	})
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.Domain.VerifyDomain(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to verify domain")
+		return nil, err
+	}
+
+	return resp.Msg.Domain, nil
+}
+
+func (c *Client) ListDomains(ctx context.Context, appID int64) ([]*domainv1.Domain, error) {
+	req := connect.NewRequest(&domainv1.ListDomainsRequest{
+		AppId: appID,
+	})
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.Domain.ListDomains(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to list domains")
+		return nil, err
+	}
+
+	return resp.Msg.Domains, nil
+}
+
+func (c *Client) RemoveDomain(ctx context.Context, appID int64, hostname string) error {
+	req := connect.NewRequest(&domainv1.RemoveDomainRequest{
+		AppId:    appID,
+		Hostname: hostname,
+	})
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	if _, err := c.Domain.RemoveDomain(ctx, req); err != nil {
+		logRequestID(ctx, err, "failed to remove domain")
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) ListJobs(ctx context.Context, appID int64) ([]*jobv1.Job, error) {
+	req := connect.NewRequest(&jobv1.ListJobsRequest{
+		AppId: appID,
+	})
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.Job.ListJobs(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to list jobs")
+		return nil, err
+	}
+
+	return resp.Msg.Jobs, nil
+}
+
+// ListJobRuns lists the runs of an app's jobs, only of job when it's not empty
+func (c *Client) ListJobRuns(ctx context.Context, appID int64, job string) ([]*jobv1.JobRun, error) {
+	req := connect.NewRequest(&jobv1.ListJobRunsRequest{
+		AppId: appID,
+	})
+	if job != "" {
+		req.Msg.Job = &job
+	}
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.Job.ListJobRuns(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to list job runs")
+		return nil, err
+	}
+
+	return resp.Msg.Runs, nil
+}
+
+func (c *Client) TriggerJob(ctx context.Context, appID int64, job string) (*jobv1.JobRun, error) {
+	req := connect.NewRequest(&jobv1.TriggerJobRequest{
+		AppId: appID,
+		Job:   job,
+	})
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.Job.TriggerJob(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to trigger job")
+		return nil, err
+	}
+
+	return resp.Msg.Run, nil
+}
+
+func (c *Client) GetQuota(ctx context.Context, workspaceID int64) (*quotav1.GetQuotaResponse, error) {
+	req := connect.NewRequest(&quotav1.GetQuotaRequest{
+		WorkspaceId: workspaceID,
+	})
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.Quota.GetQuota(ctx, req)
+	if err != nil {
+		logRequestID(ctx, err, "failed to get quota")
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
 // APIError represents an HTTP API error
 type APIError struct {
 	StatusCode int
@@ -3,8 +3,11 @@ package config
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -26,6 +29,38 @@ var BannedSubdomains = []string{
 	"config", "configuration", "settings", "setup", "install", "uninstall",
 }
 
+// MaxGRPCServices is how many routing.grpcServices entries fit in a GRPCRoute rule
+const MaxGRPCServices = 64
+
+// MaxPorts is how many extra routing.ports an app can declare
+const MaxPorts = 10
+
+// MaxVolumes is how many volumes an app can mount
+const MaxVolumes = 5
+
+// MaxJobs is how many scheduled jobs an app can declare
+const MaxJobs = 10
+
+// MinSleepAfter keeps apps from sleeping between the request metrics scrapes that show they're in use
+const MinSleepAfter = time.Hour
+
+var (
+	grpcServicePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+	grpcMethodPattern  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// an HTTP header field name
+	headerNamePattern = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`)
+	// a Kubernetes port name, which names the app's Service port and its gateway listener
+	portNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,13}[a-z0-9])?$`)
+	// a pod volume name, also appended to the app's name to name its PersistentVolumeClaim
+	volumeNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,28}[a-z0-9])?$`)
+	// a job name, appended to the app's name to name its CronJob, which leaves room for the Jobs it creates
+	jobNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,18}[a-z0-9])?$`)
+	// the ranges, steps and lists of a standard five field cron expression
+	cronFieldPattern = regexp.MustCompile(`^(\*|\?|[0-9A-Za-z]+(-[0-9A-Za-z]+)?)(/[0-9]+)?(,(\*|[0-9A-Za-z]+(-[0-9A-Za-z]+)?)(/[0-9]+)?)*$`)
+	// a whole number of binary units, which every storage class can provision
+	volumeSizePattern = regexp.MustCompile(`^[1-9][0-9]*(Mi|Gi|Ti)$`)
+)
+
 // Default provides sensible defaults for a new AppConfig
 var Default = &AppConfig{
 	Metadata: Metadata{
@@ -55,6 +90,7 @@ var Default = &AppConfig{
 		IdleTimeout: 60,
 		PathPrefix:  "/",
 		Port:        8000,
+		Protocol:    ProtocolHTTP,
 	},
 	Health: Health{
 		Interval:           30,
@@ -107,13 +143,38 @@ func FillSensibleDefaults(cfg *AppConfig) {
 	if cfg.Routing.IdleTimeout == 0 {
 		cfg.Routing.IdleTimeout = Default.Routing.IdleTimeout
 	}
+	if cfg.Routing.Protocol == "" {
+		cfg.Routing.Protocol = Default.Routing.Protocol
+	}
 
+	if cfg.Health.Interval == 0 {
+		cfg.Health.Interval = Default.Health.Interval
+	}
 	if cfg.Health.Timeout == 0 {
 		cfg.Health.Timeout = Default.Health.Timeout
 	}
 	if cfg.Health.FailThreshold == 0 {
 		cfg.Health.FailThreshold = Default.Health.FailThreshold
 	}
+	if cfg.Health.Liveness == nil {
+		cfg.Health.Liveness = &Probe{}
+	}
+	if cfg.Health.Liveness.InitialDelay == 0 {
+		cfg.Health.Liveness.InitialDelay = cfg.Health.StartupGracePeriod
+	}
+	fillProbeDefaults(cfg.Health.Liveness, cfg)
+	if cfg.Health.Readiness == nil {
+		readiness := *cfg.Health.Liveness
+		readiness.Headers = maps.Clone(readiness.Headers)
+		readiness.Command = slices.Clone(readiness.Command)
+		// unready pods get no traffic anyway, so readiness starts checking right away
+		readiness.InitialDelay = 0
+		cfg.Health.Readiness = &readiness
+	}
+	fillProbeDefaults(cfg.Health.Readiness, cfg)
+	if cfg.Health.Startup != nil {
+		fillProbeDefaults(cfg.Health.Startup, cfg)
+	}
 
 	if cfg.Obs.Logging.RetentionPeriod == "" {
 		cfg.Obs.Logging.RetentionPeriod = Default.Obs.Logging.RetentionPeriod
@@ -129,6 +190,39 @@ func FillSensibleDefaults(cfg *AppConfig) {
 	}
 }
 
+// fillProbeDefaults fills a probe's unset fields from the app's routing and Health defaults
+func fillProbeDefaults(p *Probe, cfg *AppConfig) {
+	if p.Type == "" {
+		p.Type = defaultProbeType(cfg.Routing.Protocol)
+	}
+	if p.Port == 0 && p.Type != ProbeExec {
+		p.Port = cfg.Routing.Port
+	}
+	if p.Path == "" && p.Type == ProbeHTTP {
+		p.Path = cfg.Health.Path
+	}
+	if p.Interval == 0 {
+		p.Interval = cfg.Health.Interval
+	}
+	if p.Timeout == 0 {
+		p.Timeout = cfg.Health.Timeout
+	}
+	if p.FailThreshold == 0 {
+		p.FailThreshold = cfg.Health.FailThreshold
+	}
+	if p.SuccessThreshold == 0 {
+		p.SuccessThreshold = 1
+	}
+}
+
+// defaultProbeType checks grpc apps with the gRPC health protocol and everything else over http
+func defaultProbeType(protocol string) string {
+	if protocol == ProtocolGRPC {
+		return ProbeGRPC
+	}
+	return ProbeHTTP
+}
+
 // Validate ensures the AppConfig is valid according to the schema
 func Validate(cfg *AppConfig) error {
 	if cfg.Metadata.ConfigVersion == "" {
@@ -164,6 +258,47 @@ func Validate(cfg *AppConfig) error {
 		return fmt.Errorf("routing.idleTimeout cannot be negative")
 	}
 
+	switch cfg.Routing.Protocol {
+	case "":
+		cfg.Routing.Protocol = ProtocolHTTP
+	case ProtocolHTTP, ProtocolGRPC:
+	default:
+		return fmt.Errorf("routing.protocol %q is not supported. allowed protocols: %s, %s", cfg.Routing.Protocol, ProtocolHTTP, ProtocolGRPC)
+	}
+
+	if cfg.Routing.Protocol == ProtocolGRPC {
+		// grpc calls are routed by service, so a grpc app takes its whole hostname
+		if cfg.Routing.PathPrefix != "/" {
+			return fmt.Errorf("routing.pathPrefix is not supported for grpc apps, use routing.grpcServices")
+		}
+		if err := ValidateGRPCServices(cfg.Routing.GRPCServices); err != nil {
+			return err
+		}
+	} else if len(cfg.Routing.GRPCServices) > 0 {
+		return fmt.Errorf("routing.grpcServices requires routing.protocol = %q", ProtocolGRPC)
+	}
+
+	if cfg.Routing.SleepAfter != "" {
+		if _, err := ParseSleepAfter(cfg.Routing.SleepAfter); err != nil {
+			return err
+		}
+		// gRPC clients can't follow the wake redirect
+		if cfg.Routing.Protocol == ProtocolGRPC {
+			return fmt.Errorf("routing.sleepAfter only applies to http apps")
+		}
+	}
+
+	for i := range cfg.Routing.Ports {
+		port := &cfg.Routing.Ports[i]
+		port.Protocol = strings.ToUpper(port.Protocol)
+		if port.Exposure == "" {
+			port.Exposure = ExposureInternal
+		}
+	}
+	if err := ValidatePorts(cfg.Routing.Ports, cfg.Routing.Port, cfg.Metadata.Name); err != nil {
+		return err
+	}
+
 	if cfg.Build.DockerfilePath == "" {
 		cfg.Build.DockerfilePath = "Dockerfile"
 	}
@@ -191,9 +326,8 @@ func Validate(cfg *AppConfig) error {
 	if cfg.Resources.Replicas.Max < cfg.Resources.Replicas.Min {
 		return fmt.Errorf("resources.replicas.max must be greater than or equal to min")
 	}
-	if cfg.Resources.Replicas.Max > 3 {
-		return fmt.Errorf("resources.replicas.max cannot exceed 3 replicas")
-	}
+	// the upper bound is the workspace's replica quota. deploys send max with the app's resources and the API
+	// rejects them when it's over.
 
 	if cfg.Resources.Scalers.Enabled {
 		if cfg.Resources.Scalers.CPUTarget == 0 && cfg.Resources.Scalers.MemoryTarget == 0 {
@@ -210,18 +344,29 @@ func Validate(cfg *AppConfig) error {
 		}
 	}
 
-	// --- Health ---
-	if cfg.Health.Path == "" {
-		return fmt.Errorf("health.path must be provided")
+	for i := range cfg.Volumes {
+		if cfg.Volumes[i].MountPath != "" {
+			cfg.Volumes[i].MountPath = path.Clean(cfg.Volumes[i].MountPath)
+		}
+	}
+	if err := ValidateVolumes(cfg.Volumes, cfg.Resources.Replicas.Max); err != nil {
+		return err
 	}
-	if !strings.HasPrefix(cfg.Health.Path, "/") {
+
+	if err := ValidateJobs(cfg.Jobs); err != nil {
+		return err
+	}
+
+	// --- Health ---
+	if cfg.Health.Path != "" && !strings.HasPrefix(cfg.Health.Path, "/") {
 		return fmt.Errorf("health.path must start with '/'")
 	}
-	if cfg.Health.Interval <= 0 {
-		return fmt.Errorf("health.interval must be greater than 0")
+	// unset interval and timeout take their defaults
+	if cfg.Health.Interval < 0 {
+		return fmt.Errorf("health.interval cannot be negative")
 	}
-	if cfg.Health.Timeout <= 0 {
-		return fmt.Errorf("health.timeout must be greater than 0")
+	if cfg.Health.Timeout < 0 {
+		return fmt.Errorf("health.timeout cannot be negative")
 	}
 	if cfg.Health.StartupGracePeriod < 0 {
 		return fmt.Errorf("health.startupGracePeriod cannot be negative")
@@ -232,6 +377,9 @@ func Validate(cfg *AppConfig) error {
 	if cfg.Health.FailThreshold < 0 {
 		return fmt.Errorf("health.failThreshold cannot be negative")
 	}
+	if err := ValidateProbes(cfg.Health, cfg.Routing.Protocol); err != nil {
+		return err
+	}
 
 	if cfg.Obs.Logging.Enabled {
 		if cfg.Obs.Logging.RetentionPeriod == "" {
@@ -280,6 +428,252 @@ func parseRetention(value string) (time.Duration, error) {
 	return time.ParseDuration(value)
 }
 
+// ParseSleepAfter reads routing.sleepAfter, a Go duration or a number of days like "7d"
+func ParseSleepAfter(value string) (time.Duration, error) {
+	duration, err := parseRetention(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid routing.sleepAfter: %q", value)
+	}
+	if duration < MinSleepAfter {
+		return 0, fmt.Errorf("routing.sleepAfter must be at least %s, got %q", MinSleepAfter, value)
+	}
+	return duration, nil
+}
+
+// ValidateGRPCServices checks routing.grpcServices entries are "package.Service" or "package.Service/Method"
+func ValidateGRPCServices(services []string) error {
+	if len(services) > MaxGRPCServices {
+		return fmt.Errorf("routing.grpcServices can list at most %d services or methods, got %d", MaxGRPCServices, len(services))
+	}
+	for _, entry := range services {
+		service, method, hasMethod := strings.Cut(entry, "/")
+		if !grpcServicePattern.MatchString(service) || (hasMethod && !grpcMethodPattern.MatchString(method)) {
+			return fmt.Errorf("routing.grpcServices entry %q must be a fully qualified service like 'pkg.Service' or 'pkg.Service/Method'", entry)
+		}
+	}
+	return nil
+}
+
+// ValidatePorts checks routing.ports have unique names and numbers that don't clash with the app's routing.port.
+// appName is reserved, it names the routing.port on the app's Service.
+func ValidatePorts(ports []Port, routingPort int32, appName string) error {
+	if len(ports) > MaxPorts {
+		return fmt.Errorf("routing.ports can list at most %d ports, got %d", MaxPorts, len(ports))
+	}
+
+	names := make(map[string]bool, len(ports))
+	numbers := make(map[string]bool, len(ports)+1)
+	numbers[fmt.Sprintf("TCP/%d", routingPort)] = true
+	for _, port := range ports {
+		if !portNamePattern.MatchString(port.Name) || port.Name == appName {
+			return fmt.Errorf("routing.ports name %q must be at most 15 lowercase letters, digits or '-', and not the app's name", port.Name)
+		}
+		if names[port.Name] {
+			return fmt.Errorf("routing.ports name %q is used more than once", port.Name)
+		}
+		names[port.Name] = true
+
+		if port.Port <= 1023 || port.Port > 65535 {
+			return fmt.Errorf("routing.ports %q port must be between 1024 and 65535, got %d", port.Name, port.Port)
+		}
+		if port.Protocol != "TCP" && port.Protocol != "UDP" {
+			return fmt.Errorf("routing.ports %q protocol must be TCP or UDP, got %q", port.Name, port.Protocol)
+		}
+		key := fmt.Sprintf("%s/%d", port.Protocol, port.Port)
+		if numbers[key] {
+			return fmt.Errorf("routing.ports %q reuses %s port %d", port.Name, port.Protocol, port.Port)
+		}
+		numbers[key] = true
+
+		if port.Exposure != ExposureInternal && port.Exposure != ExposurePublic {
+			return fmt.Errorf("routing.ports %q exposure must be %q or %q, got %q", port.Name, ExposureInternal, ExposurePublic, port.Exposure)
+		}
+	}
+	return nil
+}
+
+// ValidateVolumes checks volumes have unique names and mount paths that don't nest in each other.
+// Persistent volumes are ReadWriteOnce, so maxReplicas must be 1 when there are any.
+func ValidateVolumes(volumes []Volume, maxReplicas int32) error {
+	if len(volumes) > MaxVolumes {
+		return fmt.Errorf("volumes can list at most %d volumes, got %d", MaxVolumes, len(volumes))
+	}
+
+	names := make(map[string]bool, len(volumes))
+	for i, volume := range volumes {
+		if !volumeNamePattern.MatchString(volume.Name) {
+			return fmt.Errorf("volumes name %q must be at most 30 lowercase letters, digits or '-'", volume.Name)
+		}
+		if names[volume.Name] {
+			return fmt.Errorf("volumes name %q is used more than once", volume.Name)
+		}
+		names[volume.Name] = true
+
+		if !strings.HasPrefix(volume.MountPath, "/") || volume.MountPath == "/" || path.Clean(volume.MountPath) != volume.MountPath {
+			return fmt.Errorf("volumes %q mountPath must be a clean absolute path other than '/', got %q", volume.Name, volume.MountPath)
+		}
+		for _, other := range volumes[:i] {
+			if volume.MountPath == other.MountPath || strings.HasPrefix(volume.MountPath, other.MountPath+"/") ||
+				strings.HasPrefix(other.MountPath, volume.MountPath+"/") {
+				return fmt.Errorf("volumes %q and %q have overlapping mount paths", other.Name, volume.Name)
+			}
+		}
+
+		switch volume.Type {
+		case VolumePersistent:
+			if volume.Size == "" {
+				return fmt.Errorf("volumes %q size must be set for persistent volumes (e.g. '10Gi')", volume.Name)
+			}
+			if maxReplicas > 1 {
+				return fmt.Errorf("volumes %q is persistent and can only be mounted by one pod, resources.replicas.max must be 1", volume.Name)
+			}
+		case VolumeEphemeral:
+		default:
+			return fmt.Errorf("volumes %q type must be %q or %q, got %q", volume.Name, VolumePersistent, VolumeEphemeral, volume.Type)
+		}
+		if volume.Size != "" && !volumeSizePattern.MatchString(volume.Size) {
+			return fmt.Errorf("volumes %q size must be a whole number of Mi, Gi or Ti, got %q", volume.Name, volume.Size)
+		}
+	}
+	return nil
+}
+
+// ValidateJobs checks jobs have unique names, a command and a cron schedule
+func ValidateJobs(jobs []Job) error {
+	if len(jobs) > MaxJobs {
+		return fmt.Errorf("jobs can list at most %d jobs, got %d", MaxJobs, len(jobs))
+	}
+
+	names := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		if !jobNamePattern.MatchString(job.Name) {
+			return fmt.Errorf("jobs name %q must be at most 20 lowercase letters, digits or '-'", job.Name)
+		}
+		if names[job.Name] {
+			return fmt.Errorf("jobs name %q is used more than once", job.Name)
+		}
+		names[job.Name] = true
+
+		if err := ValidateSchedule(job.Schedule); err != nil {
+			return fmt.Errorf("jobs %q: %w", job.Name, err)
+		}
+		if len(job.Command) == 0 || job.Command[0] == "" {
+			return fmt.Errorf("jobs %q command must be set", job.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateSchedule checks a job schedule is a five field cron expression or one of the macros CronJobs accept.
+// Field values are checked by the cluster when the CronJob is applied.
+func ValidateSchedule(schedule string) error {
+	switch schedule {
+	case "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly":
+		return nil
+	}
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return fmt.Errorf("schedule %q must be a cron expression with 5 fields, like '0 3 * * *', or a macro like '@daily'", schedule)
+	}
+	for _, field := range fields {
+		if !cronFieldPattern.MatchString(field) {
+			return fmt.Errorf("schedule %q has an invalid field %q", schedule, field)
+		}
+	}
+	return nil
+}
+
+// HasPersistentVolumes is true when any of volumes keeps its data across restarts
+func HasPersistentVolumes(volumes []Volume) bool {
+	return slices.ContainsFunc(volumes, func(v Volume) bool { return v.Type == VolumePersistent })
+}
+
+// ValidateProbes checks the liveness, readiness and startup probes of health.
+// Unset probe fields are valid, they take their defaults from health and routing.
+func ValidateProbes(health Health, protocol string) error {
+	// without a liveness probe, an http app is checked at health.path
+	if health.Liveness == nil && defaultProbeType(protocol) == ProbeHTTP && health.Path == "" {
+		return fmt.Errorf("health.path must be provided")
+	}
+
+	probes := []struct {
+		field string
+		probe *Probe
+		// kubernetes only allows a success threshold of 1 for liveness and startup probes
+		singleSuccess bool
+	}{
+		{"liveness", health.Liveness, true},
+		{"readiness", health.Readiness, false},
+		{"startup", health.Startup, true},
+	}
+	for _, p := range probes {
+		if p.probe == nil {
+			continue
+		}
+		if err := validateProbe("health."+p.field, p.probe, protocol, health.Path); err != nil {
+			return err
+		}
+		if p.singleSuccess && p.probe.SuccessThreshold > 1 {
+			return fmt.Errorf("health.%s.successThreshold must be 1", p.field)
+		}
+	}
+	return nil
+}
+
+func validateProbe(field string, p *Probe, protocol, healthPath string) error {
+	probeType := p.Type
+	if probeType == "" {
+		probeType = defaultProbeType(protocol)
+	}
+
+	switch probeType {
+	case ProbeHTTP:
+		path := p.Path
+		if path == "" {
+			path = healthPath
+		}
+		if path == "" {
+			return fmt.Errorf("%s.path must be provided for http probes", field)
+		}
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("%s.path must start with '/'", field)
+		}
+		for name := range p.Headers {
+			if !headerNamePattern.MatchString(name) {
+				return fmt.Errorf("%s.headers has invalid header name %q", field, name)
+			}
+		}
+	case ProbeExec:
+		if len(p.Command) == 0 || p.Command[0] == "" {
+			return fmt.Errorf("%s.command must be provided for exec probes", field)
+		}
+	case ProbeTCP, ProbeGRPC:
+	default:
+		return fmt.Errorf("%s.type must be one of %s, %s, %s or %s, got %q", field, ProbeHTTP, ProbeTCP, ProbeExec, ProbeGRPC, p.Type)
+	}
+
+	if probeType != ProbeHTTP && (p.Path != "" || len(p.Headers) > 0) {
+		return fmt.Errorf("%s.path and %s.headers only apply to http probes", field, field)
+	}
+	if probeType != ProbeExec && len(p.Command) > 0 {
+		return fmt.Errorf("%s.command only applies to exec probes", field)
+	}
+	if probeType != ProbeGRPC && p.Service != "" {
+		return fmt.Errorf("%s.service only applies to grpc probes", field)
+	}
+
+	if p.Port < 0 || p.Port > 65535 {
+		return fmt.Errorf("%s.port must be between 1 and 65535, got %d", field, p.Port)
+	}
+	if p.InitialDelay < 0 || p.InitialDelay > 300 {
+		return fmt.Errorf("%s.initialDelay must be between 0 and 300 seconds, got %d", field, p.InitialDelay)
+	}
+	if p.Interval < 0 || p.Timeout < 0 || p.FailThreshold < 0 || p.SuccessThreshold < 0 {
+		return fmt.Errorf("%s interval, timeout and thresholds cannot be negative", field)
+	}
+	return nil
+}
+
 // isBannedSubdomain checks if a subdomain is in the banned list
 func isBannedSubdomain(subdomain string) bool {
 	for _, banned := range BannedSubdomains {
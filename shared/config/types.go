@@ -5,10 +5,13 @@ type AppConfig struct {
 	Metadata  Metadata  `json:"metadata" toml:"Metadata"`
 	Resources Resources `json:"resources" toml:"Resources"`
 	Build     Build     `json:"build" toml:"Build"`
+	Deploy    Deploy    `json:"deploy,omitzero" toml:"Deploy"`
 	Routing   Routing   `json:"routing" toml:"Routing"`
 	Health    Health    `json:"health" toml:"Health"`
 	Env       Env       `json:"env,omitzero" toml:"Env"`
 	Obs       Obs       `json:"obs,omitzero" toml:"Obs"`
+	Volumes   []Volume  `json:"volumes,omitempty" toml:"Volumes"`
+	Jobs      []Job     `json:"jobs,omitempty" toml:"Jobs"`
 }
 
 type Metadata struct {
@@ -16,6 +19,7 @@ type Metadata struct {
 	Description   string `json:"description,omitempty" toml:"Description"`
 	Name          string `json:"name" toml:"Name"`
 	Type          string `json:"type,omitempty" toml:"Type"`
+	Region        string `json:"region,omitempty" toml:"Region"`
 }
 
 type Resources struct {
@@ -41,19 +45,122 @@ type Build struct {
 	Type           string `json:"type" toml:"Type"`
 }
 
+type Deploy struct {
+	// Release runs through /bin/sh in the new image before its pods are rolled out, like a migration.
+	// The deploy fails, leaving the running version in place, unless it exits 0.
+	Release string `json:"release,omitempty" toml:"Release"`
+}
+
 type Routing struct {
 	Port        int32  `json:"port" toml:"Port"`
 	Subdomain   string `json:"subdomain" toml:"Subdomain"`
 	PathPrefix  string `json:"pathPrefix,omitempty" toml:"PathPrefix"`
 	IdleTimeout int32  `json:"idleTimeout,omitempty" toml:"IdleTimeout"`
+	// Protocol is ProtocolHTTP or ProtocolGRPC
+	Protocol string `json:"protocol,omitempty" toml:"Protocol"`
+	// GRPCServices limits a grpc app's route to these "package.Service" or "package.Service/Method" names.
+	// Every call is routed when empty.
+	GRPCServices []string `json:"grpcServices,omitempty" toml:"GRPCServices"`
+	// SleepAfter scales the app to zero after it serves no requests for this long, like "7d" or "12h".
+	// The next request wakes it. Empty never sleeps.
+	SleepAfter string `json:"sleepAfter,omitempty" toml:"SleepAfter"`
+	// Ports are extra TCP or UDP ports the app listens on besides Port
+	Ports []Port `json:"ports,omitempty" toml:"Ports"`
+}
+
+type Port struct {
+	Name     string `json:"name" toml:"Name"`
+	Port     int32  `json:"port" toml:"Port"`
+	Protocol string `json:"protocol" toml:"Protocol"`
+	// Exposure is ExposureInternal or ExposurePublic
+	Exposure string `json:"exposure,omitempty" toml:"Exposure"`
+	// ListenerPort is the gateway port a public port is reachable on, assigned by the API at deploy time
+	ListenerPort int32 `json:"listenerPort,omitempty" toml:"-"`
 }
 
+// Port exposures
+const (
+	// ExposureInternal ports are only reachable from inside the cluster, through the app's Service
+	ExposureInternal = "internal"
+	// ExposurePublic ports are also reachable through a gateway listener on the app's hostname
+	ExposurePublic = "public"
+)
+
+// Routing protocols
+const (
+	ProtocolHTTP = "http"
+	ProtocolGRPC = "grpc"
+)
+
+// Health holds the defaults of the app's probes, and the probes themselves once they're configured.
+// Probe fields left unset take the matching Health field.
 type Health struct {
 	Path               string `json:"path" toml:"Path"`
 	Interval           int32  `json:"interval" toml:"Interval"`
 	Timeout            int32  `json:"timeout" toml:"Timeout"`
 	StartupGracePeriod int32  `json:"startupGracePeriod,omitempty" toml:"StartupGracePeriod"`
 	FailThreshold      int32  `json:"failThreshold,omitempty" toml:"FailThreshold"`
+	// Liveness restarts the container when it fails, an http check of Path by default, or grpc for grpc apps
+	Liveness *Probe `json:"liveness,omitempty" toml:"Liveness"`
+	// Readiness takes the pod out of load balancing while it fails, the same check as Liveness by default
+	Readiness *Probe `json:"readiness,omitempty" toml:"Readiness"`
+	// Startup holds off the other probes until it passes, for apps that are slow to start. Unset by default.
+	Startup *Probe `json:"startup,omitempty" toml:"Startup"`
+}
+
+type Probe struct {
+	// Type is ProbeHTTP, ProbeTCP, ProbeExec or ProbeGRPC
+	Type string `json:"type" toml:"Type"`
+	// Port defaults to routing.port, unused by exec probes
+	Port    int32             `json:"port,omitempty" toml:"Port"`
+	Path    string            `json:"path,omitempty" toml:"Path"`
+	Headers map[string]string `json:"headers,omitempty" toml:"Headers"`
+	// Command runs in the container for exec probes, exiting 0 passes
+	Command []string `json:"command,omitempty" toml:"Command"`
+	// Service is sent in grpc health checks, empty checks the server as a whole
+	Service          string `json:"service,omitempty" toml:"Service"`
+	InitialDelay     int32  `json:"initialDelay,omitempty" toml:"InitialDelay"`
+	Interval         int32  `json:"interval,omitempty" toml:"Interval"`
+	Timeout          int32  `json:"timeout,omitempty" toml:"Timeout"`
+	FailThreshold    int32  `json:"failThreshold,omitempty" toml:"FailThreshold"`
+	SuccessThreshold int32  `json:"successThreshold,omitempty" toml:"SuccessThreshold"`
+}
+
+// Probe types
+const (
+	ProbeHTTP = "http"
+	ProbeTCP  = "tcp"
+	ProbeExec = "exec"
+	ProbeGRPC = "grpc"
+)
+
+type Volume struct {
+	Name      string `json:"name" toml:"Name"`
+	MountPath string `json:"mountPath" toml:"MountPath"`
+	// Size is a quantity like "10Gi", required for persistent volumes and a limit on ephemeral ones
+	Size string `json:"size,omitempty" toml:"Size"`
+	// Type is VolumePersistent or VolumeEphemeral
+	Type string `json:"type" toml:"Type"`
+}
+
+// Volume types
+const (
+	// VolumePersistent volumes are PersistentVolumeClaims that keep their data across restarts and deploys.
+	// They're ReadWriteOnce, so an app with one runs a single replica.
+	VolumePersistent = "persistent"
+	// VolumeEphemeral volumes are emptyDirs, scratch space that lives as long as the pod
+	VolumeEphemeral = "ephemeral"
+)
+
+// Job runs a command in the app's image on a schedule, with the app's env and service account
+type Job struct {
+	Name string `json:"name" toml:"Name"`
+	// Schedule is a cron expression like "0 3 * * *" or a macro like "@hourly", in UTC
+	Schedule string   `json:"schedule" toml:"Schedule"`
+	Command  []string `json:"command" toml:"Command"`
+	// CPU and Memory override the app's resources for the job's pod
+	CPU    string `json:"cpu,omitempty" toml:"CPU"`
+	Memory string `json:"memory,omitempty" toml:"Memory"`
 }
 
 type Env struct {
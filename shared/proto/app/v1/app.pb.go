@@ -81,6 +81,53 @@ func (AppType) EnumDescriptor() ([]byte, []int) {
 	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{0}
 }
 
+// order of historical log queries. live tails are always in arrival order.
+type LogOrder int32
+
+const (
+	LogOrder_LOG_ORDER_DESC LogOrder = 0
+	LogOrder_LOG_ORDER_ASC  LogOrder = 1
+)
+
+// Enum value maps for LogOrder.
+var (
+	LogOrder_name = map[int32]string{
+		0: "LOG_ORDER_DESC",
+		1: "LOG_ORDER_ASC",
+	}
+	LogOrder_value = map[string]int32{
+		"LOG_ORDER_DESC": 0,
+		"LOG_ORDER_ASC":  1,
+	}
+)
+
+func (x LogOrder) Enum() *LogOrder {
+	p := new(LogOrder)
+	*p = x
+	return p
+}
+
+func (x LogOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_shared_proto_app_v1_app_proto_enumTypes[1].Descriptor()
+}
+
+func (LogOrder) Type() protoreflect.EnumType {
+	return &file_shared_proto_app_v1_app_proto_enumTypes[1]
+}
+
+func (x LogOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogOrder.Descriptor instead.
+func (LogOrder) EnumDescriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{1}
+}
+
 type App struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -198,12 +245,14 @@ func (x *App) GetUpdatedAt() *timestamppb.Timestamp {
 }
 
 type CreateAppRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkspaceId   int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Type          AppType                `protobuf:"varint,4,opt,name=type,proto3,enum=loco.app.v1.AppType" json:"type,omitempty"`
-	Subdomain     string                 `protobuf:"bytes,5,opt,name=subdomain,proto3" json:"subdomain,omitempty"`
-	Domain        *string                `protobuf:"bytes,6,opt,name=domain,proto3,oneof" json:"domain,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type        AppType                `protobuf:"varint,4,opt,name=type,proto3,enum=loco.app.v1.AppType" json:"type,omitempty"`
+	Subdomain   string                 `protobuf:"bytes,5,opt,name=subdomain,proto3" json:"subdomain,omitempty"`
+	Domain      *string                `protobuf:"bytes,6,opt,name=domain,proto3,oneof" json:"domain,omitempty"`
+	// preferred cluster region for the workspace's first app. later apps follow the workspace.
+	Region        *string `protobuf:"bytes,7,opt,name=region,proto3,oneof" json:"region,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -273,6 +322,13 @@ func (x *CreateAppRequest) GetDomain() string {
 	return ""
 }
 
+func (x *CreateAppRequest) GetRegion() string {
+	if x != nil && x.Region != nil {
+		return *x.Region
+	}
+	return ""
+}
+
 type CreateAppResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	App           *App                   `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
@@ -702,8 +758,10 @@ func (x *UpdateAppResponse) GetApp() *App {
 }
 
 type DeleteAppRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// keep the data of the app's persistent volumes instead of deleting it with the app
+	RetainVolumes bool `protobuf:"varint,2,opt,name=retain_volumes,json=retainVolumes,proto3" json:"retain_volumes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -745,11 +803,20 @@ func (x *DeleteAppRequest) GetId() int64 {
 	return 0
 }
 
+func (x *DeleteAppRequest) GetRetainVolumes() bool {
+	if x != nil {
+		return x.RetainVolumes
+	}
+	return false
+}
+
 type DeleteAppResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// the PersistentVolumes kept with retain_volumes, an operator can bind them to a new claim
+	RetainedVolumes []string `protobuf:"bytes,2,rep,name=retained_volumes,json=retainedVolumes,proto3" json:"retained_volumes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteAppResponse) Reset() {
@@ -789,11 +856,21 @@ func (x *DeleteAppResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *DeleteAppResponse) GetRetainedVolumes() []string {
+	if x != nil {
+		return x.RetainedVolumes
+	}
+	return nil
+}
+
 // --- Subdomain ---
 type CheckSubdomainAvailabilityRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Subdomain     string                 `protobuf:"bytes,1,opt,name=subdomain,proto3" json:"subdomain,omitempty"`
-	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Subdomain string                 `protobuf:"bytes,1,opt,name=subdomain,proto3" json:"subdomain,omitempty"`
+	Domain    string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// the workspace the app would be created in, only its members may check. A subdomain already used by
+	// the workspace's apps is available to it, they share the hostname
+	WorkspaceId   *int64 `protobuf:"varint,3,opt,name=workspace_id,json=workspaceId,proto3,oneof" json:"workspace_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -842,6 +919,13 @@ func (x *CheckSubdomainAvailabilityRequest) GetDomain() string {
 	return ""
 }
 
+func (x *CheckSubdomainAvailabilityRequest) GetWorkspaceId() int64 {
+	if x != nil && x.WorkspaceId != nil {
+		return *x.WorkspaceId
+	}
+	return 0
+}
+
 type CheckSubdomainAvailabilityResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Available     bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
@@ -1006,28 +1090,35 @@ func (x *DeploymentStatus) GetErrorMessage() string {
 	return ""
 }
 
-type GetAppStatusResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	App               *App                   `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
-	CurrentDeployment *DeploymentStatus      `protobuf:"bytes,2,opt,name=current_deployment,json=currentDeployment,proto3" json:"current_deployment,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// a TCP or UDP port of the app
+type VolumeStatus struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	MountPath string                 `protobuf:"bytes,3,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"`
+	Size      string                 `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
+	// the most used by any of the app's pods, unset while no pod reports it
+	UsedBytes *int64 `protobuf:"varint,5,opt,name=used_bytes,json=usedBytes,proto3,oneof" json:"used_bytes,omitempty"`
+	// the space the volume's filesystem has, unset while no pod reports it
+	CapacityBytes *int64 `protobuf:"varint,6,opt,name=capacity_bytes,json=capacityBytes,proto3,oneof" json:"capacity_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAppStatusResponse) Reset() {
-	*x = GetAppStatusResponse{}
+func (x *VolumeStatus) Reset() {
+	*x = VolumeStatus{}
 	mi := &file_shared_proto_app_v1_app_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAppStatusResponse) String() string {
+func (x *VolumeStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAppStatusResponse) ProtoMessage() {}
+func (*VolumeStatus) ProtoMessage() {}
 
-func (x *GetAppStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *VolumeStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_shared_proto_app_v1_app_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1039,112 +1130,80 @@ func (x *GetAppStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAppStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetAppStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VolumeStatus.ProtoReflect.Descriptor instead.
+func (*VolumeStatus) Descriptor() ([]byte, []int) {
 	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetAppStatusResponse) GetApp() *App {
+func (x *VolumeStatus) GetName() string {
 	if x != nil {
-		return x.App
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *GetAppStatusResponse) GetCurrentDeployment() *DeploymentStatus {
+func (x *VolumeStatus) GetType() string {
 	if x != nil {
-		return x.CurrentDeployment
+		return x.Type
 	}
-	return nil
-}
-
-type StreamLogsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
-	Follow        *bool                  `protobuf:"varint,3,opt,name=follow,proto3,oneof" json:"follow,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *StreamLogsRequest) Reset() {
-	*x = StreamLogsRequest{}
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[18]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *StreamLogsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*StreamLogsRequest) ProtoMessage() {}
-
-func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[18]
+func (x *VolumeStatus) GetMountPath() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MountPath
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
-func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{18}
+	return ""
 }
 
-func (x *StreamLogsRequest) GetAppId() int64 {
+func (x *VolumeStatus) GetSize() string {
 	if x != nil {
-		return x.AppId
+		return x.Size
 	}
-	return 0
+	return ""
 }
 
-func (x *StreamLogsRequest) GetLimit() int32 {
-	if x != nil && x.Limit != nil {
-		return *x.Limit
+func (x *VolumeStatus) GetUsedBytes() int64 {
+	if x != nil && x.UsedBytes != nil {
+		return *x.UsedBytes
 	}
 	return 0
 }
 
-func (x *StreamLogsRequest) GetFollow() bool {
-	if x != nil && x.Follow != nil {
-		return *x.Follow
+func (x *VolumeStatus) GetCapacityBytes() int64 {
+	if x != nil && x.CapacityBytes != nil {
+		return *x.CapacityBytes
 	}
-	return false
+	return 0
 }
 
-type LogEntry struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PodName       string                 `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
-	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Container     string                 `protobuf:"bytes,3,opt,name=container,proto3" json:"container,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Log           string                 `protobuf:"bytes,5,opt,name=log,proto3" json:"log,omitempty"`
-	Level         string                 `protobuf:"bytes,6,opt,name=level,proto3" json:"level,omitempty"`
+type Endpoint struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Protocol string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Port     int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Public   bool                   `protobuf:"varint,4,opt,name=public,proto3" json:"public,omitempty"`
+	// host:port to connect to, the gateway listener for public ports and the app's Service otherwise
+	Address       string `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogEntry) Reset() {
-	*x = LogEntry{}
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[19]
+func (x *Endpoint) Reset() {
+	*x = Endpoint{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogEntry) String() string {
+func (x *Endpoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogEntry) ProtoMessage() {}
+func (*Endpoint) ProtoMessage() {}
 
-func (x *LogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[19]
+func (x *Endpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1155,79 +1214,74 @@ func (x *LogEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
-func (*LogEntry) Descriptor() ([]byte, []int) {
-	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{19}
-}
-
-func (x *LogEntry) GetPodName() string {
-	if x != nil {
-		return x.PodName
-	}
-	return ""
+// Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *LogEntry) GetNamespace() string {
+func (x *Endpoint) GetName() string {
 	if x != nil {
-		return x.Namespace
+		return x.Name
 	}
 	return ""
 }
 
-func (x *LogEntry) GetContainer() string {
+func (x *Endpoint) GetProtocol() string {
 	if x != nil {
-		return x.Container
+		return x.Protocol
 	}
 	return ""
 }
 
-func (x *LogEntry) GetTimestamp() *timestamppb.Timestamp {
+func (x *Endpoint) GetPort() int32 {
 	if x != nil {
-		return x.Timestamp
+		return x.Port
 	}
-	return nil
+	return 0
 }
 
-func (x *LogEntry) GetLog() string {
+func (x *Endpoint) GetPublic() bool {
 	if x != nil {
-		return x.Log
+		return x.Public
 	}
-	return ""
+	return false
 }
 
-func (x *LogEntry) GetLevel() string {
+func (x *Endpoint) GetAddress() string {
 	if x != nil {
-		return x.Level
+		return x.Address
 	}
 	return ""
 }
 
-type Event struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	PodName       string                 `protobuf:"bytes,5,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+type GetAppStatusResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	App               *App                   `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
+	CurrentDeployment *DeploymentStatus      `protobuf:"bytes,2,opt,name=current_deployment,json=currentDeployment,proto3" json:"current_deployment,omitempty"`
+	Endpoints         []*Endpoint            `protobuf:"bytes,3,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// scaled to zero for being idle, the next request wakes it
+	Sleeping      bool                   `protobuf:"varint,4,opt,name=sleeping,proto3" json:"sleeping,omitempty"`
+	SleptAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=slept_at,json=sleptAt,proto3" json:"slept_at,omitempty"`
+	Volumes       []*VolumeStatus        `protobuf:"bytes,6,rep,name=volumes,proto3" json:"volumes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[20]
+func (x *GetAppStatusResponse) Reset() {
+	*x = GetAppStatusResponse{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Event) String() string {
+func (x *GetAppStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*GetAppStatusResponse) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[20]
+func (x *GetAppStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1238,69 +1292,98 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
-	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetAppStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetAppStatusResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *Event) GetTimestamp() *timestamppb.Timestamp {
+func (x *GetAppStatusResponse) GetApp() *App {
 	if x != nil {
-		return x.Timestamp
+		return x.App
 	}
 	return nil
 }
 
-func (x *Event) GetReason() string {
+func (x *GetAppStatusResponse) GetCurrentDeployment() *DeploymentStatus {
 	if x != nil {
-		return x.Reason
+		return x.CurrentDeployment
 	}
-	return ""
+	return nil
 }
 
-func (x *Event) GetMessage() string {
+func (x *GetAppStatusResponse) GetEndpoints() []*Endpoint {
 	if x != nil {
-		return x.Message
+		return x.Endpoints
 	}
-	return ""
+	return nil
 }
 
-func (x *Event) GetType() string {
+func (x *GetAppStatusResponse) GetSleeping() bool {
 	if x != nil {
-		return x.Type
+		return x.Sleeping
 	}
-	return ""
+	return false
 }
 
-func (x *Event) GetPodName() string {
+func (x *GetAppStatusResponse) GetSleptAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.PodName
+		return x.SleptAt
 	}
-	return ""
+	return nil
 }
 
-type GetEventsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+func (x *GetAppStatusResponse) GetVolumes() []*VolumeStatus {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+// without follow, logs are read from the log store, so entries from crashed and replaced pods are included.
+// with follow or previous, live pods are tailed and until and order don't apply.
+type StreamLogsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	AppId  int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Limit  *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Follow *bool                  `protobuf:"varint,3,opt,name=follow,proto3,oneof" json:"follow,omitempty"`
+	// defaults to 24h before until
+	Since *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=since,proto3,oneof" json:"since,omitempty"`
+	// defaults to now
+	Until *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=until,proto3,oneof" json:"until,omitempty"`
+	// case insensitive substring of the log line
+	Query *string `protobuf:"bytes,6,opt,name=query,proto3,oneof" json:"query,omitempty"`
+	// minimum level: debug, info, warn, error or fatal
+	Level *string  `protobuf:"bytes,7,opt,name=level,proto3,oneof" json:"level,omitempty"`
+	Order LogOrder `protobuf:"varint,8,opt,name=order,proto3,enum=loco.app.v1.LogOrder" json:"order,omitempty"`
+	// regex matched against pod names
+	Pod *string `protobuf:"bytes,9,opt,name=pod,proto3,oneof" json:"pod,omitempty"`
+	// only these containers, all when empty
+	Containers []string `protobuf:"bytes,10,rep,name=containers,proto3" json:"containers,omitempty"`
+	// regex a line must match
+	Grep *string `protobuf:"bytes,11,opt,name=grep,proto3,oneof" json:"grep,omitempty"`
+	// regex a line must not match
+	Exclude *string `protobuf:"bytes,12,opt,name=exclude,proto3,oneof" json:"exclude,omitempty"`
+	// logs of each pod's previous container, e.g. after a crash. always read from live pods.
+	Previous      *bool `protobuf:"varint,13,opt,name=previous,proto3,oneof" json:"previous,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEventsRequest) Reset() {
-	*x = GetEventsRequest{}
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[21]
+func (x *StreamLogsRequest) Reset() {
+	*x = StreamLogsRequest{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEventsRequest) String() string {
+func (x *StreamLogsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventsRequest) ProtoMessage() {}
+func (*StreamLogsRequest) ProtoMessage() {}
 
-func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[21]
+func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1311,47 +1394,1107 @@ func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventsRequest.ProtoReflect.Descriptor instead.
-func (*GetEventsRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GetEventsRequest) GetAppId() int64 {
+func (x *StreamLogsRequest) GetAppId() int64 {
 	if x != nil {
 		return x.AppId
 	}
 	return 0
 }
 
-func (x *GetEventsRequest) GetLimit() int32 {
+func (x *StreamLogsRequest) GetLimit() int32 {
 	if x != nil && x.Limit != nil {
 		return *x.Limit
 	}
 	return 0
 }
 
-type GetEventsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *StreamLogsRequest) GetFollow() bool {
+	if x != nil && x.Follow != nil {
+		return *x.Follow
+	}
+	return false
 }
 
-func (x *GetEventsResponse) Reset() {
-	*x = GetEventsResponse{}
+func (x *StreamLogsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *StreamLogsRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *StreamLogsRequest) GetQuery() string {
+	if x != nil && x.Query != nil {
+		return *x.Query
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetLevel() string {
+	if x != nil && x.Level != nil {
+		return *x.Level
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetOrder() LogOrder {
+	if x != nil {
+		return x.Order
+	}
+	return LogOrder_LOG_ORDER_DESC
+}
+
+func (x *StreamLogsRequest) GetPod() string {
+	if x != nil && x.Pod != nil {
+		return *x.Pod
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetContainers() []string {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *StreamLogsRequest) GetGrep() string {
+	if x != nil && x.Grep != nil {
+		return *x.Grep
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetExclude() string {
+	if x != nil && x.Exclude != nil {
+		return *x.Exclude
+	}
+	return ""
+}
+
+func (x *StreamLogsRequest) GetPrevious() bool {
+	if x != nil && x.Previous != nil {
+		return *x.Previous
+	}
+	return false
+}
+
+type LogEntry struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PodName   string                 `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Container string                 `protobuf:"bytes,3,opt,name=container,proto3" json:"container,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Log       string                 `protobuf:"bytes,5,opt,name=log,proto3" json:"log,omitempty"`
+	// normalized to DEBUG, INFO, WARN, ERROR or FATAL
+	Level string `protobuf:"bytes,6,opt,name=level,proto3" json:"level,omitempty"`
+	// keys parsed from JSON or logfmt lines of apps with structured logging, log holds their message
+	Fields        map[string]string `protobuf:"bytes,7,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEntry) ProtoMessage() {}
+
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LogEntry) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *LogEntry) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *LogEntry) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *LogEntry) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *LogEntry) GetLog() string {
+	if x != nil {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *LogEntry) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LogEntry) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// live pod values come from metrics-server, series from the metrics store
+type GetAppMetricsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	AppId int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// defaults to 1h before until
+	Since *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3,oneof" json:"since,omitempty"`
+	// defaults to now
+	Until *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3,oneof" json:"until,omitempty"`
+	// bucket width of the series, widened when the range would yield too many points
+	StepSeconds   *int32 `protobuf:"varint,4,opt,name=step_seconds,json=stepSeconds,proto3,oneof" json:"step_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppMetricsRequest) Reset() {
+	*x = GetAppMetricsRequest{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppMetricsRequest) ProtoMessage() {}
+
+func (x *GetAppMetricsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_shared_proto_app_v1_app_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetAppMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetAppMetricsRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *GetAppMetricsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetAppMetricsRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *GetAppMetricsRequest) GetStepSeconds() int32 {
+	if x != nil && x.StepSeconds != nil {
+		return *x.StepSeconds
+	}
+	return 0
+}
+
+type MetricPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricPoint) Reset() {
+	*x = MetricPoint{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricPoint) ProtoMessage() {}
+
+func (x *MetricPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricPoint.ProtoReflect.Descriptor instead.
+func (*MetricPoint) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MetricPoint) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *MetricPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// name is one of cpu_millicores, memory_bytes, restarts, requests_per_second or latency_p95_ms.
+// series without a pod are totals across the app's pods.
+type MetricSeries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Pod           *string                `protobuf:"bytes,2,opt,name=pod,proto3,oneof" json:"pod,omitempty"`
+	Hostname      *string                `protobuf:"bytes,3,opt,name=hostname,proto3,oneof" json:"hostname,omitempty"`
+	Points        []*MetricPoint         `protobuf:"bytes,4,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricSeries) Reset() {
+	*x = MetricSeries{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricSeries) ProtoMessage() {}
+
+func (x *MetricSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricSeries.ProtoReflect.Descriptor instead.
+func (*MetricSeries) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MetricSeries) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MetricSeries) GetPod() string {
+	if x != nil && x.Pod != nil {
+		return *x.Pod
+	}
+	return ""
+}
+
+func (x *MetricSeries) GetHostname() string {
+	if x != nil && x.Hostname != nil {
+		return *x.Hostname
+	}
+	return ""
+}
+
+func (x *MetricSeries) GetPoints() []*MetricPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type PodMetrics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Pod   string                 `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	Phase string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	Ready bool                   `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	// unset when metrics-server has no sample for the pod
+	CpuMillicores *int64 `protobuf:"varint,4,opt,name=cpu_millicores,json=cpuMillicores,proto3,oneof" json:"cpu_millicores,omitempty"`
+	MemoryBytes   *int64 `protobuf:"varint,5,opt,name=memory_bytes,json=memoryBytes,proto3,oneof" json:"memory_bytes,omitempty"`
+	Restarts      int32  `protobuf:"varint,6,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PodMetrics) Reset() {
+	*x = PodMetrics{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PodMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PodMetrics) ProtoMessage() {}
+
+func (x *PodMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PodMetrics.ProtoReflect.Descriptor instead.
+func (*PodMetrics) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PodMetrics) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *PodMetrics) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *PodMetrics) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *PodMetrics) GetCpuMillicores() int64 {
+	if x != nil && x.CpuMillicores != nil {
+		return *x.CpuMillicores
+	}
+	return 0
+}
+
+func (x *PodMetrics) GetMemoryBytes() int64 {
+	if x != nil && x.MemoryBytes != nil {
+		return *x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *PodMetrics) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+type GetAppMetricsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Pods  []*PodMetrics          `protobuf:"bytes,1,rep,name=pods,proto3" json:"pods,omitempty"`
+	// sums over pods
+	CpuMillicores int64 `protobuf:"varint,2,opt,name=cpu_millicores,json=cpuMillicores,proto3" json:"cpu_millicores,omitempty"`
+	MemoryBytes   int64 `protobuf:"varint,3,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+	Restarts      int32 `protobuf:"varint,4,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	// empty when no metrics store is configured
+	Series        []*MetricSeries        `protobuf:"bytes,5,rep,name=series,proto3" json:"series,omitempty"`
+	StepSeconds   int32                  `protobuf:"varint,6,opt,name=step_seconds,json=stepSeconds,proto3" json:"step_seconds,omitempty"`
+	CollectedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=collected_at,json=collectedAt,proto3" json:"collected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppMetricsResponse) Reset() {
+	*x = GetAppMetricsResponse{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppMetricsResponse) ProtoMessage() {}
+
+func (x *GetAppMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppMetricsResponse.ProtoReflect.Descriptor instead.
+func (*GetAppMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetAppMetricsResponse) GetPods() []*PodMetrics {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
+func (x *GetAppMetricsResponse) GetCpuMillicores() int64 {
+	if x != nil {
+		return x.CpuMillicores
+	}
+	return 0
+}
+
+func (x *GetAppMetricsResponse) GetMemoryBytes() int64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *GetAppMetricsResponse) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *GetAppMetricsResponse) GetSeries() []*MetricSeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *GetAppMetricsResponse) GetStepSeconds() int32 {
+	if x != nil {
+		return x.StepSeconds
+	}
+	return 0
+}
+
+func (x *GetAppMetricsResponse) GetCollectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CollectedAt
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	PodName       string                 `protobuf:"bytes,5,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Event) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Event) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Event) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+type GetEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventsRequest) Reset() {
+	*x = GetEventsRequest{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsRequest) ProtoMessage() {}
+
+func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetEventsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetEventsRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *GetEventsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type GetEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventsResponse) Reset() {
+	*x = GetEventsResponse{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsResponse) ProtoMessage() {}
+
+func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetEventsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetEventsResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ScaleAppRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Replicas      *int32                 `protobuf:"varint,2,opt,name=replicas,proto3,oneof" json:"replicas,omitempty"`
+	Cpu           *string                `protobuf:"bytes,3,opt,name=cpu,proto3,oneof" json:"cpu,omitempty"`
+	Memory        *string                `protobuf:"bytes,4,opt,name=memory,proto3,oneof" json:"memory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScaleAppRequest) Reset() {
+	*x = ScaleAppRequest{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScaleAppRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScaleAppRequest) ProtoMessage() {}
+
+func (x *ScaleAppRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScaleAppRequest.ProtoReflect.Descriptor instead.
+func (*ScaleAppRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ScaleAppRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *ScaleAppRequest) GetReplicas() int32 {
+	if x != nil && x.Replicas != nil {
+		return *x.Replicas
+	}
+	return 0
+}
+
+func (x *ScaleAppRequest) GetCpu() string {
+	if x != nil && x.Cpu != nil {
+		return *x.Cpu
+	}
+	return ""
+}
+
+func (x *ScaleAppRequest) GetMemory() string {
+	if x != nil && x.Memory != nil {
+		return *x.Memory
+	}
+	return ""
+}
+
+type ScaleAppResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployment    *DeploymentStatus      `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScaleAppResponse) Reset() {
+	*x = ScaleAppResponse{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScaleAppResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScaleAppResponse) ProtoMessage() {}
+
+func (x *ScaleAppResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScaleAppResponse.ProtoReflect.Descriptor instead.
+func (*ScaleAppResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ScaleAppResponse) GetDeployment() *DeploymentStatus {
+	if x != nil {
+		return x.Deployment
+	}
+	return nil
+}
+
+type UpdateAppEnvRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Env           map[string]string      `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAppEnvRequest) Reset() {
+	*x = UpdateAppEnvRequest{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAppEnvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAppEnvRequest) ProtoMessage() {}
+
+func (x *UpdateAppEnvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAppEnvRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAppEnvRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateAppEnvRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *UpdateAppEnvRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+type UpdateAppEnvResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployment    *DeploymentStatus      `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAppEnvResponse) Reset() {
+	*x = UpdateAppEnvResponse{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAppEnvResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAppEnvResponse) ProtoMessage() {}
+
+func (x *UpdateAppEnvResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAppEnvResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAppEnvResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateAppEnvResponse) GetDeployment() *DeploymentStatus {
+	if x != nil {
+		return x.Deployment
+	}
+	return nil
+}
+
+type RestartAppRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	AppId int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// restarts only this pod, the rest of the app keeps serving
+	Pod *string `protobuf:"bytes,2,opt,name=pod,proto3,oneof" json:"pod,omitempty"`
+	// keep reporting until the restarted pods are ready
+	Wait          bool `protobuf:"varint,3,opt,name=wait,proto3" json:"wait,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestartAppRequest) Reset() {
+	*x = RestartAppRequest{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartAppRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartAppRequest) ProtoMessage() {}
+
+func (x *RestartAppRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartAppRequest.ProtoReflect.Descriptor instead.
+func (*RestartAppRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RestartAppRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *RestartAppRequest) GetPod() string {
+	if x != nil && x.Pod != nil {
+		return *x.Pod
+	}
+	return ""
+}
+
+func (x *RestartAppRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
+// reported like a deployment's events. Without wait the last status is in_progress,
+// otherwise succeeded or failed.
+type RestartAppEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ErrorMessage  *string                `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestartAppEvent) Reset() {
+	*x = RestartAppEvent{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartAppEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartAppEvent) ProtoMessage() {}
+
+func (x *RestartAppEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartAppEvent.ProtoReflect.Descriptor instead.
+func (*RestartAppEvent) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RestartAppEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RestartAppEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RestartAppEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *RestartAppEvent) GetErrorMessage() string {
+	if x != nil && x.ErrorMessage != nil {
+		return *x.ErrorMessage
+	}
+	return ""
+}
+
+type RunCommandRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppId   int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Command []string               `protobuf:"bytes,2,rep,name=command,proto3" json:"command,omitempty"`
+	// override the app's resources for the command's pod
+	Cpu           *string `protobuf:"bytes,3,opt,name=cpu,proto3,oneof" json:"cpu,omitempty"`
+	Memory        *string `protobuf:"bytes,4,opt,name=memory,proto3,oneof" json:"memory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunCommandRequest) Reset() {
+	*x = RunCommandRequest{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEventsResponse) String() string {
+func (x *RunCommandRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEventsResponse) ProtoMessage() {}
+func (*RunCommandRequest) ProtoMessage() {}
 
-func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[22]
+func (x *RunCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1362,43 +2505,67 @@ func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
-func (*GetEventsResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use RunCommandRequest.ProtoReflect.Descriptor instead.
+func (*RunCommandRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *GetEventsResponse) GetEvents() []*Event {
+func (x *RunCommandRequest) GetAppId() int64 {
 	if x != nil {
-		return x.Events
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *RunCommandRequest) GetCommand() []string {
+	if x != nil {
+		return x.Command
 	}
 	return nil
 }
 
-type ScaleAppRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	Replicas      *int32                 `protobuf:"varint,2,opt,name=replicas,proto3,oneof" json:"replicas,omitempty"`
-	Cpu           *string                `protobuf:"bytes,3,opt,name=cpu,proto3,oneof" json:"cpu,omitempty"`
-	Memory        *string                `protobuf:"bytes,4,opt,name=memory,proto3,oneof" json:"memory,omitempty"`
+func (x *RunCommandRequest) GetCpu() string {
+	if x != nil && x.Cpu != nil {
+		return *x.Cpu
+	}
+	return ""
+}
+
+func (x *RunCommandRequest) GetMemory() string {
+	if x != nil && x.Memory != nil {
+		return *x.Memory
+	}
+	return ""
+}
+
+// the first message names the Job running the command, then each carries a log line,
+// the last one has the exit code
+type RunCommandResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Job      *string                `protobuf:"bytes,1,opt,name=job,proto3,oneof" json:"job,omitempty"`
+	Log      *LogEntry              `protobuf:"bytes,2,opt,name=log,proto3,oneof" json:"log,omitempty"`
+	ExitCode *int32                 `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3,oneof" json:"exit_code,omitempty"`
+	// why the command was stopped, like OOMKilled or DeadlineExceeded
+	Reason        *string `protobuf:"bytes,4,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ScaleAppRequest) Reset() {
-	*x = ScaleAppRequest{}
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[23]
+func (x *RunCommandResponse) Reset() {
+	*x = RunCommandResponse{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScaleAppRequest) String() string {
+func (x *RunCommandResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScaleAppRequest) ProtoMessage() {}
+func (*RunCommandResponse) ProtoMessage() {}
 
-func (x *ScaleAppRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[23]
+func (x *RunCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1409,61 +2576,151 @@ func (x *ScaleAppRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScaleAppRequest.ProtoReflect.Descriptor instead.
-func (*ScaleAppRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use RunCommandResponse.ProtoReflect.Descriptor instead.
+func (*RunCommandResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ScaleAppRequest) GetAppId() int64 {
+func (x *RunCommandResponse) GetJob() string {
+	if x != nil && x.Job != nil {
+		return *x.Job
+	}
+	return ""
+}
+
+func (x *RunCommandResponse) GetLog() *LogEntry {
 	if x != nil {
-		return x.AppId
+		return x.Log
 	}
-	return 0
+	return nil
 }
 
-func (x *ScaleAppRequest) GetReplicas() int32 {
-	if x != nil && x.Replicas != nil {
-		return *x.Replicas
+func (x *RunCommandResponse) GetExitCode() int32 {
+	if x != nil && x.ExitCode != nil {
+		return *x.ExitCode
 	}
 	return 0
 }
 
-func (x *ScaleAppRequest) GetCpu() string {
-	if x != nil && x.Cpu != nil {
-		return *x.Cpu
+func (x *RunCommandResponse) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
 	}
 	return ""
 }
 
-func (x *ScaleAppRequest) GetMemory() string {
-	if x != nil && x.Memory != nil {
-		return *x.Memory
+// the first message picks the pod and command, the ones after carry stdin and terminal resizes.
+// Closing the stream's sending side closes the command's stdin.
+type ExecRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	AppId int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// defaults to a running pod of the app
+	Pod     *string  `protobuf:"bytes,2,opt,name=pod,proto3,oneof" json:"pod,omitempty"`
+	Command []string `protobuf:"bytes,3,rep,name=command,proto3" json:"command,omitempty"`
+	// runs the command in a terminal, its stderr then comes through stdout
+	Tty   bool   `protobuf:"varint,4,opt,name=tty,proto3" json:"tty,omitempty"`
+	Stdin []byte `protobuf:"bytes,5,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// sent with the first message and whenever the terminal is resized
+	Size          *TerminalSize `protobuf:"bytes,6,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecRequest) Reset() {
+	*x = ExecRequest{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecRequest) ProtoMessage() {}
+
+func (x *ExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecRequest.ProtoReflect.Descriptor instead.
+func (*ExecRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ExecRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *ExecRequest) GetPod() string {
+	if x != nil && x.Pod != nil {
+		return *x.Pod
 	}
 	return ""
 }
 
-type ScaleAppResponse struct {
+func (x *ExecRequest) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
+}
+
+func (x *ExecRequest) GetStdin() []byte {
+	if x != nil {
+		return x.Stdin
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetSize() *TerminalSize {
+	if x != nil {
+		return x.Size
+	}
+	return nil
+}
+
+type TerminalSize struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Deployment    *DeploymentStatus      `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	Width         uint32                 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height        uint32                 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ScaleAppResponse) Reset() {
-	*x = ScaleAppResponse{}
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[24]
+func (x *TerminalSize) Reset() {
+	*x = TerminalSize{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScaleAppResponse) String() string {
+func (x *TerminalSize) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScaleAppResponse) ProtoMessage() {}
+func (*TerminalSize) ProtoMessage() {}
 
-func (x *ScaleAppResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[24]
+func (x *TerminalSize) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1474,41 +2731,51 @@ func (x *ScaleAppResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScaleAppResponse.ProtoReflect.Descriptor instead.
-func (*ScaleAppResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use TerminalSize.ProtoReflect.Descriptor instead.
+func (*TerminalSize) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *ScaleAppResponse) GetDeployment() *DeploymentStatus {
+func (x *TerminalSize) GetWidth() uint32 {
 	if x != nil {
-		return x.Deployment
+		return x.Width
 	}
-	return nil
+	return 0
 }
 
-type UpdateAppEnvRequest struct {
+func (x *TerminalSize) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// the first message names the pod the command runs in, then each carries output, the last one has the exit code
+type ExecResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	Env           map[string]string      `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Pod           *string                `protobuf:"bytes,1,opt,name=pod,proto3,oneof" json:"pod,omitempty"`
+	Stdout        []byte                 `protobuf:"bytes,2,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr        []byte                 `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	ExitCode      *int32                 `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3,oneof" json:"exit_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAppEnvRequest) Reset() {
-	*x = UpdateAppEnvRequest{}
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[25]
+func (x *ExecResponse) Reset() {
+	*x = ExecResponse{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAppEnvRequest) String() string {
+func (x *ExecResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAppEnvRequest) ProtoMessage() {}
+func (*ExecResponse) ProtoMessage() {}
 
-func (x *UpdateAppEnvRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[25]
+func (x *ExecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1519,47 +2786,156 @@ func (x *UpdateAppEnvRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAppEnvRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAppEnvRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ExecResponse.ProtoReflect.Descriptor instead.
+func (*ExecResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *UpdateAppEnvRequest) GetAppId() int64 {
+func (x *ExecResponse) GetPod() string {
+	if x != nil && x.Pod != nil {
+		return *x.Pod
+	}
+	return ""
+}
+
+func (x *ExecResponse) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *ExecResponse) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *ExecResponse) GetExitCode() int32 {
+	if x != nil && x.ExitCode != nil {
+		return *x.ExitCode
+	}
+	return 0
+}
+
+// the first message picks the pod and port. The ones after carry the data of the client's connections,
+// each opening with its first message.
+type PortForwardRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	AppId int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// defaults to a running pod of the app
+	Pod  *string `protobuf:"bytes,2,opt,name=pod,proto3,oneof" json:"pod,omitempty"`
+	Port int32   `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	// numbered from 1 by the client, each new connection with a higher number
+	ConnectionId uint32 `protobuf:"varint,4,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Data         []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	// the client won't send more data on the connection
+	Close         bool `protobuf:"varint,6,opt,name=close,proto3" json:"close,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortForwardRequest) Reset() {
+	*x = PortForwardRequest{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortForwardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortForwardRequest) ProtoMessage() {}
+
+func (x *PortForwardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortForwardRequest.ProtoReflect.Descriptor instead.
+func (*PortForwardRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PortForwardRequest) GetAppId() int64 {
 	if x != nil {
 		return x.AppId
 	}
 	return 0
 }
 
-func (x *UpdateAppEnvRequest) GetEnv() map[string]string {
+func (x *PortForwardRequest) GetPod() string {
+	if x != nil && x.Pod != nil {
+		return *x.Pod
+	}
+	return ""
+}
+
+func (x *PortForwardRequest) GetPort() int32 {
 	if x != nil {
-		return x.Env
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PortForwardRequest) GetConnectionId() uint32 {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return 0
+}
+
+func (x *PortForwardRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
-type UpdateAppEnvResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Deployment    *DeploymentStatus      `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+func (x *PortForwardRequest) GetClose() bool {
+	if x != nil {
+		return x.Close
+	}
+	return false
+}
+
+// the first message names the pod being forwarded to, then each carries the data of a connection or its end
+type PortForwardResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Pod          *string                `protobuf:"bytes,1,opt,name=pod,proto3,oneof" json:"pod,omitempty"`
+	ConnectionId uint32                 `protobuf:"varint,2,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Data         []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// the pod closed the connection, or it couldn't be opened
+	Close         bool    `protobuf:"varint,4,opt,name=close,proto3" json:"close,omitempty"`
+	Error         *string `protobuf:"bytes,5,opt,name=error,proto3,oneof" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAppEnvResponse) Reset() {
-	*x = UpdateAppEnvResponse{}
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[26]
+func (x *PortForwardResponse) Reset() {
+	*x = PortForwardResponse{}
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAppEnvResponse) String() string {
+func (x *PortForwardResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAppEnvResponse) ProtoMessage() {}
+func (*PortForwardResponse) ProtoMessage() {}
 
-func (x *UpdateAppEnvResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_app_v1_app_proto_msgTypes[26]
+func (x *PortForwardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_app_v1_app_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1570,18 +2946,46 @@ func (x *UpdateAppEnvResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAppEnvResponse.ProtoReflect.Descriptor instead.
-func (*UpdateAppEnvResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use PortForwardResponse.ProtoReflect.Descriptor instead.
+func (*PortForwardResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_app_v1_app_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *UpdateAppEnvResponse) GetDeployment() *DeploymentStatus {
+func (x *PortForwardResponse) GetPod() string {
+	if x != nil && x.Pod != nil {
+		return *x.Pod
+	}
+	return ""
+}
+
+func (x *PortForwardResponse) GetConnectionId() uint32 {
 	if x != nil {
-		return x.Deployment
+		return x.ConnectionId
+	}
+	return 0
+}
+
+func (x *PortForwardResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
+func (x *PortForwardResponse) GetClose() bool {
+	if x != nil {
+		return x.Close
+	}
+	return false
+}
+
+func (x *PortForwardResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
 var File_shared_proto_app_v1_app_proto protoreflect.FileDescriptor
 
 const file_shared_proto_app_v1_app_proto_rawDesc = "" +
@@ -1600,14 +3004,16 @@ const file_shared_proto_app_v1_app_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb9\x01\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe1\x01\n" +
 	"\x10CreateAppRequest\x12!\n" +
 	"\fworkspace_id\x18\x01 \x01(\x03R\vworkspaceId\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12(\n" +
 	"\x04type\x18\x04 \x01(\x0e2\x14.loco.app.v1.AppTypeR\x04type\x12\x1c\n" +
 	"\tsubdomain\x18\x05 \x01(\tR\tsubdomain\x12\x1b\n" +
-	"\x06domain\x18\x06 \x01(\tH\x00R\x06domain\x88\x01\x01B\t\n" +
-	"\a_domain\"7\n" +
+	"\x06domain\x18\x06 \x01(\tH\x00R\x06domain\x88\x01\x01\x12\x1b\n" +
+	"\x06region\x18\a \x01(\tH\x01R\x06region\x88\x01\x01B\t\n" +
+	"\a_domainB\t\n" +
+	"\a_region\"7\n" +
 	"\x11CreateAppResponse\x12\"\n" +
 	"\x03app\x18\x01 \x01(\v2\x10.loco.app.v1.AppR\x03app\"\x1f\n" +
 	"\rGetAppRequest\x12\x0e\n" +
@@ -1633,14 +3039,18 @@ const file_shared_proto_app_v1_app_proto_rawDesc = "" +
 	"_subdomainB\t\n" +
 	"\a_domain\"7\n" +
 	"\x11UpdateAppResponse\x12\"\n" +
-	"\x03app\x18\x01 \x01(\v2\x10.loco.app.v1.AppR\x03app\"\"\n" +
+	"\x03app\x18\x01 \x01(\v2\x10.loco.app.v1.AppR\x03app\"I\n" +
 	"\x10DeleteAppRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"-\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12%\n" +
+	"\x0eretain_volumes\x18\x02 \x01(\bR\rretainVolumes\"X\n" +
 	"\x11DeleteAppResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"Y\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12)\n" +
+	"\x10retained_volumes\x18\x02 \x03(\tR\x0fretainedVolumes\"\x92\x01\n" +
 	"!CheckSubdomainAvailabilityRequest\x12\x1c\n" +
 	"\tsubdomain\x18\x01 \x01(\tR\tsubdomain\x12\x16\n" +
-	"\x06domain\x18\x02 \x01(\tR\x06domain\"B\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12&\n" +
+	"\fworkspace_id\x18\x03 \x01(\x03H\x00R\vworkspaceId\x88\x01\x01B\x0f\n" +
+	"\r_workspace_id\"B\n" +
 	"\"CheckSubdomainAvailabilityResponse\x12\x1c\n" +
 	"\tavailable\x18\x01 \x01(\bR\tavailable\",\n" +
 	"\x13GetAppStatusRequest\x12\x15\n" +
@@ -1653,23 +3063,106 @@ const file_shared_proto_app_v1_app_proto_rawDesc = "" +
 	"\rerror_message\x18\x05 \x01(\tH\x01R\ferrorMessage\x88\x01\x01B\n" +
 	"\n" +
 	"\b_messageB\x10\n" +
-	"\x0e_error_message\"\x88\x01\n" +
+	"\x0e_error_message\"\xdb\x01\n" +
+	"\fVolumeStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"mount_path\x18\x03 \x01(\tR\tmountPath\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\tR\x04size\x12\"\n" +
+	"\n" +
+	"used_bytes\x18\x05 \x01(\x03H\x00R\tusedBytes\x88\x01\x01\x12*\n" +
+	"\x0ecapacity_bytes\x18\x06 \x01(\x03H\x01R\rcapacityBytes\x88\x01\x01B\r\n" +
+	"\v_used_bytesB\x11\n" +
+	"\x0f_capacity_bytes\"\x80\x01\n" +
+	"\bEndpoint\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x16\n" +
+	"\x06public\x18\x04 \x01(\bR\x06public\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\"\xc5\x02\n" +
 	"\x14GetAppStatusResponse\x12\"\n" +
 	"\x03app\x18\x01 \x01(\v2\x10.loco.app.v1.AppR\x03app\x12L\n" +
-	"\x12current_deployment\x18\x02 \x01(\v2\x1d.loco.app.v1.DeploymentStatusR\x11currentDeployment\"w\n" +
+	"\x12current_deployment\x18\x02 \x01(\v2\x1d.loco.app.v1.DeploymentStatusR\x11currentDeployment\x123\n" +
+	"\tendpoints\x18\x03 \x03(\v2\x15.loco.app.v1.EndpointR\tendpoints\x12\x1a\n" +
+	"\bsleeping\x18\x04 \x01(\bR\bsleeping\x125\n" +
+	"\bslept_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\asleptAt\x123\n" +
+	"\avolumes\x18\x06 \x03(\v2\x19.loco.app.v1.VolumeStatusR\avolumes\"\xaa\x04\n" +
 	"\x11StreamLogsRequest\x12\x15\n" +
 	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x19\n" +
 	"\x05limit\x18\x02 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12\x1b\n" +
-	"\x06follow\x18\x03 \x01(\bH\x01R\x06follow\x88\x01\x01B\b\n" +
+	"\x06follow\x18\x03 \x01(\bH\x01R\x06follow\x88\x01\x01\x125\n" +
+	"\x05since\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x05since\x88\x01\x01\x125\n" +
+	"\x05until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\x05until\x88\x01\x01\x12\x19\n" +
+	"\x05query\x18\x06 \x01(\tH\x04R\x05query\x88\x01\x01\x12\x19\n" +
+	"\x05level\x18\a \x01(\tH\x05R\x05level\x88\x01\x01\x12+\n" +
+	"\x05order\x18\b \x01(\x0e2\x15.loco.app.v1.LogOrderR\x05order\x12\x15\n" +
+	"\x03pod\x18\t \x01(\tH\x06R\x03pod\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"containers\x18\n" +
+	" \x03(\tR\n" +
+	"containers\x12\x17\n" +
+	"\x04grep\x18\v \x01(\tH\aR\x04grep\x88\x01\x01\x12\x1d\n" +
+	"\aexclude\x18\f \x01(\tH\bR\aexclude\x88\x01\x01\x12\x1f\n" +
+	"\bprevious\x18\r \x01(\bH\tR\bprevious\x88\x01\x01B\b\n" +
 	"\x06_limitB\t\n" +
-	"\a_follow\"\xc3\x01\n" +
+	"\a_followB\b\n" +
+	"\x06_sinceB\b\n" +
+	"\x06_untilB\b\n" +
+	"\x06_queryB\b\n" +
+	"\x06_levelB\x06\n" +
+	"\x04_podB\a\n" +
+	"\x05_grepB\n" +
+	"\n" +
+	"\b_excludeB\v\n" +
+	"\t_previous\"\xb9\x02\n" +
 	"\bLogEntry\x12\x19\n" +
 	"\bpod_name\x18\x01 \x01(\tR\apodName\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1c\n" +
 	"\tcontainer\x18\x03 \x01(\tR\tcontainer\x128\n" +
 	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x10\n" +
 	"\x03log\x18\x05 \x01(\tR\x03log\x12\x14\n" +
-	"\x05level\x18\x06 \x01(\tR\x05level\"\xa2\x01\n" +
+	"\x05level\x18\x06 \x01(\tR\x05level\x129\n" +
+	"\x06fields\x18\a \x03(\v2!.loco.app.v1.LogEntry.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe8\x01\n" +
+	"\x14GetAppMetricsRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x125\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x05since\x88\x01\x01\x125\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x05until\x88\x01\x01\x12&\n" +
+	"\fstep_seconds\x18\x04 \x01(\x05H\x02R\vstepSeconds\x88\x01\x01B\b\n" +
+	"\x06_sinceB\b\n" +
+	"\x06_untilB\x0f\n" +
+	"\r_step_seconds\"]\n" +
+	"\vMetricPoint\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"\xa1\x01\n" +
+	"\fMetricSeries\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x03pod\x18\x02 \x01(\tH\x00R\x03pod\x88\x01\x01\x12\x1f\n" +
+	"\bhostname\x18\x03 \x01(\tH\x01R\bhostname\x88\x01\x01\x120\n" +
+	"\x06points\x18\x04 \x03(\v2\x18.loco.app.v1.MetricPointR\x06pointsB\x06\n" +
+	"\x04_podB\v\n" +
+	"\t_hostname\"\xde\x01\n" +
+	"\n" +
+	"PodMetrics\x12\x10\n" +
+	"\x03pod\x18\x01 \x01(\tR\x03pod\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x14\n" +
+	"\x05ready\x18\x03 \x01(\bR\x05ready\x12*\n" +
+	"\x0ecpu_millicores\x18\x04 \x01(\x03H\x00R\rcpuMillicores\x88\x01\x01\x12&\n" +
+	"\fmemory_bytes\x18\x05 \x01(\x03H\x01R\vmemoryBytes\x88\x01\x01\x12\x1a\n" +
+	"\brestarts\x18\x06 \x01(\x05R\brestartsB\x11\n" +
+	"\x0f_cpu_millicoresB\x0f\n" +
+	"\r_memory_bytes\"\xbf\x02\n" +
+	"\x15GetAppMetricsResponse\x12+\n" +
+	"\x04pods\x18\x01 \x03(\v2\x17.loco.app.v1.PodMetricsR\x04pods\x12%\n" +
+	"\x0ecpu_millicores\x18\x02 \x01(\x03R\rcpuMillicores\x12!\n" +
+	"\fmemory_bytes\x18\x03 \x01(\x03R\vmemoryBytes\x12\x1a\n" +
+	"\brestarts\x18\x04 \x01(\x05R\brestarts\x121\n" +
+	"\x06series\x18\x05 \x03(\v2\x19.loco.app.v1.MetricSeriesR\x06series\x12!\n" +
+	"\fstep_seconds\x18\x06 \x01(\x05R\vstepSeconds\x12=\n" +
+	"\fcollected_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcollectedAt\"\xa2\x01\n" +
 	"\x05Event\x128\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
@@ -1703,14 +3196,82 @@ const file_shared_proto_app_v1_app_proto_rawDesc = "" +
 	"\x14UpdateAppEnvResponse\x12=\n" +
 	"\n" +
 	"deployment\x18\x01 \x01(\v2\x1d.loco.app.v1.DeploymentStatusR\n" +
-	"deployment*R\n" +
+	"deployment\"]\n" +
+	"\x11RestartAppRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x15\n" +
+	"\x03pod\x18\x02 \x01(\tH\x00R\x03pod\x88\x01\x01\x12\x12\n" +
+	"\x04wait\x18\x03 \x01(\bR\x04waitB\x06\n" +
+	"\x04_pod\"\xb9\x01\n" +
+	"\x0fRestartAppEvent\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12(\n" +
+	"\rerror_message\x18\x04 \x01(\tH\x00R\ferrorMessage\x88\x01\x01B\x10\n" +
+	"\x0e_error_message\"\x8b\x01\n" +
+	"\x11RunCommandRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x18\n" +
+	"\acommand\x18\x02 \x03(\tR\acommand\x12\x15\n" +
+	"\x03cpu\x18\x03 \x01(\tH\x00R\x03cpu\x88\x01\x01\x12\x1b\n" +
+	"\x06memory\x18\x04 \x01(\tH\x01R\x06memory\x88\x01\x01B\x06\n" +
+	"\x04_cpuB\t\n" +
+	"\a_memory\"\xc1\x01\n" +
+	"\x12RunCommandResponse\x12\x15\n" +
+	"\x03job\x18\x01 \x01(\tH\x00R\x03job\x88\x01\x01\x12,\n" +
+	"\x03log\x18\x02 \x01(\v2\x15.loco.app.v1.LogEntryH\x01R\x03log\x88\x01\x01\x12 \n" +
+	"\texit_code\x18\x03 \x01(\x05H\x02R\bexitCode\x88\x01\x01\x12\x1b\n" +
+	"\x06reason\x18\x04 \x01(\tH\x03R\x06reason\x88\x01\x01B\x06\n" +
+	"\x04_jobB\x06\n" +
+	"\x04_logB\f\n" +
+	"\n" +
+	"_exit_codeB\t\n" +
+	"\a_reason\"\xc2\x01\n" +
+	"\vExecRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x15\n" +
+	"\x03pod\x18\x02 \x01(\tH\x00R\x03pod\x88\x01\x01\x12\x18\n" +
+	"\acommand\x18\x03 \x03(\tR\acommand\x12\x10\n" +
+	"\x03tty\x18\x04 \x01(\bR\x03tty\x12\x14\n" +
+	"\x05stdin\x18\x05 \x01(\fR\x05stdin\x122\n" +
+	"\x04size\x18\x06 \x01(\v2\x19.loco.app.v1.TerminalSizeH\x01R\x04size\x88\x01\x01B\x06\n" +
+	"\x04_podB\a\n" +
+	"\x05_size\"<\n" +
+	"\fTerminalSize\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\rR\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\rR\x06height\"\x8d\x01\n" +
+	"\fExecResponse\x12\x15\n" +
+	"\x03pod\x18\x01 \x01(\tH\x00R\x03pod\x88\x01\x01\x12\x16\n" +
+	"\x06stdout\x18\x02 \x01(\fR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\x03 \x01(\fR\x06stderr\x12 \n" +
+	"\texit_code\x18\x04 \x01(\x05H\x01R\bexitCode\x88\x01\x01B\x06\n" +
+	"\x04_podB\f\n" +
+	"\n" +
+	"_exit_code\"\xad\x01\n" +
+	"\x12PortForwardRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x15\n" +
+	"\x03pod\x18\x02 \x01(\tH\x00R\x03pod\x88\x01\x01\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12#\n" +
+	"\rconnection_id\x18\x04 \x01(\rR\fconnectionId\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12\x14\n" +
+	"\x05close\x18\x06 \x01(\bR\x05closeB\x06\n" +
+	"\x04_pod\"\xa8\x01\n" +
+	"\x13PortForwardResponse\x12\x15\n" +
+	"\x03pod\x18\x01 \x01(\tH\x00R\x03pod\x88\x01\x01\x12#\n" +
+	"\rconnection_id\x18\x02 \x01(\rR\fconnectionId\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x14\n" +
+	"\x05close\x18\x04 \x01(\bR\x05close\x12\x19\n" +
+	"\x05error\x18\x05 \x01(\tH\x01R\x05error\x88\x01\x01B\x06\n" +
+	"\x04_podB\b\n" +
+	"\x06_error*R\n" +
 	"\aAppType\x12\v\n" +
 	"\aSERVICE\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\f\n" +
 	"\bFUNCTION\x10\x02\x12\t\n" +
 	"\x05CACHE\x10\x03\x12\t\n" +
 	"\x05QUEUE\x10\x04\x12\b\n" +
-	"\x04BLOB\x10\x052\xd6\a\n" +
+	"\x04BLOB\x10\x05*1\n" +
+	"\bLogOrder\x12\x12\n" +
+	"\x0eLOG_ORDER_DESC\x10\x00\x12\x11\n" +
+	"\rLOG_ORDER_ASC\x10\x012\xe4\n" +
+	"\n" +
 	"\n" +
 	"AppService\x12J\n" +
 	"\tCreateApp\x12\x1d.loco.app.v1.CreateAppRequest\x1a\x1e.loco.app.v1.CreateAppResponse\x12A\n" +
@@ -1722,10 +3283,17 @@ const file_shared_proto_app_v1_app_proto_rawDesc = "" +
 	"\fGetAppStatus\x12 .loco.app.v1.GetAppStatusRequest\x1a!.loco.app.v1.GetAppStatusResponse\x12}\n" +
 	"\x1aCheckSubdomainAvailability\x12..loco.app.v1.CheckSubdomainAvailabilityRequest\x1a/.loco.app.v1.CheckSubdomainAvailabilityResponse\x12E\n" +
 	"\n" +
-	"StreamLogs\x12\x1e.loco.app.v1.StreamLogsRequest\x1a\x15.loco.app.v1.LogEntry0\x01\x12J\n" +
+	"StreamLogs\x12\x1e.loco.app.v1.StreamLogsRequest\x1a\x15.loco.app.v1.LogEntry0\x01\x12V\n" +
+	"\rGetAppMetrics\x12!.loco.app.v1.GetAppMetricsRequest\x1a\".loco.app.v1.GetAppMetricsResponse\x12J\n" +
 	"\tGetEvents\x12\x1d.loco.app.v1.GetEventsRequest\x1a\x1e.loco.app.v1.GetEventsResponse\x12G\n" +
 	"\bScaleApp\x12\x1c.loco.app.v1.ScaleAppRequest\x1a\x1d.loco.app.v1.ScaleAppResponse\x12S\n" +
-	"\fUpdateAppEnv\x12 .loco.app.v1.UpdateAppEnvRequest\x1a!.loco.app.v1.UpdateAppEnvResponseB7Z5github.com/nikumar1206/loco/shared/proto/app/v1;appv1b\x06proto3"
+	"\fUpdateAppEnv\x12 .loco.app.v1.UpdateAppEnvRequest\x1a!.loco.app.v1.UpdateAppEnvResponse\x12L\n" +
+	"\n" +
+	"RestartApp\x12\x1e.loco.app.v1.RestartAppRequest\x1a\x1c.loco.app.v1.RestartAppEvent0\x01\x12O\n" +
+	"\n" +
+	"RunCommand\x12\x1e.loco.app.v1.RunCommandRequest\x1a\x1f.loco.app.v1.RunCommandResponse0\x01\x12?\n" +
+	"\x04Exec\x12\x18.loco.app.v1.ExecRequest\x1a\x19.loco.app.v1.ExecResponse(\x010\x01\x12T\n" +
+	"\vPortForward\x12\x1f.loco.app.v1.PortForwardRequest\x1a .loco.app.v1.PortForwardResponse(\x010\x01B7Z5github.com/nikumar1206/loco/shared/proto/app/v1;appv1b\x06proto3"
 
 var (
 	file_shared_proto_app_v1_app_proto_rawDescOnce sync.Once
@@ -1739,87 +3307,132 @@ func file_shared_proto_app_v1_app_proto_rawDescGZIP() []byte {
 	return file_shared_proto_app_v1_app_proto_rawDescData
 }
 
-var file_shared_proto_app_v1_app_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_shared_proto_app_v1_app_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_shared_proto_app_v1_app_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_shared_proto_app_v1_app_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_shared_proto_app_v1_app_proto_goTypes = []any{
 	(AppType)(0),                               // 0: loco.app.v1.AppType
-	(*App)(nil),                                // 1: loco.app.v1.App
-	(*CreateAppRequest)(nil),                   // 2: loco.app.v1.CreateAppRequest
-	(*CreateAppResponse)(nil),                  // 3: loco.app.v1.CreateAppResponse
-	(*GetAppRequest)(nil),                      // 4: loco.app.v1.GetAppRequest
-	(*GetAppResponse)(nil),                     // 5: loco.app.v1.GetAppResponse
-	(*GetAppByNameRequest)(nil),                // 6: loco.app.v1.GetAppByNameRequest
-	(*GetAppByNameResponse)(nil),               // 7: loco.app.v1.GetAppByNameResponse
-	(*ListAppsRequest)(nil),                    // 8: loco.app.v1.ListAppsRequest
-	(*ListAppsResponse)(nil),                   // 9: loco.app.v1.ListAppsResponse
-	(*UpdateAppRequest)(nil),                   // 10: loco.app.v1.UpdateAppRequest
-	(*UpdateAppResponse)(nil),                  // 11: loco.app.v1.UpdateAppResponse
-	(*DeleteAppRequest)(nil),                   // 12: loco.app.v1.DeleteAppRequest
-	(*DeleteAppResponse)(nil),                  // 13: loco.app.v1.DeleteAppResponse
-	(*CheckSubdomainAvailabilityRequest)(nil),  // 14: loco.app.v1.CheckSubdomainAvailabilityRequest
-	(*CheckSubdomainAvailabilityResponse)(nil), // 15: loco.app.v1.CheckSubdomainAvailabilityResponse
-	(*GetAppStatusRequest)(nil),                // 16: loco.app.v1.GetAppStatusRequest
-	(*DeploymentStatus)(nil),                   // 17: loco.app.v1.DeploymentStatus
-	(*GetAppStatusResponse)(nil),               // 18: loco.app.v1.GetAppStatusResponse
-	(*StreamLogsRequest)(nil),                  // 19: loco.app.v1.StreamLogsRequest
-	(*LogEntry)(nil),                           // 20: loco.app.v1.LogEntry
-	(*Event)(nil),                              // 21: loco.app.v1.Event
-	(*GetEventsRequest)(nil),                   // 22: loco.app.v1.GetEventsRequest
-	(*GetEventsResponse)(nil),                  // 23: loco.app.v1.GetEventsResponse
-	(*ScaleAppRequest)(nil),                    // 24: loco.app.v1.ScaleAppRequest
-	(*ScaleAppResponse)(nil),                   // 25: loco.app.v1.ScaleAppResponse
-	(*UpdateAppEnvRequest)(nil),                // 26: loco.app.v1.UpdateAppEnvRequest
-	(*UpdateAppEnvResponse)(nil),               // 27: loco.app.v1.UpdateAppEnvResponse
-	nil,                                        // 28: loco.app.v1.UpdateAppEnvRequest.EnvEntry
-	(*timestamppb.Timestamp)(nil),              // 29: google.protobuf.Timestamp
+	(LogOrder)(0),                              // 1: loco.app.v1.LogOrder
+	(*App)(nil),                                // 2: loco.app.v1.App
+	(*CreateAppRequest)(nil),                   // 3: loco.app.v1.CreateAppRequest
+	(*CreateAppResponse)(nil),                  // 4: loco.app.v1.CreateAppResponse
+	(*GetAppRequest)(nil),                      // 5: loco.app.v1.GetAppRequest
+	(*GetAppResponse)(nil),                     // 6: loco.app.v1.GetAppResponse
+	(*GetAppByNameRequest)(nil),                // 7: loco.app.v1.GetAppByNameRequest
+	(*GetAppByNameResponse)(nil),               // 8: loco.app.v1.GetAppByNameResponse
+	(*ListAppsRequest)(nil),                    // 9: loco.app.v1.ListAppsRequest
+	(*ListAppsResponse)(nil),                   // 10: loco.app.v1.ListAppsResponse
+	(*UpdateAppRequest)(nil),                   // 11: loco.app.v1.UpdateAppRequest
+	(*UpdateAppResponse)(nil),                  // 12: loco.app.v1.UpdateAppResponse
+	(*DeleteAppRequest)(nil),                   // 13: loco.app.v1.DeleteAppRequest
+	(*DeleteAppResponse)(nil),                  // 14: loco.app.v1.DeleteAppResponse
+	(*CheckSubdomainAvailabilityRequest)(nil),  // 15: loco.app.v1.CheckSubdomainAvailabilityRequest
+	(*CheckSubdomainAvailabilityResponse)(nil), // 16: loco.app.v1.CheckSubdomainAvailabilityResponse
+	(*GetAppStatusRequest)(nil),                // 17: loco.app.v1.GetAppStatusRequest
+	(*DeploymentStatus)(nil),                   // 18: loco.app.v1.DeploymentStatus
+	(*VolumeStatus)(nil),                       // 19: loco.app.v1.VolumeStatus
+	(*Endpoint)(nil),                           // 20: loco.app.v1.Endpoint
+	(*GetAppStatusResponse)(nil),               // 21: loco.app.v1.GetAppStatusResponse
+	(*StreamLogsRequest)(nil),                  // 22: loco.app.v1.StreamLogsRequest
+	(*LogEntry)(nil),                           // 23: loco.app.v1.LogEntry
+	(*GetAppMetricsRequest)(nil),               // 24: loco.app.v1.GetAppMetricsRequest
+	(*MetricPoint)(nil),                        // 25: loco.app.v1.MetricPoint
+	(*MetricSeries)(nil),                       // 26: loco.app.v1.MetricSeries
+	(*PodMetrics)(nil),                         // 27: loco.app.v1.PodMetrics
+	(*GetAppMetricsResponse)(nil),              // 28: loco.app.v1.GetAppMetricsResponse
+	(*Event)(nil),                              // 29: loco.app.v1.Event
+	(*GetEventsRequest)(nil),                   // 30: loco.app.v1.GetEventsRequest
+	(*GetEventsResponse)(nil),                  // 31: loco.app.v1.GetEventsResponse
+	(*ScaleAppRequest)(nil),                    // 32: loco.app.v1.ScaleAppRequest
+	(*ScaleAppResponse)(nil),                   // 33: loco.app.v1.ScaleAppResponse
+	(*UpdateAppEnvRequest)(nil),                // 34: loco.app.v1.UpdateAppEnvRequest
+	(*UpdateAppEnvResponse)(nil),               // 35: loco.app.v1.UpdateAppEnvResponse
+	(*RestartAppRequest)(nil),                  // 36: loco.app.v1.RestartAppRequest
+	(*RestartAppEvent)(nil),                    // 37: loco.app.v1.RestartAppEvent
+	(*RunCommandRequest)(nil),                  // 38: loco.app.v1.RunCommandRequest
+	(*RunCommandResponse)(nil),                 // 39: loco.app.v1.RunCommandResponse
+	(*ExecRequest)(nil),                        // 40: loco.app.v1.ExecRequest
+	(*TerminalSize)(nil),                       // 41: loco.app.v1.TerminalSize
+	(*ExecResponse)(nil),                       // 42: loco.app.v1.ExecResponse
+	(*PortForwardRequest)(nil),                 // 43: loco.app.v1.PortForwardRequest
+	(*PortForwardResponse)(nil),                // 44: loco.app.v1.PortForwardResponse
+	nil,                                        // 45: loco.app.v1.LogEntry.FieldsEntry
+	nil,                                        // 46: loco.app.v1.UpdateAppEnvRequest.EnvEntry
+	(*timestamppb.Timestamp)(nil),              // 47: google.protobuf.Timestamp
 }
 var file_shared_proto_app_v1_app_proto_depIdxs = []int32{
 	0,  // 0: loco.app.v1.App.type:type_name -> loco.app.v1.AppType
-	29, // 1: loco.app.v1.App.created_at:type_name -> google.protobuf.Timestamp
-	29, // 2: loco.app.v1.App.updated_at:type_name -> google.protobuf.Timestamp
+	47, // 1: loco.app.v1.App.created_at:type_name -> google.protobuf.Timestamp
+	47, // 2: loco.app.v1.App.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 3: loco.app.v1.CreateAppRequest.type:type_name -> loco.app.v1.AppType
-	1,  // 4: loco.app.v1.CreateAppResponse.app:type_name -> loco.app.v1.App
-	1,  // 5: loco.app.v1.GetAppResponse.app:type_name -> loco.app.v1.App
-	1,  // 6: loco.app.v1.GetAppByNameResponse.app:type_name -> loco.app.v1.App
-	1,  // 7: loco.app.v1.ListAppsResponse.apps:type_name -> loco.app.v1.App
-	1,  // 8: loco.app.v1.UpdateAppResponse.app:type_name -> loco.app.v1.App
-	1,  // 9: loco.app.v1.GetAppStatusResponse.app:type_name -> loco.app.v1.App
-	17, // 10: loco.app.v1.GetAppStatusResponse.current_deployment:type_name -> loco.app.v1.DeploymentStatus
-	29, // 11: loco.app.v1.LogEntry.timestamp:type_name -> google.protobuf.Timestamp
-	29, // 12: loco.app.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
-	21, // 13: loco.app.v1.GetEventsResponse.events:type_name -> loco.app.v1.Event
-	17, // 14: loco.app.v1.ScaleAppResponse.deployment:type_name -> loco.app.v1.DeploymentStatus
-	28, // 15: loco.app.v1.UpdateAppEnvRequest.env:type_name -> loco.app.v1.UpdateAppEnvRequest.EnvEntry
-	17, // 16: loco.app.v1.UpdateAppEnvResponse.deployment:type_name -> loco.app.v1.DeploymentStatus
-	2,  // 17: loco.app.v1.AppService.CreateApp:input_type -> loco.app.v1.CreateAppRequest
-	4,  // 18: loco.app.v1.AppService.GetApp:input_type -> loco.app.v1.GetAppRequest
-	6,  // 19: loco.app.v1.AppService.GetAppByName:input_type -> loco.app.v1.GetAppByNameRequest
-	8,  // 20: loco.app.v1.AppService.ListApps:input_type -> loco.app.v1.ListAppsRequest
-	10, // 21: loco.app.v1.AppService.UpdateApp:input_type -> loco.app.v1.UpdateAppRequest
-	12, // 22: loco.app.v1.AppService.DeleteApp:input_type -> loco.app.v1.DeleteAppRequest
-	16, // 23: loco.app.v1.AppService.GetAppStatus:input_type -> loco.app.v1.GetAppStatusRequest
-	14, // 24: loco.app.v1.AppService.CheckSubdomainAvailability:input_type -> loco.app.v1.CheckSubdomainAvailabilityRequest
-	19, // 25: loco.app.v1.AppService.StreamLogs:input_type -> loco.app.v1.StreamLogsRequest
-	22, // 26: loco.app.v1.AppService.GetEvents:input_type -> loco.app.v1.GetEventsRequest
-	24, // 27: loco.app.v1.AppService.ScaleApp:input_type -> loco.app.v1.ScaleAppRequest
-	26, // 28: loco.app.v1.AppService.UpdateAppEnv:input_type -> loco.app.v1.UpdateAppEnvRequest
-	3,  // 29: loco.app.v1.AppService.CreateApp:output_type -> loco.app.v1.CreateAppResponse
-	5,  // 30: loco.app.v1.AppService.GetApp:output_type -> loco.app.v1.GetAppResponse
-	7,  // 31: loco.app.v1.AppService.GetAppByName:output_type -> loco.app.v1.GetAppByNameResponse
-	9,  // 32: loco.app.v1.AppService.ListApps:output_type -> loco.app.v1.ListAppsResponse
-	11, // 33: loco.app.v1.AppService.UpdateApp:output_type -> loco.app.v1.UpdateAppResponse
-	13, // 34: loco.app.v1.AppService.DeleteApp:output_type -> loco.app.v1.DeleteAppResponse
-	18, // 35: loco.app.v1.AppService.GetAppStatus:output_type -> loco.app.v1.GetAppStatusResponse
-	15, // 36: loco.app.v1.AppService.CheckSubdomainAvailability:output_type -> loco.app.v1.CheckSubdomainAvailabilityResponse
-	20, // 37: loco.app.v1.AppService.StreamLogs:output_type -> loco.app.v1.LogEntry
-	23, // 38: loco.app.v1.AppService.GetEvents:output_type -> loco.app.v1.GetEventsResponse
-	25, // 39: loco.app.v1.AppService.ScaleApp:output_type -> loco.app.v1.ScaleAppResponse
-	27, // 40: loco.app.v1.AppService.UpdateAppEnv:output_type -> loco.app.v1.UpdateAppEnvResponse
-	29, // [29:41] is the sub-list for method output_type
-	17, // [17:29] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	2,  // 4: loco.app.v1.CreateAppResponse.app:type_name -> loco.app.v1.App
+	2,  // 5: loco.app.v1.GetAppResponse.app:type_name -> loco.app.v1.App
+	2,  // 6: loco.app.v1.GetAppByNameResponse.app:type_name -> loco.app.v1.App
+	2,  // 7: loco.app.v1.ListAppsResponse.apps:type_name -> loco.app.v1.App
+	2,  // 8: loco.app.v1.UpdateAppResponse.app:type_name -> loco.app.v1.App
+	2,  // 9: loco.app.v1.GetAppStatusResponse.app:type_name -> loco.app.v1.App
+	18, // 10: loco.app.v1.GetAppStatusResponse.current_deployment:type_name -> loco.app.v1.DeploymentStatus
+	20, // 11: loco.app.v1.GetAppStatusResponse.endpoints:type_name -> loco.app.v1.Endpoint
+	47, // 12: loco.app.v1.GetAppStatusResponse.slept_at:type_name -> google.protobuf.Timestamp
+	19, // 13: loco.app.v1.GetAppStatusResponse.volumes:type_name -> loco.app.v1.VolumeStatus
+	47, // 14: loco.app.v1.StreamLogsRequest.since:type_name -> google.protobuf.Timestamp
+	47, // 15: loco.app.v1.StreamLogsRequest.until:type_name -> google.protobuf.Timestamp
+	1,  // 16: loco.app.v1.StreamLogsRequest.order:type_name -> loco.app.v1.LogOrder
+	47, // 17: loco.app.v1.LogEntry.timestamp:type_name -> google.protobuf.Timestamp
+	45, // 18: loco.app.v1.LogEntry.fields:type_name -> loco.app.v1.LogEntry.FieldsEntry
+	47, // 19: loco.app.v1.GetAppMetricsRequest.since:type_name -> google.protobuf.Timestamp
+	47, // 20: loco.app.v1.GetAppMetricsRequest.until:type_name -> google.protobuf.Timestamp
+	47, // 21: loco.app.v1.MetricPoint.timestamp:type_name -> google.protobuf.Timestamp
+	25, // 22: loco.app.v1.MetricSeries.points:type_name -> loco.app.v1.MetricPoint
+	27, // 23: loco.app.v1.GetAppMetricsResponse.pods:type_name -> loco.app.v1.PodMetrics
+	26, // 24: loco.app.v1.GetAppMetricsResponse.series:type_name -> loco.app.v1.MetricSeries
+	47, // 25: loco.app.v1.GetAppMetricsResponse.collected_at:type_name -> google.protobuf.Timestamp
+	47, // 26: loco.app.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
+	29, // 27: loco.app.v1.GetEventsResponse.events:type_name -> loco.app.v1.Event
+	18, // 28: loco.app.v1.ScaleAppResponse.deployment:type_name -> loco.app.v1.DeploymentStatus
+	46, // 29: loco.app.v1.UpdateAppEnvRequest.env:type_name -> loco.app.v1.UpdateAppEnvRequest.EnvEntry
+	18, // 30: loco.app.v1.UpdateAppEnvResponse.deployment:type_name -> loco.app.v1.DeploymentStatus
+	47, // 31: loco.app.v1.RestartAppEvent.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 32: loco.app.v1.RunCommandResponse.log:type_name -> loco.app.v1.LogEntry
+	41, // 33: loco.app.v1.ExecRequest.size:type_name -> loco.app.v1.TerminalSize
+	3,  // 34: loco.app.v1.AppService.CreateApp:input_type -> loco.app.v1.CreateAppRequest
+	5,  // 35: loco.app.v1.AppService.GetApp:input_type -> loco.app.v1.GetAppRequest
+	7,  // 36: loco.app.v1.AppService.GetAppByName:input_type -> loco.app.v1.GetAppByNameRequest
+	9,  // 37: loco.app.v1.AppService.ListApps:input_type -> loco.app.v1.ListAppsRequest
+	11, // 38: loco.app.v1.AppService.UpdateApp:input_type -> loco.app.v1.UpdateAppRequest
+	13, // 39: loco.app.v1.AppService.DeleteApp:input_type -> loco.app.v1.DeleteAppRequest
+	17, // 40: loco.app.v1.AppService.GetAppStatus:input_type -> loco.app.v1.GetAppStatusRequest
+	15, // 41: loco.app.v1.AppService.CheckSubdomainAvailability:input_type -> loco.app.v1.CheckSubdomainAvailabilityRequest
+	22, // 42: loco.app.v1.AppService.StreamLogs:input_type -> loco.app.v1.StreamLogsRequest
+	24, // 43: loco.app.v1.AppService.GetAppMetrics:input_type -> loco.app.v1.GetAppMetricsRequest
+	30, // 44: loco.app.v1.AppService.GetEvents:input_type -> loco.app.v1.GetEventsRequest
+	32, // 45: loco.app.v1.AppService.ScaleApp:input_type -> loco.app.v1.ScaleAppRequest
+	34, // 46: loco.app.v1.AppService.UpdateAppEnv:input_type -> loco.app.v1.UpdateAppEnvRequest
+	36, // 47: loco.app.v1.AppService.RestartApp:input_type -> loco.app.v1.RestartAppRequest
+	38, // 48: loco.app.v1.AppService.RunCommand:input_type -> loco.app.v1.RunCommandRequest
+	40, // 49: loco.app.v1.AppService.Exec:input_type -> loco.app.v1.ExecRequest
+	43, // 50: loco.app.v1.AppService.PortForward:input_type -> loco.app.v1.PortForwardRequest
+	4,  // 51: loco.app.v1.AppService.CreateApp:output_type -> loco.app.v1.CreateAppResponse
+	6,  // 52: loco.app.v1.AppService.GetApp:output_type -> loco.app.v1.GetAppResponse
+	8,  // 53: loco.app.v1.AppService.GetAppByName:output_type -> loco.app.v1.GetAppByNameResponse
+	10, // 54: loco.app.v1.AppService.ListApps:output_type -> loco.app.v1.ListAppsResponse
+	12, // 55: loco.app.v1.AppService.UpdateApp:output_type -> loco.app.v1.UpdateAppResponse
+	14, // 56: loco.app.v1.AppService.DeleteApp:output_type -> loco.app.v1.DeleteAppResponse
+	21, // 57: loco.app.v1.AppService.GetAppStatus:output_type -> loco.app.v1.GetAppStatusResponse
+	16, // 58: loco.app.v1.AppService.CheckSubdomainAvailability:output_type -> loco.app.v1.CheckSubdomainAvailabilityResponse
+	23, // 59: loco.app.v1.AppService.StreamLogs:output_type -> loco.app.v1.LogEntry
+	28, // 60: loco.app.v1.AppService.GetAppMetrics:output_type -> loco.app.v1.GetAppMetricsResponse
+	31, // 61: loco.app.v1.AppService.GetEvents:output_type -> loco.app.v1.GetEventsResponse
+	33, // 62: loco.app.v1.AppService.ScaleApp:output_type -> loco.app.v1.ScaleAppResponse
+	35, // 63: loco.app.v1.AppService.UpdateAppEnv:output_type -> loco.app.v1.UpdateAppEnvResponse
+	37, // 64: loco.app.v1.AppService.RestartApp:output_type -> loco.app.v1.RestartAppEvent
+	39, // 65: loco.app.v1.AppService.RunCommand:output_type -> loco.app.v1.RunCommandResponse
+	42, // 66: loco.app.v1.AppService.Exec:output_type -> loco.app.v1.ExecResponse
+	44, // 67: loco.app.v1.AppService.PortForward:output_type -> loco.app.v1.PortForwardResponse
+	51, // [51:68] is the sub-list for method output_type
+	34, // [34:51] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_shared_proto_app_v1_app_proto_init() }
@@ -1829,17 +3442,30 @@ func file_shared_proto_app_v1_app_proto_init() {
 	}
 	file_shared_proto_app_v1_app_proto_msgTypes[1].OneofWrappers = []any{}
 	file_shared_proto_app_v1_app_proto_msgTypes[9].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[13].OneofWrappers = []any{}
 	file_shared_proto_app_v1_app_proto_msgTypes[16].OneofWrappers = []any{}
-	file_shared_proto_app_v1_app_proto_msgTypes[18].OneofWrappers = []any{}
-	file_shared_proto_app_v1_app_proto_msgTypes[21].OneofWrappers = []any{}
-	file_shared_proto_app_v1_app_proto_msgTypes[23].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[17].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[20].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[22].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[24].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[25].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[28].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[30].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[34].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[35].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[36].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[37].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[38].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[40].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[41].OneofWrappers = []any{}
+	file_shared_proto_app_v1_app_proto_msgTypes[42].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_app_v1_app_proto_rawDesc), len(file_shared_proto_app_v1_app_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   28,
+			NumEnums:      2,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
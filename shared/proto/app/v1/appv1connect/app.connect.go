@@ -52,12 +52,23 @@ const (
 	AppServiceCheckSubdomainAvailabilityProcedure = "/loco.app.v1.AppService/CheckSubdomainAvailability"
 	// AppServiceStreamLogsProcedure is the fully-qualified name of the AppService's StreamLogs RPC.
 	AppServiceStreamLogsProcedure = "/loco.app.v1.AppService/StreamLogs"
+	// AppServiceGetAppMetricsProcedure is the fully-qualified name of the AppService's GetAppMetrics
+	// RPC.
+	AppServiceGetAppMetricsProcedure = "/loco.app.v1.AppService/GetAppMetrics"
 	// AppServiceGetEventsProcedure is the fully-qualified name of the AppService's GetEvents RPC.
 	AppServiceGetEventsProcedure = "/loco.app.v1.AppService/GetEvents"
 	// AppServiceScaleAppProcedure is the fully-qualified name of the AppService's ScaleApp RPC.
 	AppServiceScaleAppProcedure = "/loco.app.v1.AppService/ScaleApp"
 	// AppServiceUpdateAppEnvProcedure is the fully-qualified name of the AppService's UpdateAppEnv RPC.
 	AppServiceUpdateAppEnvProcedure = "/loco.app.v1.AppService/UpdateAppEnv"
+	// AppServiceRestartAppProcedure is the fully-qualified name of the AppService's RestartApp RPC.
+	AppServiceRestartAppProcedure = "/loco.app.v1.AppService/RestartApp"
+	// AppServiceRunCommandProcedure is the fully-qualified name of the AppService's RunCommand RPC.
+	AppServiceRunCommandProcedure = "/loco.app.v1.AppService/RunCommand"
+	// AppServiceExecProcedure is the fully-qualified name of the AppService's Exec RPC.
+	AppServiceExecProcedure = "/loco.app.v1.AppService/Exec"
+	// AppServicePortForwardProcedure is the fully-qualified name of the AppService's PortForward RPC.
+	AppServicePortForwardProcedure = "/loco.app.v1.AppService/PortForward"
 )
 
 // AppServiceClient is a client for the loco.app.v1.AppService service.
@@ -73,11 +84,20 @@ type AppServiceClient interface {
 	CheckSubdomainAvailability(context.Context, *connect.Request[v1.CheckSubdomainAvailabilityRequest]) (*connect.Response[v1.CheckSubdomainAvailabilityResponse], error)
 	// Logs
 	StreamLogs(context.Context, *connect.Request[v1.StreamLogsRequest]) (*connect.ServerStreamForClient[v1.LogEntry], error)
+	// Metrics
+	GetAppMetrics(context.Context, *connect.Request[v1.GetAppMetricsRequest]) (*connect.Response[v1.GetAppMetricsResponse], error)
 	// Events
 	GetEvents(context.Context, *connect.Request[v1.GetEventsRequest]) (*connect.Response[v1.GetEventsResponse], error)
 	// App Operations
 	ScaleApp(context.Context, *connect.Request[v1.ScaleAppRequest]) (*connect.Response[v1.ScaleAppResponse], error)
 	UpdateAppEnv(context.Context, *connect.Request[v1.UpdateAppEnvRequest]) (*connect.Response[v1.UpdateAppEnvResponse], error)
+	RestartApp(context.Context, *connect.Request[v1.RestartAppRequest]) (*connect.ServerStreamForClient[v1.RestartAppEvent], error)
+	// One-off commands
+	RunCommand(context.Context, *connect.Request[v1.RunCommandRequest]) (*connect.ServerStreamForClient[v1.RunCommandResponse], error)
+	// Interactive shell
+	Exec(context.Context) *connect.BidiStreamForClient[v1.ExecRequest, v1.ExecResponse]
+	// Port forwarding
+	PortForward(context.Context) *connect.BidiStreamForClient[v1.PortForwardRequest, v1.PortForwardResponse]
 }
 
 // NewAppServiceClient constructs a client for the loco.app.v1.AppService service. By default, it
@@ -145,6 +165,12 @@ func NewAppServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithSchema(appServiceMethods.ByName("StreamLogs")),
 			connect.WithClientOptions(opts...),
 		),
+		getAppMetrics: connect.NewClient[v1.GetAppMetricsRequest, v1.GetAppMetricsResponse](
+			httpClient,
+			baseURL+AppServiceGetAppMetricsProcedure,
+			connect.WithSchema(appServiceMethods.ByName("GetAppMetrics")),
+			connect.WithClientOptions(opts...),
+		),
 		getEvents: connect.NewClient[v1.GetEventsRequest, v1.GetEventsResponse](
 			httpClient,
 			baseURL+AppServiceGetEventsProcedure,
@@ -163,6 +189,30 @@ func NewAppServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithSchema(appServiceMethods.ByName("UpdateAppEnv")),
 			connect.WithClientOptions(opts...),
 		),
+		restartApp: connect.NewClient[v1.RestartAppRequest, v1.RestartAppEvent](
+			httpClient,
+			baseURL+AppServiceRestartAppProcedure,
+			connect.WithSchema(appServiceMethods.ByName("RestartApp")),
+			connect.WithClientOptions(opts...),
+		),
+		runCommand: connect.NewClient[v1.RunCommandRequest, v1.RunCommandResponse](
+			httpClient,
+			baseURL+AppServiceRunCommandProcedure,
+			connect.WithSchema(appServiceMethods.ByName("RunCommand")),
+			connect.WithClientOptions(opts...),
+		),
+		exec: connect.NewClient[v1.ExecRequest, v1.ExecResponse](
+			httpClient,
+			baseURL+AppServiceExecProcedure,
+			connect.WithSchema(appServiceMethods.ByName("Exec")),
+			connect.WithClientOptions(opts...),
+		),
+		portForward: connect.NewClient[v1.PortForwardRequest, v1.PortForwardResponse](
+			httpClient,
+			baseURL+AppServicePortForwardProcedure,
+			connect.WithSchema(appServiceMethods.ByName("PortForward")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -177,9 +227,14 @@ type appServiceClient struct {
 	getAppStatus               *connect.Client[v1.GetAppStatusRequest, v1.GetAppStatusResponse]
 	checkSubdomainAvailability *connect.Client[v1.CheckSubdomainAvailabilityRequest, v1.CheckSubdomainAvailabilityResponse]
 	streamLogs                 *connect.Client[v1.StreamLogsRequest, v1.LogEntry]
+	getAppMetrics              *connect.Client[v1.GetAppMetricsRequest, v1.GetAppMetricsResponse]
 	getEvents                  *connect.Client[v1.GetEventsRequest, v1.GetEventsResponse]
 	scaleApp                   *connect.Client[v1.ScaleAppRequest, v1.ScaleAppResponse]
 	updateAppEnv               *connect.Client[v1.UpdateAppEnvRequest, v1.UpdateAppEnvResponse]
+	restartApp                 *connect.Client[v1.RestartAppRequest, v1.RestartAppEvent]
+	runCommand                 *connect.Client[v1.RunCommandRequest, v1.RunCommandResponse]
+	exec                       *connect.Client[v1.ExecRequest, v1.ExecResponse]
+	portForward                *connect.Client[v1.PortForwardRequest, v1.PortForwardResponse]
 }
 
 // CreateApp calls loco.app.v1.AppService.CreateApp.
@@ -227,6 +282,11 @@ func (c *appServiceClient) StreamLogs(ctx context.Context, req *connect.Request[
 	return c.streamLogs.CallServerStream(ctx, req)
 }
 
+// GetAppMetrics calls loco.app.v1.AppService.GetAppMetrics.
+func (c *appServiceClient) GetAppMetrics(ctx context.Context, req *connect.Request[v1.GetAppMetricsRequest]) (*connect.Response[v1.GetAppMetricsResponse], error) {
+	return c.getAppMetrics.CallUnary(ctx, req)
+}
+
 // GetEvents calls loco.app.v1.AppService.GetEvents.
 func (c *appServiceClient) GetEvents(ctx context.Context, req *connect.Request[v1.GetEventsRequest]) (*connect.Response[v1.GetEventsResponse], error) {
 	return c.getEvents.CallUnary(ctx, req)
@@ -242,6 +302,26 @@ func (c *appServiceClient) UpdateAppEnv(ctx context.Context, req *connect.Reques
 	return c.updateAppEnv.CallUnary(ctx, req)
 }
 
+// RestartApp calls loco.app.v1.AppService.RestartApp.
+func (c *appServiceClient) RestartApp(ctx context.Context, req *connect.Request[v1.RestartAppRequest]) (*connect.ServerStreamForClient[v1.RestartAppEvent], error) {
+	return c.restartApp.CallServerStream(ctx, req)
+}
+
+// RunCommand calls loco.app.v1.AppService.RunCommand.
+func (c *appServiceClient) RunCommand(ctx context.Context, req *connect.Request[v1.RunCommandRequest]) (*connect.ServerStreamForClient[v1.RunCommandResponse], error) {
+	return c.runCommand.CallServerStream(ctx, req)
+}
+
+// Exec calls loco.app.v1.AppService.Exec.
+func (c *appServiceClient) Exec(ctx context.Context) *connect.BidiStreamForClient[v1.ExecRequest, v1.ExecResponse] {
+	return c.exec.CallBidiStream(ctx)
+}
+
+// PortForward calls loco.app.v1.AppService.PortForward.
+func (c *appServiceClient) PortForward(ctx context.Context) *connect.BidiStreamForClient[v1.PortForwardRequest, v1.PortForwardResponse] {
+	return c.portForward.CallBidiStream(ctx)
+}
+
 // AppServiceHandler is an implementation of the loco.app.v1.AppService service.
 type AppServiceHandler interface {
 	// App CRUD
@@ -255,11 +335,20 @@ type AppServiceHandler interface {
 	CheckSubdomainAvailability(context.Context, *connect.Request[v1.CheckSubdomainAvailabilityRequest]) (*connect.Response[v1.CheckSubdomainAvailabilityResponse], error)
 	// Logs
 	StreamLogs(context.Context, *connect.Request[v1.StreamLogsRequest], *connect.ServerStream[v1.LogEntry]) error
+	// Metrics
+	GetAppMetrics(context.Context, *connect.Request[v1.GetAppMetricsRequest]) (*connect.Response[v1.GetAppMetricsResponse], error)
 	// Events
 	GetEvents(context.Context, *connect.Request[v1.GetEventsRequest]) (*connect.Response[v1.GetEventsResponse], error)
 	// App Operations
 	ScaleApp(context.Context, *connect.Request[v1.ScaleAppRequest]) (*connect.Response[v1.ScaleAppResponse], error)
 	UpdateAppEnv(context.Context, *connect.Request[v1.UpdateAppEnvRequest]) (*connect.Response[v1.UpdateAppEnvResponse], error)
+	RestartApp(context.Context, *connect.Request[v1.RestartAppRequest], *connect.ServerStream[v1.RestartAppEvent]) error
+	// One-off commands
+	RunCommand(context.Context, *connect.Request[v1.RunCommandRequest], *connect.ServerStream[v1.RunCommandResponse]) error
+	// Interactive shell
+	Exec(context.Context, *connect.BidiStream[v1.ExecRequest, v1.ExecResponse]) error
+	// Port forwarding
+	PortForward(context.Context, *connect.BidiStream[v1.PortForwardRequest, v1.PortForwardResponse]) error
 }
 
 // NewAppServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -323,6 +412,12 @@ func NewAppServiceHandler(svc AppServiceHandler, opts ...connect.HandlerOption)
 		connect.WithSchema(appServiceMethods.ByName("StreamLogs")),
 		connect.WithHandlerOptions(opts...),
 	)
+	appServiceGetAppMetricsHandler := connect.NewUnaryHandler(
+		AppServiceGetAppMetricsProcedure,
+		svc.GetAppMetrics,
+		connect.WithSchema(appServiceMethods.ByName("GetAppMetrics")),
+		connect.WithHandlerOptions(opts...),
+	)
 	appServiceGetEventsHandler := connect.NewUnaryHandler(
 		AppServiceGetEventsProcedure,
 		svc.GetEvents,
@@ -341,6 +436,30 @@ func NewAppServiceHandler(svc AppServiceHandler, opts ...connect.HandlerOption)
 		connect.WithSchema(appServiceMethods.ByName("UpdateAppEnv")),
 		connect.WithHandlerOptions(opts...),
 	)
+	appServiceRestartAppHandler := connect.NewServerStreamHandler(
+		AppServiceRestartAppProcedure,
+		svc.RestartApp,
+		connect.WithSchema(appServiceMethods.ByName("RestartApp")),
+		connect.WithHandlerOptions(opts...),
+	)
+	appServiceRunCommandHandler := connect.NewServerStreamHandler(
+		AppServiceRunCommandProcedure,
+		svc.RunCommand,
+		connect.WithSchema(appServiceMethods.ByName("RunCommand")),
+		connect.WithHandlerOptions(opts...),
+	)
+	appServiceExecHandler := connect.NewBidiStreamHandler(
+		AppServiceExecProcedure,
+		svc.Exec,
+		connect.WithSchema(appServiceMethods.ByName("Exec")),
+		connect.WithHandlerOptions(opts...),
+	)
+	appServicePortForwardHandler := connect.NewBidiStreamHandler(
+		AppServicePortForwardProcedure,
+		svc.PortForward,
+		connect.WithSchema(appServiceMethods.ByName("PortForward")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/loco.app.v1.AppService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AppServiceCreateAppProcedure:
@@ -361,12 +480,22 @@ func NewAppServiceHandler(svc AppServiceHandler, opts ...connect.HandlerOption)
 			appServiceCheckSubdomainAvailabilityHandler.ServeHTTP(w, r)
 		case AppServiceStreamLogsProcedure:
 			appServiceStreamLogsHandler.ServeHTTP(w, r)
+		case AppServiceGetAppMetricsProcedure:
+			appServiceGetAppMetricsHandler.ServeHTTP(w, r)
 		case AppServiceGetEventsProcedure:
 			appServiceGetEventsHandler.ServeHTTP(w, r)
 		case AppServiceScaleAppProcedure:
 			appServiceScaleAppHandler.ServeHTTP(w, r)
 		case AppServiceUpdateAppEnvProcedure:
 			appServiceUpdateAppEnvHandler.ServeHTTP(w, r)
+		case AppServiceRestartAppProcedure:
+			appServiceRestartAppHandler.ServeHTTP(w, r)
+		case AppServiceRunCommandProcedure:
+			appServiceRunCommandHandler.ServeHTTP(w, r)
+		case AppServiceExecProcedure:
+			appServiceExecHandler.ServeHTTP(w, r)
+		case AppServicePortForwardProcedure:
+			appServicePortForwardHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -412,6 +541,10 @@ func (UnimplementedAppServiceHandler) StreamLogs(context.Context, *connect.Reque
 	return connect.NewError(connect.CodeUnimplemented, errors.New("loco.app.v1.AppService.StreamLogs is not implemented"))
 }
 
+func (UnimplementedAppServiceHandler) GetAppMetrics(context.Context, *connect.Request[v1.GetAppMetricsRequest]) (*connect.Response[v1.GetAppMetricsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.app.v1.AppService.GetAppMetrics is not implemented"))
+}
+
 func (UnimplementedAppServiceHandler) GetEvents(context.Context, *connect.Request[v1.GetEventsRequest]) (*connect.Response[v1.GetEventsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.app.v1.AppService.GetEvents is not implemented"))
 }
@@ -423,3 +556,19 @@ func (UnimplementedAppServiceHandler) ScaleApp(context.Context, *connect.Request
 func (UnimplementedAppServiceHandler) UpdateAppEnv(context.Context, *connect.Request[v1.UpdateAppEnvRequest]) (*connect.Response[v1.UpdateAppEnvResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.app.v1.AppService.UpdateAppEnv is not implemented"))
 }
+
+func (UnimplementedAppServiceHandler) RestartApp(context.Context, *connect.Request[v1.RestartAppRequest], *connect.ServerStream[v1.RestartAppEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("loco.app.v1.AppService.RestartApp is not implemented"))
+}
+
+func (UnimplementedAppServiceHandler) RunCommand(context.Context, *connect.Request[v1.RunCommandRequest], *connect.ServerStream[v1.RunCommandResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("loco.app.v1.AppService.RunCommand is not implemented"))
+}
+
+func (UnimplementedAppServiceHandler) Exec(context.Context, *connect.BidiStream[v1.ExecRequest, v1.ExecResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("loco.app.v1.AppService.Exec is not implemented"))
+}
+
+func (UnimplementedAppServiceHandler) PortForward(context.Context, *connect.BidiStream[v1.PortForwardRequest, v1.PortForwardResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("loco.app.v1.AppService.PortForward is not implemented"))
+}
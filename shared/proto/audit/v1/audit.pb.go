@@ -0,0 +1,458 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: shared/proto/audit/v1/audit.proto
+
+package auditv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuditEvent struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId      *int64                 `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3,oneof" json:"actor_id,omitempty"`
+	Actor        string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Procedure    string                 `protobuf:"bytes,4,opt,name=procedure,proto3" json:"procedure,omitempty"`
+	OrgId        *int64                 `protobuf:"varint,5,opt,name=org_id,json=orgId,proto3,oneof" json:"org_id,omitempty"`
+	WorkspaceId  *int64                 `protobuf:"varint,6,opt,name=workspace_id,json=workspaceId,proto3,oneof" json:"workspace_id,omitempty"`
+	AppId        *int64                 `protobuf:"varint,7,opt,name=app_id,json=appId,proto3,oneof" json:"app_id,omitempty"`
+	ResourceType string                 `protobuf:"bytes,8,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId   *int64                 `protobuf:"varint,9,opt,name=resource_id,json=resourceId,proto3,oneof" json:"resource_id,omitempty"`
+	// JSON encoded, with secrets redacted
+	Request       *string                `protobuf:"bytes,10,opt,name=request,proto3,oneof" json:"request,omitempty"`
+	Before        *string                `protobuf:"bytes,11,opt,name=before,proto3,oneof" json:"before,omitempty"`
+	After         *string                `protobuf:"bytes,12,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	Success       bool                   `protobuf:"varint,13,opt,name=success,proto3" json:"success,omitempty"`
+	Error         *string                `protobuf:"bytes,14,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	RequestId     string                 `protobuf:"bytes,15,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	SourceIp      string                 `protobuf:"bytes,16,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_shared_proto_audit_v1_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_audit_v1_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_shared_proto_audit_v1_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActorId() int64 {
+	if x != nil && x.ActorId != nil {
+		return *x.ActorId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetProcedure() string {
+	if x != nil {
+		return x.Procedure
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOrgId() int64 {
+	if x != nil && x.OrgId != nil {
+		return *x.OrgId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetWorkspaceId() int64 {
+	if x != nil && x.WorkspaceId != nil {
+		return *x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetAppId() int64 {
+	if x != nil && x.AppId != nil {
+		return *x.AppId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetResourceId() int64 {
+	if x != nil && x.ResourceId != nil {
+		return *x.ResourceId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetRequest() string {
+	if x != nil && x.Request != nil {
+		return *x.Request
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetBefore() string {
+	if x != nil && x.Before != nil {
+		return *x.Before
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AuditEvent) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetSourceIp() string {
+	if x != nil {
+		return x.SourceIp
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	AppId         *int64                 `protobuf:"varint,2,opt,name=app_id,json=appId,proto3,oneof" json:"app_id,omitempty"`
+	ActorId       *int64                 `protobuf:"varint,3,opt,name=actor_id,json=actorId,proto3,oneof" json:"actor_id,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=since,proto3,oneof" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=until,proto3,oneof" json:"until,omitempty"`
+	Limit         *int32                 `protobuf:"varint,6,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset        *int32                 `protobuf:"varint,7,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsRequest) Reset() {
+	*x = ListEventsRequest{}
+	mi := &file_shared_proto_audit_v1_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsRequest) ProtoMessage() {}
+
+func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_audit_v1_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_audit_v1_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListEventsRequest) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetAppId() int64 {
+	if x != nil && x.AppId != nil {
+		return *x.AppId
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetActorId() int64 {
+	if x != nil && x.ActorId != nil {
+		return *x.ActorId
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetOffset() int32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+type ListEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsResponse) Reset() {
+	*x = ListEventsResponse{}
+	mi := &file_shared_proto_audit_v1_audit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsResponse) ProtoMessage() {}
+
+func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_audit_v1_audit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_audit_v1_audit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListEventsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_shared_proto_audit_v1_audit_proto protoreflect.FileDescriptor
+
+const file_shared_proto_audit_v1_audit_proto_rawDesc = "" +
+	"\n" +
+	"!shared/proto/audit/v1/audit.proto\x12\rloco.audit.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x05\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1e\n" +
+	"\bactor_id\x18\x02 \x01(\x03H\x00R\aactorId\x88\x01\x01\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x1c\n" +
+	"\tprocedure\x18\x04 \x01(\tR\tprocedure\x12\x1a\n" +
+	"\x06org_id\x18\x05 \x01(\x03H\x01R\x05orgId\x88\x01\x01\x12&\n" +
+	"\fworkspace_id\x18\x06 \x01(\x03H\x02R\vworkspaceId\x88\x01\x01\x12\x1a\n" +
+	"\x06app_id\x18\a \x01(\x03H\x03R\x05appId\x88\x01\x01\x12#\n" +
+	"\rresource_type\x18\b \x01(\tR\fresourceType\x12$\n" +
+	"\vresource_id\x18\t \x01(\x03H\x04R\n" +
+	"resourceId\x88\x01\x01\x12\x1d\n" +
+	"\arequest\x18\n" +
+	" \x01(\tH\x05R\arequest\x88\x01\x01\x12\x1b\n" +
+	"\x06before\x18\v \x01(\tH\x06R\x06before\x88\x01\x01\x12\x19\n" +
+	"\x05after\x18\f \x01(\tH\aR\x05after\x88\x01\x01\x12\x18\n" +
+	"\asuccess\x18\r \x01(\bR\asuccess\x12\x19\n" +
+	"\x05error\x18\x0e \x01(\tH\bR\x05error\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x0f \x01(\tR\trequestId\x12\x1b\n" +
+	"\tsource_ip\x18\x10 \x01(\tR\bsourceIp\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\v\n" +
+	"\t_actor_idB\t\n" +
+	"\a_org_idB\x0f\n" +
+	"\r_workspace_idB\t\n" +
+	"\a_app_idB\x0e\n" +
+	"\f_resource_idB\n" +
+	"\n" +
+	"\b_requestB\t\n" +
+	"\a_beforeB\b\n" +
+	"\x06_afterB\b\n" +
+	"\x06_error\"\xd9\x02\n" +
+	"\x11ListEventsRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x03R\vworkspaceId\x12\x1a\n" +
+	"\x06app_id\x18\x02 \x01(\x03H\x00R\x05appId\x88\x01\x01\x12\x1e\n" +
+	"\bactor_id\x18\x03 \x01(\x03H\x01R\aactorId\x88\x01\x01\x125\n" +
+	"\x05since\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x05since\x88\x01\x01\x125\n" +
+	"\x05until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\x05until\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x06 \x01(\x05H\x04R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\a \x01(\x05H\x05R\x06offset\x88\x01\x01B\t\n" +
+	"\a_app_idB\v\n" +
+	"\t_actor_idB\b\n" +
+	"\x06_sinceB\b\n" +
+	"\x06_untilB\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_offset\"]\n" +
+	"\x12ListEventsResponse\x121\n" +
+	"\x06events\x18\x01 \x03(\v2\x19.loco.audit.v1.AuditEventR\x06events\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total2a\n" +
+	"\fAuditService\x12Q\n" +
+	"\n" +
+	"ListEvents\x12 .loco.audit.v1.ListEventsRequest\x1a!.loco.audit.v1.ListEventsResponseB;Z9github.com/nikumar1206/loco/shared/proto/audit/v1;auditv1b\x06proto3"
+
+var (
+	file_shared_proto_audit_v1_audit_proto_rawDescOnce sync.Once
+	file_shared_proto_audit_v1_audit_proto_rawDescData []byte
+)
+
+func file_shared_proto_audit_v1_audit_proto_rawDescGZIP() []byte {
+	file_shared_proto_audit_v1_audit_proto_rawDescOnce.Do(func() {
+		file_shared_proto_audit_v1_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shared_proto_audit_v1_audit_proto_rawDesc), len(file_shared_proto_audit_v1_audit_proto_rawDesc)))
+	})
+	return file_shared_proto_audit_v1_audit_proto_rawDescData
+}
+
+var file_shared_proto_audit_v1_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_shared_proto_audit_v1_audit_proto_goTypes = []any{
+	(*AuditEvent)(nil),            // 0: loco.audit.v1.AuditEvent
+	(*ListEventsRequest)(nil),     // 1: loco.audit.v1.ListEventsRequest
+	(*ListEventsResponse)(nil),    // 2: loco.audit.v1.ListEventsResponse
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_shared_proto_audit_v1_audit_proto_depIdxs = []int32{
+	3, // 0: loco.audit.v1.AuditEvent.created_at:type_name -> google.protobuf.Timestamp
+	3, // 1: loco.audit.v1.ListEventsRequest.since:type_name -> google.protobuf.Timestamp
+	3, // 2: loco.audit.v1.ListEventsRequest.until:type_name -> google.protobuf.Timestamp
+	0, // 3: loco.audit.v1.ListEventsResponse.events:type_name -> loco.audit.v1.AuditEvent
+	1, // 4: loco.audit.v1.AuditService.ListEvents:input_type -> loco.audit.v1.ListEventsRequest
+	2, // 5: loco.audit.v1.AuditService.ListEvents:output_type -> loco.audit.v1.ListEventsResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_shared_proto_audit_v1_audit_proto_init() }
+func file_shared_proto_audit_v1_audit_proto_init() {
+	if File_shared_proto_audit_v1_audit_proto != nil {
+		return
+	}
+	file_shared_proto_audit_v1_audit_proto_msgTypes[0].OneofWrappers = []any{}
+	file_shared_proto_audit_v1_audit_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_audit_v1_audit_proto_rawDesc), len(file_shared_proto_audit_v1_audit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shared_proto_audit_v1_audit_proto_goTypes,
+		DependencyIndexes: file_shared_proto_audit_v1_audit_proto_depIdxs,
+		MessageInfos:      file_shared_proto_audit_v1_audit_proto_msgTypes,
+	}.Build()
+	File_shared_proto_audit_v1_audit_proto = out.File
+	file_shared_proto_audit_v1_audit_proto_goTypes = nil
+	file_shared_proto_audit_v1_audit_proto_depIdxs = nil
+}
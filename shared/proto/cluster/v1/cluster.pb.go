@@ -0,0 +1,1664 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: shared/proto/cluster/v1/cluster.proto
+
+package clusterv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// credentials are never returned, has_credentials says whether the cluster has its own
+type Cluster struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Region          string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	Provider        string                 `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	Endpoint        *string                `protobuf:"bytes,5,opt,name=endpoint,proto3,oneof" json:"endpoint,omitempty"`
+	IsActive        bool                   `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	HealthStatus    string                 `protobuf:"bytes,7,opt,name=health_status,json=healthStatus,proto3" json:"health_status,omitempty"`
+	LastHealthCheck *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_health_check,json=lastHealthCheck,proto3,oneof" json:"last_health_check,omitempty"`
+	HasCredentials  bool                   `protobuf:"varint,9,opt,name=has_credentials,json=hasCredentials,proto3" json:"has_credentials,omitempty"`
+	CreatedBy       int64                  `protobuf:"varint,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Cluster) Reset() {
+	*x = Cluster{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cluster) ProtoMessage() {}
+
+func (x *Cluster) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cluster.ProtoReflect.Descriptor instead.
+func (*Cluster) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cluster) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Cluster) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cluster) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Cluster) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Cluster) GetEndpoint() string {
+	if x != nil && x.Endpoint != nil {
+		return *x.Endpoint
+	}
+	return ""
+}
+
+func (x *Cluster) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Cluster) GetHealthStatus() string {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return ""
+}
+
+func (x *Cluster) GetLastHealthCheck() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHealthCheck
+	}
+	return nil
+}
+
+func (x *Cluster) GetHasCredentials() bool {
+	if x != nil {
+		return x.HasCredentials
+	}
+	return false
+}
+
+func (x *Cluster) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *Cluster) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Cluster) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type RegisterClusterRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Region   string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Provider string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Endpoint *string                `protobuf:"bytes,4,opt,name=endpoint,proto3,oneof" json:"endpoint,omitempty"`
+	// kubeconfig for the cluster. omit it to register the cluster loco-api itself runs in.
+	Kubeconfig    *string `protobuf:"bytes,5,opt,name=kubeconfig,proto3,oneof" json:"kubeconfig,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterClusterRequest) Reset() {
+	*x = RegisterClusterRequest{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterClusterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterClusterRequest) ProtoMessage() {}
+
+func (x *RegisterClusterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterClusterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterClusterRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisterClusterRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterClusterRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RegisterClusterRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *RegisterClusterRequest) GetEndpoint() string {
+	if x != nil && x.Endpoint != nil {
+		return *x.Endpoint
+	}
+	return ""
+}
+
+func (x *RegisterClusterRequest) GetKubeconfig() string {
+	if x != nil && x.Kubeconfig != nil {
+		return *x.Kubeconfig
+	}
+	return ""
+}
+
+type RegisterClusterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cluster       *Cluster               `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterClusterResponse) Reset() {
+	*x = RegisterClusterResponse{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterClusterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterClusterResponse) ProtoMessage() {}
+
+func (x *RegisterClusterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterClusterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterClusterResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterClusterResponse) GetCluster() *Cluster {
+	if x != nil {
+		return x.Cluster
+	}
+	return nil
+}
+
+type ListClustersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClustersRequest) Reset() {
+	*x = ListClustersRequest{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClustersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClustersRequest) ProtoMessage() {}
+
+func (x *ListClustersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClustersRequest.ProtoReflect.Descriptor instead.
+func (*ListClustersRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{3}
+}
+
+type ListClustersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clusters      []*Cluster             `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClustersResponse) Reset() {
+	*x = ListClustersResponse{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClustersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClustersResponse) ProtoMessage() {}
+
+func (x *ListClustersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClustersResponse.ProtoReflect.Descriptor instead.
+func (*ListClustersResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListClustersResponse) GetClusters() []*Cluster {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+// deactivated clusters receive no new apps, apps already on them keep running
+type DeactivateClusterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateClusterRequest) Reset() {
+	*x = DeactivateClusterRequest{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateClusterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateClusterRequest) ProtoMessage() {}
+
+func (x *DeactivateClusterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateClusterRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateClusterRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeactivateClusterRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeactivateClusterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cluster       *Cluster               `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateClusterResponse) Reset() {
+	*x = DeactivateClusterResponse{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateClusterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateClusterResponse) ProtoMessage() {}
+
+func (x *DeactivateClusterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateClusterResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateClusterResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeactivateClusterResponse) GetCluster() *Cluster {
+	if x != nil {
+		return x.Cluster
+	}
+	return nil
+}
+
+// cpu is in millicores and memory in MiB
+type NodeCapacity struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Name                     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AllocatableCpuMillicores int64                  `protobuf:"varint,2,opt,name=allocatable_cpu_millicores,json=allocatableCpuMillicores,proto3" json:"allocatable_cpu_millicores,omitempty"`
+	AllocatableMemoryMb      int64                  `protobuf:"varint,3,opt,name=allocatable_memory_mb,json=allocatableMemoryMb,proto3" json:"allocatable_memory_mb,omitempty"`
+	RequestedCpuMillicores   int64                  `protobuf:"varint,4,opt,name=requested_cpu_millicores,json=requestedCpuMillicores,proto3" json:"requested_cpu_millicores,omitempty"`
+	RequestedMemoryMb        int64                  `protobuf:"varint,5,opt,name=requested_memory_mb,json=requestedMemoryMb,proto3" json:"requested_memory_mb,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *NodeCapacity) Reset() {
+	*x = NodeCapacity{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeCapacity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeCapacity) ProtoMessage() {}
+
+func (x *NodeCapacity) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeCapacity.ProtoReflect.Descriptor instead.
+func (*NodeCapacity) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NodeCapacity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodeCapacity) GetAllocatableCpuMillicores() int64 {
+	if x != nil {
+		return x.AllocatableCpuMillicores
+	}
+	return 0
+}
+
+func (x *NodeCapacity) GetAllocatableMemoryMb() int64 {
+	if x != nil {
+		return x.AllocatableMemoryMb
+	}
+	return 0
+}
+
+func (x *NodeCapacity) GetRequestedCpuMillicores() int64 {
+	if x != nil {
+		return x.RequestedCpuMillicores
+	}
+	return 0
+}
+
+func (x *NodeCapacity) GetRequestedMemoryMb() int64 {
+	if x != nil {
+		return x.RequestedMemoryMb
+	}
+	return 0
+}
+
+type GetCapacityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClusterId     int64                  `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapacityRequest) Reset() {
+	*x = GetCapacityRequest{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapacityRequest) ProtoMessage() {}
+
+func (x *GetCapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapacityRequest.ProtoReflect.Descriptor instead.
+func (*GetCapacityRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCapacityRequest) GetClusterId() int64 {
+	if x != nil {
+		return x.ClusterId
+	}
+	return 0
+}
+
+type GetCapacityResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Nodes                    []*NodeCapacity        `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	AllocatableCpuMillicores int64                  `protobuf:"varint,2,opt,name=allocatable_cpu_millicores,json=allocatableCpuMillicores,proto3" json:"allocatable_cpu_millicores,omitempty"`
+	AllocatableMemoryMb      int64                  `protobuf:"varint,3,opt,name=allocatable_memory_mb,json=allocatableMemoryMb,proto3" json:"allocatable_memory_mb,omitempty"`
+	FreeCpuMillicores        int64                  `protobuf:"varint,4,opt,name=free_cpu_millicores,json=freeCpuMillicores,proto3" json:"free_cpu_millicores,omitempty"`
+	FreeMemoryMb             int64                  `protobuf:"varint,5,opt,name=free_memory_mb,json=freeMemoryMb,proto3" json:"free_memory_mb,omitempty"`
+	// requests of pods that haven't been scheduled yet
+	PendingCpuMillicores int64 `protobuf:"varint,6,opt,name=pending_cpu_millicores,json=pendingCpuMillicores,proto3" json:"pending_cpu_millicores,omitempty"`
+	PendingMemoryMb      int64 `protobuf:"varint,7,opt,name=pending_memory_mb,json=pendingMemoryMb,proto3" json:"pending_memory_mb,omitempty"`
+	// deployments must fit headroom times their requests in free capacity
+	Headroom      float64 `protobuf:"fixed64,8,opt,name=headroom,proto3" json:"headroom,omitempty"`
+	ClusterId     int64   `protobuf:"varint,9,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapacityResponse) Reset() {
+	*x = GetCapacityResponse{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapacityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapacityResponse) ProtoMessage() {}
+
+func (x *GetCapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapacityResponse.ProtoReflect.Descriptor instead.
+func (*GetCapacityResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetCapacityResponse) GetNodes() []*NodeCapacity {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *GetCapacityResponse) GetAllocatableCpuMillicores() int64 {
+	if x != nil {
+		return x.AllocatableCpuMillicores
+	}
+	return 0
+}
+
+func (x *GetCapacityResponse) GetAllocatableMemoryMb() int64 {
+	if x != nil {
+		return x.AllocatableMemoryMb
+	}
+	return 0
+}
+
+func (x *GetCapacityResponse) GetFreeCpuMillicores() int64 {
+	if x != nil {
+		return x.FreeCpuMillicores
+	}
+	return 0
+}
+
+func (x *GetCapacityResponse) GetFreeMemoryMb() int64 {
+	if x != nil {
+		return x.FreeMemoryMb
+	}
+	return 0
+}
+
+func (x *GetCapacityResponse) GetPendingCpuMillicores() int64 {
+	if x != nil {
+		return x.PendingCpuMillicores
+	}
+	return 0
+}
+
+func (x *GetCapacityResponse) GetPendingMemoryMb() int64 {
+	if x != nil {
+		return x.PendingMemoryMb
+	}
+	return 0
+}
+
+func (x *GetCapacityResponse) GetHeadroom() float64 {
+	if x != nil {
+		return x.Headroom
+	}
+	return 0
+}
+
+func (x *GetCapacityResponse) GetClusterId() int64 {
+	if x != nil {
+		return x.ClusterId
+	}
+	return 0
+}
+
+// critical checks failing make a cluster unhealthy, the rest only degrade it
+type HealthCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Ok            bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Critical      bool                   `protobuf:"varint,4,opt,name=critical,proto3" json:"critical,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheck) Reset() {
+	*x = HealthCheck{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheck) ProtoMessage() {}
+
+func (x *HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HealthCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *HealthCheck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetCritical() bool {
+	if x != nil {
+		return x.Critical
+	}
+	return false
+}
+
+type HealthCheckRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Checks        []*HealthCheck         `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckRun) Reset() {
+	*x = HealthCheckRun{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckRun) ProtoMessage() {}
+
+func (x *HealthCheckRun) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckRun.ProtoReflect.Descriptor instead.
+func (*HealthCheckRun) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HealthCheckRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthCheckRun) GetChecks() []*HealthCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *HealthCheckRun) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+type GetClusterHealthRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ClusterId int64                  `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	// number of runs to return, most recent first. defaults to 20
+	Limit         *int32 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClusterHealthRequest) Reset() {
+	*x = GetClusterHealthRequest{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClusterHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClusterHealthRequest) ProtoMessage() {}
+
+func (x *GetClusterHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClusterHealthRequest.ProtoReflect.Descriptor instead.
+func (*GetClusterHealthRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetClusterHealthRequest) GetClusterId() int64 {
+	if x != nil {
+		return x.ClusterId
+	}
+	return 0
+}
+
+func (x *GetClusterHealthRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type GetClusterHealthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cluster       *Cluster               `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	History       []*HealthCheckRun      `protobuf:"bytes,2,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClusterHealthResponse) Reset() {
+	*x = GetClusterHealthResponse{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClusterHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClusterHealthResponse) ProtoMessage() {}
+
+func (x *GetClusterHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClusterHealthResponse.ProtoReflect.Descriptor instead.
+func (*GetClusterHealthResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetClusterHealthResponse) GetCluster() *Cluster {
+	if x != nil {
+		return x.Cluster
+	}
+	return nil
+}
+
+func (x *GetClusterHealthResponse) GetHistory() []*HealthCheckRun {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type DriftedObject struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// missing, modified or unexpected
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// what differs. secret values are never included
+	Fields        []string `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriftedObject) Reset() {
+	*x = DriftedObject{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriftedObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriftedObject) ProtoMessage() {}
+
+func (x *DriftedObject) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriftedObject.ProtoReflect.Descriptor instead.
+func (*DriftedObject) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DriftedObject) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DriftedObject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DriftedObject) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DriftedObject) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type AppDrift struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	WorkspaceId   int64                  `protobuf:"varint,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	ClusterId     int64                  `protobuf:"varint,3,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	AppName       string                 `protobuf:"bytes,4,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	DeploymentId  int64                  `protobuf:"varint,6,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Objects       []*DriftedObject       `protobuf:"bytes,7,rep,name=objects,proto3" json:"objects,omitempty"`
+	Repaired      bool                   `protobuf:"varint,8,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	Error         *string                `protobuf:"bytes,9,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppDrift) Reset() {
+	*x = AppDrift{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppDrift) ProtoMessage() {}
+
+func (x *AppDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppDrift.ProtoReflect.Descriptor instead.
+func (*AppDrift) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AppDrift) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *AppDrift) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *AppDrift) GetClusterId() int64 {
+	if x != nil {
+		return x.ClusterId
+	}
+	return 0
+}
+
+func (x *AppDrift) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *AppDrift) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AppDrift) GetDeploymentId() int64 {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return 0
+}
+
+func (x *AppDrift) GetObjects() []*DriftedObject {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+func (x *AppDrift) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+func (x *AppDrift) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+// a namespace labelled as managed by loco without an app row behind it
+type OrphanedNamespace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClusterId     int64                  `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrphanedNamespace) Reset() {
+	*x = OrphanedNamespace{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrphanedNamespace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrphanedNamespace) ProtoMessage() {}
+
+func (x *OrphanedNamespace) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrphanedNamespace.ProtoReflect.Descriptor instead.
+func (*OrphanedNamespace) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OrphanedNamespace) GetClusterId() int64 {
+	if x != nil {
+		return x.ClusterId
+	}
+	return 0
+}
+
+func (x *OrphanedNamespace) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *OrphanedNamespace) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type DetectDriftRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// scan a single cluster. omit to scan every cluster
+	ClusterId *int64 `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3,oneof" json:"cluster_id,omitempty"`
+	// re-apply drifted objects from the current deployment. orphaned namespaces are never deleted
+	Repair        bool `protobuf:"varint,2,opt,name=repair,proto3" json:"repair,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectDriftRequest) Reset() {
+	*x = DetectDriftRequest{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectDriftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectDriftRequest) ProtoMessage() {}
+
+func (x *DetectDriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectDriftRequest.ProtoReflect.Descriptor instead.
+func (*DetectDriftRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DetectDriftRequest) GetClusterId() int64 {
+	if x != nil && x.ClusterId != nil {
+		return *x.ClusterId
+	}
+	return 0
+}
+
+func (x *DetectDriftRequest) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+type DetectDriftResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only apps with drift or that couldn't be checked
+	Apps               []*AppDrift            `protobuf:"bytes,1,rep,name=apps,proto3" json:"apps,omitempty"`
+	OrphanedNamespaces []*OrphanedNamespace   `protobuf:"bytes,2,rep,name=orphaned_namespaces,json=orphanedNamespaces,proto3" json:"orphaned_namespaces,omitempty"`
+	CheckedAt          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DetectDriftResponse) Reset() {
+	*x = DetectDriftResponse{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectDriftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectDriftResponse) ProtoMessage() {}
+
+func (x *DetectDriftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectDriftResponse.ProtoReflect.Descriptor instead.
+func (*DetectDriftResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DetectDriftResponse) GetApps() []*AppDrift {
+	if x != nil {
+		return x.Apps
+	}
+	return nil
+}
+
+func (x *DetectDriftResponse) GetOrphanedNamespaces() []*OrphanedNamespace {
+	if x != nil {
+		return x.OrphanedNamespaces
+	}
+	return nil
+}
+
+func (x *DetectDriftResponse) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+// rebuilds every app on the source cluster onto the target from its current deployment.
+// each app moves to the target once it's ready there, so a partial rebuild can be run again.
+type RebuildClusterRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SourceClusterId int64                  `protobuf:"varint,1,opt,name=source_cluster_id,json=sourceClusterId,proto3" json:"source_cluster_id,omitempty"`
+	TargetClusterId int64                  `protobuf:"varint,2,opt,name=target_cluster_id,json=targetClusterId,proto3" json:"target_cluster_id,omitempty"`
+	// apps rebuilt at once, defaults to 4
+	Concurrency   *int32 `protobuf:"varint,3,opt,name=concurrency,proto3,oneof" json:"concurrency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildClusterRequest) Reset() {
+	*x = RebuildClusterRequest{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildClusterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildClusterRequest) ProtoMessage() {}
+
+func (x *RebuildClusterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildClusterRequest.ProtoReflect.Descriptor instead.
+func (*RebuildClusterRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RebuildClusterRequest) GetSourceClusterId() int64 {
+	if x != nil {
+		return x.SourceClusterId
+	}
+	return 0
+}
+
+func (x *RebuildClusterRequest) GetTargetClusterId() int64 {
+	if x != nil {
+		return x.TargetClusterId
+	}
+	return 0
+}
+
+func (x *RebuildClusterRequest) GetConcurrency() int32 {
+	if x != nil && x.Concurrency != nil {
+		return *x.Concurrency
+	}
+	return 0
+}
+
+// one message per phase change of an app
+type RebuildClusterResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AppId     int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	AppName   string                 `protobuf:"bytes,2,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	Namespace string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// queued, allocating, waiting, done, skipped or failed
+	Phase   string `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// apps that have finished, whatever the outcome
+	Completed     int32 `protobuf:"varint,6,opt,name=completed,proto3" json:"completed,omitempty"`
+	Total         int32 `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildClusterResponse) Reset() {
+	*x = RebuildClusterResponse{}
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildClusterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildClusterResponse) ProtoMessage() {}
+
+func (x *RebuildClusterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_cluster_v1_cluster_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildClusterResponse.ProtoReflect.Descriptor instead.
+func (*RebuildClusterResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RebuildClusterResponse) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *RebuildClusterResponse) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *RebuildClusterResponse) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RebuildClusterResponse) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *RebuildClusterResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RebuildClusterResponse) GetCompleted() int32 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *RebuildClusterResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_shared_proto_cluster_v1_cluster_proto protoreflect.FileDescriptor
+
+const file_shared_proto_cluster_v1_cluster_proto_rawDesc = "" +
+	"\n" +
+	"%shared/proto/cluster/v1/cluster.proto\x12\x0floco.cluster.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf2\x03\n" +
+	"\aCluster\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12\x1f\n" +
+	"\bendpoint\x18\x05 \x01(\tH\x00R\bendpoint\x88\x01\x01\x12\x1b\n" +
+	"\tis_active\x18\x06 \x01(\bR\bisActive\x12#\n" +
+	"\rhealth_status\x18\a \x01(\tR\fhealthStatus\x12K\n" +
+	"\x11last_health_check\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x0flastHealthCheck\x88\x01\x01\x12'\n" +
+	"\x0fhas_credentials\x18\t \x01(\bR\x0ehasCredentials\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\n" +
+	" \x01(\x03R\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\v\n" +
+	"\t_endpointB\x14\n" +
+	"\x12_last_health_check\"\xc2\x01\n" +
+	"\x16RegisterClusterRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x1f\n" +
+	"\bendpoint\x18\x04 \x01(\tH\x00R\bendpoint\x88\x01\x01\x12#\n" +
+	"\n" +
+	"kubeconfig\x18\x05 \x01(\tH\x01R\n" +
+	"kubeconfig\x88\x01\x01B\v\n" +
+	"\t_endpointB\r\n" +
+	"\v_kubeconfig\"M\n" +
+	"\x17RegisterClusterResponse\x122\n" +
+	"\acluster\x18\x01 \x01(\v2\x18.loco.cluster.v1.ClusterR\acluster\"\x15\n" +
+	"\x13ListClustersRequest\"L\n" +
+	"\x14ListClustersResponse\x124\n" +
+	"\bclusters\x18\x01 \x03(\v2\x18.loco.cluster.v1.ClusterR\bclusters\"*\n" +
+	"\x18DeactivateClusterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"O\n" +
+	"\x19DeactivateClusterResponse\x122\n" +
+	"\acluster\x18\x01 \x01(\v2\x18.loco.cluster.v1.ClusterR\acluster\"\xfe\x01\n" +
+	"\fNodeCapacity\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12<\n" +
+	"\x1aallocatable_cpu_millicores\x18\x02 \x01(\x03R\x18allocatableCpuMillicores\x122\n" +
+	"\x15allocatable_memory_mb\x18\x03 \x01(\x03R\x13allocatableMemoryMb\x128\n" +
+	"\x18requested_cpu_millicores\x18\x04 \x01(\x03R\x16requestedCpuMillicores\x12.\n" +
+	"\x13requested_memory_mb\x18\x05 \x01(\x03R\x11requestedMemoryMb\"3\n" +
+	"\x12GetCapacityRequest\x12\x1d\n" +
+	"\n" +
+	"cluster_id\x18\x01 \x01(\x03R\tclusterId\"\xaf\x03\n" +
+	"\x13GetCapacityResponse\x123\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x1d.loco.cluster.v1.NodeCapacityR\x05nodes\x12<\n" +
+	"\x1aallocatable_cpu_millicores\x18\x02 \x01(\x03R\x18allocatableCpuMillicores\x122\n" +
+	"\x15allocatable_memory_mb\x18\x03 \x01(\x03R\x13allocatableMemoryMb\x12.\n" +
+	"\x13free_cpu_millicores\x18\x04 \x01(\x03R\x11freeCpuMillicores\x12$\n" +
+	"\x0efree_memory_mb\x18\x05 \x01(\x03R\ffreeMemoryMb\x124\n" +
+	"\x16pending_cpu_millicores\x18\x06 \x01(\x03R\x14pendingCpuMillicores\x12*\n" +
+	"\x11pending_memory_mb\x18\a \x01(\x03R\x0fpendingMemoryMb\x12\x1a\n" +
+	"\bheadroom\x18\b \x01(\x01R\bheadroom\x12\x1d\n" +
+	"\n" +
+	"cluster_id\x18\t \x01(\x03R\tclusterId\"g\n" +
+	"\vHealthCheck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1a\n" +
+	"\bcritical\x18\x04 \x01(\bR\bcritical\"\x99\x01\n" +
+	"\x0eHealthCheckRun\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x124\n" +
+	"\x06checks\x18\x02 \x03(\v2\x1c.loco.cluster.v1.HealthCheckR\x06checks\x129\n" +
+	"\n" +
+	"checked_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\"]\n" +
+	"\x17GetClusterHealthRequest\x12\x1d\n" +
+	"\n" +
+	"cluster_id\x18\x01 \x01(\x03R\tclusterId\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x05H\x00R\x05limit\x88\x01\x01B\b\n" +
+	"\x06_limit\"\x89\x01\n" +
+	"\x18GetClusterHealthResponse\x122\n" +
+	"\acluster\x18\x01 \x01(\v2\x18.loco.cluster.v1.ClusterR\acluster\x129\n" +
+	"\ahistory\x18\x02 \x03(\v2\x1f.loco.cluster.v1.HealthCheckRunR\ahistory\"g\n" +
+	"\rDriftedObject\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\"\xbc\x02\n" +
+	"\bAppDrift\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\x03R\vworkspaceId\x12\x1d\n" +
+	"\n" +
+	"cluster_id\x18\x03 \x01(\x03R\tclusterId\x12\x19\n" +
+	"\bapp_name\x18\x04 \x01(\tR\aappName\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12#\n" +
+	"\rdeployment_id\x18\x06 \x01(\x03R\fdeploymentId\x128\n" +
+	"\aobjects\x18\a \x03(\v2\x1e.loco.cluster.v1.DriftedObjectR\aobjects\x12\x1a\n" +
+	"\brepaired\x18\b \x01(\bR\brepaired\x12\x19\n" +
+	"\x05error\x18\t \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"\x8b\x01\n" +
+	"\x11OrphanedNamespace\x12\x1d\n" +
+	"\n" +
+	"cluster_id\x18\x01 \x01(\x03R\tclusterId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"_\n" +
+	"\x12DetectDriftRequest\x12\"\n" +
+	"\n" +
+	"cluster_id\x18\x01 \x01(\x03H\x00R\tclusterId\x88\x01\x01\x12\x16\n" +
+	"\x06repair\x18\x02 \x01(\bR\x06repairB\r\n" +
+	"\v_cluster_id\"\xd4\x01\n" +
+	"\x13DetectDriftResponse\x12-\n" +
+	"\x04apps\x18\x01 \x03(\v2\x19.loco.cluster.v1.AppDriftR\x04apps\x12S\n" +
+	"\x13orphaned_namespaces\x18\x02 \x03(\v2\".loco.cluster.v1.OrphanedNamespaceR\x12orphanedNamespaces\x129\n" +
+	"\n" +
+	"checked_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\"\xa6\x01\n" +
+	"\x15RebuildClusterRequest\x12*\n" +
+	"\x11source_cluster_id\x18\x01 \x01(\x03R\x0fsourceClusterId\x12*\n" +
+	"\x11target_cluster_id\x18\x02 \x01(\x03R\x0ftargetClusterId\x12%\n" +
+	"\vconcurrency\x18\x03 \x01(\x05H\x00R\vconcurrency\x88\x01\x01B\x0e\n" +
+	"\f_concurrency\"\xcc\x01\n" +
+	"\x16RebuildClusterResponse\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x19\n" +
+	"\bapp_name\x18\x02 \x01(\tR\aappName\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05phase\x18\x04 \x01(\tR\x05phase\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1c\n" +
+	"\tcompleted\x18\x06 \x01(\x05R\tcompleted\x12\x14\n" +
+	"\x05total\x18\a \x01(\x05R\x05total2\xc1\x05\n" +
+	"\x0eClusterService\x12d\n" +
+	"\x0fRegisterCluster\x12'.loco.cluster.v1.RegisterClusterRequest\x1a(.loco.cluster.v1.RegisterClusterResponse\x12[\n" +
+	"\fListClusters\x12$.loco.cluster.v1.ListClustersRequest\x1a%.loco.cluster.v1.ListClustersResponse\x12j\n" +
+	"\x11DeactivateCluster\x12).loco.cluster.v1.DeactivateClusterRequest\x1a*.loco.cluster.v1.DeactivateClusterResponse\x12X\n" +
+	"\vGetCapacity\x12#.loco.cluster.v1.GetCapacityRequest\x1a$.loco.cluster.v1.GetCapacityResponse\x12g\n" +
+	"\x10GetClusterHealth\x12(.loco.cluster.v1.GetClusterHealthRequest\x1a).loco.cluster.v1.GetClusterHealthResponse\x12X\n" +
+	"\vDetectDrift\x12#.loco.cluster.v1.DetectDriftRequest\x1a$.loco.cluster.v1.DetectDriftResponse\x12c\n" +
+	"\x0eRebuildCluster\x12&.loco.cluster.v1.RebuildClusterRequest\x1a'.loco.cluster.v1.RebuildClusterResponse0\x01B?Z=github.com/nikumar1206/loco/shared/proto/cluster/v1;clusterv1b\x06proto3"
+
+var (
+	file_shared_proto_cluster_v1_cluster_proto_rawDescOnce sync.Once
+	file_shared_proto_cluster_v1_cluster_proto_rawDescData []byte
+)
+
+func file_shared_proto_cluster_v1_cluster_proto_rawDescGZIP() []byte {
+	file_shared_proto_cluster_v1_cluster_proto_rawDescOnce.Do(func() {
+		file_shared_proto_cluster_v1_cluster_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shared_proto_cluster_v1_cluster_proto_rawDesc), len(file_shared_proto_cluster_v1_cluster_proto_rawDesc)))
+	})
+	return file_shared_proto_cluster_v1_cluster_proto_rawDescData
+}
+
+var file_shared_proto_cluster_v1_cluster_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_shared_proto_cluster_v1_cluster_proto_goTypes = []any{
+	(*Cluster)(nil),                   // 0: loco.cluster.v1.Cluster
+	(*RegisterClusterRequest)(nil),    // 1: loco.cluster.v1.RegisterClusterRequest
+	(*RegisterClusterResponse)(nil),   // 2: loco.cluster.v1.RegisterClusterResponse
+	(*ListClustersRequest)(nil),       // 3: loco.cluster.v1.ListClustersRequest
+	(*ListClustersResponse)(nil),      // 4: loco.cluster.v1.ListClustersResponse
+	(*DeactivateClusterRequest)(nil),  // 5: loco.cluster.v1.DeactivateClusterRequest
+	(*DeactivateClusterResponse)(nil), // 6: loco.cluster.v1.DeactivateClusterResponse
+	(*NodeCapacity)(nil),              // 7: loco.cluster.v1.NodeCapacity
+	(*GetCapacityRequest)(nil),        // 8: loco.cluster.v1.GetCapacityRequest
+	(*GetCapacityResponse)(nil),       // 9: loco.cluster.v1.GetCapacityResponse
+	(*HealthCheck)(nil),               // 10: loco.cluster.v1.HealthCheck
+	(*HealthCheckRun)(nil),            // 11: loco.cluster.v1.HealthCheckRun
+	(*GetClusterHealthRequest)(nil),   // 12: loco.cluster.v1.GetClusterHealthRequest
+	(*GetClusterHealthResponse)(nil),  // 13: loco.cluster.v1.GetClusterHealthResponse
+	(*DriftedObject)(nil),             // 14: loco.cluster.v1.DriftedObject
+	(*AppDrift)(nil),                  // 15: loco.cluster.v1.AppDrift
+	(*OrphanedNamespace)(nil),         // 16: loco.cluster.v1.OrphanedNamespace
+	(*DetectDriftRequest)(nil),        // 17: loco.cluster.v1.DetectDriftRequest
+	(*DetectDriftResponse)(nil),       // 18: loco.cluster.v1.DetectDriftResponse
+	(*RebuildClusterRequest)(nil),     // 19: loco.cluster.v1.RebuildClusterRequest
+	(*RebuildClusterResponse)(nil),    // 20: loco.cluster.v1.RebuildClusterResponse
+	(*timestamppb.Timestamp)(nil),     // 21: google.protobuf.Timestamp
+}
+var file_shared_proto_cluster_v1_cluster_proto_depIdxs = []int32{
+	21, // 0: loco.cluster.v1.Cluster.last_health_check:type_name -> google.protobuf.Timestamp
+	21, // 1: loco.cluster.v1.Cluster.created_at:type_name -> google.protobuf.Timestamp
+	21, // 2: loco.cluster.v1.Cluster.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: loco.cluster.v1.RegisterClusterResponse.cluster:type_name -> loco.cluster.v1.Cluster
+	0,  // 4: loco.cluster.v1.ListClustersResponse.clusters:type_name -> loco.cluster.v1.Cluster
+	0,  // 5: loco.cluster.v1.DeactivateClusterResponse.cluster:type_name -> loco.cluster.v1.Cluster
+	7,  // 6: loco.cluster.v1.GetCapacityResponse.nodes:type_name -> loco.cluster.v1.NodeCapacity
+	10, // 7: loco.cluster.v1.HealthCheckRun.checks:type_name -> loco.cluster.v1.HealthCheck
+	21, // 8: loco.cluster.v1.HealthCheckRun.checked_at:type_name -> google.protobuf.Timestamp
+	0,  // 9: loco.cluster.v1.GetClusterHealthResponse.cluster:type_name -> loco.cluster.v1.Cluster
+	11, // 10: loco.cluster.v1.GetClusterHealthResponse.history:type_name -> loco.cluster.v1.HealthCheckRun
+	14, // 11: loco.cluster.v1.AppDrift.objects:type_name -> loco.cluster.v1.DriftedObject
+	21, // 12: loco.cluster.v1.OrphanedNamespace.created_at:type_name -> google.protobuf.Timestamp
+	15, // 13: loco.cluster.v1.DetectDriftResponse.apps:type_name -> loco.cluster.v1.AppDrift
+	16, // 14: loco.cluster.v1.DetectDriftResponse.orphaned_namespaces:type_name -> loco.cluster.v1.OrphanedNamespace
+	21, // 15: loco.cluster.v1.DetectDriftResponse.checked_at:type_name -> google.protobuf.Timestamp
+	1,  // 16: loco.cluster.v1.ClusterService.RegisterCluster:input_type -> loco.cluster.v1.RegisterClusterRequest
+	3,  // 17: loco.cluster.v1.ClusterService.ListClusters:input_type -> loco.cluster.v1.ListClustersRequest
+	5,  // 18: loco.cluster.v1.ClusterService.DeactivateCluster:input_type -> loco.cluster.v1.DeactivateClusterRequest
+	8,  // 19: loco.cluster.v1.ClusterService.GetCapacity:input_type -> loco.cluster.v1.GetCapacityRequest
+	12, // 20: loco.cluster.v1.ClusterService.GetClusterHealth:input_type -> loco.cluster.v1.GetClusterHealthRequest
+	17, // 21: loco.cluster.v1.ClusterService.DetectDrift:input_type -> loco.cluster.v1.DetectDriftRequest
+	19, // 22: loco.cluster.v1.ClusterService.RebuildCluster:input_type -> loco.cluster.v1.RebuildClusterRequest
+	2,  // 23: loco.cluster.v1.ClusterService.RegisterCluster:output_type -> loco.cluster.v1.RegisterClusterResponse
+	4,  // 24: loco.cluster.v1.ClusterService.ListClusters:output_type -> loco.cluster.v1.ListClustersResponse
+	6,  // 25: loco.cluster.v1.ClusterService.DeactivateCluster:output_type -> loco.cluster.v1.DeactivateClusterResponse
+	9,  // 26: loco.cluster.v1.ClusterService.GetCapacity:output_type -> loco.cluster.v1.GetCapacityResponse
+	13, // 27: loco.cluster.v1.ClusterService.GetClusterHealth:output_type -> loco.cluster.v1.GetClusterHealthResponse
+	18, // 28: loco.cluster.v1.ClusterService.DetectDrift:output_type -> loco.cluster.v1.DetectDriftResponse
+	20, // 29: loco.cluster.v1.ClusterService.RebuildCluster:output_type -> loco.cluster.v1.RebuildClusterResponse
+	23, // [23:30] is the sub-list for method output_type
+	16, // [16:23] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_shared_proto_cluster_v1_cluster_proto_init() }
+func file_shared_proto_cluster_v1_cluster_proto_init() {
+	if File_shared_proto_cluster_v1_cluster_proto != nil {
+		return
+	}
+	file_shared_proto_cluster_v1_cluster_proto_msgTypes[0].OneofWrappers = []any{}
+	file_shared_proto_cluster_v1_cluster_proto_msgTypes[1].OneofWrappers = []any{}
+	file_shared_proto_cluster_v1_cluster_proto_msgTypes[12].OneofWrappers = []any{}
+	file_shared_proto_cluster_v1_cluster_proto_msgTypes[15].OneofWrappers = []any{}
+	file_shared_proto_cluster_v1_cluster_proto_msgTypes[17].OneofWrappers = []any{}
+	file_shared_proto_cluster_v1_cluster_proto_msgTypes[19].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_cluster_v1_cluster_proto_rawDesc), len(file_shared_proto_cluster_v1_cluster_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shared_proto_cluster_v1_cluster_proto_goTypes,
+		DependencyIndexes: file_shared_proto_cluster_v1_cluster_proto_depIdxs,
+		MessageInfos:      file_shared_proto_cluster_v1_cluster_proto_msgTypes,
+	}.Build()
+	File_shared_proto_cluster_v1_cluster_proto = out.File
+	file_shared_proto_cluster_v1_cluster_proto_goTypes = nil
+	file_shared_proto_cluster_v1_cluster_proto_depIdxs = nil
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: shared/proto/cluster/v1/cluster.proto
+
+package clusterv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/nikumar1206/loco/shared/proto/cluster/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ClusterServiceName is the fully-qualified name of the ClusterService service.
+	ClusterServiceName = "loco.cluster.v1.ClusterService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ClusterServiceRegisterClusterProcedure is the fully-qualified name of the ClusterService's
+	// RegisterCluster RPC.
+	ClusterServiceRegisterClusterProcedure = "/loco.cluster.v1.ClusterService/RegisterCluster"
+	// ClusterServiceListClustersProcedure is the fully-qualified name of the ClusterService's
+	// ListClusters RPC.
+	ClusterServiceListClustersProcedure = "/loco.cluster.v1.ClusterService/ListClusters"
+	// ClusterServiceDeactivateClusterProcedure is the fully-qualified name of the ClusterService's
+	// DeactivateCluster RPC.
+	ClusterServiceDeactivateClusterProcedure = "/loco.cluster.v1.ClusterService/DeactivateCluster"
+	// ClusterServiceGetCapacityProcedure is the fully-qualified name of the ClusterService's
+	// GetCapacity RPC.
+	ClusterServiceGetCapacityProcedure = "/loco.cluster.v1.ClusterService/GetCapacity"
+	// ClusterServiceGetClusterHealthProcedure is the fully-qualified name of the ClusterService's
+	// GetClusterHealth RPC.
+	ClusterServiceGetClusterHealthProcedure = "/loco.cluster.v1.ClusterService/GetClusterHealth"
+	// ClusterServiceDetectDriftProcedure is the fully-qualified name of the ClusterService's
+	// DetectDrift RPC.
+	ClusterServiceDetectDriftProcedure = "/loco.cluster.v1.ClusterService/DetectDrift"
+	// ClusterServiceRebuildClusterProcedure is the fully-qualified name of the ClusterService's
+	// RebuildCluster RPC.
+	ClusterServiceRebuildClusterProcedure = "/loco.cluster.v1.ClusterService/RebuildCluster"
+)
+
+// ClusterServiceClient is a client for the loco.cluster.v1.ClusterService service.
+type ClusterServiceClient interface {
+	RegisterCluster(context.Context, *connect.Request[v1.RegisterClusterRequest]) (*connect.Response[v1.RegisterClusterResponse], error)
+	ListClusters(context.Context, *connect.Request[v1.ListClustersRequest]) (*connect.Response[v1.ListClustersResponse], error)
+	DeactivateCluster(context.Context, *connect.Request[v1.DeactivateClusterRequest]) (*connect.Response[v1.DeactivateClusterResponse], error)
+	GetCapacity(context.Context, *connect.Request[v1.GetCapacityRequest]) (*connect.Response[v1.GetCapacityResponse], error)
+	GetClusterHealth(context.Context, *connect.Request[v1.GetClusterHealthRequest]) (*connect.Response[v1.GetClusterHealthResponse], error)
+	DetectDrift(context.Context, *connect.Request[v1.DetectDriftRequest]) (*connect.Response[v1.DetectDriftResponse], error)
+	RebuildCluster(context.Context, *connect.Request[v1.RebuildClusterRequest]) (*connect.ServerStreamForClient[v1.RebuildClusterResponse], error)
+}
+
+// NewClusterServiceClient constructs a client for the loco.cluster.v1.ClusterService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewClusterServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ClusterServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	clusterServiceMethods := v1.File_shared_proto_cluster_v1_cluster_proto.Services().ByName("ClusterService").Methods()
+	return &clusterServiceClient{
+		registerCluster: connect.NewClient[v1.RegisterClusterRequest, v1.RegisterClusterResponse](
+			httpClient,
+			baseURL+ClusterServiceRegisterClusterProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("RegisterCluster")),
+			connect.WithClientOptions(opts...),
+		),
+		listClusters: connect.NewClient[v1.ListClustersRequest, v1.ListClustersResponse](
+			httpClient,
+			baseURL+ClusterServiceListClustersProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("ListClusters")),
+			connect.WithClientOptions(opts...),
+		),
+		deactivateCluster: connect.NewClient[v1.DeactivateClusterRequest, v1.DeactivateClusterResponse](
+			httpClient,
+			baseURL+ClusterServiceDeactivateClusterProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("DeactivateCluster")),
+			connect.WithClientOptions(opts...),
+		),
+		getCapacity: connect.NewClient[v1.GetCapacityRequest, v1.GetCapacityResponse](
+			httpClient,
+			baseURL+ClusterServiceGetCapacityProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("GetCapacity")),
+			connect.WithClientOptions(opts...),
+		),
+		getClusterHealth: connect.NewClient[v1.GetClusterHealthRequest, v1.GetClusterHealthResponse](
+			httpClient,
+			baseURL+ClusterServiceGetClusterHealthProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("GetClusterHealth")),
+			connect.WithClientOptions(opts...),
+		),
+		detectDrift: connect.NewClient[v1.DetectDriftRequest, v1.DetectDriftResponse](
+			httpClient,
+			baseURL+ClusterServiceDetectDriftProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("DetectDrift")),
+			connect.WithClientOptions(opts...),
+		),
+		rebuildCluster: connect.NewClient[v1.RebuildClusterRequest, v1.RebuildClusterResponse](
+			httpClient,
+			baseURL+ClusterServiceRebuildClusterProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("RebuildCluster")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// clusterServiceClient implements ClusterServiceClient.
+type clusterServiceClient struct {
+	registerCluster   *connect.Client[v1.RegisterClusterRequest, v1.RegisterClusterResponse]
+	listClusters      *connect.Client[v1.ListClustersRequest, v1.ListClustersResponse]
+	deactivateCluster *connect.Client[v1.DeactivateClusterRequest, v1.DeactivateClusterResponse]
+	getCapacity       *connect.Client[v1.GetCapacityRequest, v1.GetCapacityResponse]
+	getClusterHealth  *connect.Client[v1.GetClusterHealthRequest, v1.GetClusterHealthResponse]
+	detectDrift       *connect.Client[v1.DetectDriftRequest, v1.DetectDriftResponse]
+	rebuildCluster    *connect.Client[v1.RebuildClusterRequest, v1.RebuildClusterResponse]
+}
+
+// RegisterCluster calls loco.cluster.v1.ClusterService.RegisterCluster.
+func (c *clusterServiceClient) RegisterCluster(ctx context.Context, req *connect.Request[v1.RegisterClusterRequest]) (*connect.Response[v1.RegisterClusterResponse], error) {
+	return c.registerCluster.CallUnary(ctx, req)
+}
+
+// ListClusters calls loco.cluster.v1.ClusterService.ListClusters.
+func (c *clusterServiceClient) ListClusters(ctx context.Context, req *connect.Request[v1.ListClustersRequest]) (*connect.Response[v1.ListClustersResponse], error) {
+	return c.listClusters.CallUnary(ctx, req)
+}
+
+// DeactivateCluster calls loco.cluster.v1.ClusterService.DeactivateCluster.
+func (c *clusterServiceClient) DeactivateCluster(ctx context.Context, req *connect.Request[v1.DeactivateClusterRequest]) (*connect.Response[v1.DeactivateClusterResponse], error) {
+	return c.deactivateCluster.CallUnary(ctx, req)
+}
+
+// GetCapacity calls loco.cluster.v1.ClusterService.GetCapacity.
+func (c *clusterServiceClient) GetCapacity(ctx context.Context, req *connect.Request[v1.GetCapacityRequest]) (*connect.Response[v1.GetCapacityResponse], error) {
+	return c.getCapacity.CallUnary(ctx, req)
+}
+
+// GetClusterHealth calls loco.cluster.v1.ClusterService.GetClusterHealth.
+func (c *clusterServiceClient) GetClusterHealth(ctx context.Context, req *connect.Request[v1.GetClusterHealthRequest]) (*connect.Response[v1.GetClusterHealthResponse], error) {
+	return c.getClusterHealth.CallUnary(ctx, req)
+}
+
+// DetectDrift calls loco.cluster.v1.ClusterService.DetectDrift.
+func (c *clusterServiceClient) DetectDrift(ctx context.Context, req *connect.Request[v1.DetectDriftRequest]) (*connect.Response[v1.DetectDriftResponse], error) {
+	return c.detectDrift.CallUnary(ctx, req)
+}
+
+// RebuildCluster calls loco.cluster.v1.ClusterService.RebuildCluster.
+func (c *clusterServiceClient) RebuildCluster(ctx context.Context, req *connect.Request[v1.RebuildClusterRequest]) (*connect.ServerStreamForClient[v1.RebuildClusterResponse], error) {
+	return c.rebuildCluster.CallServerStream(ctx, req)
+}
+
+// ClusterServiceHandler is an implementation of the loco.cluster.v1.ClusterService service.
+type ClusterServiceHandler interface {
+	RegisterCluster(context.Context, *connect.Request[v1.RegisterClusterRequest]) (*connect.Response[v1.RegisterClusterResponse], error)
+	ListClusters(context.Context, *connect.Request[v1.ListClustersRequest]) (*connect.Response[v1.ListClustersResponse], error)
+	DeactivateCluster(context.Context, *connect.Request[v1.DeactivateClusterRequest]) (*connect.Response[v1.DeactivateClusterResponse], error)
+	GetCapacity(context.Context, *connect.Request[v1.GetCapacityRequest]) (*connect.Response[v1.GetCapacityResponse], error)
+	GetClusterHealth(context.Context, *connect.Request[v1.GetClusterHealthRequest]) (*connect.Response[v1.GetClusterHealthResponse], error)
+	DetectDrift(context.Context, *connect.Request[v1.DetectDriftRequest]) (*connect.Response[v1.DetectDriftResponse], error)
+	RebuildCluster(context.Context, *connect.Request[v1.RebuildClusterRequest], *connect.ServerStream[v1.RebuildClusterResponse]) error
+}
+
+// NewClusterServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewClusterServiceHandler(svc ClusterServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	clusterServiceMethods := v1.File_shared_proto_cluster_v1_cluster_proto.Services().ByName("ClusterService").Methods()
+	clusterServiceRegisterClusterHandler := connect.NewUnaryHandler(
+		ClusterServiceRegisterClusterProcedure,
+		svc.RegisterCluster,
+		connect.WithSchema(clusterServiceMethods.ByName("RegisterCluster")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clusterServiceListClustersHandler := connect.NewUnaryHandler(
+		ClusterServiceListClustersProcedure,
+		svc.ListClusters,
+		connect.WithSchema(clusterServiceMethods.ByName("ListClusters")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clusterServiceDeactivateClusterHandler := connect.NewUnaryHandler(
+		ClusterServiceDeactivateClusterProcedure,
+		svc.DeactivateCluster,
+		connect.WithSchema(clusterServiceMethods.ByName("DeactivateCluster")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clusterServiceGetCapacityHandler := connect.NewUnaryHandler(
+		ClusterServiceGetCapacityProcedure,
+		svc.GetCapacity,
+		connect.WithSchema(clusterServiceMethods.ByName("GetCapacity")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clusterServiceGetClusterHealthHandler := connect.NewUnaryHandler(
+		ClusterServiceGetClusterHealthProcedure,
+		svc.GetClusterHealth,
+		connect.WithSchema(clusterServiceMethods.ByName("GetClusterHealth")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clusterServiceDetectDriftHandler := connect.NewUnaryHandler(
+		ClusterServiceDetectDriftProcedure,
+		svc.DetectDrift,
+		connect.WithSchema(clusterServiceMethods.ByName("DetectDrift")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clusterServiceRebuildClusterHandler := connect.NewServerStreamHandler(
+		ClusterServiceRebuildClusterProcedure,
+		svc.RebuildCluster,
+		connect.WithSchema(clusterServiceMethods.ByName("RebuildCluster")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/loco.cluster.v1.ClusterService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ClusterServiceRegisterClusterProcedure:
+			clusterServiceRegisterClusterHandler.ServeHTTP(w, r)
+		case ClusterServiceListClustersProcedure:
+			clusterServiceListClustersHandler.ServeHTTP(w, r)
+		case ClusterServiceDeactivateClusterProcedure:
+			clusterServiceDeactivateClusterHandler.ServeHTTP(w, r)
+		case ClusterServiceGetCapacityProcedure:
+			clusterServiceGetCapacityHandler.ServeHTTP(w, r)
+		case ClusterServiceGetClusterHealthProcedure:
+			clusterServiceGetClusterHealthHandler.ServeHTTP(w, r)
+		case ClusterServiceDetectDriftProcedure:
+			clusterServiceDetectDriftHandler.ServeHTTP(w, r)
+		case ClusterServiceRebuildClusterProcedure:
+			clusterServiceRebuildClusterHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedClusterServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedClusterServiceHandler struct{}
+
+func (UnimplementedClusterServiceHandler) RegisterCluster(context.Context, *connect.Request[v1.RegisterClusterRequest]) (*connect.Response[v1.RegisterClusterResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.cluster.v1.ClusterService.RegisterCluster is not implemented"))
+}
+
+func (UnimplementedClusterServiceHandler) ListClusters(context.Context, *connect.Request[v1.ListClustersRequest]) (*connect.Response[v1.ListClustersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.cluster.v1.ClusterService.ListClusters is not implemented"))
+}
+
+func (UnimplementedClusterServiceHandler) DeactivateCluster(context.Context, *connect.Request[v1.DeactivateClusterRequest]) (*connect.Response[v1.DeactivateClusterResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.cluster.v1.ClusterService.DeactivateCluster is not implemented"))
+}
+
+func (UnimplementedClusterServiceHandler) GetCapacity(context.Context, *connect.Request[v1.GetCapacityRequest]) (*connect.Response[v1.GetCapacityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.cluster.v1.ClusterService.GetCapacity is not implemented"))
+}
+
+func (UnimplementedClusterServiceHandler) GetClusterHealth(context.Context, *connect.Request[v1.GetClusterHealthRequest]) (*connect.Response[v1.GetClusterHealthResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.cluster.v1.ClusterService.GetClusterHealth is not implemented"))
+}
+
+func (UnimplementedClusterServiceHandler) DetectDrift(context.Context, *connect.Request[v1.DetectDriftRequest]) (*connect.Response[v1.DetectDriftResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.cluster.v1.ClusterService.DetectDrift is not implemented"))
+}
+
+func (UnimplementedClusterServiceHandler) RebuildCluster(context.Context, *connect.Request[v1.RebuildClusterRequest], *connect.ServerStream[v1.RebuildClusterResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("loco.cluster.v1.ClusterService.RebuildCluster is not implemented"))
+}
@@ -23,9 +23,13 @@ const (
 )
 
 type Port struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Port          int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
-	Protocol      string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Port     int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	Protocol string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// names the port on the app's Service, unset for the first port which takes the app's name
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// also reachable from outside the cluster through a gateway listener
+	Public        bool `protobuf:"varint,4,opt,name=public,proto3" json:"public,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,17 +78,219 @@ func (x *Port) GetProtocol() string {
 	return ""
 }
 
-type ResourceSpec struct {
+func (x *Port) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Port) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+// a container probe, unset fields take their defaults from the deployment's routing
+type Probe struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "http", "tcp", "exec" or "grpc"
+	Type             string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Port             int32             `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Path             string            `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Headers          map[string]string `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Command          []string          `protobuf:"bytes,5,rep,name=command,proto3" json:"command,omitempty"`
+	Service          string            `protobuf:"bytes,6,opt,name=service,proto3" json:"service,omitempty"`
+	InitialDelay     int32             `protobuf:"varint,7,opt,name=initial_delay,json=initialDelay,proto3" json:"initial_delay,omitempty"`
+	Interval         int32             `protobuf:"varint,8,opt,name=interval,proto3" json:"interval,omitempty"`
+	Timeout          int32             `protobuf:"varint,9,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	FailThreshold    int32             `protobuf:"varint,10,opt,name=fail_threshold,json=failThreshold,proto3" json:"fail_threshold,omitempty"`
+	SuccessThreshold int32             `protobuf:"varint,11,opt,name=success_threshold,json=successThreshold,proto3" json:"success_threshold,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Probe) Reset() {
+	*x = Probe{}
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Probe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Probe) ProtoMessage() {}
+
+func (x *Probe) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Probe.ProtoReflect.Descriptor instead.
+func (*Probe) Descriptor() ([]byte, []int) {
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Probe) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Probe) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Probe) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Probe) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Probe) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Probe) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Probe) GetInitialDelay() int32 {
+	if x != nil {
+		return x.InitialDelay
+	}
+	return 0
+}
+
+func (x *Probe) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *Probe) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *Probe) GetFailThreshold() int32 {
+	if x != nil {
+		return x.FailThreshold
+	}
+	return 0
+}
+
+func (x *Probe) GetSuccessThreshold() int32 {
+	if x != nil {
+		return x.SuccessThreshold
+	}
+	return 0
+}
+
+type HealthChecks struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cpu           *string                `protobuf:"bytes,1,opt,name=cpu,proto3,oneof" json:"cpu,omitempty"`
-	Memory        *string                `protobuf:"bytes,2,opt,name=memory,proto3,oneof" json:"memory,omitempty"`
+	Liveness      *Probe                 `protobuf:"bytes,1,opt,name=liveness,proto3" json:"liveness,omitempty"`
+	Readiness     *Probe                 `protobuf:"bytes,2,opt,name=readiness,proto3" json:"readiness,omitempty"`
+	Startup       *Probe                 `protobuf:"bytes,3,opt,name=startup,proto3" json:"startup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthChecks) Reset() {
+	*x = HealthChecks{}
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthChecks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthChecks) ProtoMessage() {}
+
+func (x *HealthChecks) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthChecks.ProtoReflect.Descriptor instead.
+func (*HealthChecks) Descriptor() ([]byte, []int) {
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HealthChecks) GetLiveness() *Probe {
+	if x != nil {
+		return x.Liveness
+	}
+	return nil
+}
+
+func (x *HealthChecks) GetReadiness() *Probe {
+	if x != nil {
+		return x.Readiness
+	}
+	return nil
+}
+
+func (x *HealthChecks) GetStartup() *Probe {
+	if x != nil {
+		return x.Startup
+	}
+	return nil
+}
+
+type ResourceSpec struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Cpu    *string                `protobuf:"bytes,1,opt,name=cpu,proto3,oneof" json:"cpu,omitempty"`
+	Memory *string                `protobuf:"bytes,2,opt,name=memory,proto3,oneof" json:"memory,omitempty"`
+	// the most replicas the app may be scaled to, counted against the replica quota. replicas when unset
+	MaxReplicas   *int32 `protobuf:"varint,3,opt,name=max_replicas,json=maxReplicas,proto3,oneof" json:"max_replicas,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ResourceSpec) Reset() {
 	*x = ResourceSpec{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[1]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -96,7 +302,7 @@ func (x *ResourceSpec) String() string {
 func (*ResourceSpec) ProtoMessage() {}
 
 func (x *ResourceSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[1]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -109,7 +315,7 @@ func (x *ResourceSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceSpec.ProtoReflect.Descriptor instead.
 func (*ResourceSpec) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{1}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ResourceSpec) GetCpu() string {
@@ -126,6 +332,13 @@ func (x *ResourceSpec) GetMemory() string {
 	return ""
 }
 
+func (x *ResourceSpec) GetMaxReplicas() int32 {
+	if x != nil && x.MaxReplicas != nil {
+		return *x.MaxReplicas
+	}
+	return 0
+}
+
 type Deployment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -149,7 +362,7 @@ type Deployment struct {
 
 func (x *Deployment) Reset() {
 	*x = Deployment{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[2]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -161,7 +374,7 @@ func (x *Deployment) String() string {
 func (*Deployment) ProtoMessage() {}
 
 func (x *Deployment) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[2]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -174,7 +387,7 @@ func (x *Deployment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Deployment.ProtoReflect.Descriptor instead.
 func (*Deployment) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{2}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Deployment) GetId() int64 {
@@ -282,21 +495,189 @@ func (x *Deployment) GetConfig() string {
 	return ""
 }
 
+type Volume struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MountPath string                 `protobuf:"bytes,2,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"`
+	// a quantity like "10Gi", required for persistent volumes
+	Size string `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	// "persistent" or "ephemeral"
+	Type          string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Volume) Reset() {
+	*x = Volume{}
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Volume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Volume) ProtoMessage() {}
+
+func (x *Volume) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
+func (*Volume) Descriptor() ([]byte, []int) {
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Volume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Volume) GetMountPath() string {
+	if x != nil {
+		return x.MountPath
+	}
+	return ""
+}
+
+func (x *Volume) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *Volume) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type Job struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// a cron expression in UTC, like "0 3 * * *", or a macro like "@daily"
+	Schedule string   `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Command  []string `protobuf:"bytes,3,rep,name=command,proto3" json:"command,omitempty"`
+	// override the app's resources for the job's pod
+	Cpu           *string `protobuf:"bytes,4,opt,name=cpu,proto3,oneof" json:"cpu,omitempty"`
+	Memory        *string `protobuf:"bytes,5,opt,name=memory,proto3,oneof" json:"memory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Job) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Job) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *Job) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Job) GetCpu() string {
+	if x != nil && x.Cpu != nil {
+		return *x.Cpu
+	}
+	return ""
+}
+
+func (x *Job) GetMemory() string {
+	if x != nil && x.Memory != nil {
+		return *x.Memory
+	}
+	return ""
+}
+
 type CreateDeploymentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
-	Replicas      *int32                 `protobuf:"varint,4,opt,name=replicas,proto3,oneof" json:"replicas,omitempty"`
-	Env           map[string]string      `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Ports         []*Port                `protobuf:"bytes,7,rep,name=ports,proto3" json:"ports,omitempty"`
-	Resources     *ResourceSpec          `protobuf:"bytes,8,opt,name=resources,proto3,oneof" json:"resources,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	AppId    int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Image    string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Replicas *int32                 `protobuf:"varint,4,opt,name=replicas,proto3,oneof" json:"replicas,omitempty"`
+	Env      map[string]string      `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// the first port serves http or grpc traffic on the app's hostname, the rest are raw TCP or UDP ports
+	Ports     []*Port       `protobuf:"bytes,7,rep,name=ports,proto3" json:"ports,omitempty"`
+	Resources *ResourceSpec `protobuf:"bytes,8,opt,name=resources,proto3,oneof" json:"resources,omitempty"`
+	// the app logs JSON or logfmt, so its lines are parsed into a message and fields
+	StructuredLogs *bool `protobuf:"varint,9,opt,name=structured_logs,json=structuredLogs,proto3,oneof" json:"structured_logs,omitempty"`
+	// the path the app is served under on its hostname, "/" when unset.
+	// other apps in the workspace can serve other paths of the same hostname.
+	PathPrefix *string `protobuf:"bytes,10,opt,name=path_prefix,json=pathPrefix,proto3,oneof" json:"path_prefix,omitempty"`
+	// "http" or "grpc", "http" when unset
+	Protocol *string `protobuf:"bytes,11,opt,name=protocol,proto3,oneof" json:"protocol,omitempty"`
+	// the "package.Service" or "package.Service/Method" names routed to a grpc app, all when empty
+	GrpcServices []string `protobuf:"bytes,12,rep,name=grpc_services,json=grpcServices,proto3" json:"grpc_services,omitempty"`
+	// the app's probes, only a liveness check of its port when unset
+	Health *HealthChecks `protobuf:"bytes,13,opt,name=health,proto3,oneof" json:"health,omitempty"`
+	// scale to zero after no requests for this long, like "7d". never sleeps when unset
+	SleepAfter *string `protobuf:"bytes,14,opt,name=sleep_after,json=sleepAfter,proto3,oneof" json:"sleep_after,omitempty"`
+	// persistent volumes keep their data across deploys and limit the app to one replica
+	Volumes []*Volume `protobuf:"bytes,15,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	// commands run on a schedule in the app's image
+	Jobs []*Job `protobuf:"bytes,16,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// runs through /bin/sh in the new image before the app's pods are updated, the deploy fails unless it exits 0
+	Release       *string `protobuf:"bytes,17,opt,name=release,proto3,oneof" json:"release,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateDeploymentRequest) Reset() {
 	*x = CreateDeploymentRequest{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[3]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -308,7 +689,7 @@ func (x *CreateDeploymentRequest) String() string {
 func (*CreateDeploymentRequest) ProtoMessage() {}
 
 func (x *CreateDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[3]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -321,7 +702,7 @@ func (x *CreateDeploymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDeploymentRequest.ProtoReflect.Descriptor instead.
 func (*CreateDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{3}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CreateDeploymentRequest) GetAppId() int64 {
@@ -366,6 +747,69 @@ func (x *CreateDeploymentRequest) GetResources() *ResourceSpec {
 	return nil
 }
 
+func (x *CreateDeploymentRequest) GetStructuredLogs() bool {
+	if x != nil && x.StructuredLogs != nil {
+		return *x.StructuredLogs
+	}
+	return false
+}
+
+func (x *CreateDeploymentRequest) GetPathPrefix() string {
+	if x != nil && x.PathPrefix != nil {
+		return *x.PathPrefix
+	}
+	return ""
+}
+
+func (x *CreateDeploymentRequest) GetProtocol() string {
+	if x != nil && x.Protocol != nil {
+		return *x.Protocol
+	}
+	return ""
+}
+
+func (x *CreateDeploymentRequest) GetGrpcServices() []string {
+	if x != nil {
+		return x.GrpcServices
+	}
+	return nil
+}
+
+func (x *CreateDeploymentRequest) GetHealth() *HealthChecks {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+func (x *CreateDeploymentRequest) GetSleepAfter() string {
+	if x != nil && x.SleepAfter != nil {
+		return *x.SleepAfter
+	}
+	return ""
+}
+
+func (x *CreateDeploymentRequest) GetVolumes() []*Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *CreateDeploymentRequest) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *CreateDeploymentRequest) GetRelease() string {
+	if x != nil && x.Release != nil {
+		return *x.Release
+	}
+	return ""
+}
+
 type CreateDeploymentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Deployment    *Deployment            `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
@@ -375,7 +819,7 @@ type CreateDeploymentResponse struct {
 
 func (x *CreateDeploymentResponse) Reset() {
 	*x = CreateDeploymentResponse{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[4]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -387,7 +831,7 @@ func (x *CreateDeploymentResponse) String() string {
 func (*CreateDeploymentResponse) ProtoMessage() {}
 
 func (x *CreateDeploymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[4]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -400,7 +844,7 @@ func (x *CreateDeploymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDeploymentResponse.ProtoReflect.Descriptor instead.
 func (*CreateDeploymentResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{4}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CreateDeploymentResponse) GetDeployment() *Deployment {
@@ -419,7 +863,7 @@ type GetDeploymentRequest struct {
 
 func (x *GetDeploymentRequest) Reset() {
 	*x = GetDeploymentRequest{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[5]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -431,7 +875,7 @@ func (x *GetDeploymentRequest) String() string {
 func (*GetDeploymentRequest) ProtoMessage() {}
 
 func (x *GetDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[5]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +888,7 @@ func (x *GetDeploymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDeploymentRequest.ProtoReflect.Descriptor instead.
 func (*GetDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{5}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetDeploymentRequest) GetDeploymentId() int64 {
@@ -463,7 +907,7 @@ type GetDeploymentResponse struct {
 
 func (x *GetDeploymentResponse) Reset() {
 	*x = GetDeploymentResponse{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[6]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -475,7 +919,7 @@ func (x *GetDeploymentResponse) String() string {
 func (*GetDeploymentResponse) ProtoMessage() {}
 
 func (x *GetDeploymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[6]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -488,7 +932,7 @@ func (x *GetDeploymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDeploymentResponse.ProtoReflect.Descriptor instead.
 func (*GetDeploymentResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{6}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetDeploymentResponse) GetDeployment() *Deployment {
@@ -509,7 +953,7 @@ type ListDeploymentsRequest struct {
 
 func (x *ListDeploymentsRequest) Reset() {
 	*x = ListDeploymentsRequest{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[7]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -521,7 +965,7 @@ func (x *ListDeploymentsRequest) String() string {
 func (*ListDeploymentsRequest) ProtoMessage() {}
 
 func (x *ListDeploymentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[7]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -534,7 +978,7 @@ func (x *ListDeploymentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDeploymentsRequest.ProtoReflect.Descriptor instead.
 func (*ListDeploymentsRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{7}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListDeploymentsRequest) GetAppId() int64 {
@@ -568,7 +1012,7 @@ type ListDeploymentsResponse struct {
 
 func (x *ListDeploymentsResponse) Reset() {
 	*x = ListDeploymentsResponse{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[8]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -580,7 +1024,7 @@ func (x *ListDeploymentsResponse) String() string {
 func (*ListDeploymentsResponse) ProtoMessage() {}
 
 func (x *ListDeploymentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[8]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,7 +1037,7 @@ func (x *ListDeploymentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDeploymentsResponse.ProtoReflect.Descriptor instead.
 func (*ListDeploymentsResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{8}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListDeploymentsResponse) GetDeployments() []*Deployment {
@@ -619,7 +1063,7 @@ type StreamDeploymentRequest struct {
 
 func (x *StreamDeploymentRequest) Reset() {
 	*x = StreamDeploymentRequest{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[9]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -631,7 +1075,7 @@ func (x *StreamDeploymentRequest) String() string {
 func (*StreamDeploymentRequest) ProtoMessage() {}
 
 func (x *StreamDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[9]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,7 +1088,7 @@ func (x *StreamDeploymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamDeploymentRequest.ProtoReflect.Descriptor instead.
 func (*StreamDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{9}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *StreamDeploymentRequest) GetDeploymentId() int64 {
@@ -655,19 +1099,21 @@ func (x *StreamDeploymentRequest) GetDeploymentId() int64 {
 }
 
 type DeploymentEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  int64                  `protobuf:"varint,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	ErrorMessage  *string                `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId int64                  `protobuf:"varint,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Status       string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message      string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ErrorMessage *string                `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"`
+	// a line of the release command's output, status and message are the deployment's at the time
+	Log           *string `protobuf:"bytes,6,opt,name=log,proto3,oneof" json:"log,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeploymentEvent) Reset() {
 	*x = DeploymentEvent{}
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[10]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -679,7 +1125,7 @@ func (x *DeploymentEvent) String() string {
 func (*DeploymentEvent) ProtoMessage() {}
 
 func (x *DeploymentEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[10]
+	mi := &file_shared_proto_deployment_v1_deployment_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +1138,7 @@ func (x *DeploymentEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeploymentEvent.ProtoReflect.Descriptor instead.
 func (*DeploymentEvent) Descriptor() ([]byte, []int) {
-	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{10}
+	return file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DeploymentEvent) GetDeploymentId() int64 {
@@ -730,19 +1176,51 @@ func (x *DeploymentEvent) GetErrorMessage() string {
 	return ""
 }
 
+func (x *DeploymentEvent) GetLog() string {
+	if x != nil && x.Log != nil {
+		return *x.Log
+	}
+	return ""
+}
+
 var File_shared_proto_deployment_v1_deployment_proto protoreflect.FileDescriptor
 
 const file_shared_proto_deployment_v1_deployment_proto_rawDesc = "" +
 	"\n" +
-	"+shared/proto/deployment/v1/deployment.proto\x12\x12loco.deployment.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"6\n" +
+	"+shared/proto/deployment/v1/deployment.proto\x12\x12loco.deployment.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"p\n" +
 	"\x04Port\x12\x12\n" +
 	"\x04port\x18\x01 \x01(\x05R\x04port\x12\x1a\n" +
-	"\bprotocol\x18\x02 \x01(\tR\bprotocol\"U\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x16\n" +
+	"\x06public\x18\x04 \x01(\bR\x06publicB\a\n" +
+	"\x05_name\"\xa4\x03\n" +
+	"\x05Probe\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12@\n" +
+	"\aheaders\x18\x04 \x03(\v2&.loco.deployment.v1.Probe.HeadersEntryR\aheaders\x12\x18\n" +
+	"\acommand\x18\x05 \x03(\tR\acommand\x12\x18\n" +
+	"\aservice\x18\x06 \x01(\tR\aservice\x12#\n" +
+	"\rinitial_delay\x18\a \x01(\x05R\finitialDelay\x12\x1a\n" +
+	"\binterval\x18\b \x01(\x05R\binterval\x12\x18\n" +
+	"\atimeout\x18\t \x01(\x05R\atimeout\x12%\n" +
+	"\x0efail_threshold\x18\n" +
+	" \x01(\x05R\rfailThreshold\x12+\n" +
+	"\x11success_threshold\x18\v \x01(\x05R\x10successThreshold\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x01\n" +
+	"\fHealthChecks\x125\n" +
+	"\bliveness\x18\x01 \x01(\v2\x19.loco.deployment.v1.ProbeR\bliveness\x127\n" +
+	"\treadiness\x18\x02 \x01(\v2\x19.loco.deployment.v1.ProbeR\treadiness\x123\n" +
+	"\astartup\x18\x03 \x01(\v2\x19.loco.deployment.v1.ProbeR\astartup\"\x8e\x01\n" +
 	"\fResourceSpec\x12\x15\n" +
 	"\x03cpu\x18\x01 \x01(\tH\x00R\x03cpu\x88\x01\x01\x12\x1b\n" +
-	"\x06memory\x18\x02 \x01(\tH\x01R\x06memory\x88\x01\x01B\x06\n" +
+	"\x06memory\x18\x02 \x01(\tH\x01R\x06memory\x88\x01\x01\x12&\n" +
+	"\fmax_replicas\x18\x03 \x01(\x05H\x02R\vmaxReplicas\x88\x01\x01B\x06\n" +
 	"\x04_cpuB\t\n" +
-	"\a_memory\"\x8b\x05\n" +
+	"\a_memoryB\x0f\n" +
+	"\r_max_replicas\"\x8b\x05\n" +
 	"\n" +
 	"Deployment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
@@ -771,20 +1249,53 @@ const file_shared_proto_deployment_v1_deployment_proto_rawDesc = "" +
 	"\x0e_error_messageB\r\n" +
 	"\v_started_atB\x0f\n" +
 	"\r_completed_atB\t\n" +
-	"\a_config\"\xf7\x02\n" +
+	"\a_config\"c\n" +
+	"\x06Volume\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"mount_path\x18\x02 \x01(\tR\tmountPath\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\tR\x04size\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\"\x96\x01\n" +
+	"\x03Job\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bschedule\x18\x02 \x01(\tR\bschedule\x12\x18\n" +
+	"\acommand\x18\x03 \x03(\tR\acommand\x12\x15\n" +
+	"\x03cpu\x18\x04 \x01(\tH\x00R\x03cpu\x88\x01\x01\x12\x1b\n" +
+	"\x06memory\x18\x05 \x01(\tH\x01R\x06memory\x88\x01\x01B\x06\n" +
+	"\x04_cpuB\t\n" +
+	"\a_memory\"\xd0\x06\n" +
 	"\x17CreateDeploymentRequest\x12\x15\n" +
 	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x14\n" +
 	"\x05image\x18\x03 \x01(\tR\x05image\x12\x1f\n" +
 	"\breplicas\x18\x04 \x01(\x05H\x00R\breplicas\x88\x01\x01\x12F\n" +
 	"\x03env\x18\x06 \x03(\v24.loco.deployment.v1.CreateDeploymentRequest.EnvEntryR\x03env\x12.\n" +
 	"\x05ports\x18\a \x03(\v2\x18.loco.deployment.v1.PortR\x05ports\x12C\n" +
-	"\tresources\x18\b \x01(\v2 .loco.deployment.v1.ResourceSpecH\x01R\tresources\x88\x01\x01\x1a6\n" +
+	"\tresources\x18\b \x01(\v2 .loco.deployment.v1.ResourceSpecH\x01R\tresources\x88\x01\x01\x12,\n" +
+	"\x0fstructured_logs\x18\t \x01(\bH\x02R\x0estructuredLogs\x88\x01\x01\x12$\n" +
+	"\vpath_prefix\x18\n" +
+	" \x01(\tH\x03R\n" +
+	"pathPrefix\x88\x01\x01\x12\x1f\n" +
+	"\bprotocol\x18\v \x01(\tH\x04R\bprotocol\x88\x01\x01\x12#\n" +
+	"\rgrpc_services\x18\f \x03(\tR\fgrpcServices\x12=\n" +
+	"\x06health\x18\r \x01(\v2 .loco.deployment.v1.HealthChecksH\x05R\x06health\x88\x01\x01\x12$\n" +
+	"\vsleep_after\x18\x0e \x01(\tH\x06R\n" +
+	"sleepAfter\x88\x01\x01\x124\n" +
+	"\avolumes\x18\x0f \x03(\v2\x1a.loco.deployment.v1.VolumeR\avolumes\x12+\n" +
+	"\x04jobs\x18\x10 \x03(\v2\x17.loco.deployment.v1.JobR\x04jobs\x12\x1d\n" +
+	"\arelease\x18\x11 \x01(\tH\aR\arelease\x88\x01\x01\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
 	"\t_replicasB\f\n" +
 	"\n" +
-	"_resources\"Z\n" +
+	"_resourcesB\x12\n" +
+	"\x10_structured_logsB\x0e\n" +
+	"\f_path_prefixB\v\n" +
+	"\t_protocolB\t\n" +
+	"\a_healthB\x0e\n" +
+	"\f_sleep_afterB\n" +
+	"\n" +
+	"\b_release\"Z\n" +
 	"\x18CreateDeploymentResponse\x12>\n" +
 	"\n" +
 	"deployment\x18\x01 \x01(\v2\x1e.loco.deployment.v1.DeploymentR\n" +
@@ -805,14 +1316,16 @@ const file_shared_proto_deployment_v1_deployment_proto_rawDesc = "" +
 	"\vdeployments\x18\x01 \x03(\v2\x1e.loco.deployment.v1.DeploymentR\vdeployments\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\">\n" +
 	"\x17StreamDeploymentRequest\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\x03R\fdeploymentId\"\xde\x01\n" +
+	"\rdeployment_id\x18\x01 \x01(\x03R\fdeploymentId\"\xfd\x01\n" +
 	"\x0fDeploymentEvent\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\x03R\fdeploymentId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x128\n" +
 	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12(\n" +
-	"\rerror_message\x18\x05 \x01(\tH\x00R\ferrorMessage\x88\x01\x01B\x10\n" +
-	"\x0e_error_message2\xbc\x03\n" +
+	"\rerror_message\x18\x05 \x01(\tH\x00R\ferrorMessage\x88\x01\x01\x12\x15\n" +
+	"\x03log\x18\x06 \x01(\tH\x01R\x03log\x88\x01\x01B\x10\n" +
+	"\x0e_error_messageB\x06\n" +
+	"\x04_log2\xbc\x03\n" +
 	"\x11DeploymentService\x12m\n" +
 	"\x10CreateDeployment\x12+.loco.deployment.v1.CreateDeploymentRequest\x1a,.loco.deployment.v1.CreateDeploymentResponse\x12d\n" +
 	"\rGetDeployment\x12(.loco.deployment.v1.GetDeploymentRequest\x1a).loco.deployment.v1.GetDeploymentResponse\x12j\n" +
@@ -831,47 +1344,59 @@ func file_shared_proto_deployment_v1_deployment_proto_rawDescGZIP() []byte {
 	return file_shared_proto_deployment_v1_deployment_proto_rawDescData
 }
 
-var file_shared_proto_deployment_v1_deployment_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_shared_proto_deployment_v1_deployment_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_shared_proto_deployment_v1_deployment_proto_goTypes = []any{
 	(*Port)(nil),                     // 0: loco.deployment.v1.Port
-	(*ResourceSpec)(nil),             // 1: loco.deployment.v1.ResourceSpec
-	(*Deployment)(nil),               // 2: loco.deployment.v1.Deployment
-	(*CreateDeploymentRequest)(nil),  // 3: loco.deployment.v1.CreateDeploymentRequest
-	(*CreateDeploymentResponse)(nil), // 4: loco.deployment.v1.CreateDeploymentResponse
-	(*GetDeploymentRequest)(nil),     // 5: loco.deployment.v1.GetDeploymentRequest
-	(*GetDeploymentResponse)(nil),    // 6: loco.deployment.v1.GetDeploymentResponse
-	(*ListDeploymentsRequest)(nil),   // 7: loco.deployment.v1.ListDeploymentsRequest
-	(*ListDeploymentsResponse)(nil),  // 8: loco.deployment.v1.ListDeploymentsResponse
-	(*StreamDeploymentRequest)(nil),  // 9: loco.deployment.v1.StreamDeploymentRequest
-	(*DeploymentEvent)(nil),          // 10: loco.deployment.v1.DeploymentEvent
-	nil,                              // 11: loco.deployment.v1.CreateDeploymentRequest.EnvEntry
-	(*timestamppb.Timestamp)(nil),    // 12: google.protobuf.Timestamp
+	(*Probe)(nil),                    // 1: loco.deployment.v1.Probe
+	(*HealthChecks)(nil),             // 2: loco.deployment.v1.HealthChecks
+	(*ResourceSpec)(nil),             // 3: loco.deployment.v1.ResourceSpec
+	(*Deployment)(nil),               // 4: loco.deployment.v1.Deployment
+	(*Volume)(nil),                   // 5: loco.deployment.v1.Volume
+	(*Job)(nil),                      // 6: loco.deployment.v1.Job
+	(*CreateDeploymentRequest)(nil),  // 7: loco.deployment.v1.CreateDeploymentRequest
+	(*CreateDeploymentResponse)(nil), // 8: loco.deployment.v1.CreateDeploymentResponse
+	(*GetDeploymentRequest)(nil),     // 9: loco.deployment.v1.GetDeploymentRequest
+	(*GetDeploymentResponse)(nil),    // 10: loco.deployment.v1.GetDeploymentResponse
+	(*ListDeploymentsRequest)(nil),   // 11: loco.deployment.v1.ListDeploymentsRequest
+	(*ListDeploymentsResponse)(nil),  // 12: loco.deployment.v1.ListDeploymentsResponse
+	(*StreamDeploymentRequest)(nil),  // 13: loco.deployment.v1.StreamDeploymentRequest
+	(*DeploymentEvent)(nil),          // 14: loco.deployment.v1.DeploymentEvent
+	nil,                              // 15: loco.deployment.v1.Probe.HeadersEntry
+	nil,                              // 16: loco.deployment.v1.CreateDeploymentRequest.EnvEntry
+	(*timestamppb.Timestamp)(nil),    // 17: google.protobuf.Timestamp
 }
 var file_shared_proto_deployment_v1_deployment_proto_depIdxs = []int32{
-	12, // 0: loco.deployment.v1.Deployment.created_at:type_name -> google.protobuf.Timestamp
-	12, // 1: loco.deployment.v1.Deployment.started_at:type_name -> google.protobuf.Timestamp
-	12, // 2: loco.deployment.v1.Deployment.completed_at:type_name -> google.protobuf.Timestamp
-	12, // 3: loco.deployment.v1.Deployment.updated_at:type_name -> google.protobuf.Timestamp
-	11, // 4: loco.deployment.v1.CreateDeploymentRequest.env:type_name -> loco.deployment.v1.CreateDeploymentRequest.EnvEntry
-	0,  // 5: loco.deployment.v1.CreateDeploymentRequest.ports:type_name -> loco.deployment.v1.Port
-	1,  // 6: loco.deployment.v1.CreateDeploymentRequest.resources:type_name -> loco.deployment.v1.ResourceSpec
-	2,  // 7: loco.deployment.v1.CreateDeploymentResponse.deployment:type_name -> loco.deployment.v1.Deployment
-	2,  // 8: loco.deployment.v1.GetDeploymentResponse.deployment:type_name -> loco.deployment.v1.Deployment
-	2,  // 9: loco.deployment.v1.ListDeploymentsResponse.deployments:type_name -> loco.deployment.v1.Deployment
-	12, // 10: loco.deployment.v1.DeploymentEvent.timestamp:type_name -> google.protobuf.Timestamp
-	3,  // 11: loco.deployment.v1.DeploymentService.CreateDeployment:input_type -> loco.deployment.v1.CreateDeploymentRequest
-	5,  // 12: loco.deployment.v1.DeploymentService.GetDeployment:input_type -> loco.deployment.v1.GetDeploymentRequest
-	7,  // 13: loco.deployment.v1.DeploymentService.ListDeployments:input_type -> loco.deployment.v1.ListDeploymentsRequest
-	9,  // 14: loco.deployment.v1.DeploymentService.StreamDeployment:input_type -> loco.deployment.v1.StreamDeploymentRequest
-	4,  // 15: loco.deployment.v1.DeploymentService.CreateDeployment:output_type -> loco.deployment.v1.CreateDeploymentResponse
-	6,  // 16: loco.deployment.v1.DeploymentService.GetDeployment:output_type -> loco.deployment.v1.GetDeploymentResponse
-	8,  // 17: loco.deployment.v1.DeploymentService.ListDeployments:output_type -> loco.deployment.v1.ListDeploymentsResponse
-	10, // 18: loco.deployment.v1.DeploymentService.StreamDeployment:output_type -> loco.deployment.v1.DeploymentEvent
-	15, // [15:19] is the sub-list for method output_type
-	11, // [11:15] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	15, // 0: loco.deployment.v1.Probe.headers:type_name -> loco.deployment.v1.Probe.HeadersEntry
+	1,  // 1: loco.deployment.v1.HealthChecks.liveness:type_name -> loco.deployment.v1.Probe
+	1,  // 2: loco.deployment.v1.HealthChecks.readiness:type_name -> loco.deployment.v1.Probe
+	1,  // 3: loco.deployment.v1.HealthChecks.startup:type_name -> loco.deployment.v1.Probe
+	17, // 4: loco.deployment.v1.Deployment.created_at:type_name -> google.protobuf.Timestamp
+	17, // 5: loco.deployment.v1.Deployment.started_at:type_name -> google.protobuf.Timestamp
+	17, // 6: loco.deployment.v1.Deployment.completed_at:type_name -> google.protobuf.Timestamp
+	17, // 7: loco.deployment.v1.Deployment.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 8: loco.deployment.v1.CreateDeploymentRequest.env:type_name -> loco.deployment.v1.CreateDeploymentRequest.EnvEntry
+	0,  // 9: loco.deployment.v1.CreateDeploymentRequest.ports:type_name -> loco.deployment.v1.Port
+	3,  // 10: loco.deployment.v1.CreateDeploymentRequest.resources:type_name -> loco.deployment.v1.ResourceSpec
+	2,  // 11: loco.deployment.v1.CreateDeploymentRequest.health:type_name -> loco.deployment.v1.HealthChecks
+	5,  // 12: loco.deployment.v1.CreateDeploymentRequest.volumes:type_name -> loco.deployment.v1.Volume
+	6,  // 13: loco.deployment.v1.CreateDeploymentRequest.jobs:type_name -> loco.deployment.v1.Job
+	4,  // 14: loco.deployment.v1.CreateDeploymentResponse.deployment:type_name -> loco.deployment.v1.Deployment
+	4,  // 15: loco.deployment.v1.GetDeploymentResponse.deployment:type_name -> loco.deployment.v1.Deployment
+	4,  // 16: loco.deployment.v1.ListDeploymentsResponse.deployments:type_name -> loco.deployment.v1.Deployment
+	17, // 17: loco.deployment.v1.DeploymentEvent.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 18: loco.deployment.v1.DeploymentService.CreateDeployment:input_type -> loco.deployment.v1.CreateDeploymentRequest
+	9,  // 19: loco.deployment.v1.DeploymentService.GetDeployment:input_type -> loco.deployment.v1.GetDeploymentRequest
+	11, // 20: loco.deployment.v1.DeploymentService.ListDeployments:input_type -> loco.deployment.v1.ListDeploymentsRequest
+	13, // 21: loco.deployment.v1.DeploymentService.StreamDeployment:input_type -> loco.deployment.v1.StreamDeploymentRequest
+	8,  // 22: loco.deployment.v1.DeploymentService.CreateDeployment:output_type -> loco.deployment.v1.CreateDeploymentResponse
+	10, // 23: loco.deployment.v1.DeploymentService.GetDeployment:output_type -> loco.deployment.v1.GetDeploymentResponse
+	12, // 24: loco.deployment.v1.DeploymentService.ListDeployments:output_type -> loco.deployment.v1.ListDeploymentsResponse
+	14, // 25: loco.deployment.v1.DeploymentService.StreamDeployment:output_type -> loco.deployment.v1.DeploymentEvent
+	22, // [22:26] is the sub-list for method output_type
+	18, // [18:22] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_shared_proto_deployment_v1_deployment_proto_init() }
@@ -879,18 +1404,20 @@ func file_shared_proto_deployment_v1_deployment_proto_init() {
 	if File_shared_proto_deployment_v1_deployment_proto != nil {
 		return
 	}
-	file_shared_proto_deployment_v1_deployment_proto_msgTypes[1].OneofWrappers = []any{}
-	file_shared_proto_deployment_v1_deployment_proto_msgTypes[2].OneofWrappers = []any{}
+	file_shared_proto_deployment_v1_deployment_proto_msgTypes[0].OneofWrappers = []any{}
 	file_shared_proto_deployment_v1_deployment_proto_msgTypes[3].OneofWrappers = []any{}
+	file_shared_proto_deployment_v1_deployment_proto_msgTypes[4].OneofWrappers = []any{}
+	file_shared_proto_deployment_v1_deployment_proto_msgTypes[6].OneofWrappers = []any{}
 	file_shared_proto_deployment_v1_deployment_proto_msgTypes[7].OneofWrappers = []any{}
-	file_shared_proto_deployment_v1_deployment_proto_msgTypes[10].OneofWrappers = []any{}
+	file_shared_proto_deployment_v1_deployment_proto_msgTypes[11].OneofWrappers = []any{}
+	file_shared_proto_deployment_v1_deployment_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_deployment_v1_deployment_proto_rawDesc), len(file_shared_proto_deployment_v1_deployment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,638 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: shared/proto/domain/v1/domain.proto
+
+package domainv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Domain struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AppId    int64                  `protobuf:"varint,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Hostname string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// pending, verified or failed
+	VerificationStatus  string  `protobuf:"bytes,4,opt,name=verification_status,json=verificationStatus,proto3" json:"verification_status,omitempty"`
+	VerificationMessage *string `protobuf:"bytes,5,opt,name=verification_message,json=verificationMessage,proto3,oneof" json:"verification_message,omitempty"`
+	// the TXT record to create, e.g. _loco-challenge.www.example.com
+	ChallengeName  string                 `protobuf:"bytes,6,opt,name=challenge_name,json=challengeName,proto3" json:"challenge_name,omitempty"`
+	ChallengeValue string                 `protobuf:"bytes,7,opt,name=challenge_value,json=challengeValue,proto3" json:"challenge_value,omitempty"`
+	VerifiedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=verified_at,json=verifiedAt,proto3,oneof" json:"verified_at,omitempty"`
+	// none, issuing, ready or failed
+	CertificateStatus  string                 `protobuf:"bytes,9,opt,name=certificate_status,json=certificateStatus,proto3" json:"certificate_status,omitempty"`
+	CertificateMessage *string                `protobuf:"bytes,10,opt,name=certificate_message,json=certificateMessage,proto3,oneof" json:"certificate_message,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Domain) Reset() {
+	*x = Domain{}
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Domain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Domain) ProtoMessage() {}
+
+func (x *Domain) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Domain.ProtoReflect.Descriptor instead.
+func (*Domain) Descriptor() ([]byte, []int) {
+	return file_shared_proto_domain_v1_domain_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Domain) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Domain) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *Domain) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Domain) GetVerificationStatus() string {
+	if x != nil {
+		return x.VerificationStatus
+	}
+	return ""
+}
+
+func (x *Domain) GetVerificationMessage() string {
+	if x != nil && x.VerificationMessage != nil {
+		return *x.VerificationMessage
+	}
+	return ""
+}
+
+func (x *Domain) GetChallengeName() string {
+	if x != nil {
+		return x.ChallengeName
+	}
+	return ""
+}
+
+func (x *Domain) GetChallengeValue() string {
+	if x != nil {
+		return x.ChallengeValue
+	}
+	return ""
+}
+
+func (x *Domain) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
+func (x *Domain) GetCertificateStatus() string {
+	if x != nil {
+		return x.CertificateStatus
+	}
+	return ""
+}
+
+func (x *Domain) GetCertificateMessage() string {
+	if x != nil && x.CertificateMessage != nil {
+		return *x.CertificateMessage
+	}
+	return ""
+}
+
+func (x *Domain) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AddDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDomainRequest) Reset() {
+	*x = AddDomainRequest{}
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDomainRequest) ProtoMessage() {}
+
+func (x *AddDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDomainRequest.ProtoReflect.Descriptor instead.
+func (*AddDomainRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_domain_v1_domain_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddDomainRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *AddDomainRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type AddDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        *Domain                `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDomainResponse) Reset() {
+	*x = AddDomainResponse{}
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDomainResponse) ProtoMessage() {}
+
+func (x *AddDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDomainResponse.ProtoReflect.Descriptor instead.
+func (*AddDomainResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_domain_v1_domain_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddDomainResponse) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+// looks up the domain's TXT record. a failed lookup is reported on the domain rather than as an error,
+// so it can simply be retried once DNS has propagated.
+type VerifyDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainRequest) Reset() {
+	*x = VerifyDomainRequest{}
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainRequest) ProtoMessage() {}
+
+func (x *VerifyDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDomainRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_domain_v1_domain_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VerifyDomainRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *VerifyDomainRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type VerifyDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        *Domain                `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainResponse) Reset() {
+	*x = VerifyDomainResponse{}
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainResponse) ProtoMessage() {}
+
+func (x *VerifyDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainResponse.ProtoReflect.Descriptor instead.
+func (*VerifyDomainResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_domain_v1_domain_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VerifyDomainResponse) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+type ListDomainsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsRequest) Reset() {
+	*x = ListDomainsRequest{}
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsRequest) ProtoMessage() {}
+
+func (x *ListDomainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsRequest.ProtoReflect.Descriptor instead.
+func (*ListDomainsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_domain_v1_domain_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDomainsRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+type ListDomainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domains       []*Domain              `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsResponse) Reset() {
+	*x = ListDomainsResponse{}
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsResponse) ProtoMessage() {}
+
+func (x *ListDomainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsResponse.ProtoReflect.Descriptor instead.
+func (*ListDomainsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_domain_v1_domain_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListDomainsResponse) GetDomains() []*Domain {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+type RemoveDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDomainRequest) Reset() {
+	*x = RemoveDomainRequest{}
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDomainRequest) ProtoMessage() {}
+
+func (x *RemoveDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDomainRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDomainRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_domain_v1_domain_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoveDomainRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *RemoveDomainRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type RemoveDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDomainResponse) Reset() {
+	*x = RemoveDomainResponse{}
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDomainResponse) ProtoMessage() {}
+
+func (x *RemoveDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_domain_v1_domain_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDomainResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDomainResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_domain_v1_domain_proto_rawDescGZIP(), []int{8}
+}
+
+var File_shared_proto_domain_v1_domain_proto protoreflect.FileDescriptor
+
+const file_shared_proto_domain_v1_domain_proto_rawDesc = "" +
+	"\n" +
+	"#shared/proto/domain/v1/domain.proto\x12\x0eloco.domain.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa7\x04\n" +
+	"\x06Domain\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\x03R\x05appId\x12\x1a\n" +
+	"\bhostname\x18\x03 \x01(\tR\bhostname\x12/\n" +
+	"\x13verification_status\x18\x04 \x01(\tR\x12verificationStatus\x126\n" +
+	"\x14verification_message\x18\x05 \x01(\tH\x00R\x13verificationMessage\x88\x01\x01\x12%\n" +
+	"\x0echallenge_name\x18\x06 \x01(\tR\rchallengeName\x12'\n" +
+	"\x0fchallenge_value\x18\a \x01(\tR\x0echallengeValue\x12@\n" +
+	"\vverified_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x01R\n" +
+	"verifiedAt\x88\x01\x01\x12-\n" +
+	"\x12certificate_status\x18\t \x01(\tR\x11certificateStatus\x124\n" +
+	"\x13certificate_message\x18\n" +
+	" \x01(\tH\x02R\x12certificateMessage\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\x17\n" +
+	"\x15_verification_messageB\x0e\n" +
+	"\f_verified_atB\x16\n" +
+	"\x14_certificate_message\"E\n" +
+	"\x10AddDomainRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x1a\n" +
+	"\bhostname\x18\x02 \x01(\tR\bhostname\"C\n" +
+	"\x11AddDomainResponse\x12.\n" +
+	"\x06domain\x18\x01 \x01(\v2\x16.loco.domain.v1.DomainR\x06domain\"H\n" +
+	"\x13VerifyDomainRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x1a\n" +
+	"\bhostname\x18\x02 \x01(\tR\bhostname\"F\n" +
+	"\x14VerifyDomainResponse\x12.\n" +
+	"\x06domain\x18\x01 \x01(\v2\x16.loco.domain.v1.DomainR\x06domain\"+\n" +
+	"\x12ListDomainsRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\"G\n" +
+	"\x13ListDomainsResponse\x120\n" +
+	"\adomains\x18\x01 \x03(\v2\x16.loco.domain.v1.DomainR\adomains\"H\n" +
+	"\x13RemoveDomainRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x1a\n" +
+	"\bhostname\x18\x02 \x01(\tR\bhostname\"\x16\n" +
+	"\x14RemoveDomainResponse2\xef\x02\n" +
+	"\rDomainService\x12P\n" +
+	"\tAddDomain\x12 .loco.domain.v1.AddDomainRequest\x1a!.loco.domain.v1.AddDomainResponse\x12Y\n" +
+	"\fVerifyDomain\x12#.loco.domain.v1.VerifyDomainRequest\x1a$.loco.domain.v1.VerifyDomainResponse\x12V\n" +
+	"\vListDomains\x12\".loco.domain.v1.ListDomainsRequest\x1a#.loco.domain.v1.ListDomainsResponse\x12Y\n" +
+	"\fRemoveDomain\x12#.loco.domain.v1.RemoveDomainRequest\x1a$.loco.domain.v1.RemoveDomainResponseB=Z;github.com/nikumar1206/loco/shared/proto/domain/v1;domainv1b\x06proto3"
+
+var (
+	file_shared_proto_domain_v1_domain_proto_rawDescOnce sync.Once
+	file_shared_proto_domain_v1_domain_proto_rawDescData []byte
+)
+
+func file_shared_proto_domain_v1_domain_proto_rawDescGZIP() []byte {
+	file_shared_proto_domain_v1_domain_proto_rawDescOnce.Do(func() {
+		file_shared_proto_domain_v1_domain_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shared_proto_domain_v1_domain_proto_rawDesc), len(file_shared_proto_domain_v1_domain_proto_rawDesc)))
+	})
+	return file_shared_proto_domain_v1_domain_proto_rawDescData
+}
+
+var file_shared_proto_domain_v1_domain_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_shared_proto_domain_v1_domain_proto_goTypes = []any{
+	(*Domain)(nil),                // 0: loco.domain.v1.Domain
+	(*AddDomainRequest)(nil),      // 1: loco.domain.v1.AddDomainRequest
+	(*AddDomainResponse)(nil),     // 2: loco.domain.v1.AddDomainResponse
+	(*VerifyDomainRequest)(nil),   // 3: loco.domain.v1.VerifyDomainRequest
+	(*VerifyDomainResponse)(nil),  // 4: loco.domain.v1.VerifyDomainResponse
+	(*ListDomainsRequest)(nil),    // 5: loco.domain.v1.ListDomainsRequest
+	(*ListDomainsResponse)(nil),   // 6: loco.domain.v1.ListDomainsResponse
+	(*RemoveDomainRequest)(nil),   // 7: loco.domain.v1.RemoveDomainRequest
+	(*RemoveDomainResponse)(nil),  // 8: loco.domain.v1.RemoveDomainResponse
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_shared_proto_domain_v1_domain_proto_depIdxs = []int32{
+	9, // 0: loco.domain.v1.Domain.verified_at:type_name -> google.protobuf.Timestamp
+	9, // 1: loco.domain.v1.Domain.created_at:type_name -> google.protobuf.Timestamp
+	0, // 2: loco.domain.v1.AddDomainResponse.domain:type_name -> loco.domain.v1.Domain
+	0, // 3: loco.domain.v1.VerifyDomainResponse.domain:type_name -> loco.domain.v1.Domain
+	0, // 4: loco.domain.v1.ListDomainsResponse.domains:type_name -> loco.domain.v1.Domain
+	1, // 5: loco.domain.v1.DomainService.AddDomain:input_type -> loco.domain.v1.AddDomainRequest
+	3, // 6: loco.domain.v1.DomainService.VerifyDomain:input_type -> loco.domain.v1.VerifyDomainRequest
+	5, // 7: loco.domain.v1.DomainService.ListDomains:input_type -> loco.domain.v1.ListDomainsRequest
+	7, // 8: loco.domain.v1.DomainService.RemoveDomain:input_type -> loco.domain.v1.RemoveDomainRequest
+	2, // 9: loco.domain.v1.DomainService.AddDomain:output_type -> loco.domain.v1.AddDomainResponse
+	4, // 10: loco.domain.v1.DomainService.VerifyDomain:output_type -> loco.domain.v1.VerifyDomainResponse
+	6, // 11: loco.domain.v1.DomainService.ListDomains:output_type -> loco.domain.v1.ListDomainsResponse
+	8, // 12: loco.domain.v1.DomainService.RemoveDomain:output_type -> loco.domain.v1.RemoveDomainResponse
+	9, // [9:13] is the sub-list for method output_type
+	5, // [5:9] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_shared_proto_domain_v1_domain_proto_init() }
+func file_shared_proto_domain_v1_domain_proto_init() {
+	if File_shared_proto_domain_v1_domain_proto != nil {
+		return
+	}
+	file_shared_proto_domain_v1_domain_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_domain_v1_domain_proto_rawDesc), len(file_shared_proto_domain_v1_domain_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shared_proto_domain_v1_domain_proto_goTypes,
+		DependencyIndexes: file_shared_proto_domain_v1_domain_proto_depIdxs,
+		MessageInfos:      file_shared_proto_domain_v1_domain_proto_msgTypes,
+	}.Build()
+	File_shared_proto_domain_v1_domain_proto = out.File
+	file_shared_proto_domain_v1_domain_proto_goTypes = nil
+	file_shared_proto_domain_v1_domain_proto_depIdxs = nil
+}
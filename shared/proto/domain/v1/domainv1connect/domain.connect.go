@@ -0,0 +1,195 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: shared/proto/domain/v1/domain.proto
+
+package domainv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/nikumar1206/loco/shared/proto/domain/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// DomainServiceName is the fully-qualified name of the DomainService service.
+	DomainServiceName = "loco.domain.v1.DomainService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// DomainServiceAddDomainProcedure is the fully-qualified name of the DomainService's AddDomain RPC.
+	DomainServiceAddDomainProcedure = "/loco.domain.v1.DomainService/AddDomain"
+	// DomainServiceVerifyDomainProcedure is the fully-qualified name of the DomainService's
+	// VerifyDomain RPC.
+	DomainServiceVerifyDomainProcedure = "/loco.domain.v1.DomainService/VerifyDomain"
+	// DomainServiceListDomainsProcedure is the fully-qualified name of the DomainService's ListDomains
+	// RPC.
+	DomainServiceListDomainsProcedure = "/loco.domain.v1.DomainService/ListDomains"
+	// DomainServiceRemoveDomainProcedure is the fully-qualified name of the DomainService's
+	// RemoveDomain RPC.
+	DomainServiceRemoveDomainProcedure = "/loco.domain.v1.DomainService/RemoveDomain"
+)
+
+// DomainServiceClient is a client for the loco.domain.v1.DomainService service.
+type DomainServiceClient interface {
+	AddDomain(context.Context, *connect.Request[v1.AddDomainRequest]) (*connect.Response[v1.AddDomainResponse], error)
+	VerifyDomain(context.Context, *connect.Request[v1.VerifyDomainRequest]) (*connect.Response[v1.VerifyDomainResponse], error)
+	ListDomains(context.Context, *connect.Request[v1.ListDomainsRequest]) (*connect.Response[v1.ListDomainsResponse], error)
+	RemoveDomain(context.Context, *connect.Request[v1.RemoveDomainRequest]) (*connect.Response[v1.RemoveDomainResponse], error)
+}
+
+// NewDomainServiceClient constructs a client for the loco.domain.v1.DomainService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewDomainServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) DomainServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	domainServiceMethods := v1.File_shared_proto_domain_v1_domain_proto.Services().ByName("DomainService").Methods()
+	return &domainServiceClient{
+		addDomain: connect.NewClient[v1.AddDomainRequest, v1.AddDomainResponse](
+			httpClient,
+			baseURL+DomainServiceAddDomainProcedure,
+			connect.WithSchema(domainServiceMethods.ByName("AddDomain")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyDomain: connect.NewClient[v1.VerifyDomainRequest, v1.VerifyDomainResponse](
+			httpClient,
+			baseURL+DomainServiceVerifyDomainProcedure,
+			connect.WithSchema(domainServiceMethods.ByName("VerifyDomain")),
+			connect.WithClientOptions(opts...),
+		),
+		listDomains: connect.NewClient[v1.ListDomainsRequest, v1.ListDomainsResponse](
+			httpClient,
+			baseURL+DomainServiceListDomainsProcedure,
+			connect.WithSchema(domainServiceMethods.ByName("ListDomains")),
+			connect.WithClientOptions(opts...),
+		),
+		removeDomain: connect.NewClient[v1.RemoveDomainRequest, v1.RemoveDomainResponse](
+			httpClient,
+			baseURL+DomainServiceRemoveDomainProcedure,
+			connect.WithSchema(domainServiceMethods.ByName("RemoveDomain")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// domainServiceClient implements DomainServiceClient.
+type domainServiceClient struct {
+	addDomain    *connect.Client[v1.AddDomainRequest, v1.AddDomainResponse]
+	verifyDomain *connect.Client[v1.VerifyDomainRequest, v1.VerifyDomainResponse]
+	listDomains  *connect.Client[v1.ListDomainsRequest, v1.ListDomainsResponse]
+	removeDomain *connect.Client[v1.RemoveDomainRequest, v1.RemoveDomainResponse]
+}
+
+// AddDomain calls loco.domain.v1.DomainService.AddDomain.
+func (c *domainServiceClient) AddDomain(ctx context.Context, req *connect.Request[v1.AddDomainRequest]) (*connect.Response[v1.AddDomainResponse], error) {
+	return c.addDomain.CallUnary(ctx, req)
+}
+
+// VerifyDomain calls loco.domain.v1.DomainService.VerifyDomain.
+func (c *domainServiceClient) VerifyDomain(ctx context.Context, req *connect.Request[v1.VerifyDomainRequest]) (*connect.Response[v1.VerifyDomainResponse], error) {
+	return c.verifyDomain.CallUnary(ctx, req)
+}
+
+// ListDomains calls loco.domain.v1.DomainService.ListDomains.
+func (c *domainServiceClient) ListDomains(ctx context.Context, req *connect.Request[v1.ListDomainsRequest]) (*connect.Response[v1.ListDomainsResponse], error) {
+	return c.listDomains.CallUnary(ctx, req)
+}
+
+// RemoveDomain calls loco.domain.v1.DomainService.RemoveDomain.
+func (c *domainServiceClient) RemoveDomain(ctx context.Context, req *connect.Request[v1.RemoveDomainRequest]) (*connect.Response[v1.RemoveDomainResponse], error) {
+	return c.removeDomain.CallUnary(ctx, req)
+}
+
+// DomainServiceHandler is an implementation of the loco.domain.v1.DomainService service.
+type DomainServiceHandler interface {
+	AddDomain(context.Context, *connect.Request[v1.AddDomainRequest]) (*connect.Response[v1.AddDomainResponse], error)
+	VerifyDomain(context.Context, *connect.Request[v1.VerifyDomainRequest]) (*connect.Response[v1.VerifyDomainResponse], error)
+	ListDomains(context.Context, *connect.Request[v1.ListDomainsRequest]) (*connect.Response[v1.ListDomainsResponse], error)
+	RemoveDomain(context.Context, *connect.Request[v1.RemoveDomainRequest]) (*connect.Response[v1.RemoveDomainResponse], error)
+}
+
+// NewDomainServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewDomainServiceHandler(svc DomainServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	domainServiceMethods := v1.File_shared_proto_domain_v1_domain_proto.Services().ByName("DomainService").Methods()
+	domainServiceAddDomainHandler := connect.NewUnaryHandler(
+		DomainServiceAddDomainProcedure,
+		svc.AddDomain,
+		connect.WithSchema(domainServiceMethods.ByName("AddDomain")),
+		connect.WithHandlerOptions(opts...),
+	)
+	domainServiceVerifyDomainHandler := connect.NewUnaryHandler(
+		DomainServiceVerifyDomainProcedure,
+		svc.VerifyDomain,
+		connect.WithSchema(domainServiceMethods.ByName("VerifyDomain")),
+		connect.WithHandlerOptions(opts...),
+	)
+	domainServiceListDomainsHandler := connect.NewUnaryHandler(
+		DomainServiceListDomainsProcedure,
+		svc.ListDomains,
+		connect.WithSchema(domainServiceMethods.ByName("ListDomains")),
+		connect.WithHandlerOptions(opts...),
+	)
+	domainServiceRemoveDomainHandler := connect.NewUnaryHandler(
+		DomainServiceRemoveDomainProcedure,
+		svc.RemoveDomain,
+		connect.WithSchema(domainServiceMethods.ByName("RemoveDomain")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/loco.domain.v1.DomainService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case DomainServiceAddDomainProcedure:
+			domainServiceAddDomainHandler.ServeHTTP(w, r)
+		case DomainServiceVerifyDomainProcedure:
+			domainServiceVerifyDomainHandler.ServeHTTP(w, r)
+		case DomainServiceListDomainsProcedure:
+			domainServiceListDomainsHandler.ServeHTTP(w, r)
+		case DomainServiceRemoveDomainProcedure:
+			domainServiceRemoveDomainHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedDomainServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedDomainServiceHandler struct{}
+
+func (UnimplementedDomainServiceHandler) AddDomain(context.Context, *connect.Request[v1.AddDomainRequest]) (*connect.Response[v1.AddDomainResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.domain.v1.DomainService.AddDomain is not implemented"))
+}
+
+func (UnimplementedDomainServiceHandler) VerifyDomain(context.Context, *connect.Request[v1.VerifyDomainRequest]) (*connect.Response[v1.VerifyDomainResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.domain.v1.DomainService.VerifyDomain is not implemented"))
+}
+
+func (UnimplementedDomainServiceHandler) ListDomains(context.Context, *connect.Request[v1.ListDomainsRequest]) (*connect.Response[v1.ListDomainsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.domain.v1.DomainService.ListDomains is not implemented"))
+}
+
+func (UnimplementedDomainServiceHandler) RemoveDomain(context.Context, *connect.Request[v1.RemoveDomainRequest]) (*connect.Response[v1.RemoveDomainResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.domain.v1.DomainService.RemoveDomain is not implemented"))
+}
@@ -0,0 +1,607 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: shared/proto/job/v1/job.proto
+
+package jobv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Job struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Schedule        string                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Command         []string               `protobuf:"bytes,3,rep,name=command,proto3" json:"command,omitempty"`
+	LastScheduledAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_scheduled_at,json=lastScheduledAt,proto3,oneof" json:"last_scheduled_at,omitempty"`
+	LastSucceededAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_succeeded_at,json=lastSucceededAt,proto3,oneof" json:"last_succeeded_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_shared_proto_job_v1_job_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Job) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Job) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *Job) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Job) GetLastScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastScheduledAt
+	}
+	return nil
+}
+
+func (x *Job) GetLastSucceededAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSucceededAt
+	}
+	return nil
+}
+
+type JobRun struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Job   string                 `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	// scheduled or manual
+	Trigger string `protobuf:"bytes,3,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// pending, running, succeeded or failed
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Message       *string                `protobuf:"bytes,5,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3,oneof" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3,oneof" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobRun) Reset() {
+	*x = JobRun{}
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobRun) ProtoMessage() {}
+
+func (x *JobRun) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobRun.ProtoReflect.Descriptor instead.
+func (*JobRun) Descriptor() ([]byte, []int) {
+	return file_shared_proto_job_v1_job_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *JobRun) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobRun) GetJob() string {
+	if x != nil {
+		return x.Job
+	}
+	return ""
+}
+
+func (x *JobRun) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *JobRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobRun) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *JobRun) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *JobRun) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *JobRun) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type ListJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_job_v1_job_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListJobsRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_job_v1_job_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// runs are kept by the cluster, the most recent ones of each job, newest first
+type ListJobRunsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	AppId int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// every job's runs when unset
+	Job           *string `protobuf:"bytes,2,opt,name=job,proto3,oneof" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobRunsRequest) Reset() {
+	*x = ListJobRunsRequest{}
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobRunsRequest) ProtoMessage() {}
+
+func (x *ListJobRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobRunsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_job_v1_job_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListJobRunsRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *ListJobRunsRequest) GetJob() string {
+	if x != nil && x.Job != nil {
+		return *x.Job
+	}
+	return ""
+}
+
+type ListJobRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*JobRun              `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobRunsResponse) Reset() {
+	*x = ListJobRunsResponse{}
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobRunsResponse) ProtoMessage() {}
+
+func (x *ListJobRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobRunsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_job_v1_job_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListJobRunsResponse) GetRuns() []*JobRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type TriggerJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         int64                  `protobuf:"varint,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Job           string                 `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerJobRequest) Reset() {
+	*x = TriggerJobRequest{}
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerJobRequest) ProtoMessage() {}
+
+func (x *TriggerJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerJobRequest.ProtoReflect.Descriptor instead.
+func (*TriggerJobRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_job_v1_job_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TriggerJobRequest) GetAppId() int64 {
+	if x != nil {
+		return x.AppId
+	}
+	return 0
+}
+
+func (x *TriggerJobRequest) GetJob() string {
+	if x != nil {
+		return x.Job
+	}
+	return ""
+}
+
+type TriggerJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Run           *JobRun                `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerJobResponse) Reset() {
+	*x = TriggerJobResponse{}
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerJobResponse) ProtoMessage() {}
+
+func (x *TriggerJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_job_v1_job_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerJobResponse.ProtoReflect.Descriptor instead.
+func (*TriggerJobResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_job_v1_job_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TriggerJobResponse) GetRun() *JobRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+var File_shared_proto_job_v1_job_proto protoreflect.FileDescriptor
+
+const file_shared_proto_job_v1_job_proto_rawDesc = "" +
+	"\n" +
+	"\x1dshared/proto/job/v1/job.proto\x12\vloco.job.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x95\x02\n" +
+	"\x03Job\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bschedule\x18\x02 \x01(\tR\bschedule\x12\x18\n" +
+	"\acommand\x18\x03 \x03(\tR\acommand\x12K\n" +
+	"\x11last_scheduled_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0flastScheduledAt\x88\x01\x01\x12K\n" +
+	"\x11last_succeeded_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x0flastSucceededAt\x88\x01\x01B\x14\n" +
+	"\x12_last_scheduled_atB\x14\n" +
+	"\x12_last_succeeded_at\"\xe7\x02\n" +
+	"\x06JobRun\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03job\x18\x02 \x01(\tR\x03job\x12\x18\n" +
+	"\atrigger\x18\x03 \x01(\tR\atrigger\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
+	"\amessage\x18\x05 \x01(\tH\x00R\amessage\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\n" +
+	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tstartedAt\x88\x01\x01\x12@\n" +
+	"\vfinished_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x02R\n" +
+	"finishedAt\x88\x01\x01B\n" +
+	"\n" +
+	"\b_messageB\r\n" +
+	"\v_started_atB\x0e\n" +
+	"\f_finished_at\"(\n" +
+	"\x0fListJobsRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\"8\n" +
+	"\x10ListJobsResponse\x12$\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x10.loco.job.v1.JobR\x04jobs\"J\n" +
+	"\x12ListJobRunsRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x15\n" +
+	"\x03job\x18\x02 \x01(\tH\x00R\x03job\x88\x01\x01B\x06\n" +
+	"\x04_job\">\n" +
+	"\x13ListJobRunsResponse\x12'\n" +
+	"\x04runs\x18\x01 \x03(\v2\x13.loco.job.v1.JobRunR\x04runs\"<\n" +
+	"\x11TriggerJobRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\x03R\x05appId\x12\x10\n" +
+	"\x03job\x18\x02 \x01(\tR\x03job\";\n" +
+	"\x12TriggerJobResponse\x12%\n" +
+	"\x03run\x18\x01 \x01(\v2\x13.loco.job.v1.JobRunR\x03run2\xf6\x01\n" +
+	"\n" +
+	"JobService\x12G\n" +
+	"\bListJobs\x12\x1c.loco.job.v1.ListJobsRequest\x1a\x1d.loco.job.v1.ListJobsResponse\x12P\n" +
+	"\vListJobRuns\x12\x1f.loco.job.v1.ListJobRunsRequest\x1a .loco.job.v1.ListJobRunsResponse\x12M\n" +
+	"\n" +
+	"TriggerJob\x12\x1e.loco.job.v1.TriggerJobRequest\x1a\x1f.loco.job.v1.TriggerJobResponseB7Z5github.com/nikumar1206/loco/shared/proto/job/v1;jobv1b\x06proto3"
+
+var (
+	file_shared_proto_job_v1_job_proto_rawDescOnce sync.Once
+	file_shared_proto_job_v1_job_proto_rawDescData []byte
+)
+
+func file_shared_proto_job_v1_job_proto_rawDescGZIP() []byte {
+	file_shared_proto_job_v1_job_proto_rawDescOnce.Do(func() {
+		file_shared_proto_job_v1_job_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shared_proto_job_v1_job_proto_rawDesc), len(file_shared_proto_job_v1_job_proto_rawDesc)))
+	})
+	return file_shared_proto_job_v1_job_proto_rawDescData
+}
+
+var file_shared_proto_job_v1_job_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_shared_proto_job_v1_job_proto_goTypes = []any{
+	(*Job)(nil),                   // 0: loco.job.v1.Job
+	(*JobRun)(nil),                // 1: loco.job.v1.JobRun
+	(*ListJobsRequest)(nil),       // 2: loco.job.v1.ListJobsRequest
+	(*ListJobsResponse)(nil),      // 3: loco.job.v1.ListJobsResponse
+	(*ListJobRunsRequest)(nil),    // 4: loco.job.v1.ListJobRunsRequest
+	(*ListJobRunsResponse)(nil),   // 5: loco.job.v1.ListJobRunsResponse
+	(*TriggerJobRequest)(nil),     // 6: loco.job.v1.TriggerJobRequest
+	(*TriggerJobResponse)(nil),    // 7: loco.job.v1.TriggerJobResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_shared_proto_job_v1_job_proto_depIdxs = []int32{
+	8,  // 0: loco.job.v1.Job.last_scheduled_at:type_name -> google.protobuf.Timestamp
+	8,  // 1: loco.job.v1.Job.last_succeeded_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: loco.job.v1.JobRun.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: loco.job.v1.JobRun.started_at:type_name -> google.protobuf.Timestamp
+	8,  // 4: loco.job.v1.JobRun.finished_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: loco.job.v1.ListJobsResponse.jobs:type_name -> loco.job.v1.Job
+	1,  // 6: loco.job.v1.ListJobRunsResponse.runs:type_name -> loco.job.v1.JobRun
+	1,  // 7: loco.job.v1.TriggerJobResponse.run:type_name -> loco.job.v1.JobRun
+	2,  // 8: loco.job.v1.JobService.ListJobs:input_type -> loco.job.v1.ListJobsRequest
+	4,  // 9: loco.job.v1.JobService.ListJobRuns:input_type -> loco.job.v1.ListJobRunsRequest
+	6,  // 10: loco.job.v1.JobService.TriggerJob:input_type -> loco.job.v1.TriggerJobRequest
+	3,  // 11: loco.job.v1.JobService.ListJobs:output_type -> loco.job.v1.ListJobsResponse
+	5,  // 12: loco.job.v1.JobService.ListJobRuns:output_type -> loco.job.v1.ListJobRunsResponse
+	7,  // 13: loco.job.v1.JobService.TriggerJob:output_type -> loco.job.v1.TriggerJobResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_shared_proto_job_v1_job_proto_init() }
+func file_shared_proto_job_v1_job_proto_init() {
+	if File_shared_proto_job_v1_job_proto != nil {
+		return
+	}
+	file_shared_proto_job_v1_job_proto_msgTypes[0].OneofWrappers = []any{}
+	file_shared_proto_job_v1_job_proto_msgTypes[1].OneofWrappers = []any{}
+	file_shared_proto_job_v1_job_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_job_v1_job_proto_rawDesc), len(file_shared_proto_job_v1_job_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shared_proto_job_v1_job_proto_goTypes,
+		DependencyIndexes: file_shared_proto_job_v1_job_proto_depIdxs,
+		MessageInfos:      file_shared_proto_job_v1_job_proto_msgTypes,
+	}.Build()
+	File_shared_proto_job_v1_job_proto = out.File
+	file_shared_proto_job_v1_job_proto_goTypes = nil
+	file_shared_proto_job_v1_job_proto_depIdxs = nil
+}
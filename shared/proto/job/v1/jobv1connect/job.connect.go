@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: shared/proto/job/v1/job.proto
+
+package jobv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/nikumar1206/loco/shared/proto/job/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// JobServiceName is the fully-qualified name of the JobService service.
+	JobServiceName = "loco.job.v1.JobService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// JobServiceListJobsProcedure is the fully-qualified name of the JobService's ListJobs RPC.
+	JobServiceListJobsProcedure = "/loco.job.v1.JobService/ListJobs"
+	// JobServiceListJobRunsProcedure is the fully-qualified name of the JobService's ListJobRuns RPC.
+	JobServiceListJobRunsProcedure = "/loco.job.v1.JobService/ListJobRuns"
+	// JobServiceTriggerJobProcedure is the fully-qualified name of the JobService's TriggerJob RPC.
+	JobServiceTriggerJobProcedure = "/loco.job.v1.JobService/TriggerJob"
+)
+
+// JobServiceClient is a client for the loco.job.v1.JobService service.
+type JobServiceClient interface {
+	ListJobs(context.Context, *connect.Request[v1.ListJobsRequest]) (*connect.Response[v1.ListJobsResponse], error)
+	ListJobRuns(context.Context, *connect.Request[v1.ListJobRunsRequest]) (*connect.Response[v1.ListJobRunsResponse], error)
+	// starts a run of the job now, alongside its schedule
+	TriggerJob(context.Context, *connect.Request[v1.TriggerJobRequest]) (*connect.Response[v1.TriggerJobResponse], error)
+}
+
+// NewJobServiceClient constructs a client for the loco.job.v1.JobService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewJobServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) JobServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	jobServiceMethods := v1.File_shared_proto_job_v1_job_proto.Services().ByName("JobService").Methods()
+	return &jobServiceClient{
+		listJobs: connect.NewClient[v1.ListJobsRequest, v1.ListJobsResponse](
+			httpClient,
+			baseURL+JobServiceListJobsProcedure,
+			connect.WithSchema(jobServiceMethods.ByName("ListJobs")),
+			connect.WithClientOptions(opts...),
+		),
+		listJobRuns: connect.NewClient[v1.ListJobRunsRequest, v1.ListJobRunsResponse](
+			httpClient,
+			baseURL+JobServiceListJobRunsProcedure,
+			connect.WithSchema(jobServiceMethods.ByName("ListJobRuns")),
+			connect.WithClientOptions(opts...),
+		),
+		triggerJob: connect.NewClient[v1.TriggerJobRequest, v1.TriggerJobResponse](
+			httpClient,
+			baseURL+JobServiceTriggerJobProcedure,
+			connect.WithSchema(jobServiceMethods.ByName("TriggerJob")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// jobServiceClient implements JobServiceClient.
+type jobServiceClient struct {
+	listJobs    *connect.Client[v1.ListJobsRequest, v1.ListJobsResponse]
+	listJobRuns *connect.Client[v1.ListJobRunsRequest, v1.ListJobRunsResponse]
+	triggerJob  *connect.Client[v1.TriggerJobRequest, v1.TriggerJobResponse]
+}
+
+// ListJobs calls loco.job.v1.JobService.ListJobs.
+func (c *jobServiceClient) ListJobs(ctx context.Context, req *connect.Request[v1.ListJobsRequest]) (*connect.Response[v1.ListJobsResponse], error) {
+	return c.listJobs.CallUnary(ctx, req)
+}
+
+// ListJobRuns calls loco.job.v1.JobService.ListJobRuns.
+func (c *jobServiceClient) ListJobRuns(ctx context.Context, req *connect.Request[v1.ListJobRunsRequest]) (*connect.Response[v1.ListJobRunsResponse], error) {
+	return c.listJobRuns.CallUnary(ctx, req)
+}
+
+// TriggerJob calls loco.job.v1.JobService.TriggerJob.
+func (c *jobServiceClient) TriggerJob(ctx context.Context, req *connect.Request[v1.TriggerJobRequest]) (*connect.Response[v1.TriggerJobResponse], error) {
+	return c.triggerJob.CallUnary(ctx, req)
+}
+
+// JobServiceHandler is an implementation of the loco.job.v1.JobService service.
+type JobServiceHandler interface {
+	ListJobs(context.Context, *connect.Request[v1.ListJobsRequest]) (*connect.Response[v1.ListJobsResponse], error)
+	ListJobRuns(context.Context, *connect.Request[v1.ListJobRunsRequest]) (*connect.Response[v1.ListJobRunsResponse], error)
+	// starts a run of the job now, alongside its schedule
+	TriggerJob(context.Context, *connect.Request[v1.TriggerJobRequest]) (*connect.Response[v1.TriggerJobResponse], error)
+}
+
+// NewJobServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewJobServiceHandler(svc JobServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	jobServiceMethods := v1.File_shared_proto_job_v1_job_proto.Services().ByName("JobService").Methods()
+	jobServiceListJobsHandler := connect.NewUnaryHandler(
+		JobServiceListJobsProcedure,
+		svc.ListJobs,
+		connect.WithSchema(jobServiceMethods.ByName("ListJobs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	jobServiceListJobRunsHandler := connect.NewUnaryHandler(
+		JobServiceListJobRunsProcedure,
+		svc.ListJobRuns,
+		connect.WithSchema(jobServiceMethods.ByName("ListJobRuns")),
+		connect.WithHandlerOptions(opts...),
+	)
+	jobServiceTriggerJobHandler := connect.NewUnaryHandler(
+		JobServiceTriggerJobProcedure,
+		svc.TriggerJob,
+		connect.WithSchema(jobServiceMethods.ByName("TriggerJob")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/loco.job.v1.JobService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case JobServiceListJobsProcedure:
+			jobServiceListJobsHandler.ServeHTTP(w, r)
+		case JobServiceListJobRunsProcedure:
+			jobServiceListJobRunsHandler.ServeHTTP(w, r)
+		case JobServiceTriggerJobProcedure:
+			jobServiceTriggerJobHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedJobServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedJobServiceHandler struct{}
+
+func (UnimplementedJobServiceHandler) ListJobs(context.Context, *connect.Request[v1.ListJobsRequest]) (*connect.Response[v1.ListJobsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.job.v1.JobService.ListJobs is not implemented"))
+}
+
+func (UnimplementedJobServiceHandler) ListJobRuns(context.Context, *connect.Request[v1.ListJobRunsRequest]) (*connect.Response[v1.ListJobRunsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.job.v1.JobService.ListJobRuns is not implemented"))
+}
+
+func (UnimplementedJobServiceHandler) TriggerJob(context.Context, *connect.Request[v1.TriggerJobRequest]) (*connect.Response[v1.TriggerJobResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.job.v1.JobService.TriggerJob is not implemented"))
+}
@@ -0,0 +1,509 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: shared/proto/quota/v1/quota.proto
+
+package quotav1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// cpu is in millicores and memory in MiB. max_replicas caps a single app.
+type Limits struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MaxApps          int32                  `protobuf:"varint,1,opt,name=max_apps,json=maxApps,proto3" json:"max_apps,omitempty"`
+	MaxCpuMillicores int64                  `protobuf:"varint,2,opt,name=max_cpu_millicores,json=maxCpuMillicores,proto3" json:"max_cpu_millicores,omitempty"`
+	MaxMemoryMb      int64                  `protobuf:"varint,3,opt,name=max_memory_mb,json=maxMemoryMb,proto3" json:"max_memory_mb,omitempty"`
+	MaxReplicas      int32                  `protobuf:"varint,4,opt,name=max_replicas,json=maxReplicas,proto3" json:"max_replicas,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Limits) Reset() {
+	*x = Limits{}
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Limits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limits) ProtoMessage() {}
+
+func (x *Limits) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limits.ProtoReflect.Descriptor instead.
+func (*Limits) Descriptor() ([]byte, []int) {
+	return file_shared_proto_quota_v1_quota_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Limits) GetMaxApps() int32 {
+	if x != nil {
+		return x.MaxApps
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxCpuMillicores() int64 {
+	if x != nil {
+		return x.MaxCpuMillicores
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxMemoryMb() int64 {
+	if x != nil {
+		return x.MaxMemoryMb
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxReplicas() int32 {
+	if x != nil {
+		return x.MaxReplicas
+	}
+	return 0
+}
+
+// usage counts replicas * per-replica resources of every app's current deployment
+type Usage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Apps          int64                  `protobuf:"varint,1,opt,name=apps,proto3" json:"apps,omitempty"`
+	CpuMillicores int64                  `protobuf:"varint,2,opt,name=cpu_millicores,json=cpuMillicores,proto3" json:"cpu_millicores,omitempty"`
+	MemoryMb      int64                  `protobuf:"varint,3,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_shared_proto_quota_v1_quota_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Usage) GetApps() int64 {
+	if x != nil {
+		return x.Apps
+	}
+	return 0
+}
+
+func (x *Usage) GetCpuMillicores() int64 {
+	if x != nil {
+		return x.CpuMillicores
+	}
+	return 0
+}
+
+func (x *Usage) GetMemoryMb() int64 {
+	if x != nil {
+		return x.MemoryMb
+	}
+	return 0
+}
+
+type Quota struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        *Limits                `protobuf:"bytes,1,opt,name=limits,proto3" json:"limits,omitempty"`
+	Usage         *Usage                 `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_shared_proto_quota_v1_quota_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Quota) GetLimits() *Limits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *Quota) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+type GetQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaRequest) Reset() {
+	*x = GetQuotaRequest{}
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaRequest) ProtoMessage() {}
+
+func (x *GetQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_quota_v1_quota_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetQuotaRequest) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+type GetQuotaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	OrgId         int64                  `protobuf:"varint,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Workspace     *Quota                 `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	Org           *Quota                 `protobuf:"bytes,4,opt,name=org,proto3" json:"org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaResponse) Reset() {
+	*x = GetQuotaResponse{}
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaResponse) ProtoMessage() {}
+
+func (x *GetQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_quota_v1_quota_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetQuotaResponse) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *GetQuotaResponse) GetOrgId() int64 {
+	if x != nil {
+		return x.OrgId
+	}
+	return 0
+}
+
+func (x *GetQuotaResponse) GetWorkspace() *Quota {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+func (x *GetQuotaResponse) GetOrg() *Quota {
+	if x != nil {
+		return x.Org
+	}
+	return nil
+}
+
+type SetWorkspaceQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Limits        *Limits                `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWorkspaceQuotaRequest) Reset() {
+	*x = SetWorkspaceQuotaRequest{}
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWorkspaceQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkspaceQuotaRequest) ProtoMessage() {}
+
+func (x *SetWorkspaceQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkspaceQuotaRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkspaceQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_quota_v1_quota_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetWorkspaceQuotaRequest) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *SetWorkspaceQuotaRequest) GetLimits() *Limits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type SetWorkspaceQuotaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        *Limits                `protobuf:"bytes,1,opt,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWorkspaceQuotaResponse) Reset() {
+	*x = SetWorkspaceQuotaResponse{}
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWorkspaceQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkspaceQuotaResponse) ProtoMessage() {}
+
+func (x *SetWorkspaceQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_quota_v1_quota_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkspaceQuotaResponse.ProtoReflect.Descriptor instead.
+func (*SetWorkspaceQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_quota_v1_quota_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetWorkspaceQuotaResponse) GetLimits() *Limits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+var File_shared_proto_quota_v1_quota_proto protoreflect.FileDescriptor
+
+const file_shared_proto_quota_v1_quota_proto_rawDesc = "" +
+	"\n" +
+	"!shared/proto/quota/v1/quota.proto\x12\rloco.quota.v1\"\x98\x01\n" +
+	"\x06Limits\x12\x19\n" +
+	"\bmax_apps\x18\x01 \x01(\x05R\amaxApps\x12,\n" +
+	"\x12max_cpu_millicores\x18\x02 \x01(\x03R\x10maxCpuMillicores\x12\"\n" +
+	"\rmax_memory_mb\x18\x03 \x01(\x03R\vmaxMemoryMb\x12!\n" +
+	"\fmax_replicas\x18\x04 \x01(\x05R\vmaxReplicas\"_\n" +
+	"\x05Usage\x12\x12\n" +
+	"\x04apps\x18\x01 \x01(\x03R\x04apps\x12%\n" +
+	"\x0ecpu_millicores\x18\x02 \x01(\x03R\rcpuMillicores\x12\x1b\n" +
+	"\tmemory_mb\x18\x03 \x01(\x03R\bmemoryMb\"b\n" +
+	"\x05Quota\x12-\n" +
+	"\x06limits\x18\x01 \x01(\v2\x15.loco.quota.v1.LimitsR\x06limits\x12*\n" +
+	"\x05usage\x18\x02 \x01(\v2\x14.loco.quota.v1.UsageR\x05usage\"4\n" +
+	"\x0fGetQuotaRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x03R\vworkspaceId\"\xa8\x01\n" +
+	"\x10GetQuotaResponse\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x03R\vworkspaceId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\x03R\x05orgId\x122\n" +
+	"\tworkspace\x18\x03 \x01(\v2\x14.loco.quota.v1.QuotaR\tworkspace\x12&\n" +
+	"\x03org\x18\x04 \x01(\v2\x14.loco.quota.v1.QuotaR\x03org\"l\n" +
+	"\x18SetWorkspaceQuotaRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x03R\vworkspaceId\x12-\n" +
+	"\x06limits\x18\x02 \x01(\v2\x15.loco.quota.v1.LimitsR\x06limits\"J\n" +
+	"\x19SetWorkspaceQuotaResponse\x12-\n" +
+	"\x06limits\x18\x01 \x01(\v2\x15.loco.quota.v1.LimitsR\x06limits2\xc3\x01\n" +
+	"\fQuotaService\x12K\n" +
+	"\bGetQuota\x12\x1e.loco.quota.v1.GetQuotaRequest\x1a\x1f.loco.quota.v1.GetQuotaResponse\x12f\n" +
+	"\x11SetWorkspaceQuota\x12'.loco.quota.v1.SetWorkspaceQuotaRequest\x1a(.loco.quota.v1.SetWorkspaceQuotaResponseB;Z9github.com/nikumar1206/loco/shared/proto/quota/v1;quotav1b\x06proto3"
+
+var (
+	file_shared_proto_quota_v1_quota_proto_rawDescOnce sync.Once
+	file_shared_proto_quota_v1_quota_proto_rawDescData []byte
+)
+
+func file_shared_proto_quota_v1_quota_proto_rawDescGZIP() []byte {
+	file_shared_proto_quota_v1_quota_proto_rawDescOnce.Do(func() {
+		file_shared_proto_quota_v1_quota_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shared_proto_quota_v1_quota_proto_rawDesc), len(file_shared_proto_quota_v1_quota_proto_rawDesc)))
+	})
+	return file_shared_proto_quota_v1_quota_proto_rawDescData
+}
+
+var file_shared_proto_quota_v1_quota_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_shared_proto_quota_v1_quota_proto_goTypes = []any{
+	(*Limits)(nil),                    // 0: loco.quota.v1.Limits
+	(*Usage)(nil),                     // 1: loco.quota.v1.Usage
+	(*Quota)(nil),                     // 2: loco.quota.v1.Quota
+	(*GetQuotaRequest)(nil),           // 3: loco.quota.v1.GetQuotaRequest
+	(*GetQuotaResponse)(nil),          // 4: loco.quota.v1.GetQuotaResponse
+	(*SetWorkspaceQuotaRequest)(nil),  // 5: loco.quota.v1.SetWorkspaceQuotaRequest
+	(*SetWorkspaceQuotaResponse)(nil), // 6: loco.quota.v1.SetWorkspaceQuotaResponse
+}
+var file_shared_proto_quota_v1_quota_proto_depIdxs = []int32{
+	0, // 0: loco.quota.v1.Quota.limits:type_name -> loco.quota.v1.Limits
+	1, // 1: loco.quota.v1.Quota.usage:type_name -> loco.quota.v1.Usage
+	2, // 2: loco.quota.v1.GetQuotaResponse.workspace:type_name -> loco.quota.v1.Quota
+	2, // 3: loco.quota.v1.GetQuotaResponse.org:type_name -> loco.quota.v1.Quota
+	0, // 4: loco.quota.v1.SetWorkspaceQuotaRequest.limits:type_name -> loco.quota.v1.Limits
+	0, // 5: loco.quota.v1.SetWorkspaceQuotaResponse.limits:type_name -> loco.quota.v1.Limits
+	3, // 6: loco.quota.v1.QuotaService.GetQuota:input_type -> loco.quota.v1.GetQuotaRequest
+	5, // 7: loco.quota.v1.QuotaService.SetWorkspaceQuota:input_type -> loco.quota.v1.SetWorkspaceQuotaRequest
+	4, // 8: loco.quota.v1.QuotaService.GetQuota:output_type -> loco.quota.v1.GetQuotaResponse
+	6, // 9: loco.quota.v1.QuotaService.SetWorkspaceQuota:output_type -> loco.quota.v1.SetWorkspaceQuotaResponse
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_shared_proto_quota_v1_quota_proto_init() }
+func file_shared_proto_quota_v1_quota_proto_init() {
+	if File_shared_proto_quota_v1_quota_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_quota_v1_quota_proto_rawDesc), len(file_shared_proto_quota_v1_quota_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shared_proto_quota_v1_quota_proto_goTypes,
+		DependencyIndexes: file_shared_proto_quota_v1_quota_proto_depIdxs,
+		MessageInfos:      file_shared_proto_quota_v1_quota_proto_msgTypes,
+	}.Build()
+	File_shared_proto_quota_v1_quota_proto = out.File
+	file_shared_proto_quota_v1_quota_proto_goTypes = nil
+	file_shared_proto_quota_v1_quota_proto_depIdxs = nil
+}
@@ -0,0 +1,137 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: shared/proto/quota/v1/quota.proto
+
+package quotav1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/nikumar1206/loco/shared/proto/quota/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// QuotaServiceName is the fully-qualified name of the QuotaService service.
+	QuotaServiceName = "loco.quota.v1.QuotaService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// QuotaServiceGetQuotaProcedure is the fully-qualified name of the QuotaService's GetQuota RPC.
+	QuotaServiceGetQuotaProcedure = "/loco.quota.v1.QuotaService/GetQuota"
+	// QuotaServiceSetWorkspaceQuotaProcedure is the fully-qualified name of the QuotaService's
+	// SetWorkspaceQuota RPC.
+	QuotaServiceSetWorkspaceQuotaProcedure = "/loco.quota.v1.QuotaService/SetWorkspaceQuota"
+)
+
+// QuotaServiceClient is a client for the loco.quota.v1.QuotaService service.
+type QuotaServiceClient interface {
+	GetQuota(context.Context, *connect.Request[v1.GetQuotaRequest]) (*connect.Response[v1.GetQuotaResponse], error)
+	SetWorkspaceQuota(context.Context, *connect.Request[v1.SetWorkspaceQuotaRequest]) (*connect.Response[v1.SetWorkspaceQuotaResponse], error)
+}
+
+// NewQuotaServiceClient constructs a client for the loco.quota.v1.QuotaService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewQuotaServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) QuotaServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	quotaServiceMethods := v1.File_shared_proto_quota_v1_quota_proto.Services().ByName("QuotaService").Methods()
+	return &quotaServiceClient{
+		getQuota: connect.NewClient[v1.GetQuotaRequest, v1.GetQuotaResponse](
+			httpClient,
+			baseURL+QuotaServiceGetQuotaProcedure,
+			connect.WithSchema(quotaServiceMethods.ByName("GetQuota")),
+			connect.WithClientOptions(opts...),
+		),
+		setWorkspaceQuota: connect.NewClient[v1.SetWorkspaceQuotaRequest, v1.SetWorkspaceQuotaResponse](
+			httpClient,
+			baseURL+QuotaServiceSetWorkspaceQuotaProcedure,
+			connect.WithSchema(quotaServiceMethods.ByName("SetWorkspaceQuota")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// quotaServiceClient implements QuotaServiceClient.
+type quotaServiceClient struct {
+	getQuota          *connect.Client[v1.GetQuotaRequest, v1.GetQuotaResponse]
+	setWorkspaceQuota *connect.Client[v1.SetWorkspaceQuotaRequest, v1.SetWorkspaceQuotaResponse]
+}
+
+// GetQuota calls loco.quota.v1.QuotaService.GetQuota.
+func (c *quotaServiceClient) GetQuota(ctx context.Context, req *connect.Request[v1.GetQuotaRequest]) (*connect.Response[v1.GetQuotaResponse], error) {
+	return c.getQuota.CallUnary(ctx, req)
+}
+
+// SetWorkspaceQuota calls loco.quota.v1.QuotaService.SetWorkspaceQuota.
+func (c *quotaServiceClient) SetWorkspaceQuota(ctx context.Context, req *connect.Request[v1.SetWorkspaceQuotaRequest]) (*connect.Response[v1.SetWorkspaceQuotaResponse], error) {
+	return c.setWorkspaceQuota.CallUnary(ctx, req)
+}
+
+// QuotaServiceHandler is an implementation of the loco.quota.v1.QuotaService service.
+type QuotaServiceHandler interface {
+	GetQuota(context.Context, *connect.Request[v1.GetQuotaRequest]) (*connect.Response[v1.GetQuotaResponse], error)
+	SetWorkspaceQuota(context.Context, *connect.Request[v1.SetWorkspaceQuotaRequest]) (*connect.Response[v1.SetWorkspaceQuotaResponse], error)
+}
+
+// NewQuotaServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewQuotaServiceHandler(svc QuotaServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	quotaServiceMethods := v1.File_shared_proto_quota_v1_quota_proto.Services().ByName("QuotaService").Methods()
+	quotaServiceGetQuotaHandler := connect.NewUnaryHandler(
+		QuotaServiceGetQuotaProcedure,
+		svc.GetQuota,
+		connect.WithSchema(quotaServiceMethods.ByName("GetQuota")),
+		connect.WithHandlerOptions(opts...),
+	)
+	quotaServiceSetWorkspaceQuotaHandler := connect.NewUnaryHandler(
+		QuotaServiceSetWorkspaceQuotaProcedure,
+		svc.SetWorkspaceQuota,
+		connect.WithSchema(quotaServiceMethods.ByName("SetWorkspaceQuota")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/loco.quota.v1.QuotaService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case QuotaServiceGetQuotaProcedure:
+			quotaServiceGetQuotaHandler.ServeHTTP(w, r)
+		case QuotaServiceSetWorkspaceQuotaProcedure:
+			quotaServiceSetWorkspaceQuotaHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedQuotaServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedQuotaServiceHandler struct{}
+
+func (UnimplementedQuotaServiceHandler) GetQuota(context.Context, *connect.Request[v1.GetQuotaRequest]) (*connect.Response[v1.GetQuotaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.quota.v1.QuotaService.GetQuota is not implemented"))
+}
+
+func (UnimplementedQuotaServiceHandler) SetWorkspaceQuota(context.Context, *connect.Request[v1.SetWorkspaceQuotaRequest]) (*connect.Response[v1.SetWorkspaceQuotaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("loco.quota.v1.QuotaService.SetWorkspaceQuota is not implemented"))
+}